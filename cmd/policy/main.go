@@ -10,12 +10,13 @@ import (
 	"os"
 	"time"
 
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/jpillora/ipfilter"
 	"github.com/kelseyhightower/envconfig"
-	"github.com/open-policy-agent/opa/rego"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	goahttp "goa.design/goa/v3/http"
@@ -25,29 +26,41 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 
+	goadata "github.com/eclipse-xfsc/custom-policy-agent/gen/data"
 	goahealth "github.com/eclipse-xfsc/custom-policy-agent/gen/health"
+	goadatasrv "github.com/eclipse-xfsc/custom-policy-agent/gen/http/data/server"
 	goahealthsrv "github.com/eclipse-xfsc/custom-policy-agent/gen/http/health/server"
 	goaopenapisrv "github.com/eclipse-xfsc/custom-policy-agent/gen/http/openapi/server"
 	goapolicysrv "github.com/eclipse-xfsc/custom-policy-agent/gen/http/policy/server"
 	"github.com/eclipse-xfsc/custom-policy-agent/gen/openapi"
 	goapolicy "github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/authz"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/clients/cache"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/clients/nats"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/clients/signer"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/clone"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/config"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/decisionlog"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/grpcserver"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/notify"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/data"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/health"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policydata"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage/memory"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage/mongodb"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/tracing"
+	policyv1 "github.com/eclipse-xfsc/custom-policy-agent/proto/policy/v1"
 	auth "github.com/eclipse-xfsc/microservice-core-go/pkg/auth"
 	graceful "github.com/eclipse-xfsc/microservice-core-go/pkg/graceful"
 )
@@ -70,6 +83,24 @@ func main() {
 
 	logger.Info("policy service started", zap.String("version", Version), zap.String("goa", goa.Version()))
 
+	// configure tracing before the HTTP clients are created
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		OTLPEndpoint:   cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure:   cfg.Tracing.OTLPInsecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    "policy",
+		ServiceVersion: Version,
+	})
+	if err != nil {
+		logger.Fatal("error configuring tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("error stopping tracing", zap.Error(err))
+		}
+	}()
+
 	httpClient := httpClient()
 
 	oauthClient := httpClient
@@ -92,11 +123,14 @@ func main() {
 	}
 	defer events.Close(context.Background())
 
+	// limit the number of policy versions used as metric labels
+	metrics.SetMaxPolicies(cfg.Metrics.MaxPolicies)
+
 	// create policy change subscribers collection
 	var subscribers []storage.PolicySubscriber
 
 	// create rego policy cache
-	regocache := regocache.New()
+	regocache := regocache.New(regocache.WithMaxSize(cfg.Policy.CacheSize))
 	subscribers = append(subscribers, regocache)
 
 	storage, err := makeStorage(cfg, logger)
@@ -115,9 +149,6 @@ func main() {
 		logger.Info("policy storage does not support policy change notifications")
 	}
 
-	// subscribe the cache for policy data changes
-	storage.AddPolicySubscribers(subscribers...)
-
 	// create policy data refresher
 	var dataRefresher *policydata.Refresher
 	dataStorage, ok := storage.(policydata.Storage)
@@ -130,6 +161,14 @@ func main() {
 		)
 	}
 
+	// create the audit log of state-changing operations
+	var auditLog policy.AuditLog
+	if auditStorage, ok := storage.(audit.Storage); ok {
+		auditLog = audit.New(auditStorage, logger)
+	} else {
+		logger.Info("policy storage does not support the audit log")
+	}
+
 	// register rego extension functions
 	{
 		cacheFuncs := regofunc.NewCacheFuncs(cfg.Cache.Addr, oauthClient)
@@ -138,26 +177,76 @@ func main() {
 		ocmFuncs := regofunc.NewOcmFuncs(cfg.OCM.Addr, httpClient)
 		signerFuncs := regofunc.NewSignerFuncs(cfg.Signer.Addr, oauthClient)
 		didWebFuncs := regofunc.NewDIDWebFuncs()
-		storageFuncs := regofunc.NewStorageFuncs(storage)
-		regofunc.Register("cacheGet", rego.Function3(cacheFuncs.CacheGetFunc()))
-		regofunc.Register("cacheSet", rego.Function4(cacheFuncs.CacheSetFunc()))
-		regofunc.Register("didResolve", rego.Function1(didResolverFuncs.ResolveFunc()))
-		regofunc.Register("taskCreate", rego.Function2(taskFuncs.CreateTaskFunc()))
-		regofunc.Register("taskListCreate", rego.Function2(taskFuncs.CreateTaskListFunc()))
-		regofunc.Register("verificationMethod", rego.Function3(signerFuncs.VerificationMethodFunc()))
-		regofunc.Register("verificationMethods", rego.Function2(signerFuncs.VerificationMethodsFunc()))
-		regofunc.Register("addVCProof", rego.Function3(signerFuncs.AddVCProofFunc()))
-		regofunc.Register("addVPProof", rego.Function4(signerFuncs.AddVPProofFunc()))
-		regofunc.Register("verifyProof", rego.Function1(signerFuncs.VerifyProofFunc()))
-		regofunc.Register("ocmLoginProofInvitation", rego.Function2(ocmFuncs.GetLoginProofInvitation()))
-		regofunc.Register("ocmSendPresentationRequest", rego.Function1(ocmFuncs.SendPresentationRequest()))
-		regofunc.Register("ocmLoginProofResult", rego.Function1(ocmFuncs.GetLoginProofResult()))
-		regofunc.Register("ocmRawProofResult", rego.Function1(ocmFuncs.GetRawProofResult()))
-		regofunc.Register("didToURL", rego.Function1(didWebFuncs.DIDToURLFunc()))
-		regofunc.Register("urlToDID", rego.Function1(didWebFuncs.URLToDIDFunc()))
-		regofunc.Register("storageGet", rego.Function1(storageFuncs.GetData()))
-		regofunc.Register("storageSet", rego.Function2(storageFuncs.SetData()))
-		regofunc.Register("storageDelete", rego.Function1(storageFuncs.DeleteData()))
+		storageFuncs := regofunc.NewStorageFuncs(storage, auditLog)
+		regofunc.Register("cacheGet", regofunc.Function3(cacheFuncs.CacheGetFunc()))
+		regofunc.Register("cacheSet", regofunc.Function4(cacheFuncs.CacheSetFunc()))
+		regofunc.Register("didResolve", regofunc.Function1(didResolverFuncs.ResolveFunc()))
+		regofunc.Register("taskCreate", regofunc.Function2(taskFuncs.CreateTaskFunc()))
+		regofunc.Register("taskListCreate", regofunc.Function2(taskFuncs.CreateTaskListFunc()))
+		regofunc.Register("verificationMethod", regofunc.Function3(signerFuncs.VerificationMethodFunc()))
+		regofunc.Register("verificationMethods", regofunc.Function2(signerFuncs.VerificationMethodsFunc()))
+		regofunc.Register("addVCProof", regofunc.Function3(signerFuncs.AddVCProofFunc()))
+		regofunc.Register("addVPProof", regofunc.Function4(signerFuncs.AddVPProofFunc()))
+		regofunc.Register("verifyProof", regofunc.Function1(signerFuncs.VerifyProofFunc()))
+		regofunc.Register("ocmLoginProofInvitation", regofunc.Function2(ocmFuncs.GetLoginProofInvitation()))
+		regofunc.Register("ocmSendPresentationRequest", regofunc.Function1(ocmFuncs.SendPresentationRequest()))
+		regofunc.Register("ocmLoginProofResult", regofunc.Function1(ocmFuncs.GetLoginProofResult()))
+		regofunc.Register("ocmRawProofResult", regofunc.Function1(ocmFuncs.GetRawProofResult()))
+		regofunc.Register("didToURL", regofunc.Function1(didWebFuncs.DIDToURLFunc()))
+		regofunc.Register("urlToDID", regofunc.Function1(didWebFuncs.URLToDIDFunc()))
+		regofunc.Register("storageGet", regofunc.Function1(storageFuncs.GetData()))
+		regofunc.Register("storageSet", regofunc.Function2(storageFuncs.SetData()))
+		regofunc.Register("storageDelete", regofunc.Function1(storageFuncs.DeleteData()))
+	}
+
+	// create policy service options and the decision log for policy evaluations
+	policyOpts := []policy.Option{
+		policy.WithBatchConcurrency(cfg.Policy.BatchConcurrency),
+		policy.WithMaxBatchSize(cfg.Policy.BatchMaxSize),
+		policy.WithShadowConcurrency(cfg.Policy.ShadowConcurrency),
+		policy.WithMaxResultWait(cfg.Policy.ResultMaxWait),
+		policy.WithJobs(cfg.Policy.JobWorkers, cfg.Policy.JobQueueSize, cfg.Policy.JobRetention, cfg.Policy.JobTimeout),
+		policy.WithJobCallbackHosts(cfg.Policy.JobCallbackHosts...),
+		policy.WithExplainCheck(caller.HasSubject(cfg.Policy.ExplainAdmins...)),
+		// subscribe the caches for policy data changes
+		policy.WithPolicySubscribers(subscribers...),
+	}
+	// the subjects of unauthenticated tokens can be chosen by any client
+	if len(cfg.Policy.ExplainAdmins) > 0 && !cfg.Auth.Enabled {
+		logger.Fatal("explain admins cannot be configured when authentication is disabled")
+	}
+	libraries, err := makeLibraries(cfg)
+	if err != nil {
+		logger.Fatal("error loading policy libraries", zap.Error(err))
+	}
+	if len(libraries) > 0 {
+		logger.Info("policy libraries are loaded", zap.Int("modules", len(libraries)))
+		policyOpts = append(policyOpts, policy.WithLibraries(libraries))
+	}
+	if auditLog != nil {
+		policyOpts = append(policyOpts, policy.WithAuditLog(auditLog))
+	}
+	decisionSink, err := makeDecisionSink(cfg, storage)
+	if err != nil {
+		logger.Fatal("error creating decision log sink", zap.Error(err))
+	}
+	if decisionStorage, ok := storage.(policy.DecisionStorage); ok && cfg.DecisionLog.Sink == "mongo" {
+		policyOpts = append(policyOpts, policy.WithDecisionStorage(decisionStorage))
+	}
+
+	var decisionLogger *decisionlog.Logger
+	if decisionSink != nil {
+		decisionOpts := []decisionlog.Option{
+			decisionlog.WithBufferSize(cfg.DecisionLog.BufferSize),
+			decisionlog.WithBatchSize(cfg.DecisionLog.BatchSize),
+			decisionlog.WithFlushInterval(cfg.DecisionLog.FlushInterval),
+			decisionlog.WithSampleRate(cfg.DecisionLog.SampleRate, cfg.DecisionLog.PolicySampleRates),
+		}
+		if cfg.DecisionLog.IncludeInput {
+			decisionOpts = append(decisionOpts, decisionlog.WithInput(cfg.DecisionLog.RedactFields))
+		}
+		decisionLogger = decisionlog.New(decisionSink, logger, decisionOpts...)
+		policyOpts = append(policyOpts, policy.WithDecisionLog(decisionLogger))
 	}
 
 	// create the errgroup running all background processes here
@@ -168,7 +257,8 @@ func main() {
 
 	// create services
 	var (
-		policySvc goapolicy.Service
+		policySvc *policy.Service
+		dataSvc   goadata.Service
 		healthSvc goahealth.Service
 	)
 	{
@@ -183,22 +273,47 @@ func main() {
 			cfg.AutoImport.PollInterval,
 			httpClient,
 			logger,
+			policyOpts...,
 		)
+		dataSvc = data.New(policySvc, cfg.DataAPI.Repository, cfg.DataAPI.Version, logger)
 		healthSvc = health.New(Version)
 	}
 
 	// create endpoints
 	var (
 		policyEndpoints  *goapolicy.Endpoints
+		dataEndpoints    *goadata.Endpoints
 		healthEndpoints  *goahealth.Endpoints
 		openapiEndpoints *openapi.Endpoints
 	)
 	{
 		policyEndpoints = goapolicy.NewEndpoints(policySvc)
+		dataEndpoints = goadata.NewEndpoints(dataSvc)
 		healthEndpoints = goahealth.NewEndpoints(healthSvc)
 		openapiEndpoints = openapi.NewEndpoints(nil)
 	}
 
+	// Authorize the requests of the policy and data services with the stored policy.
+	// The policy is evaluated by the service directly, so it isn't authorized itself.
+	var authorizer *authz.Authorizer
+	if cfg.APIAuthz.Enabled {
+		if cfg.APIAuthz.PolicyRepository == "" || cfg.APIAuthz.PolicyGroup == "" || cfg.APIAuthz.PolicyName == "" {
+			logger.Fatal("API authorization policy repository, group and name must be configured")
+		}
+		if !cfg.Auth.Enabled {
+			logger.Warn("API authorization is enabled, but authentication is disabled")
+		}
+		authorizer = authz.New(policySvc, authz.Policy{
+			Repository: cfg.APIAuthz.PolicyRepository,
+			Group:      cfg.APIAuthz.PolicyGroup,
+			Name:       cfg.APIAuthz.PolicyName,
+			Version:    cfg.APIAuthz.PolicyVersion,
+			Rule:       cfg.APIAuthz.PolicyRule,
+		}, logger)
+		policyEndpoints.Use(authorizer.Endpoint)
+		dataEndpoints.Use(authorizer.Endpoint)
+	}
+
 	// Provide the transport specific request decoder and response encoder.
 	// The goa http package has built-in support for JSON, XML and gob.
 	// Other encodings can be used by providing the corresponding functions,
@@ -218,17 +333,44 @@ func main() {
 	// responses.
 	var (
 		policyServer  *goapolicysrv.Server
+		dataServer    *goadatasrv.Server
 		healthServer  *goahealthsrv.Server
 		openapiServer *goaopenapisrv.Server
 	)
 	{
 		policyServer = goapolicysrv.New(policyEndpoints, mux, dec, enc, nil, errFormatter)
+		dataServer = goadatasrv.New(dataEndpoints, mux, dec, enc, nil, errFormatter)
 		healthServer = goahealthsrv.New(healthEndpoints, mux, dec, enc, nil, errFormatter)
 		openapiServer = goaopenapisrv.New(openapiEndpoints, mux, dec, enc, nil, errFormatter, nil, nil)
 	}
 
 	// Apply middlewares on the servers
 	policyServer.Evaluate = header.Middleware()(policyServer.Evaluate)
+	policyServer.EvaluateRule = header.Middleware()(policyServer.EvaluateRule)
+	policyServer.PartialEvaluate = header.Middleware()(policyServer.PartialEvaluate)
+	policyServer.EvaluateBatch = header.Middleware()(policyServer.EvaluateBatch)
+	policyServer.EvaluateBatchStream = header.Middleware()(policyServer.EvaluateBatchStream)
+	dataServer.GetDocument = header.Middleware()(dataServer.GetDocument)
+	dataServer.GetDocumentWithInput = header.Middleware()(dataServer.GetDocumentWithInput)
+
+	// the inputs of evaluations are limited to the maximum input size of
+	// the policy while the request body is read, before it's decoded
+	policyServer.Evaluate = policySvc.InputLimit(mux.Vars)(policyServer.Evaluate)
+	policyServer.EvaluateRule = policySvc.InputLimit(mux.Vars)(policyServer.EvaluateRule)
+	policyServer.Validate = policySvc.InputLimit(mux.Vars)(policyServer.Validate)
+
+	// Add the caller identity to the request context for the decision log.
+	// The same middlewares are applied to the requests of the gRPC server.
+	trustedProxies, err := caller.ParseProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		logger.Fatal("invalid trusted proxies", zap.Error(err))
+	}
+	callerOpts := []caller.Option{caller.WithTrustedProxies(trustedProxies...)}
+	if cfg.Auth.Enabled {
+		// the bearer tokens of all requests are verified by the authentication middleware
+		callerOpts = append(callerOpts, caller.WithVerifiedTokens())
+	}
+	middlewares := []grpcserver.Middleware{caller.Middleware(callerOpts...)}
 
 	// Apply IP filter middleware if enabled
 	if cfg.IPFilter.Enabled {
@@ -238,7 +380,7 @@ func main() {
 			Logger:         zap.NewStdLog(logger),
 		})
 
-		policyServer.Use(m.Wrap)
+		middlewares = append(middlewares, m.Wrap)
 	}
 
 	// Apply Authentication middleware if enabled
@@ -247,18 +389,30 @@ func main() {
 		if err != nil {
 			logger.Fatal("failed to create authentication middleware", zap.Error(err))
 		}
-		policyServer.Use(m.Handler())
+		middlewares = append(middlewares, m.Handler())
+	}
+
+	for _, m := range middlewares {
+		policyServer.Use(m)
+		dataServer.Use(m)
+	}
+
+	// the path parameters are part of the input of the API authorization policy
+	if cfg.APIAuthz.Enabled {
+		policyServer.Use(authz.PathParams(mux.Vars))
+		dataServer.Use(authz.PathParams(mux.Vars))
 	}
 
 	// Configure the mux.
 	goapolicysrv.Mount(mux, policyServer)
+	goadatasrv.Mount(mux, dataServer)
 	goahealthsrv.Mount(mux, healthServer)
 	goaopenapisrv.Mount(mux, openapiServer)
 
 	// expose metrics
 	go exposeMetrics(cfg.Metrics.Addr, logger)
 
-	var handler http.Handler = mux
+	var handler http.Handler = tracing.Handler(mux)
 	srv := &http.Server{
 		Addr:              cfg.HTTP.Host + ":" + cfg.HTTP.Port,
 		Handler:           handler,
@@ -280,6 +434,44 @@ func main() {
 		}
 		return errors.New("server stopped successfully")
 	})
+	if cfg.GRPC.Enabled {
+		// request headers are available to the policies, the same as for HTTP evaluations
+		grpcMiddlewares := append([]grpcserver.Middleware{header.Middleware()}, middlewares...)
+		unaryInterceptors := []grpc.UnaryServerInterceptor{grpcserver.UnaryInterceptor(grpcMiddlewares...)}
+		streamInterceptors := []grpc.StreamServerInterceptor{grpcserver.StreamInterceptor(grpcMiddlewares...)}
+		// the gRPC methods are authorized after the middlewares added the caller to the context
+		if authorizer != nil {
+			unaryInterceptors = append(unaryInterceptors, grpcserver.AuthorizeUnaryInterceptor(authorizer))
+			streamInterceptors = append(streamInterceptors, grpcserver.AuthorizeStreamInterceptor(authorizer))
+		}
+		grpcSrv := grpc.NewServer(
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.ChainUnaryInterceptor(unaryInterceptors...),
+			grpc.ChainStreamInterceptor(streamInterceptors...),
+		)
+		policyv1.RegisterPolicyServiceServer(grpcSrv, grpcserver.New(policySvc, cfg.Policy.BatchConcurrency, logger))
+
+		g.Go(func() error {
+			return grpcserver.Serve(ctx, grpcSrv, cfg.GRPC.Host+":"+cfg.GRPC.Port, logger)
+		})
+	}
+	if cfg.ExtAuthz.Enabled {
+		if cfg.ExtAuthz.PolicyRepository == "" || cfg.ExtAuthz.PolicyGroup == "" || cfg.ExtAuthz.PolicyName == "" {
+			logger.Fatal("external authorization policy repository, group and name must be configured")
+		}
+		authzSrv := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+		authv3.RegisterAuthorizationServer(authzSrv, grpcserver.NewAuthorization(policySvc, grpcserver.AuthorizationPolicy{
+			Repository: cfg.ExtAuthz.PolicyRepository,
+			Group:      cfg.ExtAuthz.PolicyGroup,
+			Name:       cfg.ExtAuthz.PolicyName,
+			Version:    cfg.ExtAuthz.PolicyVersion,
+			Rule:       cfg.ExtAuthz.PolicyRule,
+		}, logger))
+
+		g.Go(func() error {
+			return grpcserver.Serve(ctx, authzSrv, cfg.ExtAuthz.Host+":"+cfg.ExtAuthz.Port, logger)
+		})
+	}
 	g.Go(func() error {
 		if err := storage.ListenPolicyDataChanges(ctx); err != nil {
 			logger.Error("mongo change streams listener stopped", zap.Error(err))
@@ -292,6 +484,11 @@ func main() {
 			return dataRefresher.Start(ctx)
 		})
 	}
+	if decisionLogger != nil {
+		g.Go(func() error {
+			return decisionLogger.Start(ctx)
+		})
+	}
 
 	if err := g.Wait(); err != nil {
 		logger.Error("run group stopped", zap.Error(err))
@@ -323,7 +520,8 @@ func errFormatter(ctx context.Context, e error) goahttp.Statuser {
 
 func httpClient() *http.Client {
 	return &http.Client{
-		Transport: &http.Transport{
+		// outgoing requests are traced and carry the trace context
+		Transport: tracing.Transport(&http.Transport{
 			Proxy: http.ProxyFromEnvironment,
 			DialContext: (&net.Dialer{
 				Timeout: 30 * time.Second,
@@ -332,7 +530,7 @@ func httpClient() *http.Client {
 			MaxIdleConnsPerHost: 100,
 			TLSHandshakeTimeout: 10 * time.Second,
 			IdleConnTimeout:     60 * time.Second,
-		},
+		}),
 		Timeout: 30 * time.Second,
 	}
 }
@@ -432,3 +630,49 @@ func makeStorage(cfg config.Config, logger *zap.Logger) (policy.Storage, error)
 
 	return nil, errors.New("storage configuration is not provided")
 }
+
+// makeLibraries loads the shared rego modules from the library
+// repository or from a local folder, if any of them is configured.
+func makeLibraries(cfg config.Config) ([]storage.Module, error) {
+	if cfg.Policy.LibraryCloneURL == "" {
+		if cfg.Policy.LibraryFolder == "" {
+			return nil, nil
+		}
+		return clone.LoadModules(cfg.Policy.LibraryFolder)
+	}
+
+	cloner, err := clone.New(clone.WithFolder("library"))
+	if err != nil {
+		return nil, err
+	}
+	defer cloner.Cleanup() //nolint:errcheck
+
+	if _, err := cloner.Clone(context.Background(), cfg.Policy.LibraryCloneURL, cfg.Policy.User, cfg.Policy.Pass, cfg.Policy.LibraryBranch); err != nil {
+		return nil, err
+	}
+
+	return cloner.Modules(cfg.Policy.LibraryFolder)
+}
+
+func makeDecisionSink(cfg config.Config, storage policy.Storage) (decisionlog.Sink, error) {
+	switch cfg.DecisionLog.Sink {
+	case "":
+		return nil, nil
+	case "mongo":
+		sink, ok := storage.(decisionlog.Sink)
+		if !ok {
+			return nil, errors.New("policy storage does not support decision logs")
+		}
+		return sink, nil
+	case "file":
+		return decisionlog.NewFileSink(cfg.DecisionLog.FilePath, cfg.DecisionLog.FileMaxSize, cfg.DecisionLog.FileMaxBackups)
+	case "nats":
+		events, err := nats.New(cfg.Nats.Addr, cfg.DecisionLog.NatsSubject, nats.WithEventType("policy_decision"))
+		if err != nil {
+			return nil, err
+		}
+		return decisionlog.NewEventsSink(events), nil
+	}
+
+	return nil, fmt.Errorf("unknown decision log sink: %s", cfg.DecisionLog.Sink)
+}
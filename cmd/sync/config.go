@@ -25,6 +25,11 @@ type Config struct {
 	// running as a service. This is the case when KeepAlive is true.
 	SyncInterval time.Duration `envconfig:"SYNC_INTERVAL" default:"120s"`
 
+	// LibraryFolder is a local folder with shared rego modules, which are
+	// compiled together with the policies when they are validated. It must
+	// contain the same modules as the library of the policy service.
+	LibraryFolder string `envconfig:"POLICY_LIBRARY_FOLDER"`
+
 	Repo repoConfig
 	DB   mongoConfig
 }
@@ -54,6 +59,7 @@ func loadConfig() (*Config, error) {
 		flag.StringVar(&cfg.Repo.Pass, "repoPass", "", "Git repo password. This flag is optional.")
 		flag.StringVar(&cfg.Repo.Branch, "branch", "", "Git branch for explicit checkout. This flag is optional.")
 		flag.StringVar(&cfg.Repo.Folder, "repoFolder", "", "Folder to search for Policies within Repo. This flag is optional.")
+		flag.StringVar(&cfg.LibraryFolder, "libraryFolder", "", "Local folder with shared library modules used for validating policies. This flag is optional.")
 		flag.StringVar(&cfg.DB.Addr, "dbAddr", "", "Mongo DB connection string.")
 		flag.StringVar(&cfg.DB.User, "dbUser", "", "Mongo DB username.")
 		flag.StringVar(&cfg.DB.Pass, "dbPass", "", "Mongo DB password.")
@@ -7,19 +7,29 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/clone"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/lint"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/policytest"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
 )
 
 const (
 	cloneFolder      = "temp"
 	policyCollection = "policies"
+	auditCollection  = "audit_log"
+
+	// auditActor is the actor of the audit records of synced policies.
+	auditActor = "sync"
 )
 
 func main() {
@@ -46,7 +56,8 @@ func main() {
 	defer db.Disconnect(context.Background()) //nolint:errcheck
 
 	for {
-		if err := sync(cfg, db); err != nil {
+		err = sync(cfg, db)
+		if err != nil {
 			log.Println(err)
 		}
 
@@ -59,6 +70,12 @@ func main() {
 
 		break // quit sync
 	}
+
+	// a failed sync, e.g. because of invalid policies, must fail the CI pipeline
+	if err != nil {
+		db.Disconnect(context.Background()) //nolint:errcheck
+		os.Exit(1)
+	}
 }
 
 func sync(cfg *Config, db *mongo.Client) error {
@@ -92,6 +109,19 @@ func sync(cfg *Config, db *mongo.Client) error {
 
 	log.Println("Policies are extracted successfully.")
 
+	log.Println("Validating policies...")
+
+	var libraries []storage.Module
+	if cfg.LibraryFolder != "" {
+		libraries, err = clone.LoadModules(cfg.LibraryFolder)
+		if err != nil {
+			return fmt.Errorf("error loading library modules: %v", err)
+		}
+	}
+
+	functions := regofunc.Declarations()
+	invalid := validatePolicies(policies, lint.New(libraries, functions), policytest.New(libraries, functions))
+
 	// insert or update policies in Mongo DB
 	if err := upsertPolicies(context.Background(), db, policies, cfg.DB.Name, cloner); err != nil {
 		return fmt.Errorf("error updating policies: %v", err)
@@ -102,11 +132,74 @@ func sync(cfg *Config, db *mongo.Client) error {
 		return fmt.Errorf("error deleting policy repo folder: %v", err)
 	}
 
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid policies are not updated", invalid)
+	}
+
 	log.Println("Policies are updated successfully.")
 
 	return nil
 }
 
+// validatePolicies removes the policies which can't be compiled, contain invalid
+// files or whose tests fail, so that new policies are not inserted and modified
+// policies are kept at their previous revision. The problems of every invalid
+// policy are reported and the number of invalid policies is returned. The test
+// report is added to the valid policies.
+func validatePolicies(policies map[string]*storage.Policy, linter *lint.Linter, tester *policytest.Tester) int {
+	keys := make([]string, 0, len(policies))
+	for k := range policies {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var invalid int
+	for _, k := range keys {
+		problems := linter.Check(policies[k])
+		if len(problems) > 0 {
+			log.Printf("[ERROR] policy %q is invalid and is not updated:\n", policies[k].Filename)
+			for _, problem := range problems {
+				log.Printf("\t%v\n", problem)
+			}
+
+			delete(policies, k)
+			invalid++
+			continue
+		}
+
+		if !policytest.HasTests(policies[k]) {
+			continue
+		}
+
+		report := tester.Run(context.Background(), policies[k])
+		if !report.Passed {
+			log.Printf("[ERROR] tests of policy %q failed and the policy is not updated:\n", policies[k].Filename)
+			if report.Error != "" {
+				log.Printf("\t%s\n", report.Error)
+			}
+			for _, r := range report.Results {
+				if r.Passed {
+					continue
+				}
+				msg := fmt.Sprintf("%s: %s failed", r.Location, r.Name)
+				if r.Error != "" {
+					msg += ": " + r.Error
+				}
+				log.Printf("\t%s\n", msg)
+			}
+
+			delete(policies, k)
+			invalid++
+			continue
+		}
+
+		log.Printf("%d tests of policy %q passed with %.1f%% coverage.\n", report.Total, policies[k].Filename, report.Coverage)
+		policies[k].TestReport = report
+	}
+
+	return invalid
+}
+
 // upsertPolicies compares policies from Git repository and MongoDB
 // and then updates the modified policies and inserts new ones.
 func upsertPolicies(ctx context.Context, db *mongo.Client, repoPolicies map[string]*storage.Policy, policyDatabase string, cloner *clone.Cloner) error {
@@ -119,13 +212,48 @@ func upsertPolicies(ctx context.Context, db *mongo.Client, repoPolicies map[stri
 	}
 
 	forUpsert := compare(currPolicies, repoPolicies)
-	if len(forUpsert) > 0 {
-		return upsert(ctx, forUpsert, collection)
+	if len(forUpsert) == 0 {
+		return nil
+	}
+
+	if err := upsert(ctx, forUpsert, collection); err != nil {
+		return err
+	}
+
+	// the policies are already updated, so audit errors don't fail the sync
+	auditLog := db.Database(policyDatabase).Collection(auditCollection)
+	if err := auditUpserts(ctx, auditLog, currPolicies, forUpsert, cloner); err != nil {
+		log.Printf("[ERROR] saving audit records failed: %v\n", err)
 	}
 
 	return nil
 }
 
+// auditUpserts saves an audit record for every inserted or updated policy
+// with the hashes of the policy before and after the sync.
+func auditUpserts(ctx context.Context, db *mongo.Collection, currPolicies map[string]*storage.Policy, policies []*storage.Policy, cloner *clone.Cloner) error {
+	now := time.Now()
+	records := make([]interface{}, 0, len(policies))
+	for _, policy := range policies {
+		before := currPolicies[cloner.ConstructKey(policy.Repository, policy.Group, policy.Name, policy.Version)]
+		records = append(records, &storage.AuditRecord{
+			MongoID:    primitive.NewObjectID(),
+			Operation:  storage.AuditSync,
+			Actor:      auditActor,
+			Repository: policy.Repository,
+			Group:      policy.Group,
+			Name:       policy.Name,
+			Version:    policy.Version,
+			BeforeHash: audit.PolicyHash(before),
+			AfterHash:  audit.PolicyHash(policy),
+			Timestamp:  now,
+		})
+	}
+
+	_, err := db.InsertMany(ctx, records)
+	return err
+}
+
 // fetchCurrPolicies fetches all policies currently stored in MongoDB
 // and returns a map with keys constructed out of the "group", "name" and
 // "version" fields of a Policy and value - a reference to the Policy
@@ -191,14 +319,20 @@ func upsert(ctx context.Context, policies []*storage.Policy, db *mongo.Collectio
 			"$set": bson.M{
 				"filename":            policy.Filename,
 				"locked":              policy.Locked,
-				"rego":                policy.Rego,
+				"modules":             policy.Modules,
 				"data":                policy.Data,
 				"dataConfig":          policy.DataConfig,
 				"outputSchema":        policy.OutputSchema,
+				"inputSchema":         policy.InputSchema,
 				"exportConfig":        policy.ExportConfig,
+				"runtimeConfig":       policy.RuntimeConfig,
+				"tests":               policy.Tests,
+				"testCases":           policy.TestCases,
+				"testReport":          policy.TestReport,
 				"lastUpdate":          time.Now(),
 				"nextDataRefreshTime": nextDataRefreshTime(policy),
 			},
+			"$unset": bson.M{"rego": ""},
 		})
 		op.SetUpsert(true)
 		ops = append(ops, op)
@@ -221,11 +355,15 @@ func nextDataRefreshTime(p *storage.Policy) time.Time {
 }
 
 func equal(p1 *storage.Policy, p2 *storage.Policy) bool {
-	if p1.Rego == p2.Rego &&
+	if slices.Equal(p1.Modules, p2.Modules) &&
 		p1.Data == p2.Data &&
 		p1.DataConfig == p2.DataConfig &&
 		p1.OutputSchema == p2.OutputSchema &&
+		p1.InputSchema == p2.InputSchema &&
 		p1.ExportConfig == p2.ExportConfig &&
+		p1.RuntimeConfig == p2.RuntimeConfig &&
+		slices.Equal(p1.Tests, p2.Tests) &&
+		p1.TestCases == p2.TestCases &&
 		p1.Repository == p2.Repository &&
 		p1.Name == p2.Name &&
 		p1.Version == p2.Version &&
@@ -28,16 +28,63 @@ var _ = Service("policy", func() {
 			GET("/policy/{repository}/{group}/{policyName}/{version}/evaluation/did.json")
 			GET("/policy/{repository}/{group}/{policyName}/{version}/evaluation")
 			POST("/policy/{repository}/{group}/{policyName}/{version}/evaluation")
+			Param("explain")
+			Param("async")
 			Header("evaluationID:x-evaluation-id", String, "EvaluationID allows overwriting the randomly generated evaluationID", func() {
 				Example("did:web:example.com")
 			})
 			Header("ttl:x-cache-ttl", Int, "Policy result cache TTL in seconds", func() {
 				Example(60)
 			})
+			Header("callbackURL:x-callback-url")
 			Body("input")
+			Response(StatusAccepted, func() {
+				Tag("jobStatus", "pending")
+				Body(func() {
+					Attribute("jobID")
+					Attribute("jobStatus")
+				})
+				Header("ETag")
+				Header("version:x-policy-version")
+			})
+			Response(StatusOK, func() {
+				Body("result")
+				Header("ETag")
+				Header("version:x-policy-version")
+			})
+		})
+	})
+
+	Method("EvaluateRule", func() {
+		Description("EvaluateRule executes a policy with the given 'data' as input and returns only the value of the given rule path.")
+		Payload(EvaluateRequest)
+		Result(EvaluateResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}")
+			POST("/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}")
+			Param("explain")
+			Param("async")
+			Header("evaluationID:x-evaluation-id", String, "EvaluationID allows overwriting the randomly generated evaluationID", func() {
+				Example("did:web:example.com")
+			})
+			Header("ttl:x-cache-ttl", Int, "Policy result cache TTL in seconds", func() {
+				Example(60)
+			})
+			Header("callbackURL:x-callback-url")
+			Body("input")
+			Response(StatusAccepted, func() {
+				Tag("jobStatus", "pending")
+				Body(func() {
+					Attribute("jobID")
+					Attribute("jobStatus")
+				})
+				Header("ETag")
+				Header("version:x-policy-version")
+			})
 			Response(StatusOK, func() {
 				Body("result")
 				Header("ETag")
+				Header("version:x-policy-version")
 			})
 		})
 	})
@@ -50,6 +97,9 @@ var _ = Service("policy", func() {
 			GET("/policy/{repository}/{group}/{policyName}/{version}/validation/did.json")
 			GET("/policy/{repository}/{group}/{policyName}/{version}/validation")
 			POST("/policy/{repository}/{group}/{policyName}/{version}/validation")
+			Param("explain")
+			Param("report")
+			Param("coerce")
 			Header("evaluationID:x-evaluation-id", String, "EvaluationID allows overwriting the randomly generated evaluationID", func() {
 				Example("did:web:example.com")
 			})
@@ -57,6 +107,60 @@ var _ = Service("policy", func() {
 				Example(60)
 			})
 			Body("input")
+			Response(StatusOK, func() {
+				Body("result")
+				Header("ETag")
+				Header("version:x-policy-version")
+			})
+		})
+	})
+
+	Method("PartialEvaluate", func() {
+		Description("PartialEvaluate executes partial evaluation of a policy rule with the given unknowns and returns the residual queries.")
+		Payload(PartialEvaluateRequest)
+		Result(PartialEvaluateResult)
+		HTTP(func() {
+			POST("/policy/{repository}/{group}/{policyName}/{version}/partial")
+			Response(StatusOK)
+		})
+	})
+
+	Method("EvaluateBatch", func() {
+		Description("EvaluateBatch executes multiple policies with their inputs concurrently and returns a result for each item.")
+		Payload(BatchEvaluateRequest)
+		Result(BatchEvaluateResult)
+		HTTP(func() {
+			POST("/v1/evaluations/batch")
+			Response(StatusOK)
+		})
+	})
+
+	Method("EvaluateBatchStream", func() {
+		Description("EvaluateBatchStream executes policies for a stream of newline delimited JSON items and streams back newline delimited JSON results.")
+		Payload(Empty)
+		Result(BatchEvaluateStreamResult)
+		HTTP(func() {
+			POST("/v1/evaluations/batch/stream")
+
+			// bypass request and response body encoder code generation,
+			// so that NDJSON items can be read and written one by one
+			// without buffering the whole dataset in memory.
+			SkipRequestBodyEncodeDecode()
+			SkipResponseBodyEncodeDecode()
+
+			Response(StatusOK, func() {
+				Header("content-type")
+			})
+		})
+	})
+
+	Method("EvaluationResult", func() {
+		Description("EvaluationResult returns the stored result of a policy evaluation by its evaluationID (ETag). If 'wait' is set, the request is held until the result is available or the wait time is over.")
+		Payload(EvaluationResultRequest)
+		Result(StoredEvaluationResult)
+		HTTP(func() {
+			GET("/v1/evaluations/{evaluationID}")
+			Param("wait")
 			Response(StatusOK, func() {
 				Body("result")
 				Header("ETag")
@@ -64,6 +168,16 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("JobStatus", func() {
+		Description("JobStatus returns the status of an asynchronous evaluation job.")
+		Payload(JobStatusRequest)
+		Result(EvaluationJob)
+		HTTP(func() {
+			GET("/v1/jobs/{jobID}")
+			Response(StatusOK)
+		})
+	})
+
 	Method("Lock", func() {
 		Description("Lock a policy so that it cannot be evaluated.")
 		Payload(LockRequest)
@@ -84,6 +198,26 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("LockHistory", func() {
+		Description("LockHistory returns the current lock and the previous lock and unlock events of a policy.")
+		Payload(LockHistoryRequest)
+		Result(LockHistoryResult)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/lock")
+			Response(StatusOK)
+		})
+	})
+
+	Method("PolicyTests", func() {
+		Description("PolicyTests returns the report of the last test run of a policy.")
+		Payload(PolicyTestsRequest)
+		Result(PolicyTestReport)
+		HTTP(func() {
+			GET("/policy/{repository}/{group}/{policyName}/{version}/tests")
+			Response(StatusOK)
+		})
+	})
+
 	Method("ExportBundle", func() {
 		Description("Export a signed policy bundle.")
 		Payload(ExportBundleRequest)
@@ -150,6 +284,50 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("DecisionLogs", func() {
+		Description("DecisionLogs returns the recorded decisions of policy evaluations, newest first.")
+		Payload(DecisionLogsRequest)
+		Result(DecisionLogsResult)
+		HTTP(func() {
+			GET("/v1/decisions")
+			Params(func() {
+				Param("repository", String, "Filter by policy repository (optional).")
+				Param("group", String, "Filter by policy group (optional).")
+				Param("policyName", String, "Filter by policy name (optional).")
+				Param("version", String, "Filter by policy version (optional).")
+				Param("evaluationID", String, "Filter by evaluation ID (optional).")
+				Param("caller", String, "Filter by caller identity (optional).")
+				Param("from", Int64, "Return decisions made at or after the given Unix timestamp (optional).")
+				Param("to", Int64, "Return decisions made at or before the given Unix timestamp (optional).")
+				Param("limit", Int, "Maximum number of returned decisions (optional).")
+				Param("offset", Int, "Number of decisions to skip (optional).")
+			})
+			Response(StatusOK)
+		})
+	})
+
+	Method("AuditLog", func() {
+		Description("AuditLog returns the records of state-changing operations, newest first.")
+		Payload(AuditLogRequest)
+		Result(AuditLogResult)
+		HTTP(func() {
+			GET("/v1/audit")
+			Params(func() {
+				Param("operation", String, "Filter by operation (optional).")
+				Param("actor", String, "Filter by actor (optional).")
+				Param("repository", String, "Filter by policy repository (optional).")
+				Param("group", String, "Filter by policy group (optional).")
+				Param("policyName", String, "Filter by policy name (optional).")
+				Param("version", String, "Filter by policy version (optional).")
+				Param("from", Int64, "Return records created at or after the given Unix timestamp (optional).")
+				Param("to", Int64, "Return records created at or before the given Unix timestamp (optional).")
+				Param("limit", Int, "Maximum number of returned records (optional).")
+				Param("offset", Int, "Number of records to skip (optional).")
+			})
+			Response(StatusOK)
+		})
+	})
+
 	Method("SetPolicyAutoImport", func() {
 		Description("SetPolicyAutoImport enables automatic import of policy bundle on a given time interval.")
 		Payload(SetPolicyAutoImportRequest)
@@ -180,6 +358,96 @@ var _ = Service("policy", func() {
 		})
 	})
 
+	Method("SetPolicyAlias", func() {
+		Description("SetPolicyAlias pins a named alias to a policy version, so that the alias can be used instead of the version in policy URLs.")
+		Payload(SetPolicyAliasRequest)
+		Result(PolicyAlias)
+		HTTP(func() {
+			PUT("/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("PolicyAliases", func() {
+		Description("PolicyAliases returns all named aliases of a policy.")
+		Payload(PolicyAliasesRequest)
+		Result(PolicyAliasesResult)
+		HTTP(func() {
+			GET("/v1/policy/{repository}/{group}/{policyName}/aliases")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DeletePolicyAlias", func() {
+		Description("DeletePolicyAlias removes a named alias of a policy.")
+		Payload(DeletePolicyAliasRequest)
+		Result(Empty)
+		HTTP(func() {
+			DELETE("/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}")
+			Response(StatusOK)
+		})
+	})
+
+	Method("SetPolicyShadow", func() {
+		Description("SetPolicyShadow configures a candidate version, which is evaluated in shadow with the same input whenever the policy version is evaluated.")
+		Payload(SetPolicyShadowRequest)
+		Result(Empty)
+		HTTP(func() {
+			PUT("/v1/policy/{repository}/{group}/{policyName}/{version}/shadow")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DeletePolicyShadow", func() {
+		Description("DeletePolicyShadow disables the shadow evaluation of a policy version.")
+		Payload(DeletePolicyShadowRequest)
+		Result(Empty)
+		HTTP(func() {
+			DELETE("/v1/policy/{repository}/{group}/{policyName}/{version}/shadow")
+			Response(StatusOK)
+		})
+	})
+
+	Method("SetPolicyCapture", func() {
+		Description("SetPolicyCapture enables the recording of evaluation inputs, headers and results of a policy version, which can be replayed against another version.")
+		Payload(SetPolicyCaptureRequest)
+		Result(Empty)
+		HTTP(func() {
+			PUT("/v1/policy/{repository}/{group}/{policyName}/{version}/capture")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DeletePolicyCapture", func() {
+		Description("DeletePolicyCapture disables the recording of evaluations of a policy version. Recorded evaluations are kept.")
+		Payload(DeletePolicyCaptureRequest)
+		Result(Empty)
+		HTTP(func() {
+			DELETE("/v1/policy/{repository}/{group}/{policyName}/{version}/capture")
+			Response(StatusOK)
+		})
+	})
+
+	Method("DeleteCapturedEvaluations", func() {
+		Description("DeleteCapturedEvaluations removes the recorded evaluations of a policy version.")
+		Payload(DeleteCapturedEvaluationsRequest)
+		Result(Empty)
+		HTTP(func() {
+			DELETE("/v1/policy/{repository}/{group}/{policyName}/{version}/captures")
+			Response(StatusOK)
+		})
+	})
+
+	Method("Replay", func() {
+		Description("Replay evaluates the recorded evaluations of a policy version with another version or with an uploaded policy source and returns the differences.")
+		Payload(ReplayRequest)
+		Result(ReplayReport)
+		HTTP(func() {
+			POST("/v1/policy/{repository}/{group}/{policyName}/{version}/replay")
+			Response(StatusOK)
+		})
+	})
+
 	Method("SubscribeForPolicyChange", func() {
 		Description("Subscribe for policy change notifications by registering webhook callbacks which the policy service will call.")
 		Payload(SubscribeRequest)
@@ -191,6 +459,39 @@ var _ = Service("policy", func() {
 	})
 })
 
+var _ = Service("data", func() {
+	Description("Data service provides the Data API of Open Policy Agent, so that OPA clients can evaluate the stored policies without changes.")
+
+	Method("GetDocument", func() {
+		Description("GetDocument evaluates the policy whose package is referenced by the data path without input and returns the value of the document at the path.")
+		Payload(DataRequest)
+		Result(DataResult)
+		HTTP(func() {
+			GET("/v1/data/{*path}")
+			Header("repository:x-policy-repository")
+			Header("version:x-policy-version")
+			Header("evaluationID:x-evaluation-id")
+			Response(StatusOK)
+		})
+	})
+
+	Method("GetDocumentWithInput", func() {
+		Description("GetDocumentWithInput evaluates the policy whose package is referenced by the data path with the given input and returns the value of the document at the path.")
+		Payload(DataInputRequest)
+		Result(DataResult)
+		HTTP(func() {
+			POST("/v1/data/{*path}")
+			Header("repository:x-policy-repository")
+			Header("version:x-policy-version")
+			Header("evaluationID:x-evaluation-id")
+			Body(func() {
+				Attribute("input")
+			})
+			Response(StatusOK)
+		})
+	})
+})
+
 var _ = Service("health", func() {
 	Description("Health service provides health check endpoints.")
 
@@ -21,24 +21,179 @@ var EvaluateRequest = Type("EvaluateRequest", func() {
 	Field(5, "input", Any, "Input data passed to the policy execution runtime.")
 	Field(6, "evaluationID", String, "Identifier created by external system and passed as parameter to overwrite the randomly generated evaluationID.")
 	Field(7, "ttl", Int, "TTL for storing policy result in cache")
+	Field(8, "explain", String, "Explain returns the evaluation trace and the output of print() calls together with the result. The result is then wrapped in a JSON object with 'result', 'explanation' and 'print' fields. Allowed only for admin callers.", func() {
+		Enum("off", "notes", "fails", "full")
+	})
+	Field(9, "rule", String, "Path of a rule inside the policy package, e.g. 'allow' or 'violations/critical'. If it's given, only the value of the rule is returned instead of the whole package.", func() {
+		Example("allow")
+	})
+	Field(10, "report", Boolean, "Report returns the validation verdict together with the result instead of an error, when the policy output doesn't conform to the output schema. The result is then wrapped in a JSON object with 'result', 'valid' and 'errors' fields. Used only for validation.")
+	Field(11, "coerce", Boolean, "Coerce applies the default values of the output schema to the policy output before it's validated. Used only for validation.")
+	Field(12, "async", Boolean, "Async executes the evaluation in the background. The response only contains the job ID and the ETag, which can be used to query the job status and the evaluation result.")
+	Field(13, "callbackURL", String, "URL receiving the result of an asynchronous evaluation with a POST request.", func() {
+		Format(FormatURI)
+		Example("https://example.com/callback")
+	})
 	Required("repository", "group", "policyName", "version")
 })
 
 var EvaluateResult = Type("EvaluateResult", func() {
 	Field(1, "result", Any, "Arbitrary JSON response.")
 	Field(2, "ETag", String, "ETag contains unique identifier of the policy evaluation and can be used to later retrieve the results from Cache.")
+	Field(3, "version", String, "Policy version which was evaluated, after resolving aliases, 'latest', 'stable' and semver ranges.")
+	Field(4, "jobID", String, "Identifier of the asynchronous evaluation job.")
+	Field(5, "jobStatus", String, "Status of the asynchronous evaluation job.")
+	Required("result", "ETag", "version")
+})
+
+var PartialEvaluateRequest = Type("PartialEvaluateRequest", func() {
+	Field(1, "repository", String, "Policy repository.", func() {
+		Example("policies")
+	})
+	Field(2, "group", String, "Policy group.", func() {
+		Example("example")
+	})
+	Field(3, "policyName", String, "Policy name.", func() {
+		Example("example")
+	})
+	Field(4, "version", String, "Policy version.", func() {
+		Example("1.0")
+	})
+	Field(5, "rule", String, "Name of the boolean policy rule which is evaluated.", func() {
+		Pattern(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+		Default("allow")
+	})
+	Field(6, "unknowns", ArrayOf(String), "References which are treated as unknown during evaluation.", func() {
+		MinLength(1)
+		Example([]string{"input.resource"})
+	})
+	Field(7, "input", Any, "Known input data passed to the policy execution runtime.")
+	Field(8, "target", String, "Target representation of the residual queries. If 'mongo' is given, the queries are also translated to a MongoDB filter document.", func() {
+		Enum("rego", "mongo")
+		Default("rego")
+	})
+	Required("repository", "group", "policyName", "version", "unknowns")
+})
+
+var PartialEvaluateResult = Type("PartialEvaluateResult", func() {
+	Field(1, "queries", ArrayOf(String), "Residual queries. The rule is true if any of the queries is true. No queries mean that the rule is never true.")
+	Field(2, "support", ArrayOf(String), "Support modules generated during partial evaluation.")
+	Field(3, "filter", Any, "MongoDB filter document equivalent to the residual queries.")
+	Field(4, "version", String, "Policy version which was evaluated, after resolving aliases, 'latest', 'stable' and semver ranges.")
+	Required("queries", "version")
+})
+
+var BatchEvaluateItem = Type("BatchEvaluateItem", func() {
+	Field(1, "repository", String, "Policy repository.", func() {
+		Example("policies")
+	})
+	Field(2, "group", String, "Policy group.", func() {
+		Example("example")
+	})
+	Field(3, "policyName", String, "Policy name.", func() {
+		Example("example")
+	})
+	Field(4, "version", String, "Policy version.", func() {
+		Example("1.0")
+	})
+	Field(5, "input", Any, "Input data passed to the policy execution runtime.")
+	Field(6, "evaluationID", String, "Identifier created by external system and passed as parameter to overwrite the randomly generated evaluationID.")
+	Field(7, "ttl", Int, "TTL for storing policy result in cache")
+	Required("repository", "group", "policyName", "version")
+})
+
+var BatchEvaluateRequest = Type("BatchEvaluateRequest", func() {
+	Field(1, "items", ArrayOf(BatchEvaluateItem), "Policy evaluations to execute.", func() {
+		MinLength(1)
+	})
+	Required("items")
+})
+
+var BatchEvaluateItemResult = Type("BatchEvaluateItemResult", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "result", Any, "Arbitrary JSON response.")
+	Field(6, "ETag", String, "ETag contains unique identifier of the policy evaluation and can be used to later retrieve the results from Cache.")
+	Field(7, "error", String, "Error message if the policy evaluation failed.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var BatchEvaluateResult = Type("BatchEvaluateResult", func() {
+	Field(1, "results", ArrayOf(BatchEvaluateItemResult), "Evaluation results in the same order as the request items.")
+	Required("results")
+})
+
+var BatchEvaluateStreamResult = Type("BatchEvaluateStreamResult", func() {
+	Field(1, "content-type", String, "Content-Type response header.")
+	Required("content-type")
+})
+
+var EvaluationResultRequest = Type("EvaluationResultRequest", func() {
+	Field(1, "evaluationID", String, "Identifier of the policy evaluation returned as ETag or set with the x-evaluation-id header.", func() {
+		Example("did:web:example.com")
+	})
+	Field(2, "wait", Int, "Seconds to wait for the result if it's not yet available (long-polling).", func() {
+		Minimum(0)
+		Maximum(60)
+		Example(10)
+	})
+	Required("evaluationID")
+})
+
+var StoredEvaluationResult = Type("StoredEvaluationResult", func() {
+	Field(1, "result", Any, "Arbitrary JSON response.")
+	Field(2, "ETag", String, "ETag contains unique identifier of the policy evaluation.")
 	Required("result", "ETag")
 })
 
+var JobStatusRequest = Type("JobStatusRequest", func() {
+	Field(1, "jobID", String, "Identifier of the asynchronous evaluation job.")
+	Required("jobID")
+})
+
+var EvaluationJob = Type("EvaluationJob", func() {
+	Field(1, "jobID", String, "Identifier of the asynchronous evaluation job.")
+	Field(2, "ETag", String, "Identifier of the policy evaluation, which can be used to retrieve the result.")
+	Field(3, "repository", String, "Policy repository.")
+	Field(4, "group", String, "Policy group.")
+	Field(5, "policyName", String, "Policy name.")
+	Field(6, "version", String, "Policy version.")
+	Field(7, "rule", String, "Path of the evaluated rule.")
+	Field(8, "status", String, "Job status.", func() {
+		Enum("pending", "running", "done", "failed")
+	})
+	Field(9, "error", String, "Error message if the evaluation failed.")
+	Field(10, "callbackURL", String, "URL receiving the evaluation result.")
+	Field(11, "callbackError", String, "Error message if the result couldn't be delivered to the callback URL.")
+	Field(12, "createdAt", Int64, "Creation time of the job as Unix timestamp.")
+	Field(13, "updatedAt", Int64, "Last update of the job as Unix timestamp.")
+	Required("jobID", "ETag", "repository", "group", "policyName", "version", "status", "createdAt", "updatedAt")
+})
+
 var LockRequest = Type("LockRequest", func() {
 	Field(1, "repository", String, "Policy repository.")
 	Field(2, "group", String, "Policy group.")
 	Field(3, "policyName", String, "Policy name.")
 	Field(4, "version", String, "Policy version.")
+	Field(5, "reason", String, "Reason for locking the policy.", func() {
+		Example("suspicious results")
+	})
+	Field(6, "unlockAt", Int64, "Time when the policy is unlocked automatically (Unix timestamp).")
 	Required("repository", "group", "policyName", "version")
 })
 
 var UnlockRequest = Type("UnlockRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "reason", String, "Reason for unlocking the policy.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var LockHistoryRequest = Type("LockHistoryRequest", func() {
 	Field(1, "repository", String, "Policy repository.")
 	Field(2, "group", String, "Policy group.")
 	Field(3, "policyName", String, "Policy name.")
@@ -46,6 +201,53 @@ var UnlockRequest = Type("UnlockRequest", func() {
 	Required("repository", "group", "policyName", "version")
 })
 
+var LockHistoryResult = Type("LockHistoryResult", func() {
+	Field(1, "locked", Boolean, "Locked specifies if the policy is locked.")
+	Field(2, "lock", PolicyLockEvent, "Current lock of a locked policy.")
+	Field(3, "history", ArrayOf(PolicyLockEvent), "Previous lock and unlock events, oldest first.")
+	Required("locked", "history")
+})
+
+var PolicyLockEvent = Type("PolicyLockEvent", func() {
+	Field(1, "locked", Boolean, "True for a lock and false for an unlock.")
+	Field(2, "reason", String, "Reason for (un)locking the policy.")
+	Field(3, "actor", String, "Subject of the caller who (un)locked the policy.")
+	Field(4, "source", String, "Source of the event.", func() {
+		Enum("manual", "validation", "import", "expiry")
+	})
+	Field(5, "unlockAt", Int64, "Time when the policy is unlocked automatically (Unix timestamp).")
+	Field(6, "timestamp", Int64, "Time of the event (Unix timestamp).")
+	Required("locked", "source", "timestamp")
+})
+
+var PolicyTestsRequest = Type("PolicyTestsRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var PolicyTestReport = Type("PolicyTestReport", func() {
+	Field(1, "passed", Boolean, "All tests of the policy passed.")
+	Field(2, "error", String, "Error which prevented the tests from running.")
+	Field(3, "total", Int, "Number of executed tests.")
+	Field(4, "failed", Int, "Number of failed tests.")
+	Field(5, "coverage", Float64, "Percentage of the policy module lines evaluated by the tests.")
+	Field(6, "results", ArrayOf(PolicyTestResult), "Results of the test rules and golden test cases.")
+	Field(7, "runAt", Int64, "Time of the test run (Unix timestamp).")
+	Required("passed", "total", "failed", "coverage", "results", "runAt")
+})
+
+var PolicyTestResult = Type("PolicyTestResult", func() {
+	Field(1, "name", String, "Name of the test rule or golden test case.")
+	Field(2, "location", String, "Location of the test.")
+	Field(3, "passed", Boolean, "The test passed.")
+	Field(4, "error", String, "Error or failure message of the test.")
+	Field(5, "duration", Int64, "Test duration in milliseconds.")
+	Required("name", "location", "passed", "duration")
+})
+
 var ExportBundleRequest = Type("ExportBundleRequest", func() {
 	Field(1, "repository", String, "Policy repository.", func() {
 		Example("policies")
@@ -90,11 +292,14 @@ var Policy = Type("Policy", func() {
 	Field(2, "policyName", String, "Policy name.")
 	Field(3, "group", String, "Policy group.")
 	Field(4, "version", String, "Policy version.")
-	Field(5, "rego", String, "Policy rego source code.")
+	Field(5, "rego", String, "Policy rego source code of the main 'policy.rego' module.")
 	Field(6, "data", String, "Policy static data.")
 	Field(7, "dataConfig", String, "Policy static data optional configuration.")
 	Field(8, "locked", Boolean, "Locked specifies if the policy is locked or allowed to execute.")
 	Field(9, "lastUpdate", Int64, "Last update (Unix timestamp).")
+	Field(10, "modules", MapOf(String, String), "Policy rego modules by filename.")
+	Field(11, "shadowVersion", String, "Candidate policy version which is evaluated in shadow.")
+	Field(12, "lock", PolicyLockEvent, "Current lock of a locked policy.")
 	Required("repository", "group", "policyName", "version", "locked", "lastUpdate")
 })
 
@@ -111,6 +316,91 @@ var PoliciesResult = Type("PoliciesResult", func() {
 	Required("policies")
 })
 
+var DecisionLogsRequest = Type("DecisionLogsRequest", func() {
+	Field(1, "repository", String)
+	Field(2, "group", String, func() { Example("example") })
+	Field(3, "policyName", String, func() { Example("example") })
+	Field(4, "version", String, func() { Example("1.0") })
+	Field(5, "evaluationID", String)
+	Field(6, "caller", String)
+	Field(7, "from", Int64)
+	Field(8, "to", Int64)
+	Field(9, "limit", Int, func() {
+		Minimum(1)
+		Maximum(1000)
+		Default(100)
+	})
+	Field(10, "offset", Int, func() {
+		Minimum(0)
+	})
+})
+
+var Decision = Type("Decision", func() {
+	Field(1, "evaluationID", String, "Evaluation ID.")
+	Field(2, "repository", String, "Policy repository.")
+	Field(3, "group", String, "Policy group.")
+	Field(4, "policyName", String, "Policy name.")
+	Field(5, "version", String, "Policy version.")
+	Field(6, "policyLastUpdate", Int64, "Last update of the evaluated policy (Unix timestamp).")
+	Field(7, "inputHash", String, "SHA256 hash of the evaluation input.")
+	Field(8, "input", Any, "Evaluation input with redacted fields (if configured).")
+	Field(9, "result", Any, "Evaluation result.")
+	Field(10, "error", String, "Evaluation error.")
+	Field(11, "duration", Int64, "Evaluation duration in milliseconds.")
+	Field(12, "caller", String, "Identity of the caller.")
+	Field(13, "clientIP", String, "Address of the caller.")
+	Field(14, "timestamp", Int64, "Time of the evaluation (Unix timestamp).")
+	Field(15, "rule", String, "Evaluated rule path inside the policy package.")
+	Field(16, "requestID", String, "Request ID of the evaluated request, taken from the X-Request-Id header, e.g. set by Envoy.")
+	Required("evaluationID", "repository", "group", "policyName", "version", "policyLastUpdate", "inputHash", "duration", "timestamp")
+})
+
+var DecisionLogsResult = Type("DecisionLogsResult", func() {
+	Field(1, "decisions", ArrayOf(Decision), "JSON array of decisions.")
+	Required("decisions")
+})
+
+var AuditLogRequest = Type("AuditLogRequest", func() {
+	Field(1, "operation", String, func() { Example("lock") })
+	Field(2, "actor", String)
+	Field(3, "repository", String)
+	Field(4, "group", String, func() { Example("example") })
+	Field(5, "policyName", String, func() { Example("example") })
+	Field(6, "version", String, func() { Example("1.0") })
+	Field(7, "from", Int64)
+	Field(8, "to", Int64)
+	Field(9, "limit", Int, func() {
+		Minimum(1)
+		Maximum(1000)
+		Default(100)
+	})
+	Field(10, "offset", Int, func() {
+		Minimum(0)
+	})
+})
+
+var AuditRecord = Type("AuditRecord", func() {
+	Field(1, "operation", String, "Executed operation.")
+	Field(2, "actor", String, "Subject of the caller or 'system' for operations of the service.")
+	Field(3, "clientIP", String, "Client IP address of the caller.")
+	Field(4, "repository", String, "Policy repository.")
+	Field(5, "group", String, "Policy group.")
+	Field(6, "policyName", String, "Policy name.")
+	Field(7, "version", String, "Policy version.")
+	Field(8, "target", String, "Changed resource if it isn't a policy, e.g. a storage key or an import URL.")
+	Field(9, "beforeHash", String, "SHA256 hash of the resource before the operation.")
+	Field(10, "afterHash", String, "SHA256 hash of the resource after the operation.")
+	Field(11, "timestamp", Int64, "Time of the operation (Unix timestamp).")
+	Field(12, "beforeVersion", String, "Policy version referenced by the resource before the operation, e.g. the version of an alias.")
+	Field(13, "afterVersion", String, "Policy version referenced by the resource after the operation, e.g. the version of an alias.")
+	Required("operation", "timestamp")
+})
+
+var AuditLogResult = Type("AuditLogResult", func() {
+	Field(1, "records", ArrayOf(AuditRecord), "JSON array of audit records.")
+	Required("records")
+})
+
 var SubscribeRequest = Type("SubscribeRequest", func() {
 	Field(1, "webhook_url", String, "Subscriber webhook url.", func() {
 		Format(FormatURI)
@@ -144,9 +434,181 @@ var DeletePolicyAutoImportRequest = Type("DeletePolicyAutoImportRequest", func()
 	Required("policyURL")
 })
 
+var SetPolicyAliasRequest = Type("SetPolicyAliasRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "alias", String, "Alias name which can be used instead of the policy version.", func() {
+		Pattern(`^[a-zA-Z][a-zA-Z0-9._-]*$`)
+		Example("production")
+	})
+	Field(5, "version", String, "Policy version referenced by the alias.", func() {
+		Example("1.0")
+	})
+	Required("repository", "group", "policyName", "alias", "version")
+})
+
+var PolicyAliasesRequest = Type("PolicyAliasesRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Required("repository", "group", "policyName")
+})
+
+var DeletePolicyAliasRequest = Type("DeletePolicyAliasRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "alias", String, "Alias name.")
+	Required("repository", "group", "policyName", "alias")
+})
+
+var SetPolicyShadowRequest = Type("SetPolicyShadowRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "shadowVersion", String, "Candidate policy version which is evaluated in shadow.", func() {
+		Example("2.0")
+	})
+	Required("repository", "group", "policyName", "version", "shadowVersion")
+})
+
+var DeletePolicyShadowRequest = Type("DeletePolicyShadowRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var SetPolicyCaptureRequest = Type("SetPolicyCaptureRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Field(5, "sampleRate", Float64, "Fraction of evaluations which are recorded.", func() {
+		Minimum(0)
+		Maximum(1)
+		Default(1)
+	})
+	Field(6, "maxRecords", Int, "Maximum number of recorded evaluations of the policy version.", func() {
+		Minimum(1)
+		Default(1000)
+	})
+	Field(7, "redactFields", ArrayOf(String), "Dot separated paths of input fields whose values are not recorded.", func() {
+		Example([]string{"user.email"})
+	})
+	Field(8, "redactHeaders", ArrayOf(String), "Names of request headers whose values are not recorded.", func() {
+		Example([]string{"X-Api-Key"})
+	})
+	Required("repository", "group", "policyName", "version")
+})
+
+var DeletePolicyCaptureRequest = Type("DeletePolicyCaptureRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var DeleteCapturedEvaluationsRequest = Type("DeleteCapturedEvaluationsRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version.")
+	Required("repository", "group", "policyName", "version")
+})
+
+var ReplayRequest = Type("ReplayRequest", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "version", String, "Policy version whose recorded evaluations are replayed.")
+	Field(5, "candidateVersion", String, "Policy version which evaluates the recorded inputs.", func() {
+		Example("2.0")
+	})
+	Field(6, "rego", String, "Source code of the main policy module, which replaces the module of the recorded version.")
+	Field(7, "limit", Int, "Maximum number of replayed evaluations, newest first.", func() {
+		Minimum(1)
+		Maximum(1000)
+		Default(100)
+	})
+	Required("repository", "group", "policyName", "version")
+})
+
+var ReplayReport = Type("ReplayReport", func() {
+	Field(1, "candidate", String, "Replayed policy version or 'rego' for an uploaded policy source.")
+	Field(2, "total", Int, "Number of replayed evaluations.")
+	Field(3, "unchanged", Int, "Number of evaluations with the same result or error.")
+	Field(4, "changed", Int, "Number of evaluations with a different result.")
+	Field(5, "newErrors", Int, "Number of evaluations which fail only with the candidate.")
+	Field(6, "fixedErrors", Int, "Number of evaluations which fail only with the recorded version.")
+	Field(7, "durationDelta", Float64, "Average difference between the candidate and the recorded evaluation duration in milliseconds, excluding extension function calls.")
+	Field(8, "maxDurationDelta", Float64, "Maximum difference between the candidate and the recorded evaluation duration in milliseconds, excluding extension function calls.")
+	Field(9, "diffs", ArrayOf(ReplayDiff), "Evaluations whose result or error differs.")
+	Required("candidate", "total", "unchanged", "changed", "newErrors", "fixedErrors", "durationDelta", "maxDurationDelta", "diffs")
+})
+
+var ReplayDiff = Type("ReplayDiff", func() {
+	Field(1, "evaluationID", String, "Evaluation ID of the recorded evaluation.")
+	Field(2, "rule", String, "Evaluated rule path inside the policy package.")
+	Field(3, "status", String, "Kind of difference.", func() {
+		Enum("changed", "new_error", "fixed_error")
+	})
+	Field(4, "result", Any, "Recorded result.")
+	Field(5, "candidateResult", Any, "Result of the candidate.")
+	Field(6, "error", String, "Recorded error.")
+	Field(7, "candidateError", String, "Error of the candidate.")
+	Field(8, "durationDelta", Float64, "Difference between the candidate and the recorded evaluation duration in milliseconds, excluding extension function calls.")
+	Field(9, "timestamp", Int64, "Time of the recorded evaluation (Unix timestamp).")
+	Required("evaluationID", "status", "durationDelta", "timestamp")
+})
+
+var PolicyAlias = Type("PolicyAlias", func() {
+	Field(1, "repository", String, "Policy repository.")
+	Field(2, "group", String, "Policy group.")
+	Field(3, "policyName", String, "Policy name.")
+	Field(4, "alias", String, "Alias name.")
+	Field(5, "version", String, "Policy version referenced by the alias.")
+	Field(6, "lastUpdate", Int64, "Last update (Unix timestamp).")
+	Required("repository", "group", "policyName", "alias", "version", "lastUpdate")
+})
+
+var PolicyAliasesResult = Type("PolicyAliasesResult", func() {
+	Field(1, "aliases", ArrayOf(PolicyAlias), "Named aliases of the policy.")
+	Required("aliases")
+})
+
 var HealthResponse = Type("HealthResponse", func() {
 	Field(1, "service", String, "Service name.")
 	Field(2, "status", String, "Status message.")
 	Field(3, "version", String, "Service runtime version.")
 	Required("service", "status", "version")
 })
+
+var DataRequest = Type("DataRequest", func() {
+	Field(1, "path", String, "Path of the document, which starts with the policy group and name followed by an optional rule path, e.g. 'example/example/allow'.", func() {
+		Example("example/example/allow")
+	})
+	Field(2, "repository", String, "Policy repository. It overwrites the configured repository of the Data API.", func() {
+		Example("policies")
+	})
+	Field(3, "version", String, "Policy version, alias, 'latest', 'stable' or semver range. It overwrites the configured version of the Data API.", func() {
+		Example("1.0")
+	})
+	Field(4, "evaluationID", String, "Identifier created by external system and passed as parameter to overwrite the randomly generated evaluationID.")
+	Required("path")
+})
+
+var DataInputRequest = Type("DataInputRequest", func() {
+	Extend(DataRequest)
+	Field(5, "input", Any, "Input data passed to the policy execution runtime.")
+})
+
+var DataResult = Type("DataResult", func() {
+	Field(1, "result", Any, "Value of the document. It's missing if the document is undefined.")
+	Field(2, "decision_id", String, "Identifier of the policy evaluation, which can be used to later retrieve the result from Cache.")
+	Required("decision_id")
+})
@@ -0,0 +1,49 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data client
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package data
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Client is the "data" service client.
+type Client struct {
+	GetDocumentEndpoint          goa.Endpoint
+	GetDocumentWithInputEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "data" service client given the endpoints.
+func NewClient(getDocument, getDocumentWithInput goa.Endpoint) *Client {
+	return &Client{
+		GetDocumentEndpoint:          getDocument,
+		GetDocumentWithInputEndpoint: getDocumentWithInput,
+	}
+}
+
+// GetDocument calls the "GetDocument" endpoint of the "data" service.
+func (c *Client) GetDocument(ctx context.Context, p *DataRequest) (res *DataResult, err error) {
+	var ires any
+	ires, err = c.GetDocumentEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*DataResult), nil
+}
+
+// GetDocumentWithInput calls the "GetDocumentWithInput" endpoint of the "data"
+// service.
+func (c *Client) GetDocumentWithInput(ctx context.Context, p *DataInputRequest) (res *DataResult, err error) {
+	var ires any
+	ires, err = c.GetDocumentWithInputEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*DataResult), nil
+}
@@ -0,0 +1,52 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data endpoints
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package data
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Endpoints wraps the "data" service endpoints.
+type Endpoints struct {
+	GetDocument          goa.Endpoint
+	GetDocumentWithInput goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "data" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		GetDocument:          NewGetDocumentEndpoint(s),
+		GetDocumentWithInput: NewGetDocumentWithInputEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "data" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.GetDocument = m(e.GetDocument)
+	e.GetDocumentWithInput = m(e.GetDocumentWithInput)
+}
+
+// NewGetDocumentEndpoint returns an endpoint function that calls the method
+// "GetDocument" of service "data".
+func NewGetDocumentEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DataRequest)
+		return s.GetDocument(ctx, p)
+	}
+}
+
+// NewGetDocumentWithInputEndpoint returns an endpoint function that calls the
+// method "GetDocumentWithInput" of service "data".
+func NewGetDocumentWithInputEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DataInputRequest)
+		return s.GetDocumentWithInput(ctx, p)
+	}
+}
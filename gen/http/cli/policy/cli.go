@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 
+	datac "github.com/eclipse-xfsc/custom-policy-agent/gen/http/data/client"
 	healthc "github.com/eclipse-xfsc/custom-policy-agent/gen/http/health/client"
 	policyc "github.com/eclipse-xfsc/custom-policy-agent/gen/http/policy/client"
 	goahttp "goa.design/goa/v3/http"
@@ -23,15 +24,17 @@ import (
 //
 //	command (subcommand1|subcommand2|...)
 func UsageCommands() string {
-	return `policy (evaluate|validate|lock|unlock|export-bundle|policy-public-key|import-bundle|list-policies|set-policy-auto-import|policy-auto-import|delete-policy-auto-import|subscribe-for-policy-change)
+	return `policy (evaluate|evaluate-rule|validate|partial-evaluate|evaluate-batch|evaluate-batch-stream|evaluation-result|job-status|lock|unlock|lock-history|policy-tests|export-bundle|policy-public-key|import-bundle|list-policies|decision-logs|audit-log|set-policy-auto-import|policy-auto-import|delete-policy-auto-import|set-policy-alias|policy-aliases|delete-policy-alias|set-policy-shadow|delete-policy-shadow|set-policy-capture|delete-policy-capture|delete-captured-evaluations|replay|subscribe-for-policy-change)
 health (liveness|readiness)
+data (get-document|get-document-with-input)
 `
 }
 
 // UsageExamples produces an example of a valid invocation of the CLI tool.
 func UsageExamples() string {
-	return os.Args[0] + ` policy evaluate --body "At ut dolore." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Similique architecto." --ttl 873309878343572558` + "\n" +
+	return os.Args[0] + ` policy evaluate --body "Provident sint." --repository "policies" --group "example" --policy-name "example" --version "1.0" --explain "off" --async false --evaluation-id "Voluptatem eligendi." --ttl 1371144262199360357 --callback-url "https://example.com/callback"` + "\n" +
 		os.Args[0] + ` health liveness` + "\n" +
+		os.Args[0] + ` data get-document --path "example/example/allow" --repository "policies" --version "1.0" --evaluation-id "Veritatis consequuntur dolorem ab tempora et et."` + "\n" +
 		""
 }
 
@@ -53,8 +56,24 @@ func ParseEndpoint(
 		policyEvaluateGroupFlag        = policyEvaluateFlags.String("group", "REQUIRED", "Policy group.")
 		policyEvaluatePolicyNameFlag   = policyEvaluateFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyEvaluateVersionFlag      = policyEvaluateFlags.String("version", "REQUIRED", "Policy version.")
+		policyEvaluateExplainFlag      = policyEvaluateFlags.String("explain", "", "")
+		policyEvaluateAsyncFlag        = policyEvaluateFlags.String("async", "", "")
 		policyEvaluateEvaluationIDFlag = policyEvaluateFlags.String("evaluation-id", "", "")
 		policyEvaluateTTLFlag          = policyEvaluateFlags.String("ttl", "", "")
+		policyEvaluateCallbackURLFlag  = policyEvaluateFlags.String("callback-url", "", "")
+
+		policyEvaluateRuleFlags            = flag.NewFlagSet("evaluate-rule", flag.ExitOnError)
+		policyEvaluateRuleBodyFlag         = policyEvaluateRuleFlags.String("body", "REQUIRED", "")
+		policyEvaluateRuleRepositoryFlag   = policyEvaluateRuleFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyEvaluateRuleGroupFlag        = policyEvaluateRuleFlags.String("group", "REQUIRED", "Policy group.")
+		policyEvaluateRulePolicyNameFlag   = policyEvaluateRuleFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyEvaluateRuleVersionFlag      = policyEvaluateRuleFlags.String("version", "REQUIRED", "Policy version.")
+		policyEvaluateRuleRuleFlag         = policyEvaluateRuleFlags.String("rule", "REQUIRED", "Path of a rule inside the policy package, e.g. 'allow' or 'violations/critical'. If it's given, only the value of the rule is returned instead of the whole package.")
+		policyEvaluateRuleExplainFlag      = policyEvaluateRuleFlags.String("explain", "", "")
+		policyEvaluateRuleAsyncFlag        = policyEvaluateRuleFlags.String("async", "", "")
+		policyEvaluateRuleEvaluationIDFlag = policyEvaluateRuleFlags.String("evaluation-id", "", "")
+		policyEvaluateRuleTTLFlag          = policyEvaluateRuleFlags.String("ttl", "", "")
+		policyEvaluateRuleCallbackURLFlag  = policyEvaluateRuleFlags.String("callback-url", "", "")
 
 		policyValidateFlags            = flag.NewFlagSet("validate", flag.ExitOnError)
 		policyValidateBodyFlag         = policyValidateFlags.String("body", "REQUIRED", "")
@@ -62,21 +81,58 @@ func ParseEndpoint(
 		policyValidateGroupFlag        = policyValidateFlags.String("group", "REQUIRED", "Policy group.")
 		policyValidatePolicyNameFlag   = policyValidateFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyValidateVersionFlag      = policyValidateFlags.String("version", "REQUIRED", "Policy version.")
+		policyValidateExplainFlag      = policyValidateFlags.String("explain", "", "")
+		policyValidateReportFlag       = policyValidateFlags.String("report", "", "")
+		policyValidateCoerceFlag       = policyValidateFlags.String("coerce", "", "")
 		policyValidateEvaluationIDFlag = policyValidateFlags.String("evaluation-id", "", "")
 		policyValidateTTLFlag          = policyValidateFlags.String("ttl", "", "")
 
+		policyPartialEvaluateFlags          = flag.NewFlagSet("partial-evaluate", flag.ExitOnError)
+		policyPartialEvaluateBodyFlag       = policyPartialEvaluateFlags.String("body", "REQUIRED", "")
+		policyPartialEvaluateRepositoryFlag = policyPartialEvaluateFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyPartialEvaluateGroupFlag      = policyPartialEvaluateFlags.String("group", "REQUIRED", "Policy group.")
+		policyPartialEvaluatePolicyNameFlag = policyPartialEvaluateFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyPartialEvaluateVersionFlag    = policyPartialEvaluateFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyEvaluateBatchFlags    = flag.NewFlagSet("evaluate-batch", flag.ExitOnError)
+		policyEvaluateBatchBodyFlag = policyEvaluateBatchFlags.String("body", "REQUIRED", "")
+
+		policyEvaluateBatchStreamFlags      = flag.NewFlagSet("evaluate-batch-stream", flag.ExitOnError)
+		policyEvaluateBatchStreamStreamFlag = policyEvaluateBatchStreamFlags.String("stream", "REQUIRED", "path to file containing the streamed request body")
+
+		policyEvaluationResultFlags            = flag.NewFlagSet("evaluation-result", flag.ExitOnError)
+		policyEvaluationResultEvaluationIDFlag = policyEvaluationResultFlags.String("evaluation-id", "REQUIRED", "Identifier of the policy evaluation returned as ETag or set with the x-evaluation-id header.")
+		policyEvaluationResultWaitFlag         = policyEvaluationResultFlags.String("wait", "", "")
+
+		policyJobStatusFlags     = flag.NewFlagSet("job-status", flag.ExitOnError)
+		policyJobStatusJobIDFlag = policyJobStatusFlags.String("job-id", "REQUIRED", "Identifier of the asynchronous evaluation job.")
+
 		policyLockFlags          = flag.NewFlagSet("lock", flag.ExitOnError)
+		policyLockBodyFlag       = policyLockFlags.String("body", "REQUIRED", "")
 		policyLockRepositoryFlag = policyLockFlags.String("repository", "REQUIRED", "Policy repository.")
 		policyLockGroupFlag      = policyLockFlags.String("group", "REQUIRED", "Policy group.")
 		policyLockPolicyNameFlag = policyLockFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyLockVersionFlag    = policyLockFlags.String("version", "REQUIRED", "Policy version.")
 
 		policyUnlockFlags          = flag.NewFlagSet("unlock", flag.ExitOnError)
+		policyUnlockBodyFlag       = policyUnlockFlags.String("body", "REQUIRED", "")
 		policyUnlockRepositoryFlag = policyUnlockFlags.String("repository", "REQUIRED", "Policy repository.")
 		policyUnlockGroupFlag      = policyUnlockFlags.String("group", "REQUIRED", "Policy group.")
 		policyUnlockPolicyNameFlag = policyUnlockFlags.String("policy-name", "REQUIRED", "Policy name.")
 		policyUnlockVersionFlag    = policyUnlockFlags.String("version", "REQUIRED", "Policy version.")
 
+		policyLockHistoryFlags          = flag.NewFlagSet("lock-history", flag.ExitOnError)
+		policyLockHistoryRepositoryFlag = policyLockHistoryFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyLockHistoryGroupFlag      = policyLockHistoryFlags.String("group", "REQUIRED", "Policy group.")
+		policyLockHistoryPolicyNameFlag = policyLockHistoryFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyLockHistoryVersionFlag    = policyLockHistoryFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyPolicyTestsFlags          = flag.NewFlagSet("policy-tests", flag.ExitOnError)
+		policyPolicyTestsRepositoryFlag = policyPolicyTestsFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyPolicyTestsGroupFlag      = policyPolicyTestsFlags.String("group", "REQUIRED", "Policy group.")
+		policyPolicyTestsPolicyNameFlag = policyPolicyTestsFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyPolicyTestsVersionFlag    = policyPolicyTestsFlags.String("version", "REQUIRED", "Policy version.")
+
 		policyExportBundleFlags          = flag.NewFlagSet("export-bundle", flag.ExitOnError)
 		policyExportBundleRepositoryFlag = policyExportBundleFlags.String("repository", "REQUIRED", "Policy repository.")
 		policyExportBundleGroupFlag      = policyExportBundleFlags.String("group", "REQUIRED", "Policy group.")
@@ -100,6 +156,30 @@ func ParseEndpoint(
 		policyListPoliciesDataFlag       = policyListPoliciesFlags.String("data", "", "")
 		policyListPoliciesDataConfigFlag = policyListPoliciesFlags.String("data-config", "", "")
 
+		policyDecisionLogsFlags            = flag.NewFlagSet("decision-logs", flag.ExitOnError)
+		policyDecisionLogsRepositoryFlag   = policyDecisionLogsFlags.String("repository", "", "")
+		policyDecisionLogsGroupFlag        = policyDecisionLogsFlags.String("group", "", "")
+		policyDecisionLogsPolicyNameFlag   = policyDecisionLogsFlags.String("policy-name", "", "")
+		policyDecisionLogsVersionFlag      = policyDecisionLogsFlags.String("version", "", "")
+		policyDecisionLogsEvaluationIDFlag = policyDecisionLogsFlags.String("evaluation-id", "", "")
+		policyDecisionLogsCallerFlag       = policyDecisionLogsFlags.String("caller", "", "")
+		policyDecisionLogsFromFlag         = policyDecisionLogsFlags.String("from", "", "")
+		policyDecisionLogsToFlag           = policyDecisionLogsFlags.String("to", "", "")
+		policyDecisionLogsLimitFlag        = policyDecisionLogsFlags.String("limit", "100", "")
+		policyDecisionLogsOffsetFlag       = policyDecisionLogsFlags.String("offset", "", "")
+
+		policyAuditLogFlags          = flag.NewFlagSet("audit-log", flag.ExitOnError)
+		policyAuditLogOperationFlag  = policyAuditLogFlags.String("operation", "", "")
+		policyAuditLogActorFlag      = policyAuditLogFlags.String("actor", "", "")
+		policyAuditLogRepositoryFlag = policyAuditLogFlags.String("repository", "", "")
+		policyAuditLogGroupFlag      = policyAuditLogFlags.String("group", "", "")
+		policyAuditLogPolicyNameFlag = policyAuditLogFlags.String("policy-name", "", "")
+		policyAuditLogVersionFlag    = policyAuditLogFlags.String("version", "", "")
+		policyAuditLogFromFlag       = policyAuditLogFlags.String("from", "", "")
+		policyAuditLogToFlag         = policyAuditLogFlags.String("to", "", "")
+		policyAuditLogLimitFlag      = policyAuditLogFlags.String("limit", "100", "")
+		policyAuditLogOffsetFlag     = policyAuditLogFlags.String("offset", "", "")
+
 		policySetPolicyAutoImportFlags    = flag.NewFlagSet("set-policy-auto-import", flag.ExitOnError)
 		policySetPolicyAutoImportBodyFlag = policySetPolicyAutoImportFlags.String("body", "REQUIRED", "")
 
@@ -108,6 +188,63 @@ func ParseEndpoint(
 		policyDeletePolicyAutoImportFlags    = flag.NewFlagSet("delete-policy-auto-import", flag.ExitOnError)
 		policyDeletePolicyAutoImportBodyFlag = policyDeletePolicyAutoImportFlags.String("body", "REQUIRED", "")
 
+		policySetPolicyAliasFlags          = flag.NewFlagSet("set-policy-alias", flag.ExitOnError)
+		policySetPolicyAliasBodyFlag       = policySetPolicyAliasFlags.String("body", "REQUIRED", "")
+		policySetPolicyAliasRepositoryFlag = policySetPolicyAliasFlags.String("repository", "REQUIRED", "Policy repository.")
+		policySetPolicyAliasGroupFlag      = policySetPolicyAliasFlags.String("group", "REQUIRED", "Policy group.")
+		policySetPolicyAliasPolicyNameFlag = policySetPolicyAliasFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policySetPolicyAliasAliasFlag      = policySetPolicyAliasFlags.String("alias", "REQUIRED", "Alias name which can be used instead of the policy version.")
+
+		policyPolicyAliasesFlags          = flag.NewFlagSet("policy-aliases", flag.ExitOnError)
+		policyPolicyAliasesRepositoryFlag = policyPolicyAliasesFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyPolicyAliasesGroupFlag      = policyPolicyAliasesFlags.String("group", "REQUIRED", "Policy group.")
+		policyPolicyAliasesPolicyNameFlag = policyPolicyAliasesFlags.String("policy-name", "REQUIRED", "Policy name.")
+
+		policyDeletePolicyAliasFlags          = flag.NewFlagSet("delete-policy-alias", flag.ExitOnError)
+		policyDeletePolicyAliasRepositoryFlag = policyDeletePolicyAliasFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDeletePolicyAliasGroupFlag      = policyDeletePolicyAliasFlags.String("group", "REQUIRED", "Policy group.")
+		policyDeletePolicyAliasPolicyNameFlag = policyDeletePolicyAliasFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDeletePolicyAliasAliasFlag      = policyDeletePolicyAliasFlags.String("alias", "REQUIRED", "Alias name.")
+
+		policySetPolicyShadowFlags          = flag.NewFlagSet("set-policy-shadow", flag.ExitOnError)
+		policySetPolicyShadowBodyFlag       = policySetPolicyShadowFlags.String("body", "REQUIRED", "")
+		policySetPolicyShadowRepositoryFlag = policySetPolicyShadowFlags.String("repository", "REQUIRED", "Policy repository.")
+		policySetPolicyShadowGroupFlag      = policySetPolicyShadowFlags.String("group", "REQUIRED", "Policy group.")
+		policySetPolicyShadowPolicyNameFlag = policySetPolicyShadowFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policySetPolicyShadowVersionFlag    = policySetPolicyShadowFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyDeletePolicyShadowFlags          = flag.NewFlagSet("delete-policy-shadow", flag.ExitOnError)
+		policyDeletePolicyShadowRepositoryFlag = policyDeletePolicyShadowFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDeletePolicyShadowGroupFlag      = policyDeletePolicyShadowFlags.String("group", "REQUIRED", "Policy group.")
+		policyDeletePolicyShadowPolicyNameFlag = policyDeletePolicyShadowFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDeletePolicyShadowVersionFlag    = policyDeletePolicyShadowFlags.String("version", "REQUIRED", "Policy version.")
+
+		policySetPolicyCaptureFlags          = flag.NewFlagSet("set-policy-capture", flag.ExitOnError)
+		policySetPolicyCaptureBodyFlag       = policySetPolicyCaptureFlags.String("body", "REQUIRED", "")
+		policySetPolicyCaptureRepositoryFlag = policySetPolicyCaptureFlags.String("repository", "REQUIRED", "Policy repository.")
+		policySetPolicyCaptureGroupFlag      = policySetPolicyCaptureFlags.String("group", "REQUIRED", "Policy group.")
+		policySetPolicyCapturePolicyNameFlag = policySetPolicyCaptureFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policySetPolicyCaptureVersionFlag    = policySetPolicyCaptureFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyDeletePolicyCaptureFlags          = flag.NewFlagSet("delete-policy-capture", flag.ExitOnError)
+		policyDeletePolicyCaptureRepositoryFlag = policyDeletePolicyCaptureFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDeletePolicyCaptureGroupFlag      = policyDeletePolicyCaptureFlags.String("group", "REQUIRED", "Policy group.")
+		policyDeletePolicyCapturePolicyNameFlag = policyDeletePolicyCaptureFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDeletePolicyCaptureVersionFlag    = policyDeletePolicyCaptureFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyDeleteCapturedEvaluationsFlags          = flag.NewFlagSet("delete-captured-evaluations", flag.ExitOnError)
+		policyDeleteCapturedEvaluationsRepositoryFlag = policyDeleteCapturedEvaluationsFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyDeleteCapturedEvaluationsGroupFlag      = policyDeleteCapturedEvaluationsFlags.String("group", "REQUIRED", "Policy group.")
+		policyDeleteCapturedEvaluationsPolicyNameFlag = policyDeleteCapturedEvaluationsFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyDeleteCapturedEvaluationsVersionFlag    = policyDeleteCapturedEvaluationsFlags.String("version", "REQUIRED", "Policy version.")
+
+		policyReplayFlags          = flag.NewFlagSet("replay", flag.ExitOnError)
+		policyReplayBodyFlag       = policyReplayFlags.String("body", "REQUIRED", "")
+		policyReplayRepositoryFlag = policyReplayFlags.String("repository", "REQUIRED", "Policy repository.")
+		policyReplayGroupFlag      = policyReplayFlags.String("group", "REQUIRED", "Policy group.")
+		policyReplayPolicyNameFlag = policyReplayFlags.String("policy-name", "REQUIRED", "Policy name.")
+		policyReplayVersionFlag    = policyReplayFlags.String("version", "REQUIRED", "Policy version whose recorded evaluations are replayed.")
+
 		policySubscribeForPolicyChangeFlags          = flag.NewFlagSet("subscribe-for-policy-change", flag.ExitOnError)
 		policySubscribeForPolicyChangeBodyFlag       = policySubscribeForPolicyChangeFlags.String("body", "REQUIRED", "")
 		policySubscribeForPolicyChangeRepositoryFlag = policySubscribeForPolicyChangeFlags.String("repository", "REQUIRED", "Policy repository.")
@@ -120,25 +257,63 @@ func ParseEndpoint(
 		healthLivenessFlags = flag.NewFlagSet("liveness", flag.ExitOnError)
 
 		healthReadinessFlags = flag.NewFlagSet("readiness", flag.ExitOnError)
+
+		dataFlags = flag.NewFlagSet("data", flag.ContinueOnError)
+
+		dataGetDocumentFlags            = flag.NewFlagSet("get-document", flag.ExitOnError)
+		dataGetDocumentPathFlag         = dataGetDocumentFlags.String("path", "REQUIRED", "Path of the document, which starts with the policy group and name followed by an optional rule path, e.g. 'example/example/allow'.")
+		dataGetDocumentRepositoryFlag   = dataGetDocumentFlags.String("repository", "", "")
+		dataGetDocumentVersionFlag      = dataGetDocumentFlags.String("version", "", "")
+		dataGetDocumentEvaluationIDFlag = dataGetDocumentFlags.String("evaluation-id", "", "")
+
+		dataGetDocumentWithInputFlags            = flag.NewFlagSet("get-document-with-input", flag.ExitOnError)
+		dataGetDocumentWithInputBodyFlag         = dataGetDocumentWithInputFlags.String("body", "REQUIRED", "")
+		dataGetDocumentWithInputPathFlag         = dataGetDocumentWithInputFlags.String("path", "REQUIRED", "Path of the document, which starts with the policy group and name followed by an optional rule path, e.g. 'example/example/allow'.")
+		dataGetDocumentWithInputRepositoryFlag   = dataGetDocumentWithInputFlags.String("repository", "", "")
+		dataGetDocumentWithInputVersionFlag      = dataGetDocumentWithInputFlags.String("version", "", "")
+		dataGetDocumentWithInputEvaluationIDFlag = dataGetDocumentWithInputFlags.String("evaluation-id", "", "")
 	)
 	policyFlags.Usage = policyUsage
 	policyEvaluateFlags.Usage = policyEvaluateUsage
+	policyEvaluateRuleFlags.Usage = policyEvaluateRuleUsage
 	policyValidateFlags.Usage = policyValidateUsage
+	policyPartialEvaluateFlags.Usage = policyPartialEvaluateUsage
+	policyEvaluateBatchFlags.Usage = policyEvaluateBatchUsage
+	policyEvaluateBatchStreamFlags.Usage = policyEvaluateBatchStreamUsage
+	policyEvaluationResultFlags.Usage = policyEvaluationResultUsage
+	policyJobStatusFlags.Usage = policyJobStatusUsage
 	policyLockFlags.Usage = policyLockUsage
 	policyUnlockFlags.Usage = policyUnlockUsage
+	policyLockHistoryFlags.Usage = policyLockHistoryUsage
+	policyPolicyTestsFlags.Usage = policyPolicyTestsUsage
 	policyExportBundleFlags.Usage = policyExportBundleUsage
 	policyPolicyPublicKeyFlags.Usage = policyPolicyPublicKeyUsage
 	policyImportBundleFlags.Usage = policyImportBundleUsage
 	policyListPoliciesFlags.Usage = policyListPoliciesUsage
+	policyDecisionLogsFlags.Usage = policyDecisionLogsUsage
+	policyAuditLogFlags.Usage = policyAuditLogUsage
 	policySetPolicyAutoImportFlags.Usage = policySetPolicyAutoImportUsage
 	policyPolicyAutoImportFlags.Usage = policyPolicyAutoImportUsage
 	policyDeletePolicyAutoImportFlags.Usage = policyDeletePolicyAutoImportUsage
+	policySetPolicyAliasFlags.Usage = policySetPolicyAliasUsage
+	policyPolicyAliasesFlags.Usage = policyPolicyAliasesUsage
+	policyDeletePolicyAliasFlags.Usage = policyDeletePolicyAliasUsage
+	policySetPolicyShadowFlags.Usage = policySetPolicyShadowUsage
+	policyDeletePolicyShadowFlags.Usage = policyDeletePolicyShadowUsage
+	policySetPolicyCaptureFlags.Usage = policySetPolicyCaptureUsage
+	policyDeletePolicyCaptureFlags.Usage = policyDeletePolicyCaptureUsage
+	policyDeleteCapturedEvaluationsFlags.Usage = policyDeleteCapturedEvaluationsUsage
+	policyReplayFlags.Usage = policyReplayUsage
 	policySubscribeForPolicyChangeFlags.Usage = policySubscribeForPolicyChangeUsage
 
 	healthFlags.Usage = healthUsage
 	healthLivenessFlags.Usage = healthLivenessUsage
 	healthReadinessFlags.Usage = healthReadinessUsage
 
+	dataFlags.Usage = dataUsage
+	dataGetDocumentFlags.Usage = dataGetDocumentUsage
+	dataGetDocumentWithInputFlags.Usage = dataGetDocumentWithInputUsage
+
 	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
 		return nil, nil, err
 	}
@@ -158,6 +333,8 @@ func ParseEndpoint(
 			svcf = policyFlags
 		case "health":
 			svcf = healthFlags
+		case "data":
+			svcf = dataFlags
 		default:
 			return nil, nil, fmt.Errorf("unknown service %q", svcn)
 		}
@@ -178,15 +355,39 @@ func ParseEndpoint(
 			case "evaluate":
 				epf = policyEvaluateFlags
 
+			case "evaluate-rule":
+				epf = policyEvaluateRuleFlags
+
 			case "validate":
 				epf = policyValidateFlags
 
+			case "partial-evaluate":
+				epf = policyPartialEvaluateFlags
+
+			case "evaluate-batch":
+				epf = policyEvaluateBatchFlags
+
+			case "evaluate-batch-stream":
+				epf = policyEvaluateBatchStreamFlags
+
+			case "evaluation-result":
+				epf = policyEvaluationResultFlags
+
+			case "job-status":
+				epf = policyJobStatusFlags
+
 			case "lock":
 				epf = policyLockFlags
 
 			case "unlock":
 				epf = policyUnlockFlags
 
+			case "lock-history":
+				epf = policyLockHistoryFlags
+
+			case "policy-tests":
+				epf = policyPolicyTestsFlags
+
 			case "export-bundle":
 				epf = policyExportBundleFlags
 
@@ -199,6 +400,12 @@ func ParseEndpoint(
 			case "list-policies":
 				epf = policyListPoliciesFlags
 
+			case "decision-logs":
+				epf = policyDecisionLogsFlags
+
+			case "audit-log":
+				epf = policyAuditLogFlags
+
 			case "set-policy-auto-import":
 				epf = policySetPolicyAutoImportFlags
 
@@ -208,6 +415,33 @@ func ParseEndpoint(
 			case "delete-policy-auto-import":
 				epf = policyDeletePolicyAutoImportFlags
 
+			case "set-policy-alias":
+				epf = policySetPolicyAliasFlags
+
+			case "policy-aliases":
+				epf = policyPolicyAliasesFlags
+
+			case "delete-policy-alias":
+				epf = policyDeletePolicyAliasFlags
+
+			case "set-policy-shadow":
+				epf = policySetPolicyShadowFlags
+
+			case "delete-policy-shadow":
+				epf = policyDeletePolicyShadowFlags
+
+			case "set-policy-capture":
+				epf = policySetPolicyCaptureFlags
+
+			case "delete-policy-capture":
+				epf = policyDeletePolicyCaptureFlags
+
+			case "delete-captured-evaluations":
+				epf = policyDeleteCapturedEvaluationsFlags
+
+			case "replay":
+				epf = policyReplayFlags
+
 			case "subscribe-for-policy-change":
 				epf = policySubscribeForPolicyChangeFlags
 
@@ -223,6 +457,16 @@ func ParseEndpoint(
 
 			}
 
+		case "data":
+			switch epn {
+			case "get-document":
+				epf = dataGetDocumentFlags
+
+			case "get-document-with-input":
+				epf = dataGetDocumentWithInputFlags
+
+			}
+
 		}
 	}
 	if epf == nil {
@@ -248,16 +492,40 @@ func ParseEndpoint(
 			switch epn {
 			case "evaluate":
 				endpoint = c.Evaluate()
-				data, err = policyc.BuildEvaluatePayload(*policyEvaluateBodyFlag, *policyEvaluateRepositoryFlag, *policyEvaluateGroupFlag, *policyEvaluatePolicyNameFlag, *policyEvaluateVersionFlag, *policyEvaluateEvaluationIDFlag, *policyEvaluateTTLFlag)
+				data, err = policyc.BuildEvaluatePayload(*policyEvaluateBodyFlag, *policyEvaluateRepositoryFlag, *policyEvaluateGroupFlag, *policyEvaluatePolicyNameFlag, *policyEvaluateVersionFlag, *policyEvaluateExplainFlag, *policyEvaluateAsyncFlag, *policyEvaluateEvaluationIDFlag, *policyEvaluateTTLFlag, *policyEvaluateCallbackURLFlag)
+			case "evaluate-rule":
+				endpoint = c.EvaluateRule()
+				data, err = policyc.BuildEvaluateRulePayload(*policyEvaluateRuleBodyFlag, *policyEvaluateRuleRepositoryFlag, *policyEvaluateRuleGroupFlag, *policyEvaluateRulePolicyNameFlag, *policyEvaluateRuleVersionFlag, *policyEvaluateRuleRuleFlag, *policyEvaluateRuleExplainFlag, *policyEvaluateRuleAsyncFlag, *policyEvaluateRuleEvaluationIDFlag, *policyEvaluateRuleTTLFlag, *policyEvaluateRuleCallbackURLFlag)
 			case "validate":
 				endpoint = c.Validate()
-				data, err = policyc.BuildValidatePayload(*policyValidateBodyFlag, *policyValidateRepositoryFlag, *policyValidateGroupFlag, *policyValidatePolicyNameFlag, *policyValidateVersionFlag, *policyValidateEvaluationIDFlag, *policyValidateTTLFlag)
+				data, err = policyc.BuildValidatePayload(*policyValidateBodyFlag, *policyValidateRepositoryFlag, *policyValidateGroupFlag, *policyValidatePolicyNameFlag, *policyValidateVersionFlag, *policyValidateExplainFlag, *policyValidateReportFlag, *policyValidateCoerceFlag, *policyValidateEvaluationIDFlag, *policyValidateTTLFlag)
+			case "partial-evaluate":
+				endpoint = c.PartialEvaluate()
+				data, err = policyc.BuildPartialEvaluatePayload(*policyPartialEvaluateBodyFlag, *policyPartialEvaluateRepositoryFlag, *policyPartialEvaluateGroupFlag, *policyPartialEvaluatePolicyNameFlag, *policyPartialEvaluateVersionFlag)
+			case "evaluate-batch":
+				endpoint = c.EvaluateBatch()
+				data, err = policyc.BuildEvaluateBatchPayload(*policyEvaluateBatchBodyFlag)
+			case "evaluate-batch-stream":
+				endpoint = c.EvaluateBatchStream()
+				data, err = policyc.BuildEvaluateBatchStreamStreamPayload(*policyEvaluateBatchStreamStreamFlag)
+			case "evaluation-result":
+				endpoint = c.EvaluationResult()
+				data, err = policyc.BuildEvaluationResultPayload(*policyEvaluationResultEvaluationIDFlag, *policyEvaluationResultWaitFlag)
+			case "job-status":
+				endpoint = c.JobStatus()
+				data, err = policyc.BuildJobStatusPayload(*policyJobStatusJobIDFlag)
 			case "lock":
 				endpoint = c.Lock()
-				data, err = policyc.BuildLockPayload(*policyLockRepositoryFlag, *policyLockGroupFlag, *policyLockPolicyNameFlag, *policyLockVersionFlag)
+				data, err = policyc.BuildLockPayload(*policyLockBodyFlag, *policyLockRepositoryFlag, *policyLockGroupFlag, *policyLockPolicyNameFlag, *policyLockVersionFlag)
 			case "unlock":
 				endpoint = c.Unlock()
-				data, err = policyc.BuildUnlockPayload(*policyUnlockRepositoryFlag, *policyUnlockGroupFlag, *policyUnlockPolicyNameFlag, *policyUnlockVersionFlag)
+				data, err = policyc.BuildUnlockPayload(*policyUnlockBodyFlag, *policyUnlockRepositoryFlag, *policyUnlockGroupFlag, *policyUnlockPolicyNameFlag, *policyUnlockVersionFlag)
+			case "lock-history":
+				endpoint = c.LockHistory()
+				data, err = policyc.BuildLockHistoryPayload(*policyLockHistoryRepositoryFlag, *policyLockHistoryGroupFlag, *policyLockHistoryPolicyNameFlag, *policyLockHistoryVersionFlag)
+			case "policy-tests":
+				endpoint = c.PolicyTests()
+				data, err = policyc.BuildPolicyTestsPayload(*policyPolicyTestsRepositoryFlag, *policyPolicyTestsGroupFlag, *policyPolicyTestsPolicyNameFlag, *policyPolicyTestsVersionFlag)
 			case "export-bundle":
 				endpoint = c.ExportBundle()
 				data, err = policyc.BuildExportBundlePayload(*policyExportBundleRepositoryFlag, *policyExportBundleGroupFlag, *policyExportBundlePolicyNameFlag, *policyExportBundleVersionFlag)
@@ -273,6 +541,12 @@ func ParseEndpoint(
 			case "list-policies":
 				endpoint = c.ListPolicies()
 				data, err = policyc.BuildListPoliciesPayload(*policyListPoliciesLockedFlag, *policyListPoliciesPolicyNameFlag, *policyListPoliciesRegoFlag, *policyListPoliciesDataFlag, *policyListPoliciesDataConfigFlag)
+			case "decision-logs":
+				endpoint = c.DecisionLogs()
+				data, err = policyc.BuildDecisionLogsPayload(*policyDecisionLogsRepositoryFlag, *policyDecisionLogsGroupFlag, *policyDecisionLogsPolicyNameFlag, *policyDecisionLogsVersionFlag, *policyDecisionLogsEvaluationIDFlag, *policyDecisionLogsCallerFlag, *policyDecisionLogsFromFlag, *policyDecisionLogsToFlag, *policyDecisionLogsLimitFlag, *policyDecisionLogsOffsetFlag)
+			case "audit-log":
+				endpoint = c.AuditLog()
+				data, err = policyc.BuildAuditLogPayload(*policyAuditLogOperationFlag, *policyAuditLogActorFlag, *policyAuditLogRepositoryFlag, *policyAuditLogGroupFlag, *policyAuditLogPolicyNameFlag, *policyAuditLogVersionFlag, *policyAuditLogFromFlag, *policyAuditLogToFlag, *policyAuditLogLimitFlag, *policyAuditLogOffsetFlag)
 			case "set-policy-auto-import":
 				endpoint = c.SetPolicyAutoImport()
 				data, err = policyc.BuildSetPolicyAutoImportPayload(*policySetPolicyAutoImportBodyFlag)
@@ -281,6 +555,33 @@ func ParseEndpoint(
 			case "delete-policy-auto-import":
 				endpoint = c.DeletePolicyAutoImport()
 				data, err = policyc.BuildDeletePolicyAutoImportPayload(*policyDeletePolicyAutoImportBodyFlag)
+			case "set-policy-alias":
+				endpoint = c.SetPolicyAlias()
+				data, err = policyc.BuildSetPolicyAliasPayload(*policySetPolicyAliasBodyFlag, *policySetPolicyAliasRepositoryFlag, *policySetPolicyAliasGroupFlag, *policySetPolicyAliasPolicyNameFlag, *policySetPolicyAliasAliasFlag)
+			case "policy-aliases":
+				endpoint = c.PolicyAliases()
+				data, err = policyc.BuildPolicyAliasesPayload(*policyPolicyAliasesRepositoryFlag, *policyPolicyAliasesGroupFlag, *policyPolicyAliasesPolicyNameFlag)
+			case "delete-policy-alias":
+				endpoint = c.DeletePolicyAlias()
+				data, err = policyc.BuildDeletePolicyAliasPayload(*policyDeletePolicyAliasRepositoryFlag, *policyDeletePolicyAliasGroupFlag, *policyDeletePolicyAliasPolicyNameFlag, *policyDeletePolicyAliasAliasFlag)
+			case "set-policy-shadow":
+				endpoint = c.SetPolicyShadow()
+				data, err = policyc.BuildSetPolicyShadowPayload(*policySetPolicyShadowBodyFlag, *policySetPolicyShadowRepositoryFlag, *policySetPolicyShadowGroupFlag, *policySetPolicyShadowPolicyNameFlag, *policySetPolicyShadowVersionFlag)
+			case "delete-policy-shadow":
+				endpoint = c.DeletePolicyShadow()
+				data, err = policyc.BuildDeletePolicyShadowPayload(*policyDeletePolicyShadowRepositoryFlag, *policyDeletePolicyShadowGroupFlag, *policyDeletePolicyShadowPolicyNameFlag, *policyDeletePolicyShadowVersionFlag)
+			case "set-policy-capture":
+				endpoint = c.SetPolicyCapture()
+				data, err = policyc.BuildSetPolicyCapturePayload(*policySetPolicyCaptureBodyFlag, *policySetPolicyCaptureRepositoryFlag, *policySetPolicyCaptureGroupFlag, *policySetPolicyCapturePolicyNameFlag, *policySetPolicyCaptureVersionFlag)
+			case "delete-policy-capture":
+				endpoint = c.DeletePolicyCapture()
+				data, err = policyc.BuildDeletePolicyCapturePayload(*policyDeletePolicyCaptureRepositoryFlag, *policyDeletePolicyCaptureGroupFlag, *policyDeletePolicyCapturePolicyNameFlag, *policyDeletePolicyCaptureVersionFlag)
+			case "delete-captured-evaluations":
+				endpoint = c.DeleteCapturedEvaluations()
+				data, err = policyc.BuildDeleteCapturedEvaluationsPayload(*policyDeleteCapturedEvaluationsRepositoryFlag, *policyDeleteCapturedEvaluationsGroupFlag, *policyDeleteCapturedEvaluationsPolicyNameFlag, *policyDeleteCapturedEvaluationsVersionFlag)
+			case "replay":
+				endpoint = c.Replay()
+				data, err = policyc.BuildReplayPayload(*policyReplayBodyFlag, *policyReplayRepositoryFlag, *policyReplayGroupFlag, *policyReplayPolicyNameFlag, *policyReplayVersionFlag)
 			case "subscribe-for-policy-change":
 				endpoint = c.SubscribeForPolicyChange()
 				data, err = policyc.BuildSubscribeForPolicyChangePayload(*policySubscribeForPolicyChangeBodyFlag, *policySubscribeForPolicyChangeRepositoryFlag, *policySubscribeForPolicyChangeGroupFlag, *policySubscribeForPolicyChangePolicyNameFlag, *policySubscribeForPolicyChangeVersionFlag)
@@ -293,6 +594,16 @@ func ParseEndpoint(
 			case "readiness":
 				endpoint = c.Readiness()
 			}
+		case "data":
+			c := datac.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "get-document":
+				endpoint = c.GetDocument()
+				data, err = datac.BuildGetDocumentPayload(*dataGetDocumentPathFlag, *dataGetDocumentRepositoryFlag, *dataGetDocumentVersionFlag, *dataGetDocumentEvaluationIDFlag)
+			case "get-document-with-input":
+				endpoint = c.GetDocumentWithInput()
+				data, err = datac.BuildGetDocumentWithInputPayload(*dataGetDocumentWithInputBodyFlag, *dataGetDocumentWithInputPathFlag, *dataGetDocumentWithInputRepositoryFlag, *dataGetDocumentWithInputVersionFlag, *dataGetDocumentWithInputEvaluationIDFlag)
+			}
 		}
 	}
 	if err != nil {
@@ -310,16 +621,35 @@ Usage:
 
 COMMAND:
     evaluate: Evaluate executes a policy with the given 'data' as input.
+    evaluate-rule: EvaluateRule executes a policy with the given 'data' as input and returns only the value of the given rule path.
     validate: Validate executes a policy with the given 'data' as input and validates the output schema.
+    partial-evaluate: PartialEvaluate executes partial evaluation of a policy rule with the given unknowns and returns the residual queries.
+    evaluate-batch: EvaluateBatch executes multiple policies with their inputs concurrently and returns a result for each item.
+    evaluate-batch-stream: EvaluateBatchStream executes policies for a stream of newline delimited JSON items and streams back newline delimited JSON results.
+    evaluation-result: EvaluationResult returns the stored result of a policy evaluation by its evaluationID (ETag). If 'wait' is set, the request is held until the result is available or the wait time is over.
+    job-status: JobStatus returns the status of an asynchronous evaluation job.
     lock: Lock a policy so that it cannot be evaluated.
     unlock: Unlock a policy so it can be evaluated again.
+    lock-history: LockHistory returns the current lock and the previous lock and unlock events of a policy.
+    policy-tests: PolicyTests returns the report of the last test run of a policy.
     export-bundle: Export a signed policy bundle.
     policy-public-key: PolicyPublicKey returns the public key in JWK format which must be used to verify a signed policy bundle.
     import-bundle: Import a signed policy bundle.
     list-policies: List policies from storage with optional filters.
+    decision-logs: DecisionLogs returns the recorded decisions of policy evaluations, newest first.
+    audit-log: AuditLog returns the records of state-changing operations, newest first.
     set-policy-auto-import: SetPolicyAutoImport enables automatic import of policy bundle on a given time interval.
     policy-auto-import: PolicyAutoImport returns all automatic import configurations.
     delete-policy-auto-import: DeletePolicyAutoImport removes a single automatic import configuration.
+    set-policy-alias: SetPolicyAlias pins a named alias to a policy version, so that the alias can be used instead of the version in policy URLs.
+    policy-aliases: PolicyAliases returns all named aliases of a policy.
+    delete-policy-alias: DeletePolicyAlias removes a named alias of a policy.
+    set-policy-shadow: SetPolicyShadow configures a candidate version, which is evaluated in shadow with the same input whenever the policy version is evaluated.
+    delete-policy-shadow: DeletePolicyShadow disables the shadow evaluation of a policy version.
+    set-policy-capture: SetPolicyCapture enables the recording of evaluation inputs, headers and results of a policy version, which can be replayed against another version.
+    delete-policy-capture: DeletePolicyCapture disables the recording of evaluations of a policy version. Recorded evaluations are kept.
+    delete-captured-evaluations: DeleteCapturedEvaluations removes the recorded evaluations of a policy version.
+    replay: Replay evaluates the recorded evaluations of a policy version with another version or with an uploaded policy source and returns the differences.
     subscribe-for-policy-change: Subscribe for policy change notifications by registering webhook callbacks which the policy service will call.
 
 Additional help:
@@ -327,7 +657,7 @@ Additional help:
 `, os.Args[0])
 }
 func policyEvaluateUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluate -body JSON -repository STRING -group STRING -policy-name STRING -version STRING -evaluation-id STRING -ttl INT
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluate -body JSON -repository STRING -group STRING -policy-name STRING -version STRING -explain STRING -async BOOL -evaluation-id STRING -ttl INT -callback-url STRING
 
 Evaluate executes a policy with the given 'data' as input.
     -body JSON: 
@@ -335,16 +665,40 @@ Evaluate executes a policy with the given 'data' as input.
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
+    -explain STRING: 
+    -async BOOL: 
+    -evaluation-id STRING: 
+    -ttl INT: 
+    -callback-url STRING: 
+
+Example:
+    %[1]s policy evaluate --body "Provident sint." --repository "policies" --group "example" --policy-name "example" --version "1.0" --explain "off" --async false --evaluation-id "Voluptatem eligendi." --ttl 1371144262199360357 --callback-url "https://example.com/callback"
+`, os.Args[0])
+}
+
+func policyEvaluateRuleUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluate-rule -body JSON -repository STRING -group STRING -policy-name STRING -version STRING -rule STRING -explain STRING -async BOOL -evaluation-id STRING -ttl INT -callback-url STRING
+
+EvaluateRule executes a policy with the given 'data' as input and returns only the value of the given rule path.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+    -rule STRING: Path of a rule inside the policy package, e.g. 'allow' or 'violations/critical'. If it's given, only the value of the rule is returned instead of the whole package.
+    -explain STRING: 
+    -async BOOL: 
     -evaluation-id STRING: 
     -ttl INT: 
+    -callback-url STRING: 
 
 Example:
-    %[1]s policy evaluate --body "At ut dolore." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Similique architecto." --ttl 873309878343572558
+    %[1]s policy evaluate-rule --body "Officiis natus illo ex in enim in." --repository "policies" --group "example" --policy-name "example" --version "1.0" --rule "allow" --explain "off" --async true --evaluation-id "Perferendis fuga quia sed et." --ttl 7730869077296136309 --callback-url "https://example.com/callback"
 `, os.Args[0])
 }
 
 func policyValidateUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy validate -body JSON -repository STRING -group STRING -policy-name STRING -version STRING -evaluation-id STRING -ttl INT
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy validate -body JSON -repository STRING -group STRING -policy-name STRING -version STRING -explain STRING -report BOOL -coerce BOOL -evaluation-id STRING -ttl INT
 
 Validate executes a policy with the given 'data' as input and validates the output schema.
     -body JSON: 
@@ -352,39 +706,165 @@ Validate executes a policy with the given 'data' as input and validates the outp
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
+    -explain STRING: 
+    -report BOOL: 
+    -coerce BOOL: 
     -evaluation-id STRING: 
     -ttl INT: 
 
 Example:
-    %[1]s policy validate --body "Vel non quo." --repository "policies" --group "example" --policy-name "example" --version "1.0" --evaluation-id "Ut quibusdam." --ttl 5809148486376728408
+    %[1]s policy validate --body "Quia impedit." --repository "policies" --group "example" --policy-name "example" --version "1.0" --explain "off" --report false --coerce true --evaluation-id "Laboriosam dolorum." --ttl 7712652247004611923
+`, os.Args[0])
+}
+
+func policyPartialEvaluateUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy partial-evaluate -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+PartialEvaluate executes partial evaluation of a policy rule with the given unknowns and returns the residual queries.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy partial-evaluate --body '{
+      "input": "Fugiat rerum et culpa eaque.",
+      "rule": "fr",
+      "target": "rego",
+      "unknowns": [
+         "input.resource"
+      ]
+   }' --repository "policies" --group "example" --policy-name "example" --version "1.0"
+`, os.Args[0])
+}
+
+func policyEvaluateBatchUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluate-batch -body JSON
+
+EvaluateBatch executes multiple policies with their inputs concurrently and returns a result for each item.
+    -body JSON: 
+
+Example:
+    %[1]s policy evaluate-batch --body '{
+      "items": [
+         {
+            "evaluationID": "Repudiandae maxime molestiae reprehenderit.",
+            "group": "example",
+            "input": "Et aut ut dolor aut.",
+            "policyName": "example",
+            "repository": "policies",
+            "ttl": 3720052407175667836,
+            "version": "1.0"
+         },
+         {
+            "evaluationID": "Repudiandae maxime molestiae reprehenderit.",
+            "group": "example",
+            "input": "Et aut ut dolor aut.",
+            "policyName": "example",
+            "repository": "policies",
+            "ttl": 3720052407175667836,
+            "version": "1.0"
+         }
+      ]
+   }'
+`, os.Args[0])
+}
+
+func policyEvaluateBatchStreamUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluate-batch-stream -stream STRING
+
+EvaluateBatchStream executes policies for a stream of newline delimited JSON items and streams back newline delimited JSON results.
+    -stream STRING: path to file containing the streamed request body
+
+Example:
+    %[1]s policy evaluate-batch-stream --stream "goa.png"
+`, os.Args[0])
+}
+
+func policyEvaluationResultUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy evaluation-result -evaluation-id STRING -wait INT
+
+EvaluationResult returns the stored result of a policy evaluation by its evaluationID (ETag). If 'wait' is set, the request is held until the result is available or the wait time is over.
+    -evaluation-id STRING: Identifier of the policy evaluation returned as ETag or set with the x-evaluation-id header.
+    -wait INT: 
+
+Example:
+    %[1]s policy evaluation-result --evaluation-id "did:web:example.com" --wait 10
+`, os.Args[0])
+}
+
+func policyJobStatusUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy job-status -job-id STRING
+
+JobStatus returns the status of an asynchronous evaluation job.
+    -job-id STRING: Identifier of the asynchronous evaluation job.
+
+Example:
+    %[1]s policy job-status --job-id "Quod iure necessitatibus."
 `, os.Args[0])
 }
 
 func policyLockUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy lock -repository STRING -group STRING -policy-name STRING -version STRING
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy lock -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
 
 Lock a policy so that it cannot be evaluated.
+    -body JSON: 
     -repository STRING: Policy repository.
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
 
 Example:
-    %[1]s policy lock --repository "Tenetur rerum necessitatibus fugit." --group "Ut velit aut nobis repellendus." --policy-name "Rem et occaecati quam." --version "Laborum harum voluptate et ut similique doloremque."
+    %[1]s policy lock --body '{
+      "reason": "suspicious results",
+      "unlockAt": 4917353493931427062
+   }' --repository "Ea illo quisquam adipisci quo." --group "Consequatur eligendi possimus sit." --policy-name "Quibusdam et." --version "Laborum incidunt rerum praesentium optio commodi quis."
 `, os.Args[0])
 }
 
 func policyUnlockUsage() {
-	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy unlock -repository STRING -group STRING -policy-name STRING -version STRING
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy unlock -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
 
 Unlock a policy so it can be evaluated again.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy unlock --body '{
+      "reason": "Dicta rerum natus similique exercitationem facere qui."
+   }' --repository "Ipsa et et ut sit consequuntur." --group "Autem fuga provident." --policy-name "Reprehenderit sit voluptas corrupti quis quia." --version "Beatae et magnam doloremque praesentium magnam."
+`, os.Args[0])
+}
+
+func policyLockHistoryUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy lock-history -repository STRING -group STRING -policy-name STRING -version STRING
+
+LockHistory returns the current lock and the previous lock and unlock events of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy lock-history --repository "Ut quod et iste consectetur voluptatem." --group "Sit omnis." --policy-name "Vitae nesciunt voluptatem voluptatem." --version "Provident aut itaque voluptates."
+`, os.Args[0])
+}
+
+func policyPolicyTestsUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy policy-tests -repository STRING -group STRING -policy-name STRING -version STRING
+
+PolicyTests returns the report of the last test run of a policy.
     -repository STRING: Policy repository.
     -group STRING: Policy group.
     -policy-name STRING: Policy name.
     -version STRING: Policy version.
 
 Example:
-    %[1]s policy unlock --repository "Recusandae eligendi." --group "Id quibusdam quia deserunt officiis." --policy-name "Molestiae sapiente unde." --version "Quae ullam qui."
+    %[1]s policy policy-tests --repository "Ut saepe vel qui pariatur." --group "Doloremque unde et provident qui voluptas ut." --policy-name "Delectus repellendus nulla assumenda ab omnis." --version "Consequatur officia illum itaque."
 `, os.Args[0])
 }
 
@@ -424,7 +904,7 @@ Import a signed policy bundle.
     -stream STRING: path to file containing the streamed request body
 
 Example:
-    %[1]s policy import-bundle --length 7909570965630849359 --stream "goa.png"
+    %[1]s policy import-bundle --length 2490990745783106142 --stream "goa.png"
 `, os.Args[0])
 }
 
@@ -439,7 +919,47 @@ List policies from storage with optional filters.
     -data-config BOOL: 
 
 Example:
-    %[1]s policy list-policies --locked true --policy-name "example" --rego false --data false --data-config true
+    %[1]s policy list-policies --locked false --policy-name "example" --rego false --data true --data-config false
+`, os.Args[0])
+}
+
+func policyDecisionLogsUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy decision-logs -repository STRING -group STRING -policy-name STRING -version STRING -evaluation-id STRING -caller STRING -from INT64 -to INT64 -limit INT -offset INT
+
+DecisionLogs returns the recorded decisions of policy evaluations, newest first.
+    -repository STRING: 
+    -group STRING: 
+    -policy-name STRING: 
+    -version STRING: 
+    -evaluation-id STRING: 
+    -caller STRING: 
+    -from INT64: 
+    -to INT64: 
+    -limit INT: 
+    -offset INT: 
+
+Example:
+    %[1]s policy decision-logs --repository "Unde natus rem." --group "example" --policy-name "example" --version "1.0" --evaluation-id "Adipisci sit atque." --caller "Aperiam impedit et." --from 4894864636967324228 --to 5511277242338552160 --limit 526 --offset 8120001204195593595
+`, os.Args[0])
+}
+
+func policyAuditLogUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy audit-log -operation STRING -actor STRING -repository STRING -group STRING -policy-name STRING -version STRING -from INT64 -to INT64 -limit INT -offset INT
+
+AuditLog returns the records of state-changing operations, newest first.
+    -operation STRING: 
+    -actor STRING: 
+    -repository STRING: 
+    -group STRING: 
+    -policy-name STRING: 
+    -version STRING: 
+    -from INT64: 
+    -to INT64: 
+    -limit INT: 
+    -offset INT: 
+
+Example:
+    %[1]s policy audit-log --operation "lock" --actor "Quae quia tempore." --repository "Eius dolor quia ratione." --group "example" --policy-name "example" --version "1.0" --from 3927783989019873781 --to 4605907663514038199 --limit 365 --offset 3545971078714785997
 `, os.Args[0])
 }
 
@@ -452,7 +972,7 @@ SetPolicyAutoImport enables automatic import of policy bundle on a given time in
 Example:
     %[1]s policy set-policy-auto-import --body '{
       "interval": "1h30m",
-      "policyURL": "http://reichert.com/terence"
+      "policyURL": "http://gorczanygrady.biz/candice_sporer"
    }'
 `, os.Args[0])
 }
@@ -475,11 +995,157 @@ DeletePolicyAutoImport removes a single automatic import configuration.
 
 Example:
     %[1]s policy delete-policy-auto-import --body '{
-      "policyURL": "http://gutkowski.name/adrian.stroman"
+      "policyURL": "http://bins.com/delaney_russel"
    }'
 `, os.Args[0])
 }
 
+func policySetPolicyAliasUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy set-policy-alias -body JSON -repository STRING -group STRING -policy-name STRING -alias STRING
+
+SetPolicyAlias pins a named alias to a policy version, so that the alias can be used instead of the version in policy URLs.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -alias STRING: Alias name which can be used instead of the policy version.
+
+Example:
+    %[1]s policy set-policy-alias --body '{
+      "version": "1.0"
+   }' --repository "Maiores voluptas iusto laudantium molestiae." --group "Sit voluptas minus iste velit itaque inventore." --policy-name "Maiores molestias et repudiandae hic." --alias "production"
+`, os.Args[0])
+}
+
+func policyPolicyAliasesUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy policy-aliases -repository STRING -group STRING -policy-name STRING
+
+PolicyAliases returns all named aliases of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+
+Example:
+    %[1]s policy policy-aliases --repository "Quasi molestiae ad tempore voluptatem nesciunt autem." --group "Amet molestias voluptatum et." --policy-name "Nam ipsum repudiandae."
+`, os.Args[0])
+}
+
+func policyDeletePolicyAliasUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy delete-policy-alias -repository STRING -group STRING -policy-name STRING -alias STRING
+
+DeletePolicyAlias removes a named alias of a policy.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -alias STRING: Alias name.
+
+Example:
+    %[1]s policy delete-policy-alias --repository "Illum cum incidunt." --group "Sequi saepe praesentium reiciendis neque fugit ut." --policy-name "Omnis aliquam eligendi iste." --alias "Iusto occaecati voluptas."
+`, os.Args[0])
+}
+
+func policySetPolicyShadowUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy set-policy-shadow -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+SetPolicyShadow configures a candidate version, which is evaluated in shadow with the same input whenever the policy version is evaluated.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy set-policy-shadow --body '{
+      "shadowVersion": "2.0"
+   }' --repository "Aut dolorem earum aut." --group "Beatae et et." --policy-name "Repellat commodi." --version "Voluptate delectus asperiores quasi quaerat quam."
+`, os.Args[0])
+}
+
+func policyDeletePolicyShadowUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy delete-policy-shadow -repository STRING -group STRING -policy-name STRING -version STRING
+
+DeletePolicyShadow disables the shadow evaluation of a policy version.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy delete-policy-shadow --repository "Rerum rerum voluptatem odio placeat." --group "Sit sed." --policy-name "Voluptas facilis perspiciatis doloribus eaque velit porro." --version "Rerum sunt sed molestias."
+`, os.Args[0])
+}
+
+func policySetPolicyCaptureUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy set-policy-capture -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+SetPolicyCapture enables the recording of evaluation inputs, headers and results of a policy version, which can be replayed against another version.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy set-policy-capture --body '{
+      "maxRecords": 313907387089597427,
+      "redactFields": [
+         "user.email"
+      ],
+      "redactHeaders": [
+         "X-Api-Key"
+      ],
+      "sampleRate": 0.6394261784061505
+   }' --repository "Qui ducimus officiis est tenetur quisquam." --group "Enim assumenda ipsam." --policy-name "Et ut doloremque aut." --version "Architecto doloribus et ut consequatur."
+`, os.Args[0])
+}
+
+func policyDeletePolicyCaptureUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy delete-policy-capture -repository STRING -group STRING -policy-name STRING -version STRING
+
+DeletePolicyCapture disables the recording of evaluations of a policy version. Recorded evaluations are kept.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy delete-policy-capture --repository "Et autem sunt inventore nisi." --group "Aut et cum." --policy-name "Ex repudiandae non." --version "Cum fugiat quod nesciunt tempora."
+`, os.Args[0])
+}
+
+func policyDeleteCapturedEvaluationsUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy delete-captured-evaluations -repository STRING -group STRING -policy-name STRING -version STRING
+
+DeleteCapturedEvaluations removes the recorded evaluations of a policy version.
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version.
+
+Example:
+    %[1]s policy delete-captured-evaluations --repository "Sit voluptas doloribus." --group "Veritatis excepturi asperiores quia iure ad eum." --policy-name "Delectus sed nemo asperiores vero." --version "Debitis neque a repellat et ut quo."
+`, os.Args[0])
+}
+
+func policyReplayUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy replay -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
+
+Replay evaluates the recorded evaluations of a policy version with another version or with an uploaded policy source and returns the differences.
+    -body JSON: 
+    -repository STRING: Policy repository.
+    -group STRING: Policy group.
+    -policy-name STRING: Policy name.
+    -version STRING: Policy version whose recorded evaluations are replayed.
+
+Example:
+    %[1]s policy replay --body '{
+      "candidateVersion": "2.0",
+      "limit": 674,
+      "rego": "Molestias ducimus expedita ad ab."
+   }' --repository "Quam aut eius rerum deserunt unde." --group "In sed voluptatem repudiandae voluptatem aliquam harum." --policy-name "Sint ab tenetur." --version "Mollitia quam sapiente voluptate."
+`, os.Args[0])
+}
+
 func policySubscribeForPolicyChangeUsage() {
 	fmt.Fprintf(os.Stderr, `%[1]s [flags] policy subscribe-for-policy-change -body JSON -repository STRING -group STRING -policy-name STRING -version STRING
 
@@ -492,9 +1158,9 @@ Subscribe for policy change notifications by registering webhook callbacks which
 
 Example:
     %[1]s policy subscribe-for-policy-change --body '{
-      "subscriber": "zhn",
-      "webhook_url": "http://hermistonhuels.biz/rossie"
-   }' --repository "Perferendis provident corrupti." --group "Exercitationem placeat." --policy-name "Debitis nam voluptas qui quisquam." --version "Aut voluptatem consequatur totam reiciendis molestiae itaque."
+      "subscriber": "flu",
+      "webhook_url": "http://glover.biz/jimmie"
+   }' --repository "Accusamus ea est odit molestiae." --group "Eum dolor itaque adipisci." --policy-name "Voluptatem beatae consequuntur aut nihil." --version "Quod iure rerum repellendus."
 `, os.Args[0])
 }
 
@@ -531,3 +1197,48 @@ Example:
     %[1]s health readiness
 `, os.Args[0])
 }
+
+// dataUsage displays the usage of the data command and its subcommands.
+func dataUsage() {
+	fmt.Fprintf(os.Stderr, `Data service provides the Data API of Open Policy Agent, so that OPA clients can evaluate the stored policies without changes.
+Usage:
+    %[1]s [globalflags] data COMMAND [flags]
+
+COMMAND:
+    get-document: GetDocument evaluates the policy whose package is referenced by the data path without input and returns the value of the document at the path.
+    get-document-with-input: GetDocumentWithInput evaluates the policy whose package is referenced by the data path with the given input and returns the value of the document at the path.
+
+Additional help:
+    %[1]s data COMMAND --help
+`, os.Args[0])
+}
+func dataGetDocumentUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] data get-document -path STRING -repository STRING -version STRING -evaluation-id STRING
+
+GetDocument evaluates the policy whose package is referenced by the data path without input and returns the value of the document at the path.
+    -path STRING: Path of the document, which starts with the policy group and name followed by an optional rule path, e.g. 'example/example/allow'.
+    -repository STRING: 
+    -version STRING: 
+    -evaluation-id STRING: 
+
+Example:
+    %[1]s data get-document --path "example/example/allow" --repository "policies" --version "1.0" --evaluation-id "Veritatis consequuntur dolorem ab tempora et et."
+`, os.Args[0])
+}
+
+func dataGetDocumentWithInputUsage() {
+	fmt.Fprintf(os.Stderr, `%[1]s [flags] data get-document-with-input -body JSON -path STRING -repository STRING -version STRING -evaluation-id STRING
+
+GetDocumentWithInput evaluates the policy whose package is referenced by the data path with the given input and returns the value of the document at the path.
+    -body JSON: 
+    -path STRING: Path of the document, which starts with the policy group and name followed by an optional rule path, e.g. 'example/example/allow'.
+    -repository STRING: 
+    -version STRING: 
+    -evaluation-id STRING: 
+
+Example:
+    %[1]s data get-document-with-input --body '{
+      "input": "Nostrum et non qui ipsum maiores enim."
+   }' --path "example/example/allow" --repository "policies" --version "1.0" --evaluation-id "Eligendi quo ut laborum quisquam."
+`, os.Args[0])
+}
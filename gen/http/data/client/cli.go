@@ -0,0 +1,96 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data HTTP client CLI support package
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	data "github.com/eclipse-xfsc/custom-policy-agent/gen/data"
+)
+
+// BuildGetDocumentPayload builds the payload for the data GetDocument endpoint
+// from CLI flags.
+func BuildGetDocumentPayload(dataGetDocumentPath string, dataGetDocumentRepository string, dataGetDocumentVersion string, dataGetDocumentEvaluationID string) (*data.DataRequest, error) {
+	var path string
+	{
+		path = dataGetDocumentPath
+	}
+	var repository *string
+	{
+		if dataGetDocumentRepository != "" {
+			repository = &dataGetDocumentRepository
+		}
+	}
+	var version *string
+	{
+		if dataGetDocumentVersion != "" {
+			version = &dataGetDocumentVersion
+		}
+	}
+	var evaluationID *string
+	{
+		if dataGetDocumentEvaluationID != "" {
+			evaluationID = &dataGetDocumentEvaluationID
+		}
+	}
+	v := &data.DataRequest{}
+	v.Path = path
+	v.Repository = repository
+	v.Version = version
+	v.EvaluationID = evaluationID
+
+	return v, nil
+}
+
+// BuildGetDocumentWithInputPayload builds the payload for the data
+// GetDocumentWithInput endpoint from CLI flags.
+func BuildGetDocumentWithInputPayload(dataGetDocumentWithInputBody string, dataGetDocumentWithInputPath string, dataGetDocumentWithInputRepository string, dataGetDocumentWithInputVersion string, dataGetDocumentWithInputEvaluationID string) (*data.DataInputRequest, error) {
+	var err error
+	var body struct {
+		// Input data passed to the policy execution runtime.
+		Input any `form:"input" json:"input" xml:"input"`
+	}
+	{
+		err = json.Unmarshal([]byte(dataGetDocumentWithInputBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"input\": \"Nostrum et non qui ipsum maiores enim.\"\n   }'")
+		}
+	}
+	var path string
+	{
+		path = dataGetDocumentWithInputPath
+	}
+	var repository *string
+	{
+		if dataGetDocumentWithInputRepository != "" {
+			repository = &dataGetDocumentWithInputRepository
+		}
+	}
+	var version *string
+	{
+		if dataGetDocumentWithInputVersion != "" {
+			version = &dataGetDocumentWithInputVersion
+		}
+	}
+	var evaluationID *string
+	{
+		if dataGetDocumentWithInputEvaluationID != "" {
+			evaluationID = &dataGetDocumentWithInputEvaluationID
+		}
+	}
+	v := &data.DataInputRequest{
+		Input: body.Input,
+	}
+	v.Path = path
+	v.Repository = repository
+	v.Version = version
+	v.EvaluationID = evaluationID
+
+	return v, nil
+}
@@ -0,0 +1,104 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data client HTTP transport
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Client lists the data service endpoint HTTP clients.
+type Client struct {
+	// GetDocument Doer is the HTTP client used to make requests to the GetDocument
+	// endpoint.
+	GetDocumentDoer goahttp.Doer
+
+	// GetDocumentWithInput Doer is the HTTP client used to make requests to the
+	// GetDocumentWithInput endpoint.
+	GetDocumentWithInputDoer goahttp.Doer
+
+	// RestoreResponseBody controls whether the response bodies are reset after
+	// decoding so they can be read again.
+	RestoreResponseBody bool
+
+	scheme  string
+	host    string
+	encoder func(*http.Request) goahttp.Encoder
+	decoder func(*http.Response) goahttp.Decoder
+}
+
+// NewClient instantiates HTTP clients for all the data service servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	return &Client{
+		GetDocumentDoer:          doer,
+		GetDocumentWithInputDoer: doer,
+		RestoreResponseBody:      restoreBody,
+		scheme:                   scheme,
+		host:                     host,
+		decoder:                  dec,
+		encoder:                  enc,
+	}
+}
+
+// GetDocument returns an endpoint that makes HTTP requests to the data service
+// GetDocument server.
+func (c *Client) GetDocument() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetDocumentRequest(c.encoder)
+		decodeResponse = DecodeGetDocumentResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetDocumentRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetDocumentDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("data", "GetDocument", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetDocumentWithInput returns an endpoint that makes HTTP requests to the
+// data service GetDocumentWithInput server.
+func (c *Client) GetDocumentWithInput() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetDocumentWithInputRequest(c.encoder)
+		decodeResponse = DecodeGetDocumentWithInputResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetDocumentWithInputRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetDocumentWithInputDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("data", "GetDocumentWithInput", err)
+		}
+		return decodeResponse(resp)
+	}
+}
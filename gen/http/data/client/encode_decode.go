@@ -0,0 +1,202 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data HTTP client encoders and decoders
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	data "github.com/eclipse-xfsc/custom-policy-agent/gen/data"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// BuildGetDocumentRequest instantiates a HTTP request object with method and
+// path set to call the "data" service "GetDocument" endpoint
+func (c *Client) BuildGetDocumentRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		path string
+	)
+	{
+		p, ok := v.(*data.DataRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("data", "GetDocument", "*data.DataRequest", v)
+		}
+		path = p.Path
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetDocumentDataPath(path)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("data", "GetDocument", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetDocumentRequest returns an encoder for requests sent to the data
+// GetDocument server.
+func EncodeGetDocumentRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*data.DataRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("data", "GetDocument", "*data.DataRequest", v)
+		}
+		if p.Repository != nil {
+			head := *p.Repository
+			req.Header.Set("x-policy-repository", head)
+		}
+		if p.Version != nil {
+			head := *p.Version
+			req.Header.Set("x-policy-version", head)
+		}
+		if p.EvaluationID != nil {
+			head := *p.EvaluationID
+			req.Header.Set("x-evaluation-id", head)
+		}
+		return nil
+	}
+}
+
+// DecodeGetDocumentResponse returns a decoder for responses returned by the
+// data GetDocument endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeGetDocumentResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetDocumentResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("data", "GetDocument", err)
+			}
+			err = ValidateGetDocumentResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("data", "GetDocument", err)
+			}
+			res := NewGetDocumentDataResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("data", "GetDocument", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetDocumentWithInputRequest instantiates a HTTP request object with
+// method and path set to call the "data" service "GetDocumentWithInput"
+// endpoint
+func (c *Client) BuildGetDocumentWithInputRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		path string
+	)
+	{
+		p, ok := v.(*data.DataInputRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("data", "GetDocumentWithInput", "*data.DataInputRequest", v)
+		}
+		path = p.Path
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetDocumentWithInputDataPath(path)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("data", "GetDocumentWithInput", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetDocumentWithInputRequest returns an encoder for requests sent to
+// the data GetDocumentWithInput server.
+func EncodeGetDocumentWithInputRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*data.DataInputRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("data", "GetDocumentWithInput", "*data.DataInputRequest", v)
+		}
+		if p.Repository != nil {
+			head := *p.Repository
+			req.Header.Set("x-policy-repository", head)
+		}
+		if p.Version != nil {
+			head := *p.Version
+			req.Header.Set("x-policy-version", head)
+		}
+		if p.EvaluationID != nil {
+			head := *p.EvaluationID
+			req.Header.Set("x-evaluation-id", head)
+		}
+		body := p
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("data", "GetDocumentWithInput", err)
+		}
+		return nil
+	}
+}
+
+// DecodeGetDocumentWithInputResponse returns a decoder for responses returned
+// by the data GetDocumentWithInput endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeGetDocumentWithInputResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetDocumentWithInputResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("data", "GetDocumentWithInput", err)
+			}
+			err = ValidateGetDocumentWithInputResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("data", "GetDocumentWithInput", err)
+			}
+			res := NewGetDocumentWithInputDataResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("data", "GetDocumentWithInput", resp.StatusCode, string(body))
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// data HTTP server encoders and decoders
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	data "github.com/eclipse-xfsc/custom-policy-agent/gen/data"
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// EncodeGetDocumentResponse returns an encoder for responses returned by the
+// data GetDocument endpoint.
+func EncodeGetDocumentResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*data.DataResult)
+		enc := encoder(ctx, w)
+		body := NewGetDocumentResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetDocumentRequest returns a decoder for requests sent to the data
+// GetDocument endpoint.
+func DecodeGetDocumentRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			path         string
+			repository   *string
+			version      *string
+			evaluationID *string
+
+			params = mux.Vars(r)
+		)
+		path = params["path"]
+		repositoryRaw := r.Header.Get("x-policy-repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		versionRaw := r.Header.Get("x-policy-version")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		evaluationIDRaw := r.Header.Get("x-evaluation-id")
+		if evaluationIDRaw != "" {
+			evaluationID = &evaluationIDRaw
+		}
+		payload := NewGetDocumentDataRequest(path, repository, version, evaluationID)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetDocumentWithInputResponse returns an encoder for responses returned
+// by the data GetDocumentWithInput endpoint.
+func EncodeGetDocumentWithInputResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*data.DataResult)
+		enc := encoder(ctx, w)
+		body := NewGetDocumentWithInputResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetDocumentWithInputRequest returns a decoder for requests sent to the
+// data GetDocumentWithInput endpoint.
+func DecodeGetDocumentWithInputRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body struct {
+				// Input data passed to the policy execution runtime.
+				Input any `form:"input" json:"input" xml:"input"`
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			path         string
+			repository   *string
+			version      *string
+			evaluationID *string
+
+			params = mux.Vars(r)
+		)
+		path = params["path"]
+		repositoryRaw := r.Header.Get("x-policy-repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		versionRaw := r.Header.Get("x-policy-version")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		evaluationIDRaw := r.Header.Get("x-evaluation-id")
+		if evaluationIDRaw != "" {
+			evaluationID = &evaluationIDRaw
+		}
+		payload := NewGetDocumentWithInputDataInputRequest(body, path, repository, version, evaluationID)
+
+		return payload, nil
+	}
+}
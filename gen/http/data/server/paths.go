@@ -0,0 +1,22 @@
+// Code generated by goa v3.20.1, DO NOT EDIT.
+//
+// HTTP request path constructors for the data service.
+//
+// Command:
+// $ goa gen github.com/eclipse-xfsc/custom-policy-agent/design
+
+package server
+
+import (
+	"fmt"
+)
+
+// GetDocumentDataPath returns the URL path to the data service GetDocument HTTP endpoint.
+func GetDocumentDataPath(path string) string {
+	return fmt.Sprintf("/v1/data/%v", path)
+}
+
+// GetDocumentWithInputDataPath returns the URL path to the data service GetDocumentWithInput HTTP endpoint.
+func GetDocumentWithInputDataPath(path string) string {
+	return fmt.Sprintf("/v1/data/%v", path)
+}
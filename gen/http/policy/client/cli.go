@@ -19,13 +19,13 @@ import (
 
 // BuildEvaluatePayload builds the payload for the policy Evaluate endpoint
 // from CLI flags.
-func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository string, policyEvaluateGroup string, policyEvaluatePolicyName string, policyEvaluateVersion string, policyEvaluateEvaluationID string, policyEvaluateTTL string) (*policy.EvaluateRequest, error) {
+func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository string, policyEvaluateGroup string, policyEvaluatePolicyName string, policyEvaluateVersion string, policyEvaluateExplain string, policyEvaluateAsync string, policyEvaluateEvaluationID string, policyEvaluateTTL string, policyEvaluateCallbackURL string) (*policy.EvaluateRequest, error) {
 	var err error
 	var body any
 	{
 		err = json.Unmarshal([]byte(policyEvaluateBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"At ut dolore.\"")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Provident sint.\"")
 		}
 	}
 	var repository string
@@ -44,6 +44,29 @@ func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository st
 	{
 		version = policyEvaluateVersion
 	}
+	var explain *string
+	{
+		if policyEvaluateExplain != "" {
+			explain = &policyEvaluateExplain
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var async *bool
+	{
+		if policyEvaluateAsync != "" {
+			var val bool
+			val, err = strconv.ParseBool(policyEvaluateAsync)
+			async = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for async, must be BOOL")
+			}
+		}
+	}
 	var evaluationID *string
 	{
 		if policyEvaluateEvaluationID != "" {
@@ -62,6 +85,115 @@ func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository st
 			}
 		}
 	}
+	var callbackURL *string
+	{
+		if policyEvaluateCallbackURL != "" {
+			callbackURL = &policyEvaluateCallbackURL
+			err = goa.MergeErrors(err, goa.ValidateFormat("callbackURL", *callbackURL, goa.FormatURI))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := body
+	res := &policy.EvaluateRequest{
+		Input: &v,
+	}
+	res.Repository = repository
+	res.Group = group
+	res.PolicyName = policyName
+	res.Version = version
+	res.Explain = explain
+	res.Async = async
+	res.EvaluationID = evaluationID
+	res.TTL = ttl
+	res.CallbackURL = callbackURL
+
+	return res, nil
+}
+
+// BuildEvaluateRulePayload builds the payload for the policy EvaluateRule
+// endpoint from CLI flags.
+func BuildEvaluateRulePayload(policyEvaluateRuleBody string, policyEvaluateRuleRepository string, policyEvaluateRuleGroup string, policyEvaluateRulePolicyName string, policyEvaluateRuleVersion string, policyEvaluateRuleRule string, policyEvaluateRuleExplain string, policyEvaluateRuleAsync string, policyEvaluateRuleEvaluationID string, policyEvaluateRuleTTL string, policyEvaluateRuleCallbackURL string) (*policy.EvaluateRequest, error) {
+	var err error
+	var body any
+	{
+		err = json.Unmarshal([]byte(policyEvaluateRuleBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Officiis natus illo ex in enim in.\"")
+		}
+	}
+	var repository string
+	{
+		repository = policyEvaluateRuleRepository
+	}
+	var group string
+	{
+		group = policyEvaluateRuleGroup
+	}
+	var policyName string
+	{
+		policyName = policyEvaluateRulePolicyName
+	}
+	var version string
+	{
+		version = policyEvaluateRuleVersion
+	}
+	var rule string
+	{
+		rule = policyEvaluateRuleRule
+	}
+	var explain *string
+	{
+		if policyEvaluateRuleExplain != "" {
+			explain = &policyEvaluateRuleExplain
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var async *bool
+	{
+		if policyEvaluateRuleAsync != "" {
+			var val bool
+			val, err = strconv.ParseBool(policyEvaluateRuleAsync)
+			async = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for async, must be BOOL")
+			}
+		}
+	}
+	var evaluationID *string
+	{
+		if policyEvaluateRuleEvaluationID != "" {
+			evaluationID = &policyEvaluateRuleEvaluationID
+		}
+	}
+	var ttl *int
+	{
+		if policyEvaluateRuleTTL != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyEvaluateRuleTTL, 10, strconv.IntSize)
+			val := int(v)
+			ttl = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for ttl, must be INT")
+			}
+		}
+	}
+	var callbackURL *string
+	{
+		if policyEvaluateRuleCallbackURL != "" {
+			callbackURL = &policyEvaluateRuleCallbackURL
+			err = goa.MergeErrors(err, goa.ValidateFormat("callbackURL", *callbackURL, goa.FormatURI))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	v := body
 	res := &policy.EvaluateRequest{
 		Input: &v,
@@ -70,21 +202,25 @@ func BuildEvaluatePayload(policyEvaluateBody string, policyEvaluateRepository st
 	res.Group = group
 	res.PolicyName = policyName
 	res.Version = version
+	res.Rule = &rule
+	res.Explain = explain
+	res.Async = async
 	res.EvaluationID = evaluationID
 	res.TTL = ttl
+	res.CallbackURL = callbackURL
 
 	return res, nil
 }
 
 // BuildValidatePayload builds the payload for the policy Validate endpoint
 // from CLI flags.
-func BuildValidatePayload(policyValidateBody string, policyValidateRepository string, policyValidateGroup string, policyValidatePolicyName string, policyValidateVersion string, policyValidateEvaluationID string, policyValidateTTL string) (*policy.EvaluateRequest, error) {
+func BuildValidatePayload(policyValidateBody string, policyValidateRepository string, policyValidateGroup string, policyValidatePolicyName string, policyValidateVersion string, policyValidateExplain string, policyValidateReport string, policyValidateCoerce string, policyValidateEvaluationID string, policyValidateTTL string) (*policy.EvaluateRequest, error) {
 	var err error
 	var body any
 	{
 		err = json.Unmarshal([]byte(policyValidateBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Vel non quo.\"")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "\"Quia impedit.\"")
 		}
 	}
 	var repository string
@@ -103,6 +239,40 @@ func BuildValidatePayload(policyValidateBody string, policyValidateRepository st
 	{
 		version = policyValidateVersion
 	}
+	var explain *string
+	{
+		if policyValidateExplain != "" {
+			explain = &policyValidateExplain
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var report *bool
+	{
+		if policyValidateReport != "" {
+			var val bool
+			val, err = strconv.ParseBool(policyValidateReport)
+			report = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for report, must be BOOL")
+			}
+		}
+	}
+	var coerce *bool
+	{
+		if policyValidateCoerce != "" {
+			var val bool
+			val, err = strconv.ParseBool(policyValidateCoerce)
+			coerce = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for coerce, must be BOOL")
+			}
+		}
+	}
 	var evaluationID *string
 	{
 		if policyValidateEvaluationID != "" {
@@ -129,15 +299,181 @@ func BuildValidatePayload(policyValidateBody string, policyValidateRepository st
 	res.Group = group
 	res.PolicyName = policyName
 	res.Version = version
+	res.Explain = explain
+	res.Report = report
+	res.Coerce = coerce
 	res.EvaluationID = evaluationID
 	res.TTL = ttl
 
 	return res, nil
 }
 
+// BuildPartialEvaluatePayload builds the payload for the policy
+// PartialEvaluate endpoint from CLI flags.
+func BuildPartialEvaluatePayload(policyPartialEvaluateBody string, policyPartialEvaluateRepository string, policyPartialEvaluateGroup string, policyPartialEvaluatePolicyName string, policyPartialEvaluateVersion string) (*policy.PartialEvaluateRequest, error) {
+	var err error
+	var body PartialEvaluateRequestBody
+	{
+		err = json.Unmarshal([]byte(policyPartialEvaluateBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"input\": \"Fugiat rerum et culpa eaque.\",\n      \"rule\": \"fr\",\n      \"target\": \"rego\",\n      \"unknowns\": [\n         \"input.resource\"\n      ]\n   }'")
+		}
+		if body.Unknowns == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("unknowns", "body"))
+		}
+		err = goa.MergeErrors(err, goa.ValidatePattern("body.rule", body.Rule, "^[a-zA-Z_][a-zA-Z0-9_]*$"))
+		if len(body.Unknowns) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.unknowns", body.Unknowns, len(body.Unknowns), 1, true))
+		}
+		if !(body.Target == "rego" || body.Target == "mongo") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.target", body.Target, []any{"rego", "mongo"}))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var repository string
+	{
+		repository = policyPartialEvaluateRepository
+	}
+	var group string
+	{
+		group = policyPartialEvaluateGroup
+	}
+	var policyName string
+	{
+		policyName = policyPartialEvaluatePolicyName
+	}
+	var version string
+	{
+		version = policyPartialEvaluateVersion
+	}
+	v := &policy.PartialEvaluateRequest{
+		Rule:   body.Rule,
+		Input:  body.Input,
+		Target: body.Target,
+	}
+	{
+		var zero string
+		if v.Rule == zero {
+			v.Rule = "allow"
+		}
+	}
+	if body.Unknowns != nil {
+		v.Unknowns = make([]string, len(body.Unknowns))
+		for i, val := range body.Unknowns {
+			v.Unknowns[i] = val
+		}
+	} else {
+		v.Unknowns = []string{}
+	}
+	{
+		var zero string
+		if v.Target == zero {
+			v.Target = "rego"
+		}
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildEvaluateBatchPayload builds the payload for the policy EvaluateBatch
+// endpoint from CLI flags.
+func BuildEvaluateBatchPayload(policyEvaluateBatchBody string) (*policy.BatchEvaluateRequest, error) {
+	var err error
+	var body EvaluateBatchRequestBody
+	{
+		err = json.Unmarshal([]byte(policyEvaluateBatchBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"items\": [\n         {\n            \"evaluationID\": \"Repudiandae maxime molestiae reprehenderit.\",\n            \"group\": \"example\",\n            \"input\": \"Et aut ut dolor aut.\",\n            \"policyName\": \"example\",\n            \"repository\": \"policies\",\n            \"ttl\": 3720052407175667836,\n            \"version\": \"1.0\"\n         },\n         {\n            \"evaluationID\": \"Repudiandae maxime molestiae reprehenderit.\",\n            \"group\": \"example\",\n            \"input\": \"Et aut ut dolor aut.\",\n            \"policyName\": \"example\",\n            \"repository\": \"policies\",\n            \"ttl\": 3720052407175667836,\n            \"version\": \"1.0\"\n         }\n      ]\n   }'")
+		}
+		if body.Items == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("items", "body"))
+		}
+		if len(body.Items) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.items", body.Items, len(body.Items), 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.BatchEvaluateRequest{}
+	if body.Items != nil {
+		v.Items = make([]*policy.BatchEvaluateItem, len(body.Items))
+		for i, val := range body.Items {
+			v.Items[i] = marshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem(val)
+		}
+	} else {
+		v.Items = []*policy.BatchEvaluateItem{}
+	}
+
+	return v, nil
+}
+
+// BuildEvaluationResultPayload builds the payload for the policy
+// EvaluationResult endpoint from CLI flags.
+func BuildEvaluationResultPayload(policyEvaluationResultEvaluationID string, policyEvaluationResultWait string) (*policy.EvaluationResultRequest, error) {
+	var err error
+	var evaluationID string
+	{
+		evaluationID = policyEvaluationResultEvaluationID
+	}
+	var wait *int
+	{
+		if policyEvaluationResultWait != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyEvaluationResultWait, 10, strconv.IntSize)
+			val := int(v)
+			wait = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for wait, must be INT")
+			}
+			if *wait < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("wait", *wait, 0, true))
+			}
+			if *wait > 60 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("wait", *wait, 60, false))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := &policy.EvaluationResultRequest{}
+	v.EvaluationID = evaluationID
+	v.Wait = wait
+
+	return v, nil
+}
+
+// BuildJobStatusPayload builds the payload for the policy JobStatus endpoint
+// from CLI flags.
+func BuildJobStatusPayload(policyJobStatusJobID string) (*policy.JobStatusRequest, error) {
+	var jobID string
+	{
+		jobID = policyJobStatusJobID
+	}
+	v := &policy.JobStatusRequest{}
+	v.JobID = jobID
+
+	return v, nil
+}
+
 // BuildLockPayload builds the payload for the policy Lock endpoint from CLI
 // flags.
-func BuildLockPayload(policyLockRepository string, policyLockGroup string, policyLockPolicyName string, policyLockVersion string) (*policy.LockRequest, error) {
+func BuildLockPayload(policyLockBody string, policyLockRepository string, policyLockGroup string, policyLockPolicyName string, policyLockVersion string) (*policy.LockRequest, error) {
+	var err error
+	var body LockRequestBody
+	{
+		err = json.Unmarshal([]byte(policyLockBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"reason\": \"suspicious results\",\n      \"unlockAt\": 4917353493931427062\n   }'")
+		}
+	}
 	var repository string
 	{
 		repository = policyLockRepository
@@ -154,7 +490,10 @@ func BuildLockPayload(policyLockRepository string, policyLockGroup string, polic
 	{
 		version = policyLockVersion
 	}
-	v := &policy.LockRequest{}
+	v := &policy.LockRequest{
+		Reason:   body.Reason,
+		UnlockAt: body.UnlockAt,
+	}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -165,7 +504,15 @@ func BuildLockPayload(policyLockRepository string, policyLockGroup string, polic
 
 // BuildUnlockPayload builds the payload for the policy Unlock endpoint from
 // CLI flags.
-func BuildUnlockPayload(policyUnlockRepository string, policyUnlockGroup string, policyUnlockPolicyName string, policyUnlockVersion string) (*policy.UnlockRequest, error) {
+func BuildUnlockPayload(policyUnlockBody string, policyUnlockRepository string, policyUnlockGroup string, policyUnlockPolicyName string, policyUnlockVersion string) (*policy.UnlockRequest, error) {
+	var err error
+	var body UnlockRequestBody
+	{
+		err = json.Unmarshal([]byte(policyUnlockBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"reason\": \"Dicta rerum natus similique exercitationem facere qui.\"\n   }'")
+		}
+	}
 	var repository string
 	{
 		repository = policyUnlockRepository
@@ -182,7 +529,65 @@ func BuildUnlockPayload(policyUnlockRepository string, policyUnlockGroup string,
 	{
 		version = policyUnlockVersion
 	}
-	v := &policy.UnlockRequest{}
+	v := &policy.UnlockRequest{
+		Reason: body.Reason,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildLockHistoryPayload builds the payload for the policy LockHistory
+// endpoint from CLI flags.
+func BuildLockHistoryPayload(policyLockHistoryRepository string, policyLockHistoryGroup string, policyLockHistoryPolicyName string, policyLockHistoryVersion string) (*policy.LockHistoryRequest, error) {
+	var repository string
+	{
+		repository = policyLockHistoryRepository
+	}
+	var group string
+	{
+		group = policyLockHistoryGroup
+	}
+	var policyName string
+	{
+		policyName = policyLockHistoryPolicyName
+	}
+	var version string
+	{
+		version = policyLockHistoryVersion
+	}
+	v := &policy.LockHistoryRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildPolicyTestsPayload builds the payload for the policy PolicyTests
+// endpoint from CLI flags.
+func BuildPolicyTestsPayload(policyPolicyTestsRepository string, policyPolicyTestsGroup string, policyPolicyTestsPolicyName string, policyPolicyTestsVersion string) (*policy.PolicyTestsRequest, error) {
+	var repository string
+	{
+		repository = policyPolicyTestsRepository
+	}
+	var group string
+	{
+		group = policyPolicyTestsGroup
+	}
+	var policyName string
+	{
+		policyName = policyPolicyTestsPolicyName
+	}
+	var version string
+	{
+		version = policyPolicyTestsVersion
+	}
+	v := &policy.PolicyTestsRequest{}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -333,26 +738,252 @@ func BuildListPoliciesPayload(policyListPoliciesLocked string, policyListPolicie
 	return v, nil
 }
 
-// BuildSetPolicyAutoImportPayload builds the payload for the policy
-// SetPolicyAutoImport endpoint from CLI flags.
-func BuildSetPolicyAutoImportPayload(policySetPolicyAutoImportBody string) (*policy.SetPolicyAutoImportRequest, error) {
+// BuildDecisionLogsPayload builds the payload for the policy DecisionLogs
+// endpoint from CLI flags.
+func BuildDecisionLogsPayload(policyDecisionLogsRepository string, policyDecisionLogsGroup string, policyDecisionLogsPolicyName string, policyDecisionLogsVersion string, policyDecisionLogsEvaluationID string, policyDecisionLogsCaller string, policyDecisionLogsFrom string, policyDecisionLogsTo string, policyDecisionLogsLimit string, policyDecisionLogsOffset string) (*policy.DecisionLogsRequest, error) {
 	var err error
-	var body SetPolicyAutoImportRequestBody
+	var repository *string
 	{
-		err = json.Unmarshal([]byte(policySetPolicyAutoImportBody), &body)
-		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"interval\": \"1h30m\",\n      \"policyURL\": \"http://reichert.com/terence\"\n   }'")
+		if policyDecisionLogsRepository != "" {
+			repository = &policyDecisionLogsRepository
 		}
-		err = goa.MergeErrors(err, goa.ValidateFormat("body.policyURL", body.PolicyURL, goa.FormatURI))
-		if utf8.RuneCountInString(body.Interval) < 2 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError("body.interval", body.Interval, utf8.RuneCountInString(body.Interval), 2, true))
+	}
+	var group *string
+	{
+		if policyDecisionLogsGroup != "" {
+			group = &policyDecisionLogsGroup
 		}
-		if err != nil {
-			return nil, err
+	}
+	var policyName *string
+	{
+		if policyDecisionLogsPolicyName != "" {
+			policyName = &policyDecisionLogsPolicyName
 		}
 	}
-	v := &policy.SetPolicyAutoImportRequest{
-		PolicyURL: body.PolicyURL,
+	var version *string
+	{
+		if policyDecisionLogsVersion != "" {
+			version = &policyDecisionLogsVersion
+		}
+	}
+	var evaluationID *string
+	{
+		if policyDecisionLogsEvaluationID != "" {
+			evaluationID = &policyDecisionLogsEvaluationID
+		}
+	}
+	var caller *string
+	{
+		if policyDecisionLogsCaller != "" {
+			caller = &policyDecisionLogsCaller
+		}
+	}
+	var from *int64
+	{
+		if policyDecisionLogsFrom != "" {
+			val, err := strconv.ParseInt(policyDecisionLogsFrom, 10, 64)
+			from = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for from, must be INT64")
+			}
+		}
+	}
+	var to *int64
+	{
+		if policyDecisionLogsTo != "" {
+			val, err := strconv.ParseInt(policyDecisionLogsTo, 10, 64)
+			to = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for to, must be INT64")
+			}
+		}
+	}
+	var limit int
+	{
+		if policyDecisionLogsLimit != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyDecisionLogsLimit, 10, strconv.IntSize)
+			limit = int(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for limit, must be INT")
+			}
+			if limit < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+			}
+			if limit > 1000 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var offset *int
+	{
+		if policyDecisionLogsOffset != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyDecisionLogsOffset, 10, strconv.IntSize)
+			val := int(v)
+			offset = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for offset, must be INT")
+			}
+			if *offset < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("offset", *offset, 0, true))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := &policy.DecisionLogsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.EvaluationID = evaluationID
+	v.Caller = caller
+	v.From = from
+	v.To = to
+	v.Limit = limit
+	v.Offset = offset
+
+	return v, nil
+}
+
+// BuildAuditLogPayload builds the payload for the policy AuditLog endpoint
+// from CLI flags.
+func BuildAuditLogPayload(policyAuditLogOperation string, policyAuditLogActor string, policyAuditLogRepository string, policyAuditLogGroup string, policyAuditLogPolicyName string, policyAuditLogVersion string, policyAuditLogFrom string, policyAuditLogTo string, policyAuditLogLimit string, policyAuditLogOffset string) (*policy.AuditLogRequest, error) {
+	var err error
+	var operation *string
+	{
+		if policyAuditLogOperation != "" {
+			operation = &policyAuditLogOperation
+		}
+	}
+	var actor *string
+	{
+		if policyAuditLogActor != "" {
+			actor = &policyAuditLogActor
+		}
+	}
+	var repository *string
+	{
+		if policyAuditLogRepository != "" {
+			repository = &policyAuditLogRepository
+		}
+	}
+	var group *string
+	{
+		if policyAuditLogGroup != "" {
+			group = &policyAuditLogGroup
+		}
+	}
+	var policyName *string
+	{
+		if policyAuditLogPolicyName != "" {
+			policyName = &policyAuditLogPolicyName
+		}
+	}
+	var version *string
+	{
+		if policyAuditLogVersion != "" {
+			version = &policyAuditLogVersion
+		}
+	}
+	var from *int64
+	{
+		if policyAuditLogFrom != "" {
+			val, err := strconv.ParseInt(policyAuditLogFrom, 10, 64)
+			from = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for from, must be INT64")
+			}
+		}
+	}
+	var to *int64
+	{
+		if policyAuditLogTo != "" {
+			val, err := strconv.ParseInt(policyAuditLogTo, 10, 64)
+			to = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for to, must be INT64")
+			}
+		}
+	}
+	var limit int
+	{
+		if policyAuditLogLimit != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyAuditLogLimit, 10, strconv.IntSize)
+			limit = int(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for limit, must be INT")
+			}
+			if limit < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+			}
+			if limit > 1000 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var offset *int
+	{
+		if policyAuditLogOffset != "" {
+			var v int64
+			v, err = strconv.ParseInt(policyAuditLogOffset, 10, strconv.IntSize)
+			val := int(v)
+			offset = &val
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for offset, must be INT")
+			}
+			if *offset < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("offset", *offset, 0, true))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := &policy.AuditLogRequest{}
+	v.Operation = operation
+	v.Actor = actor
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.From = from
+	v.To = to
+	v.Limit = limit
+	v.Offset = offset
+
+	return v, nil
+}
+
+// BuildSetPolicyAutoImportPayload builds the payload for the policy
+// SetPolicyAutoImport endpoint from CLI flags.
+func BuildSetPolicyAutoImportPayload(policySetPolicyAutoImportBody string) (*policy.SetPolicyAutoImportRequest, error) {
+	var err error
+	var body SetPolicyAutoImportRequestBody
+	{
+		err = json.Unmarshal([]byte(policySetPolicyAutoImportBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"interval\": \"1h30m\",\n      \"policyURL\": \"http://gorczanygrady.biz/candice_sporer\"\n   }'")
+		}
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.policyURL", body.PolicyURL, goa.FormatURI))
+		if utf8.RuneCountInString(body.Interval) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.interval", body.Interval, utf8.RuneCountInString(body.Interval), 2, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.SetPolicyAutoImportRequest{
+		PolicyURL: body.PolicyURL,
 		Interval:  body.Interval,
 	}
 
@@ -367,7 +998,7 @@ func BuildDeletePolicyAutoImportPayload(policyDeletePolicyAutoImportBody string)
 	{
 		err = json.Unmarshal([]byte(policyDeletePolicyAutoImportBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"policyURL\": \"http://gutkowski.name/adrian.stroman\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"policyURL\": \"http://bins.com/delaney_russel\"\n   }'")
 		}
 		err = goa.MergeErrors(err, goa.ValidateFormat("body.policyURL", body.PolicyURL, goa.FormatURI))
 		if err != nil {
@@ -381,6 +1012,351 @@ func BuildDeletePolicyAutoImportPayload(policyDeletePolicyAutoImportBody string)
 	return v, nil
 }
 
+// BuildSetPolicyAliasPayload builds the payload for the policy SetPolicyAlias
+// endpoint from CLI flags.
+func BuildSetPolicyAliasPayload(policySetPolicyAliasBody string, policySetPolicyAliasRepository string, policySetPolicyAliasGroup string, policySetPolicyAliasPolicyName string, policySetPolicyAliasAlias string) (*policy.SetPolicyAliasRequest, error) {
+	var err error
+	var body SetPolicyAliasRequestBody
+	{
+		err = json.Unmarshal([]byte(policySetPolicyAliasBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"version\": \"1.0\"\n   }'")
+		}
+	}
+	var repository string
+	{
+		repository = policySetPolicyAliasRepository
+	}
+	var group string
+	{
+		group = policySetPolicyAliasGroup
+	}
+	var policyName string
+	{
+		policyName = policySetPolicyAliasPolicyName
+	}
+	var alias string
+	{
+		alias = policySetPolicyAliasAlias
+		err = goa.MergeErrors(err, goa.ValidatePattern("alias", alias, "^[a-zA-Z][a-zA-Z0-9._-]*$"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	v := &policy.SetPolicyAliasRequest{
+		Version: body.Version,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Alias = alias
+
+	return v, nil
+}
+
+// BuildPolicyAliasesPayload builds the payload for the policy PolicyAliases
+// endpoint from CLI flags.
+func BuildPolicyAliasesPayload(policyPolicyAliasesRepository string, policyPolicyAliasesGroup string, policyPolicyAliasesPolicyName string) (*policy.PolicyAliasesRequest, error) {
+	var repository string
+	{
+		repository = policyPolicyAliasesRepository
+	}
+	var group string
+	{
+		group = policyPolicyAliasesGroup
+	}
+	var policyName string
+	{
+		policyName = policyPolicyAliasesPolicyName
+	}
+	v := &policy.PolicyAliasesRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+
+	return v, nil
+}
+
+// BuildDeletePolicyAliasPayload builds the payload for the policy
+// DeletePolicyAlias endpoint from CLI flags.
+func BuildDeletePolicyAliasPayload(policyDeletePolicyAliasRepository string, policyDeletePolicyAliasGroup string, policyDeletePolicyAliasPolicyName string, policyDeletePolicyAliasAlias string) (*policy.DeletePolicyAliasRequest, error) {
+	var repository string
+	{
+		repository = policyDeletePolicyAliasRepository
+	}
+	var group string
+	{
+		group = policyDeletePolicyAliasGroup
+	}
+	var policyName string
+	{
+		policyName = policyDeletePolicyAliasPolicyName
+	}
+	var alias string
+	{
+		alias = policyDeletePolicyAliasAlias
+	}
+	v := &policy.DeletePolicyAliasRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Alias = alias
+
+	return v, nil
+}
+
+// BuildSetPolicyShadowPayload builds the payload for the policy
+// SetPolicyShadow endpoint from CLI flags.
+func BuildSetPolicyShadowPayload(policySetPolicyShadowBody string, policySetPolicyShadowRepository string, policySetPolicyShadowGroup string, policySetPolicyShadowPolicyName string, policySetPolicyShadowVersion string) (*policy.SetPolicyShadowRequest, error) {
+	var err error
+	var body SetPolicyShadowRequestBody
+	{
+		err = json.Unmarshal([]byte(policySetPolicyShadowBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"shadowVersion\": \"2.0\"\n   }'")
+		}
+	}
+	var repository string
+	{
+		repository = policySetPolicyShadowRepository
+	}
+	var group string
+	{
+		group = policySetPolicyShadowGroup
+	}
+	var policyName string
+	{
+		policyName = policySetPolicyShadowPolicyName
+	}
+	var version string
+	{
+		version = policySetPolicyShadowVersion
+	}
+	v := &policy.SetPolicyShadowRequest{
+		ShadowVersion: body.ShadowVersion,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildDeletePolicyShadowPayload builds the payload for the policy
+// DeletePolicyShadow endpoint from CLI flags.
+func BuildDeletePolicyShadowPayload(policyDeletePolicyShadowRepository string, policyDeletePolicyShadowGroup string, policyDeletePolicyShadowPolicyName string, policyDeletePolicyShadowVersion string) (*policy.DeletePolicyShadowRequest, error) {
+	var repository string
+	{
+		repository = policyDeletePolicyShadowRepository
+	}
+	var group string
+	{
+		group = policyDeletePolicyShadowGroup
+	}
+	var policyName string
+	{
+		policyName = policyDeletePolicyShadowPolicyName
+	}
+	var version string
+	{
+		version = policyDeletePolicyShadowVersion
+	}
+	v := &policy.DeletePolicyShadowRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildSetPolicyCapturePayload builds the payload for the policy
+// SetPolicyCapture endpoint from CLI flags.
+func BuildSetPolicyCapturePayload(policySetPolicyCaptureBody string, policySetPolicyCaptureRepository string, policySetPolicyCaptureGroup string, policySetPolicyCapturePolicyName string, policySetPolicyCaptureVersion string) (*policy.SetPolicyCaptureRequest, error) {
+	var err error
+	var body SetPolicyCaptureRequestBody
+	{
+		err = json.Unmarshal([]byte(policySetPolicyCaptureBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"maxRecords\": 313907387089597427,\n      \"redactFields\": [\n         \"user.email\"\n      ],\n      \"redactHeaders\": [\n         \"X-Api-Key\"\n      ],\n      \"sampleRate\": 0.6394261784061505\n   }'")
+		}
+		if body.SampleRate < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.sampleRate", body.SampleRate, 0, true))
+		}
+		if body.SampleRate > 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.sampleRate", body.SampleRate, 1, false))
+		}
+		if body.MaxRecords < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.maxRecords", body.MaxRecords, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var repository string
+	{
+		repository = policySetPolicyCaptureRepository
+	}
+	var group string
+	{
+		group = policySetPolicyCaptureGroup
+	}
+	var policyName string
+	{
+		policyName = policySetPolicyCapturePolicyName
+	}
+	var version string
+	{
+		version = policySetPolicyCaptureVersion
+	}
+	v := &policy.SetPolicyCaptureRequest{
+		SampleRate: body.SampleRate,
+		MaxRecords: body.MaxRecords,
+	}
+	{
+		var zero float64
+		if v.SampleRate == zero {
+			v.SampleRate = 1
+		}
+	}
+	{
+		var zero int
+		if v.MaxRecords == zero {
+			v.MaxRecords = 1000
+		}
+	}
+	if body.RedactFields != nil {
+		v.RedactFields = make([]string, len(body.RedactFields))
+		for i, val := range body.RedactFields {
+			v.RedactFields[i] = val
+		}
+	}
+	if body.RedactHeaders != nil {
+		v.RedactHeaders = make([]string, len(body.RedactHeaders))
+		for i, val := range body.RedactHeaders {
+			v.RedactHeaders[i] = val
+		}
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildDeletePolicyCapturePayload builds the payload for the policy
+// DeletePolicyCapture endpoint from CLI flags.
+func BuildDeletePolicyCapturePayload(policyDeletePolicyCaptureRepository string, policyDeletePolicyCaptureGroup string, policyDeletePolicyCapturePolicyName string, policyDeletePolicyCaptureVersion string) (*policy.DeletePolicyCaptureRequest, error) {
+	var repository string
+	{
+		repository = policyDeletePolicyCaptureRepository
+	}
+	var group string
+	{
+		group = policyDeletePolicyCaptureGroup
+	}
+	var policyName string
+	{
+		policyName = policyDeletePolicyCapturePolicyName
+	}
+	var version string
+	{
+		version = policyDeletePolicyCaptureVersion
+	}
+	v := &policy.DeletePolicyCaptureRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildDeleteCapturedEvaluationsPayload builds the payload for the policy
+// DeleteCapturedEvaluations endpoint from CLI flags.
+func BuildDeleteCapturedEvaluationsPayload(policyDeleteCapturedEvaluationsRepository string, policyDeleteCapturedEvaluationsGroup string, policyDeleteCapturedEvaluationsPolicyName string, policyDeleteCapturedEvaluationsVersion string) (*policy.DeleteCapturedEvaluationsRequest, error) {
+	var repository string
+	{
+		repository = policyDeleteCapturedEvaluationsRepository
+	}
+	var group string
+	{
+		group = policyDeleteCapturedEvaluationsGroup
+	}
+	var policyName string
+	{
+		policyName = policyDeleteCapturedEvaluationsPolicyName
+	}
+	var version string
+	{
+		version = policyDeleteCapturedEvaluationsVersion
+	}
+	v := &policy.DeleteCapturedEvaluationsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
+// BuildReplayPayload builds the payload for the policy Replay endpoint from
+// CLI flags.
+func BuildReplayPayload(policyReplayBody string, policyReplayRepository string, policyReplayGroup string, policyReplayPolicyName string, policyReplayVersion string) (*policy.ReplayRequest, error) {
+	var err error
+	var body ReplayRequestBody
+	{
+		err = json.Unmarshal([]byte(policyReplayBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"candidateVersion\": \"2.0\",\n      \"limit\": 674,\n      \"rego\": \"Molestias ducimus expedita ad ab.\"\n   }'")
+		}
+		if body.Limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.limit", body.Limit, 1, true))
+		}
+		if body.Limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.limit", body.Limit, 1000, false))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var repository string
+	{
+		repository = policyReplayRepository
+	}
+	var group string
+	{
+		group = policyReplayGroup
+	}
+	var policyName string
+	{
+		policyName = policyReplayPolicyName
+	}
+	var version string
+	{
+		version = policyReplayVersion
+	}
+	v := &policy.ReplayRequest{
+		CandidateVersion: body.CandidateVersion,
+		Rego:             body.Rego,
+		Limit:            body.Limit,
+	}
+	{
+		var zero int
+		if v.Limit == zero {
+			v.Limit = 100
+		}
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v, nil
+}
+
 // BuildSubscribeForPolicyChangePayload builds the payload for the policy
 // SubscribeForPolicyChange endpoint from CLI flags.
 func BuildSubscribeForPolicyChangePayload(policySubscribeForPolicyChangeBody string, policySubscribeForPolicyChangeRepository string, policySubscribeForPolicyChangeGroup string, policySubscribeForPolicyChangePolicyName string, policySubscribeForPolicyChangeVersion string) (*policy.SubscribeRequest, error) {
@@ -389,7 +1365,7 @@ func BuildSubscribeForPolicyChangePayload(policySubscribeForPolicyChangeBody str
 	{
 		err = json.Unmarshal([]byte(policySubscribeForPolicyChangeBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"subscriber\": \"zhn\",\n      \"webhook_url\": \"http://hermistonhuels.biz/rossie\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"subscriber\": \"flu\",\n      \"webhook_url\": \"http://glover.biz/jimmie\"\n   }'")
 		}
 		err = goa.MergeErrors(err, goa.ValidateFormat("body.webhook_url", body.WebhookURL, goa.FormatURI))
 		if utf8.RuneCountInString(body.Subscriber) < 3 {
@@ -22,16 +22,48 @@ type Client struct {
 	// endpoint.
 	EvaluateDoer goahttp.Doer
 
+	// EvaluateRule Doer is the HTTP client used to make requests to the
+	// EvaluateRule endpoint.
+	EvaluateRuleDoer goahttp.Doer
+
 	// Validate Doer is the HTTP client used to make requests to the Validate
 	// endpoint.
 	ValidateDoer goahttp.Doer
 
+	// PartialEvaluate Doer is the HTTP client used to make requests to the
+	// PartialEvaluate endpoint.
+	PartialEvaluateDoer goahttp.Doer
+
+	// EvaluateBatch Doer is the HTTP client used to make requests to the
+	// EvaluateBatch endpoint.
+	EvaluateBatchDoer goahttp.Doer
+
+	// EvaluateBatchStream Doer is the HTTP client used to make requests to the
+	// EvaluateBatchStream endpoint.
+	EvaluateBatchStreamDoer goahttp.Doer
+
+	// EvaluationResult Doer is the HTTP client used to make requests to the
+	// EvaluationResult endpoint.
+	EvaluationResultDoer goahttp.Doer
+
+	// JobStatus Doer is the HTTP client used to make requests to the JobStatus
+	// endpoint.
+	JobStatusDoer goahttp.Doer
+
 	// Lock Doer is the HTTP client used to make requests to the Lock endpoint.
 	LockDoer goahttp.Doer
 
 	// Unlock Doer is the HTTP client used to make requests to the Unlock endpoint.
 	UnlockDoer goahttp.Doer
 
+	// LockHistory Doer is the HTTP client used to make requests to the LockHistory
+	// endpoint.
+	LockHistoryDoer goahttp.Doer
+
+	// PolicyTests Doer is the HTTP client used to make requests to the PolicyTests
+	// endpoint.
+	PolicyTestsDoer goahttp.Doer
+
 	// ExportBundle Doer is the HTTP client used to make requests to the
 	// ExportBundle endpoint.
 	ExportBundleDoer goahttp.Doer
@@ -48,6 +80,14 @@ type Client struct {
 	// ListPolicies endpoint.
 	ListPoliciesDoer goahttp.Doer
 
+	// DecisionLogs Doer is the HTTP client used to make requests to the
+	// DecisionLogs endpoint.
+	DecisionLogsDoer goahttp.Doer
+
+	// AuditLog Doer is the HTTP client used to make requests to the AuditLog
+	// endpoint.
+	AuditLogDoer goahttp.Doer
+
 	// SetPolicyAutoImport Doer is the HTTP client used to make requests to the
 	// SetPolicyAutoImport endpoint.
 	SetPolicyAutoImportDoer goahttp.Doer
@@ -60,6 +100,41 @@ type Client struct {
 	// DeletePolicyAutoImport endpoint.
 	DeletePolicyAutoImportDoer goahttp.Doer
 
+	// SetPolicyAlias Doer is the HTTP client used to make requests to the
+	// SetPolicyAlias endpoint.
+	SetPolicyAliasDoer goahttp.Doer
+
+	// PolicyAliases Doer is the HTTP client used to make requests to the
+	// PolicyAliases endpoint.
+	PolicyAliasesDoer goahttp.Doer
+
+	// DeletePolicyAlias Doer is the HTTP client used to make requests to the
+	// DeletePolicyAlias endpoint.
+	DeletePolicyAliasDoer goahttp.Doer
+
+	// SetPolicyShadow Doer is the HTTP client used to make requests to the
+	// SetPolicyShadow endpoint.
+	SetPolicyShadowDoer goahttp.Doer
+
+	// DeletePolicyShadow Doer is the HTTP client used to make requests to the
+	// DeletePolicyShadow endpoint.
+	DeletePolicyShadowDoer goahttp.Doer
+
+	// SetPolicyCapture Doer is the HTTP client used to make requests to the
+	// SetPolicyCapture endpoint.
+	SetPolicyCaptureDoer goahttp.Doer
+
+	// DeletePolicyCapture Doer is the HTTP client used to make requests to the
+	// DeletePolicyCapture endpoint.
+	DeletePolicyCaptureDoer goahttp.Doer
+
+	// DeleteCapturedEvaluations Doer is the HTTP client used to make requests to
+	// the DeleteCapturedEvaluations endpoint.
+	DeleteCapturedEvaluationsDoer goahttp.Doer
+
+	// Replay Doer is the HTTP client used to make requests to the Replay endpoint.
+	ReplayDoer goahttp.Doer
+
 	// SubscribeForPolicyChange Doer is the HTTP client used to make requests to
 	// the SubscribeForPolicyChange endpoint.
 	SubscribeForPolicyChangeDoer goahttp.Doer
@@ -84,23 +159,42 @@ func NewClient(
 	restoreBody bool,
 ) *Client {
 	return &Client{
-		EvaluateDoer:                 doer,
-		ValidateDoer:                 doer,
-		LockDoer:                     doer,
-		UnlockDoer:                   doer,
-		ExportBundleDoer:             doer,
-		PolicyPublicKeyDoer:          doer,
-		ImportBundleDoer:             doer,
-		ListPoliciesDoer:             doer,
-		SetPolicyAutoImportDoer:      doer,
-		PolicyAutoImportDoer:         doer,
-		DeletePolicyAutoImportDoer:   doer,
-		SubscribeForPolicyChangeDoer: doer,
-		RestoreResponseBody:          restoreBody,
-		scheme:                       scheme,
-		host:                         host,
-		decoder:                      dec,
-		encoder:                      enc,
+		EvaluateDoer:                  doer,
+		EvaluateRuleDoer:              doer,
+		ValidateDoer:                  doer,
+		PartialEvaluateDoer:           doer,
+		EvaluateBatchDoer:             doer,
+		EvaluateBatchStreamDoer:       doer,
+		EvaluationResultDoer:          doer,
+		JobStatusDoer:                 doer,
+		LockDoer:                      doer,
+		UnlockDoer:                    doer,
+		LockHistoryDoer:               doer,
+		PolicyTestsDoer:               doer,
+		ExportBundleDoer:              doer,
+		PolicyPublicKeyDoer:           doer,
+		ImportBundleDoer:              doer,
+		ListPoliciesDoer:              doer,
+		DecisionLogsDoer:              doer,
+		AuditLogDoer:                  doer,
+		SetPolicyAutoImportDoer:       doer,
+		PolicyAutoImportDoer:          doer,
+		DeletePolicyAutoImportDoer:    doer,
+		SetPolicyAliasDoer:            doer,
+		PolicyAliasesDoer:             doer,
+		DeletePolicyAliasDoer:         doer,
+		SetPolicyShadowDoer:           doer,
+		DeletePolicyShadowDoer:        doer,
+		SetPolicyCaptureDoer:          doer,
+		DeletePolicyCaptureDoer:       doer,
+		DeleteCapturedEvaluationsDoer: doer,
+		ReplayDoer:                    doer,
+		SubscribeForPolicyChangeDoer:  doer,
+		RestoreResponseBody:           restoreBody,
+		scheme:                        scheme,
+		host:                          host,
+		decoder:                       dec,
+		encoder:                       enc,
 	}
 }
 
@@ -128,6 +222,30 @@ func (c *Client) Evaluate() goa.Endpoint {
 	}
 }
 
+// EvaluateRule returns an endpoint that makes HTTP requests to the policy
+// service EvaluateRule server.
+func (c *Client) EvaluateRule() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeEvaluateRuleRequest(c.encoder)
+		decodeResponse = DecodeEvaluateRuleResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildEvaluateRuleRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.EvaluateRuleDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "EvaluateRule", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // Validate returns an endpoint that makes HTTP requests to the policy service
 // Validate server.
 func (c *Client) Validate() goa.Endpoint {
@@ -152,10 +270,126 @@ func (c *Client) Validate() goa.Endpoint {
 	}
 }
 
+// PartialEvaluate returns an endpoint that makes HTTP requests to the policy
+// service PartialEvaluate server.
+func (c *Client) PartialEvaluate() goa.Endpoint {
+	var (
+		encodeRequest  = EncodePartialEvaluateRequest(c.encoder)
+		decodeResponse = DecodePartialEvaluateResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPartialEvaluateRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PartialEvaluateDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PartialEvaluate", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// EvaluateBatch returns an endpoint that makes HTTP requests to the policy
+// service EvaluateBatch server.
+func (c *Client) EvaluateBatch() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeEvaluateBatchRequest(c.encoder)
+		decodeResponse = DecodeEvaluateBatchResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildEvaluateBatchRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.EvaluateBatchDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "EvaluateBatch", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// EvaluateBatchStream returns an endpoint that makes HTTP requests to the
+// policy service EvaluateBatchStream server.
+func (c *Client) EvaluateBatchStream() goa.Endpoint {
+	var (
+		decodeResponse = DecodeEvaluateBatchStreamResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildEvaluateBatchStreamRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.EvaluateBatchStreamDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "EvaluateBatchStream", err)
+		}
+		res, err := decodeResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return &policy.EvaluateBatchStreamResponseData{Result: res.(*policy.BatchEvaluateStreamResult), Body: resp.Body}, nil
+	}
+}
+
+// EvaluationResult returns an endpoint that makes HTTP requests to the policy
+// service EvaluationResult server.
+func (c *Client) EvaluationResult() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeEvaluationResultRequest(c.encoder)
+		decodeResponse = DecodeEvaluationResultResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildEvaluationResultRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.EvaluationResultDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "EvaluationResult", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// JobStatus returns an endpoint that makes HTTP requests to the policy service
+// JobStatus server.
+func (c *Client) JobStatus() goa.Endpoint {
+	var (
+		decodeResponse = DecodeJobStatusResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildJobStatusRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.JobStatusDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "JobStatus", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // Lock returns an endpoint that makes HTTP requests to the policy service Lock
 // server.
 func (c *Client) Lock() goa.Endpoint {
 	var (
+		encodeRequest  = EncodeLockRequest(c.encoder)
 		decodeResponse = DecodeLockResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
@@ -163,6 +397,10 @@ func (c *Client) Lock() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
 		resp, err := c.LockDoer.Do(req)
 		if err != nil {
 			return nil, goahttp.ErrRequestError("policy", "Lock", err)
@@ -175,6 +413,7 @@ func (c *Client) Lock() goa.Endpoint {
 // Unlock server.
 func (c *Client) Unlock() goa.Endpoint {
 	var (
+		encodeRequest  = EncodeUnlockRequest(c.encoder)
 		decodeResponse = DecodeUnlockResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
@@ -182,6 +421,10 @@ func (c *Client) Unlock() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
 		resp, err := c.UnlockDoer.Do(req)
 		if err != nil {
 			return nil, goahttp.ErrRequestError("policy", "Unlock", err)
@@ -190,6 +433,44 @@ func (c *Client) Unlock() goa.Endpoint {
 	}
 }
 
+// LockHistory returns an endpoint that makes HTTP requests to the policy
+// service LockHistory server.
+func (c *Client) LockHistory() goa.Endpoint {
+	var (
+		decodeResponse = DecodeLockHistoryResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildLockHistoryRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.LockHistoryDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "LockHistory", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PolicyTests returns an endpoint that makes HTTP requests to the policy
+// service PolicyTests server.
+func (c *Client) PolicyTests() goa.Endpoint {
+	var (
+		decodeResponse = DecodePolicyTestsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPolicyTestsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PolicyTestsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PolicyTests", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // ExportBundle returns an endpoint that makes HTTP requests to the policy
 // service ExportBundle server.
 func (c *Client) ExportBundle() goa.Endpoint {
@@ -281,6 +562,54 @@ func (c *Client) ListPolicies() goa.Endpoint {
 	}
 }
 
+// DecisionLogs returns an endpoint that makes HTTP requests to the policy
+// service DecisionLogs server.
+func (c *Client) DecisionLogs() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeDecisionLogsRequest(c.encoder)
+		decodeResponse = DecodeDecisionLogsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDecisionLogsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DecisionLogsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DecisionLogs", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// AuditLog returns an endpoint that makes HTTP requests to the policy service
+// AuditLog server.
+func (c *Client) AuditLog() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeAuditLogRequest(c.encoder)
+		decodeResponse = DecodeAuditLogResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildAuditLogRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.AuditLogDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "AuditLog", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // SetPolicyAutoImport returns an endpoint that makes HTTP requests to the
 // policy service SetPolicyAutoImport server.
 func (c *Client) SetPolicyAutoImport() goa.Endpoint {
@@ -348,6 +677,197 @@ func (c *Client) DeletePolicyAutoImport() goa.Endpoint {
 	}
 }
 
+// SetPolicyAlias returns an endpoint that makes HTTP requests to the policy
+// service SetPolicyAlias server.
+func (c *Client) SetPolicyAlias() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeSetPolicyAliasRequest(c.encoder)
+		decodeResponse = DecodeSetPolicyAliasResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildSetPolicyAliasRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.SetPolicyAliasDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "SetPolicyAlias", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PolicyAliases returns an endpoint that makes HTTP requests to the policy
+// service PolicyAliases server.
+func (c *Client) PolicyAliases() goa.Endpoint {
+	var (
+		decodeResponse = DecodePolicyAliasesResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPolicyAliasesRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PolicyAliasesDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "PolicyAliases", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeletePolicyAlias returns an endpoint that makes HTTP requests to the policy
+// service DeletePolicyAlias server.
+func (c *Client) DeletePolicyAlias() goa.Endpoint {
+	var (
+		decodeResponse = DecodeDeletePolicyAliasResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeletePolicyAliasRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeletePolicyAliasDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DeletePolicyAlias", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// SetPolicyShadow returns an endpoint that makes HTTP requests to the policy
+// service SetPolicyShadow server.
+func (c *Client) SetPolicyShadow() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeSetPolicyShadowRequest(c.encoder)
+		decodeResponse = DecodeSetPolicyShadowResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildSetPolicyShadowRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.SetPolicyShadowDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "SetPolicyShadow", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeletePolicyShadow returns an endpoint that makes HTTP requests to the
+// policy service DeletePolicyShadow server.
+func (c *Client) DeletePolicyShadow() goa.Endpoint {
+	var (
+		decodeResponse = DecodeDeletePolicyShadowResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeletePolicyShadowRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeletePolicyShadowDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DeletePolicyShadow", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// SetPolicyCapture returns an endpoint that makes HTTP requests to the policy
+// service SetPolicyCapture server.
+func (c *Client) SetPolicyCapture() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeSetPolicyCaptureRequest(c.encoder)
+		decodeResponse = DecodeSetPolicyCaptureResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildSetPolicyCaptureRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.SetPolicyCaptureDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "SetPolicyCapture", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeletePolicyCapture returns an endpoint that makes HTTP requests to the
+// policy service DeletePolicyCapture server.
+func (c *Client) DeletePolicyCapture() goa.Endpoint {
+	var (
+		decodeResponse = DecodeDeletePolicyCaptureResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeletePolicyCaptureRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeletePolicyCaptureDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DeletePolicyCapture", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeleteCapturedEvaluations returns an endpoint that makes HTTP requests to
+// the policy service DeleteCapturedEvaluations server.
+func (c *Client) DeleteCapturedEvaluations() goa.Endpoint {
+	var (
+		decodeResponse = DecodeDeleteCapturedEvaluationsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeleteCapturedEvaluationsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeleteCapturedEvaluationsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "DeleteCapturedEvaluations", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// Replay returns an endpoint that makes HTTP requests to the policy service
+// Replay server.
+func (c *Client) Replay() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeReplayRequest(c.encoder)
+		decodeResponse = DecodeReplayResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildReplayRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ReplayDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("policy", "Replay", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
 // SubscribeForPolicyChange returns an endpoint that makes HTTP requests to the
 // policy service SubscribeForPolicyChange server.
 func (c *Client) SubscribeForPolicyChange() goa.Endpoint {
@@ -70,6 +70,18 @@ func EncodeEvaluateRequest(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			headStr := strconv.Itoa(head)
 			req.Header.Set("x-cache-ttl", headStr)
 		}
+		if p.CallbackURL != nil {
+			head := *p.CallbackURL
+			req.Header.Set("x-callback-url", head)
+		}
+		values := req.URL.Query()
+		if p.Explain != nil {
+			values.Add("explain", *p.Explain)
+		}
+		if p.Async != nil {
+			values.Add("async", fmt.Sprintf("%v", *p.Async))
+		}
+		req.URL.RawQuery = values.Encode()
 		body := p.Input
 		if err := encoder(req).Encode(&body); err != nil {
 			return goahttp.ErrEncodingError("policy", "Evaluate", err)
@@ -96,6 +108,36 @@ func DecodeEvaluateResponse(decoder func(*http.Response) goahttp.Decoder, restor
 			defer resp.Body.Close()
 		}
 		switch resp.StatusCode {
+		case http.StatusAccepted:
+			var (
+				body EvaluateAcceptedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "Evaluate", err)
+			}
+			var (
+				eTag    string
+				version string
+			)
+			eTagRaw := resp.Header.Get("Etag")
+			if eTagRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
+			}
+			eTag = eTagRaw
+			versionRaw := resp.Header.Get("X-Policy-Version")
+			if versionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+			}
+			version = versionRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "Evaluate", err)
+			}
+			res := NewEvaluateResultAccepted(&body, eTag, version)
+			tmp := "pending"
+			res.JobStatus = &tmp
+			return res, nil
 		case http.StatusOK:
 			var (
 				body any
@@ -106,17 +148,23 @@ func DecodeEvaluateResponse(decoder func(*http.Response) goahttp.Decoder, restor
 				return nil, goahttp.ErrDecodingError("policy", "Evaluate", err)
 			}
 			var (
-				eTag string
+				eTag    string
+				version string
 			)
 			eTagRaw := resp.Header.Get("Etag")
 			if eTagRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
 			}
 			eTag = eTagRaw
+			versionRaw := resp.Header.Get("X-Policy-Version")
+			if versionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+			}
+			version = versionRaw
 			if err != nil {
 				return nil, goahttp.ErrValidationError("policy", "Evaluate", err)
 			}
-			res := NewEvaluateResultOK(body, eTag)
+			res := NewEvaluateResultOK(body, eTag, version)
 			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
@@ -125,29 +173,33 @@ func DecodeEvaluateResponse(decoder func(*http.Response) goahttp.Decoder, restor
 	}
 }
 
-// BuildValidateRequest instantiates a HTTP request object with method and path
-// set to call the "policy" service "Validate" endpoint
-func (c *Client) BuildValidateRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildEvaluateRuleRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "EvaluateRule" endpoint
+func (c *Client) BuildEvaluateRuleRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
 		repository string
 		group      string
 		policyName string
 		version    string
+		rule       string
 	)
 	{
 		p, ok := v.(*policy.EvaluateRequest)
 		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "Validate", "*policy.EvaluateRequest", v)
+			return nil, goahttp.ErrInvalidType("policy", "EvaluateRule", "*policy.EvaluateRequest", v)
 		}
 		repository = p.Repository
 		group = p.Group
 		policyName = p.PolicyName
 		version = p.Version
+		if p.Rule != nil {
+			rule = *p.Rule
+		}
 	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ValidatePolicyPath(repository, group, policyName, version)}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: EvaluateRulePolicyPath(repository, group, policyName, version, rule)}
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "Validate", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "EvaluateRule", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -156,13 +208,13 @@ func (c *Client) BuildValidateRequest(ctx context.Context, v any) (*http.Request
 	return req, nil
 }
 
-// EncodeValidateRequest returns an encoder for requests sent to the policy
-// Validate server.
-func EncodeValidateRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+// EncodeEvaluateRuleRequest returns an encoder for requests sent to the policy
+// EvaluateRule server.
+func EncodeEvaluateRuleRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
 	return func(req *http.Request, v any) error {
 		p, ok := v.(*policy.EvaluateRequest)
 		if !ok {
-			return goahttp.ErrInvalidType("policy", "Validate", "*policy.EvaluateRequest", v)
+			return goahttp.ErrInvalidType("policy", "EvaluateRule", "*policy.EvaluateRequest", v)
 		}
 		if p.EvaluationID != nil {
 			head := *p.EvaluationID
@@ -173,18 +225,30 @@ func EncodeValidateRequest(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			headStr := strconv.Itoa(head)
 			req.Header.Set("x-cache-ttl", headStr)
 		}
+		if p.CallbackURL != nil {
+			head := *p.CallbackURL
+			req.Header.Set("x-callback-url", head)
+		}
+		values := req.URL.Query()
+		if p.Explain != nil {
+			values.Add("explain", *p.Explain)
+		}
+		if p.Async != nil {
+			values.Add("async", fmt.Sprintf("%v", *p.Async))
+		}
+		req.URL.RawQuery = values.Encode()
 		body := p.Input
 		if err := encoder(req).Encode(&body); err != nil {
-			return goahttp.ErrEncodingError("policy", "Validate", err)
+			return goahttp.ErrEncodingError("policy", "EvaluateRule", err)
 		}
 		return nil
 	}
 }
 
-// DecodeValidateResponse returns a decoder for responses returned by the
-// policy Validate endpoint. restoreBody controls whether the response body
+// DecodeEvaluateRuleResponse returns a decoder for responses returned by the
+// policy EvaluateRule endpoint. restoreBody controls whether the response body
 // should be restored after having been read.
-func DecodeValidateResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+func DecodeEvaluateRuleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -199,6 +263,36 @@ func DecodeValidateResponse(decoder func(*http.Response) goahttp.Decoder, restor
 			defer resp.Body.Close()
 		}
 		switch resp.StatusCode {
+		case http.StatusAccepted:
+			var (
+				body EvaluateRuleAcceptedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "EvaluateRule", err)
+			}
+			var (
+				eTag    string
+				version string
+			)
+			eTagRaw := resp.Header.Get("Etag")
+			if eTagRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
+			}
+			eTag = eTagRaw
+			versionRaw := resp.Header.Get("X-Policy-Version")
+			if versionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+			}
+			version = versionRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "EvaluateRule", err)
+			}
+			res := NewEvaluateRuleEvaluateResultAccepted(&body, eTag, version)
+			tmp := "pending"
+			res.JobStatus = &tmp
+			return res, nil
 		case http.StatusOK:
 			var (
 				body any
@@ -206,31 +300,37 @@ func DecodeValidateResponse(decoder func(*http.Response) goahttp.Decoder, restor
 			)
 			err = decoder(resp).Decode(&body)
 			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "Validate", err)
+				return nil, goahttp.ErrDecodingError("policy", "EvaluateRule", err)
 			}
 			var (
-				eTag string
+				eTag    string
+				version string
 			)
 			eTagRaw := resp.Header.Get("Etag")
 			if eTagRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
 			}
 			eTag = eTagRaw
+			versionRaw := resp.Header.Get("X-Policy-Version")
+			if versionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+			}
+			version = versionRaw
 			if err != nil {
-				return nil, goahttp.ErrValidationError("policy", "Validate", err)
+				return nil, goahttp.ErrValidationError("policy", "EvaluateRule", err)
 			}
-			res := NewValidateEvaluateResultOK(body, eTag)
+			res := NewEvaluateRuleEvaluateResultOK(body, eTag, version)
 			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "Validate", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "EvaluateRule", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildLockRequest instantiates a HTTP request object with method and path set
-// to call the "policy" service "Lock" endpoint
-func (c *Client) BuildLockRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildValidateRequest instantiates a HTTP request object with method and path
+// set to call the "policy" service "Validate" endpoint
+func (c *Client) BuildValidateRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
 		repository string
 		group      string
@@ -238,19 +338,19 @@ func (c *Client) BuildLockRequest(ctx context.Context, v any) (*http.Request, er
 		version    string
 	)
 	{
-		p, ok := v.(*policy.LockRequest)
+		p, ok := v.(*policy.EvaluateRequest)
 		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "Lock", "*policy.LockRequest", v)
+			return nil, goahttp.ErrInvalidType("policy", "Validate", "*policy.EvaluateRequest", v)
 		}
 		repository = p.Repository
 		group = p.Group
 		policyName = p.PolicyName
 		version = p.Version
 	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: LockPolicyPath(repository, group, policyName, version)}
-	req, err := http.NewRequest("POST", u.String(), nil)
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ValidatePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "Lock", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "Validate", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -259,10 +359,46 @@ func (c *Client) BuildLockRequest(ctx context.Context, v any) (*http.Request, er
 	return req, nil
 }
 
-// DecodeLockResponse returns a decoder for responses returned by the policy
-// Lock endpoint. restoreBody controls whether the response body should be
-// restored after having been read.
-func DecodeLockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// EncodeValidateRequest returns an encoder for requests sent to the policy
+// Validate server.
+func EncodeValidateRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.EvaluateRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "Validate", "*policy.EvaluateRequest", v)
+		}
+		if p.EvaluationID != nil {
+			head := *p.EvaluationID
+			req.Header.Set("x-evaluation-id", head)
+		}
+		if p.TTL != nil {
+			head := *p.TTL
+			headStr := strconv.Itoa(head)
+			req.Header.Set("x-cache-ttl", headStr)
+		}
+		values := req.URL.Query()
+		if p.Explain != nil {
+			values.Add("explain", *p.Explain)
+		}
+		if p.Report != nil {
+			values.Add("report", fmt.Sprintf("%v", *p.Report))
+		}
+		if p.Coerce != nil {
+			values.Add("coerce", fmt.Sprintf("%v", *p.Coerce))
+		}
+		req.URL.RawQuery = values.Encode()
+		body := p.Input
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "Validate", err)
+		}
+		return nil
+	}
+}
+
+// DecodeValidateResponse returns a decoder for responses returned by the
+// policy Validate endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeValidateResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -278,17 +414,43 @@ func DecodeLockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBod
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			return nil, nil
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "Validate", err)
+			}
+			var (
+				eTag    string
+				version string
+			)
+			eTagRaw := resp.Header.Get("Etag")
+			if eTagRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
+			}
+			eTag = eTagRaw
+			versionRaw := resp.Header.Get("X-Policy-Version")
+			if versionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+			}
+			version = versionRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "Validate", err)
+			}
+			res := NewValidateEvaluateResultOK(body, eTag, version)
+			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "Lock", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "Validate", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildUnlockRequest instantiates a HTTP request object with method and path
-// set to call the "policy" service "Unlock" endpoint
-func (c *Client) BuildUnlockRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildPartialEvaluateRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "PartialEvaluate" endpoint
+func (c *Client) BuildPartialEvaluateRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
 		repository string
 		group      string
@@ -296,19 +458,19 @@ func (c *Client) BuildUnlockRequest(ctx context.Context, v any) (*http.Request,
 		version    string
 	)
 	{
-		p, ok := v.(*policy.UnlockRequest)
+		p, ok := v.(*policy.PartialEvaluateRequest)
 		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "Unlock", "*policy.UnlockRequest", v)
+			return nil, goahttp.ErrInvalidType("policy", "PartialEvaluate", "*policy.PartialEvaluateRequest", v)
 		}
 		repository = p.Repository
 		group = p.Group
 		policyName = p.PolicyName
 		version = p.Version
 	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UnlockPolicyPath(repository, group, policyName, version)}
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PartialEvaluatePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "Unlock", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "PartialEvaluate", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -317,10 +479,26 @@ func (c *Client) BuildUnlockRequest(ctx context.Context, v any) (*http.Request,
 	return req, nil
 }
 
-// DecodeUnlockResponse returns a decoder for responses returned by the policy
-// Unlock endpoint. restoreBody controls whether the response body should be
-// restored after having been read.
-func DecodeUnlockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// EncodePartialEvaluateRequest returns an encoder for requests sent to the
+// policy PartialEvaluate server.
+func EncodePartialEvaluateRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.PartialEvaluateRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "PartialEvaluate", "*policy.PartialEvaluateRequest", v)
+		}
+		body := NewPartialEvaluateRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "PartialEvaluate", err)
+		}
+		return nil
+	}
+}
+
+// DecodePartialEvaluateResponse returns a decoder for responses returned by
+// the policy PartialEvaluate endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodePartialEvaluateResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -336,37 +514,34 @@ func DecodeUnlockResponse(decoder func(*http.Response) goahttp.Decoder, restoreB
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			return nil, nil
+			var (
+				body PartialEvaluateResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PartialEvaluate", err)
+			}
+			err = ValidatePartialEvaluateResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PartialEvaluate", err)
+			}
+			res := NewPartialEvaluateResultOK(&body)
+			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "Unlock", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "PartialEvaluate", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildExportBundleRequest instantiates a HTTP request object with method and
-// path set to call the "policy" service "ExportBundle" endpoint
-func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
-	var (
-		repository string
-		group      string
-		policyName string
-		version    string
-	)
-	{
-		p, ok := v.(*policy.ExportBundleRequest)
-		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "ExportBundle", "*policy.ExportBundleRequest", v)
-		}
-		repository = p.Repository
-		group = p.Group
-		policyName = p.PolicyName
-		version = p.Version
-	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ExportBundlePolicyPath(repository, group, policyName, version)}
-	req, err := http.NewRequest("GET", u.String(), nil)
+// BuildEvaluateBatchRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "EvaluateBatch" endpoint
+func (c *Client) BuildEvaluateBatchRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: EvaluateBatchPolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "ExportBundle", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "EvaluateBatch", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -375,10 +550,26 @@ func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Req
 	return req, nil
 }
 
-// DecodeExportBundleResponse returns a decoder for responses returned by the
-// policy ExportBundle endpoint. restoreBody controls whether the response body
-// should be restored after having been read.
-func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// EncodeEvaluateBatchRequest returns an encoder for requests sent to the
+// policy EvaluateBatch server.
+func EncodeEvaluateBatchRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.BatchEvaluateRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "EvaluateBatch", "*policy.BatchEvaluateRequest", v)
+		}
+		body := NewEvaluateBatchRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "EvaluateBatch", err)
+		}
+		return nil
+	}
+}
+
+// DecodeEvaluateBatchResponse returns a decoder for responses returned by the
+// policy EvaluateBatch endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeEvaluateBatchResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -389,71 +580,1362 @@ func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, re
 			defer func() {
 				resp.Body = io.NopCloser(bytes.NewBuffer(b))
 			}()
+		} else {
+			defer resp.Body.Close()
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
 			var (
-				contentType        string
-				contentLength      int
-				contentDisposition string
-				err                error
+				body EvaluateBatchResponseBody
+				err  error
 			)
-			contentTypeRaw := resp.Header.Get("Content-Type")
-			if contentTypeRaw == "" {
-				err = goa.MergeErrors(err, goa.MissingFieldError("content-type", "header"))
-			}
-			contentType = contentTypeRaw
-			{
-				contentLengthRaw := resp.Header.Get("Content-Length")
-				if contentLengthRaw == "" {
-					return nil, goahttp.ErrValidationError("policy", "ExportBundle", goa.MissingFieldError("content-length", "header"))
-				}
-				v, err2 := strconv.ParseInt(contentLengthRaw, 10, strconv.IntSize)
-				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("content-length", contentLengthRaw, "integer"))
-				}
-				contentLength = int(v)
-			}
-			contentDispositionRaw := resp.Header.Get("Content-Disposition")
-			if contentDispositionRaw == "" {
-				err = goa.MergeErrors(err, goa.MissingFieldError("content-disposition", "header"))
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "EvaluateBatch", err)
 			}
-			contentDisposition = contentDispositionRaw
+			err = ValidateEvaluateBatchResponseBody(&body)
 			if err != nil {
-				return nil, goahttp.ErrValidationError("policy", "ExportBundle", err)
+				return nil, goahttp.ErrValidationError("policy", "EvaluateBatch", err)
 			}
-			res := NewExportBundleResultOK(contentType, contentLength, contentDisposition)
+			res := NewEvaluateBatchBatchEvaluateResultOK(&body)
 			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "ExportBundle", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "EvaluateBatch", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildPolicyPublicKeyRequest instantiates a HTTP request object with method
-// and path set to call the "policy" service "PolicyPublicKey" endpoint
-func (c *Client) BuildPolicyPublicKeyRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildEvaluateBatchStreamRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "EvaluateBatchStream"
+// endpoint
+func (c *Client) BuildEvaluateBatchStreamRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
-		repository string
-		group      string
-		policyName string
-		version    string
+		body io.Reader
+	)
+	rd, ok := v.(*policy.EvaluateBatchStreamRequestData)
+	if !ok {
+		return nil, goahttp.ErrInvalidType("policy", "EvaluateBatchStream", "policy.EvaluateBatchStreamRequestData", v)
+	}
+	body = rd.Body
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: EvaluateBatchStreamPolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), body)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "EvaluateBatchStream", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeEvaluateBatchStreamResponse returns a decoder for responses returned
+// by the policy EvaluateBatchStream endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeEvaluateBatchStreamResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				contentType string
+				err         error
+			)
+			contentTypeRaw := resp.Header.Get("Content-Type")
+			if contentTypeRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("content-type", "header"))
+			}
+			contentType = contentTypeRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "EvaluateBatchStream", err)
+			}
+			res := NewEvaluateBatchStreamBatchEvaluateStreamResultOK(contentType)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "EvaluateBatchStream", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// // BuildEvaluateBatchStreamStreamPayload creates a streaming endpoint request
+// payload from the method payload and the path to the file to be streamed
+func BuildEvaluateBatchStreamStreamPayload(fpath string) (*policy.EvaluateBatchStreamRequestData, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	return &policy.EvaluateBatchStreamRequestData{
+		Body: f,
+	}, nil
+}
+
+// BuildEvaluationResultRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "EvaluationResult" endpoint
+func (c *Client) BuildEvaluationResultRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		evaluationID string
+	)
+	{
+		p, ok := v.(*policy.EvaluationResultRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "EvaluationResult", "*policy.EvaluationResultRequest", v)
+		}
+		evaluationID = p.EvaluationID
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: EvaluationResultPolicyPath(evaluationID)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "EvaluationResult", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeEvaluationResultRequest returns an encoder for requests sent to the
+// policy EvaluationResult server.
+func EncodeEvaluationResultRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.EvaluationResultRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "EvaluationResult", "*policy.EvaluationResultRequest", v)
+		}
+		values := req.URL.Query()
+		if p.Wait != nil {
+			values.Add("wait", fmt.Sprintf("%v", *p.Wait))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeEvaluationResultResponse returns a decoder for responses returned by
+// the policy EvaluationResult endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeEvaluationResultResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "EvaluationResult", err)
+			}
+			var (
+				eTag string
+			)
+			eTagRaw := resp.Header.Get("Etag")
+			if eTagRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "header"))
+			}
+			eTag = eTagRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "EvaluationResult", err)
+			}
+			res := NewEvaluationResultStoredEvaluationResultOK(body, eTag)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "EvaluationResult", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildJobStatusRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "JobStatus" endpoint
+func (c *Client) BuildJobStatusRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		jobID string
+	)
+	{
+		p, ok := v.(*policy.JobStatusRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "JobStatus", "*policy.JobStatusRequest", v)
+		}
+		jobID = p.JobID
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: JobStatusPolicyPath(jobID)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "JobStatus", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeJobStatusResponse returns a decoder for responses returned by the
+// policy JobStatus endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeJobStatusResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body JobStatusResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "JobStatus", err)
+			}
+			err = ValidateJobStatusResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "JobStatus", err)
+			}
+			res := NewJobStatusEvaluationJobOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "JobStatus", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildLockRequest instantiates a HTTP request object with method and path set
+// to call the "policy" service "Lock" endpoint
+func (c *Client) BuildLockRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.LockRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "Lock", "*policy.LockRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: LockPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "Lock", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeLockRequest returns an encoder for requests sent to the policy Lock
+// server.
+func EncodeLockRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.LockRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "Lock", "*policy.LockRequest", v)
+		}
+		body := NewLockRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "Lock", err)
+		}
+		return nil
+	}
+}
+
+// DecodeLockResponse returns a decoder for responses returned by the policy
+// Lock endpoint. restoreBody controls whether the response body should be
+// restored after having been read.
+func DecodeLockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "Lock", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildUnlockRequest instantiates a HTTP request object with method and path
+// set to call the "policy" service "Unlock" endpoint
+func (c *Client) BuildUnlockRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.UnlockRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "Unlock", "*policy.UnlockRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UnlockPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "Unlock", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeUnlockRequest returns an encoder for requests sent to the policy
+// Unlock server.
+func EncodeUnlockRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.UnlockRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "Unlock", "*policy.UnlockRequest", v)
+		}
+		body := NewUnlockRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "Unlock", err)
+		}
+		return nil
+	}
+}
+
+// DecodeUnlockResponse returns a decoder for responses returned by the policy
+// Unlock endpoint. restoreBody controls whether the response body should be
+// restored after having been read.
+func DecodeUnlockResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "Unlock", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildLockHistoryRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "LockHistory" endpoint
+func (c *Client) BuildLockHistoryRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.LockHistoryRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "LockHistory", "*policy.LockHistoryRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: LockHistoryPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "LockHistory", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeLockHistoryResponse returns a decoder for responses returned by the
+// policy LockHistory endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeLockHistoryResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body LockHistoryResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "LockHistory", err)
+			}
+			err = ValidateLockHistoryResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "LockHistory", err)
+			}
+			res := NewLockHistoryResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "LockHistory", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyTestsRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "PolicyTests" endpoint
+func (c *Client) BuildPolicyTestsRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.PolicyTestsRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "PolicyTests", "*policy.PolicyTestsRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyTestsPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyTests", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodePolicyTestsResponse returns a decoder for responses returned by the
+// policy PolicyTests endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodePolicyTestsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body PolicyTestsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyTests", err)
+			}
+			err = ValidatePolicyTestsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PolicyTests", err)
+			}
+			res := NewPolicyTestsPolicyTestReportOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyTests", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildExportBundleRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "ExportBundle" endpoint
+func (c *Client) BuildExportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.ExportBundleRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "ExportBundle", "*policy.ExportBundleRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ExportBundlePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "ExportBundle", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeExportBundleResponse returns a decoder for responses returned by the
+// policy ExportBundle endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeExportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				contentType        string
+				contentLength      int
+				contentDisposition string
+				err                error
+			)
+			contentTypeRaw := resp.Header.Get("Content-Type")
+			if contentTypeRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("content-type", "header"))
+			}
+			contentType = contentTypeRaw
+			{
+				contentLengthRaw := resp.Header.Get("Content-Length")
+				if contentLengthRaw == "" {
+					return nil, goahttp.ErrValidationError("policy", "ExportBundle", goa.MissingFieldError("content-length", "header"))
+				}
+				v, err2 := strconv.ParseInt(contentLengthRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("content-length", contentLengthRaw, "integer"))
+				}
+				contentLength = int(v)
+			}
+			contentDispositionRaw := resp.Header.Get("Content-Disposition")
+			if contentDispositionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("content-disposition", "header"))
+			}
+			contentDisposition = contentDispositionRaw
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "ExportBundle", err)
+			}
+			res := NewExportBundleResultOK(contentType, contentLength, contentDisposition)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "ExportBundle", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyPublicKeyRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "PolicyPublicKey" endpoint
+func (c *Client) BuildPolicyPublicKeyRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.PolicyPublicKeyRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "PolicyPublicKey", "*policy.PolicyPublicKeyRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyPublicKeyPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyPublicKey", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodePolicyPublicKeyResponse returns a decoder for responses returned by
+// the policy PolicyPublicKey endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodePolicyPublicKeyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyPublicKey", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyPublicKey", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildImportBundleRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "ImportBundle" endpoint
+func (c *Client) BuildImportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		body io.Reader
+	)
+	rd, ok := v.(*policy.ImportBundleRequestData)
+	if !ok {
+		return nil, goahttp.ErrInvalidType("policy", "ImportBundle", "policy.ImportBundleRequestData", v)
+	}
+	body = rd.Body
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ImportBundlePolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), body)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "ImportBundle", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeImportBundleRequest returns an encoder for requests sent to the policy
+// ImportBundle server.
+func EncodeImportBundleRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		data, ok := v.(*policy.ImportBundleRequestData)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "ImportBundle", "*policy.ImportBundleRequestData", v)
+		}
+		p := data.Payload
+		if p.Length != nil {
+			head := *p.Length
+			headStr := strconv.Itoa(head)
+			req.Header.Set("Content-Length", headStr)
+		}
+		return nil
+	}
+}
+
+// DecodeImportBundleResponse returns a decoder for responses returned by the
+// policy ImportBundle endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeImportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "ImportBundle", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "ImportBundle", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// // BuildImportBundleStreamPayload creates a streaming endpoint request payload
+// from the method payload and the path to the file to be streamed
+func BuildImportBundleStreamPayload(payload any, fpath string) (*policy.ImportBundleRequestData, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	return &policy.ImportBundleRequestData{
+		Payload: payload.(*policy.ImportBundlePayload),
+		Body:    f,
+	}, nil
+}
+
+// BuildListPoliciesRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "ListPolicies" endpoint
+func (c *Client) BuildListPoliciesRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListPoliciesPolicyPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "ListPolicies", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeListPoliciesRequest returns an encoder for requests sent to the policy
+// ListPolicies server.
+func EncodeListPoliciesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.PoliciesRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "ListPolicies", "*policy.PoliciesRequest", v)
+		}
+		values := req.URL.Query()
+		if p.Locked != nil {
+			values.Add("locked", fmt.Sprintf("%v", *p.Locked))
+		}
+		if p.PolicyName != nil {
+			values.Add("policyName", *p.PolicyName)
+		}
+		if p.Rego != nil {
+			values.Add("rego", fmt.Sprintf("%v", *p.Rego))
+		}
+		if p.Data != nil {
+			values.Add("data", fmt.Sprintf("%v", *p.Data))
+		}
+		if p.DataConfig != nil {
+			values.Add("dataConfig", fmt.Sprintf("%v", *p.DataConfig))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeListPoliciesResponse returns a decoder for responses returned by the
+// policy ListPolicies endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeListPoliciesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ListPoliciesResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "ListPolicies", err)
+			}
+			err = ValidateListPoliciesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "ListPolicies", err)
+			}
+			res := NewListPoliciesPoliciesResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "ListPolicies", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildDecisionLogsRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "DecisionLogs" endpoint
+func (c *Client) BuildDecisionLogsRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DecisionLogsPolicyPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "DecisionLogs", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeDecisionLogsRequest returns an encoder for requests sent to the policy
+// DecisionLogs server.
+func EncodeDecisionLogsRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.DecisionLogsRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "DecisionLogs", "*policy.DecisionLogsRequest", v)
+		}
+		values := req.URL.Query()
+		if p.Repository != nil {
+			values.Add("repository", *p.Repository)
+		}
+		if p.Group != nil {
+			values.Add("group", *p.Group)
+		}
+		if p.PolicyName != nil {
+			values.Add("policyName", *p.PolicyName)
+		}
+		if p.Version != nil {
+			values.Add("version", *p.Version)
+		}
+		if p.EvaluationID != nil {
+			values.Add("evaluationID", *p.EvaluationID)
+		}
+		if p.Caller != nil {
+			values.Add("caller", *p.Caller)
+		}
+		if p.From != nil {
+			values.Add("from", fmt.Sprintf("%v", *p.From))
+		}
+		if p.To != nil {
+			values.Add("to", fmt.Sprintf("%v", *p.To))
+		}
+		values.Add("limit", fmt.Sprintf("%v", p.Limit))
+		if p.Offset != nil {
+			values.Add("offset", fmt.Sprintf("%v", *p.Offset))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeDecisionLogsResponse returns a decoder for responses returned by the
+// policy DecisionLogs endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeDecisionLogsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body DecisionLogsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "DecisionLogs", err)
+			}
+			err = ValidateDecisionLogsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "DecisionLogs", err)
+			}
+			res := NewDecisionLogsResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DecisionLogs", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildAuditLogRequest instantiates a HTTP request object with method and path
+// set to call the "policy" service "AuditLog" endpoint
+func (c *Client) BuildAuditLogRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: AuditLogPolicyPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "AuditLog", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeAuditLogRequest returns an encoder for requests sent to the policy
+// AuditLog server.
+func EncodeAuditLogRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.AuditLogRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "AuditLog", "*policy.AuditLogRequest", v)
+		}
+		values := req.URL.Query()
+		if p.Operation != nil {
+			values.Add("operation", *p.Operation)
+		}
+		if p.Actor != nil {
+			values.Add("actor", *p.Actor)
+		}
+		if p.Repository != nil {
+			values.Add("repository", *p.Repository)
+		}
+		if p.Group != nil {
+			values.Add("group", *p.Group)
+		}
+		if p.PolicyName != nil {
+			values.Add("policyName", *p.PolicyName)
+		}
+		if p.Version != nil {
+			values.Add("version", *p.Version)
+		}
+		if p.From != nil {
+			values.Add("from", fmt.Sprintf("%v", *p.From))
+		}
+		if p.To != nil {
+			values.Add("to", fmt.Sprintf("%v", *p.To))
+		}
+		values.Add("limit", fmt.Sprintf("%v", p.Limit))
+		if p.Offset != nil {
+			values.Add("offset", fmt.Sprintf("%v", *p.Offset))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeAuditLogResponse returns a decoder for responses returned by the
+// policy AuditLog endpoint. restoreBody controls whether the response body
+// should be restored after having been read.
+func DecodeAuditLogResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body AuditLogResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "AuditLog", err)
+			}
+			err = ValidateAuditLogResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "AuditLog", err)
+			}
+			res := NewAuditLogResultOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "AuditLog", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildSetPolicyAutoImportRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "SetPolicyAutoImport"
+// endpoint
+func (c *Client) BuildSetPolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SetPolicyAutoImportPolicyPath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "SetPolicyAutoImport", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeSetPolicyAutoImportRequest returns an encoder for requests sent to the
+// policy SetPolicyAutoImport server.
+func EncodeSetPolicyAutoImportRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.SetPolicyAutoImportRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "SetPolicyAutoImport", "*policy.SetPolicyAutoImportRequest", v)
+		}
+		body := NewSetPolicyAutoImportRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "SetPolicyAutoImport", err)
+		}
+		return nil
+	}
+}
+
+// DecodeSetPolicyAutoImportResponse returns a decoder for responses returned
+// by the policy SetPolicyAutoImport endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeSetPolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "SetPolicyAutoImport", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "SetPolicyAutoImport", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyAutoImportRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "PolicyAutoImport" endpoint
+func (c *Client) BuildPolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyAutoImportPolicyPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyAutoImport", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodePolicyAutoImportResponse returns a decoder for responses returned by
+// the policy PolicyAutoImport endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodePolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "PolicyAutoImport", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyAutoImport", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildDeletePolicyAutoImportRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "DeletePolicyAutoImport"
+// endpoint
+func (c *Client) BuildDeletePolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyAutoImportPolicyPath()}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicyAutoImport", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeDeletePolicyAutoImportRequest returns an encoder for requests sent to
+// the policy DeletePolicyAutoImport server.
+func EncodeDeletePolicyAutoImportRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.DeletePolicyAutoImportRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "DeletePolicyAutoImport", "*policy.DeletePolicyAutoImportRequest", v)
+		}
+		body := NewDeletePolicyAutoImportRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "DeletePolicyAutoImport", err)
+		}
+		return nil
+	}
+}
+
+// DecodeDeletePolicyAutoImportResponse returns a decoder for responses
+// returned by the policy DeletePolicyAutoImport endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+func DecodeDeletePolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body any
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "DeletePolicyAutoImport", err)
+			}
+			return body, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicyAutoImport", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildSetPolicyAliasRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "SetPolicyAlias" endpoint
+func (c *Client) BuildSetPolicyAliasRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		alias      string
 	)
 	{
-		p, ok := v.(*policy.PolicyPublicKeyRequest)
+		p, ok := v.(*policy.SetPolicyAliasRequest)
 		if !ok {
-			return nil, goahttp.ErrInvalidType("policy", "PolicyPublicKey", "*policy.PolicyPublicKeyRequest", v)
+			return nil, goahttp.ErrInvalidType("policy", "SetPolicyAlias", "*policy.SetPolicyAliasRequest", v)
 		}
 		repository = p.Repository
 		group = p.Group
 		policyName = p.PolicyName
-		version = p.Version
+		alias = p.Alias
 	}
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyPublicKeyPolicyPath(repository, group, policyName, version)}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SetPolicyAliasPolicyPath(repository, group, policyName, alias)}
+	req, err := http.NewRequest("PUT", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "SetPolicyAlias", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeSetPolicyAliasRequest returns an encoder for requests sent to the
+// policy SetPolicyAlias server.
+func EncodeSetPolicyAliasRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*policy.SetPolicyAliasRequest)
+		if !ok {
+			return goahttp.ErrInvalidType("policy", "SetPolicyAlias", "*policy.SetPolicyAliasRequest", v)
+		}
+		body := NewSetPolicyAliasRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "SetPolicyAlias", err)
+		}
+		return nil
+	}
+}
+
+// DecodeSetPolicyAliasResponse returns a decoder for responses returned by the
+// policy SetPolicyAlias endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeSetPolicyAliasResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body SetPolicyAliasResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("policy", "SetPolicyAlias", err)
+			}
+			err = ValidateSetPolicyAliasResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "SetPolicyAlias", err)
+			}
+			res := NewSetPolicyAliasPolicyAliasOK(&body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "SetPolicyAlias", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPolicyAliasesRequest instantiates a HTTP request object with method and
+// path set to call the "policy" service "PolicyAliases" endpoint
+func (c *Client) BuildPolicyAliasesRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+	)
+	{
+		p, ok := v.(*policy.PolicyAliasesRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "PolicyAliases", "*policy.PolicyAliasesRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyAliasesPolicyPath(repository, group, policyName)}
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "PolicyPublicKey", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "PolicyAliases", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -462,10 +1944,10 @@ func (c *Client) BuildPolicyPublicKeyRequest(ctx context.Context, v any) (*http.
 	return req, nil
 }
 
-// DecodePolicyPublicKeyResponse returns a decoder for responses returned by
-// the policy PolicyPublicKey endpoint. restoreBody controls whether the
-// response body should be restored after having been read.
-func DecodePolicyPublicKeyResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// DecodePolicyAliasesResponse returns a decoder for responses returned by the
+// policy PolicyAliases endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodePolicyAliasesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -482,36 +1964,49 @@ func DecodePolicyPublicKeyResponse(decoder func(*http.Response) goahttp.Decoder,
 		switch resp.StatusCode {
 		case http.StatusOK:
 			var (
-				body any
+				body PolicyAliasesResponseBody
 				err  error
 			)
 			err = decoder(resp).Decode(&body)
 			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "PolicyPublicKey", err)
+				return nil, goahttp.ErrDecodingError("policy", "PolicyAliases", err)
 			}
-			return body, nil
+			err = ValidatePolicyAliasesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "PolicyAliases", err)
+			}
+			res := NewPolicyAliasesResultOK(&body)
+			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "PolicyPublicKey", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "PolicyAliases", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildImportBundleRequest instantiates a HTTP request object with method and
-// path set to call the "policy" service "ImportBundle" endpoint
-func (c *Client) BuildImportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+// BuildDeletePolicyAliasRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "DeletePolicyAlias" endpoint
+func (c *Client) BuildDeletePolicyAliasRequest(ctx context.Context, v any) (*http.Request, error) {
 	var (
-		body io.Reader
+		repository string
+		group      string
+		policyName string
+		alias      string
 	)
-	rd, ok := v.(*policy.ImportBundleRequestData)
-	if !ok {
-		return nil, goahttp.ErrInvalidType("policy", "ImportBundle", "policy.ImportBundleRequestData", v)
+	{
+		p, ok := v.(*policy.DeletePolicyAliasRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DeletePolicyAlias", "*policy.DeletePolicyAliasRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		alias = p.Alias
 	}
-	body = rd.Body
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ImportBundlePolicyPath()}
-	req, err := http.NewRequest("POST", u.String(), body)
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyAliasPolicyPath(repository, group, policyName, alias)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "ImportBundle", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicyAlias", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -520,28 +2015,84 @@ func (c *Client) BuildImportBundleRequest(ctx context.Context, v any) (*http.Req
 	return req, nil
 }
 
-// EncodeImportBundleRequest returns an encoder for requests sent to the policy
-// ImportBundle server.
-func EncodeImportBundleRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+// DecodeDeletePolicyAliasResponse returns a decoder for responses returned by
+// the policy DeletePolicyAlias endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeDeletePolicyAliasResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicyAlias", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildSetPolicyShadowRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "SetPolicyShadow" endpoint
+func (c *Client) BuildSetPolicyShadowRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.SetPolicyShadowRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "SetPolicyShadow", "*policy.SetPolicyShadowRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SetPolicyShadowPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("PUT", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("policy", "SetPolicyShadow", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeSetPolicyShadowRequest returns an encoder for requests sent to the
+// policy SetPolicyShadow server.
+func EncodeSetPolicyShadowRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
 	return func(req *http.Request, v any) error {
-		data, ok := v.(*policy.ImportBundleRequestData)
+		p, ok := v.(*policy.SetPolicyShadowRequest)
 		if !ok {
-			return goahttp.ErrInvalidType("policy", "ImportBundle", "*policy.ImportBundleRequestData", v)
+			return goahttp.ErrInvalidType("policy", "SetPolicyShadow", "*policy.SetPolicyShadowRequest", v)
 		}
-		p := data.Payload
-		if p.Length != nil {
-			head := *p.Length
-			headStr := strconv.Itoa(head)
-			req.Header.Set("Content-Length", headStr)
+		body := NewSetPolicyShadowRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "SetPolicyShadow", err)
 		}
 		return nil
 	}
 }
 
-// DecodeImportBundleResponse returns a decoder for responses returned by the
-// policy ImportBundle endpoint. restoreBody controls whether the response body
-// should be restored after having been read.
-func DecodeImportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// DecodeSetPolicyShadowResponse returns a decoder for responses returned by
+// the policy SetPolicyShadow endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeSetPolicyShadowResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -557,42 +2108,96 @@ func DecodeImportBundleResponse(decoder func(*http.Response) goahttp.Decoder, re
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			var (
-				body any
-				err  error
-			)
-			err = decoder(resp).Decode(&body)
-			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "ImportBundle", err)
-			}
-			return body, nil
+			return nil, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "ImportBundle", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "SetPolicyShadow", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// // BuildImportBundleStreamPayload creates a streaming endpoint request payload
-// from the method payload and the path to the file to be streamed
-func BuildImportBundleStreamPayload(payload any, fpath string) (*policy.ImportBundleRequestData, error) {
-	f, err := os.Open(fpath)
+// BuildDeletePolicyShadowRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "DeletePolicyShadow"
+// endpoint
+func (c *Client) BuildDeletePolicyShadowRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.DeletePolicyShadowRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DeletePolicyShadow", "*policy.DeletePolicyShadowRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyShadowPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicyShadow", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeDeletePolicyShadowResponse returns a decoder for responses returned by
+// the policy DeletePolicyShadow endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeDeletePolicyShadowResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicyShadow", resp.StatusCode, string(body))
+		}
 	}
-	return &policy.ImportBundleRequestData{
-		Payload: payload.(*policy.ImportBundlePayload),
-		Body:    f,
-	}, nil
 }
 
-// BuildListPoliciesRequest instantiates a HTTP request object with method and
-// path set to call the "policy" service "ListPolicies" endpoint
-func (c *Client) BuildListPoliciesRequest(ctx context.Context, v any) (*http.Request, error) {
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListPoliciesPolicyPath()}
-	req, err := http.NewRequest("GET", u.String(), nil)
+// BuildSetPolicyCaptureRequest instantiates a HTTP request object with method
+// and path set to call the "policy" service "SetPolicyCapture" endpoint
+func (c *Client) BuildSetPolicyCaptureRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.SetPolicyCaptureRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "SetPolicyCapture", "*policy.SetPolicyCaptureRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SetPolicyCapturePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("PUT", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "ListPolicies", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "SetPolicyCapture", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -601,39 +2206,26 @@ func (c *Client) BuildListPoliciesRequest(ctx context.Context, v any) (*http.Req
 	return req, nil
 }
 
-// EncodeListPoliciesRequest returns an encoder for requests sent to the policy
-// ListPolicies server.
-func EncodeListPoliciesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+// EncodeSetPolicyCaptureRequest returns an encoder for requests sent to the
+// policy SetPolicyCapture server.
+func EncodeSetPolicyCaptureRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
 	return func(req *http.Request, v any) error {
-		p, ok := v.(*policy.PoliciesRequest)
+		p, ok := v.(*policy.SetPolicyCaptureRequest)
 		if !ok {
-			return goahttp.ErrInvalidType("policy", "ListPolicies", "*policy.PoliciesRequest", v)
-		}
-		values := req.URL.Query()
-		if p.Locked != nil {
-			values.Add("locked", fmt.Sprintf("%v", *p.Locked))
-		}
-		if p.PolicyName != nil {
-			values.Add("policyName", *p.PolicyName)
+			return goahttp.ErrInvalidType("policy", "SetPolicyCapture", "*policy.SetPolicyCaptureRequest", v)
 		}
-		if p.Rego != nil {
-			values.Add("rego", fmt.Sprintf("%v", *p.Rego))
-		}
-		if p.Data != nil {
-			values.Add("data", fmt.Sprintf("%v", *p.Data))
-		}
-		if p.DataConfig != nil {
-			values.Add("dataConfig", fmt.Sprintf("%v", *p.DataConfig))
+		body := NewSetPolicyCaptureRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("policy", "SetPolicyCapture", err)
 		}
-		req.URL.RawQuery = values.Encode()
 		return nil
 	}
 }
 
-// DecodeListPoliciesResponse returns a decoder for responses returned by the
-// policy ListPolicies endpoint. restoreBody controls whether the response body
-// should be restored after having been read.
-func DecodeListPoliciesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// DecodeSetPolicyCaptureResponse returns a decoder for responses returned by
+// the policy SetPolicyCapture endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeSetPolicyCaptureResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -649,35 +2241,38 @@ func DecodeListPoliciesResponse(decoder func(*http.Response) goahttp.Decoder, re
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			var (
-				body ListPoliciesResponseBody
-				err  error
-			)
-			err = decoder(resp).Decode(&body)
-			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "ListPolicies", err)
-			}
-			err = ValidateListPoliciesResponseBody(&body)
-			if err != nil {
-				return nil, goahttp.ErrValidationError("policy", "ListPolicies", err)
-			}
-			res := NewListPoliciesPoliciesResultOK(&body)
-			return res, nil
+			return nil, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "ListPolicies", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "SetPolicyCapture", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildSetPolicyAutoImportRequest instantiates a HTTP request object with
-// method and path set to call the "policy" service "SetPolicyAutoImport"
+// BuildDeletePolicyCaptureRequest instantiates a HTTP request object with
+// method and path set to call the "policy" service "DeletePolicyCapture"
 // endpoint
-func (c *Client) BuildSetPolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: SetPolicyAutoImportPolicyPath()}
-	req, err := http.NewRequest("POST", u.String(), nil)
+func (c *Client) BuildDeletePolicyCaptureRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.DeletePolicyCaptureRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DeletePolicyCapture", "*policy.DeletePolicyCaptureRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyCapturePolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "SetPolicyAutoImport", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicyCapture", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -686,26 +2281,10 @@ func (c *Client) BuildSetPolicyAutoImportRequest(ctx context.Context, v any) (*h
 	return req, nil
 }
 
-// EncodeSetPolicyAutoImportRequest returns an encoder for requests sent to the
-// policy SetPolicyAutoImport server.
-func EncodeSetPolicyAutoImportRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
-	return func(req *http.Request, v any) error {
-		p, ok := v.(*policy.SetPolicyAutoImportRequest)
-		if !ok {
-			return goahttp.ErrInvalidType("policy", "SetPolicyAutoImport", "*policy.SetPolicyAutoImportRequest", v)
-		}
-		body := NewSetPolicyAutoImportRequestBody(p)
-		if err := encoder(req).Encode(&body); err != nil {
-			return goahttp.ErrEncodingError("policy", "SetPolicyAutoImport", err)
-		}
-		return nil
-	}
-}
-
-// DecodeSetPolicyAutoImportResponse returns a decoder for responses returned
-// by the policy SetPolicyAutoImport endpoint. restoreBody controls whether the
+// DecodeDeletePolicyCaptureResponse returns a decoder for responses returned
+// by the policy DeletePolicyCapture endpoint. restoreBody controls whether the
 // response body should be restored after having been read.
-func DecodeSetPolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+func DecodeDeletePolicyCaptureResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -721,29 +2300,38 @@ func DecodeSetPolicyAutoImportResponse(decoder func(*http.Response) goahttp.Deco
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			var (
-				body any
-				err  error
-			)
-			err = decoder(resp).Decode(&body)
-			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "SetPolicyAutoImport", err)
-			}
-			return body, nil
+			return nil, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "SetPolicyAutoImport", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicyCapture", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildPolicyAutoImportRequest instantiates a HTTP request object with method
-// and path set to call the "policy" service "PolicyAutoImport" endpoint
-func (c *Client) BuildPolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PolicyAutoImportPolicyPath()}
-	req, err := http.NewRequest("GET", u.String(), nil)
+// BuildDeleteCapturedEvaluationsRequest instantiates a HTTP request object
+// with method and path set to call the "policy" service
+// "DeleteCapturedEvaluations" endpoint
+func (c *Client) BuildDeleteCapturedEvaluationsRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.DeleteCapturedEvaluationsRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "DeleteCapturedEvaluations", "*policy.DeleteCapturedEvaluationsRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeleteCapturedEvaluationsPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "PolicyAutoImport", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "DeleteCapturedEvaluations", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -752,10 +2340,10 @@ func (c *Client) BuildPolicyAutoImportRequest(ctx context.Context, v any) (*http
 	return req, nil
 }
 
-// DecodePolicyAutoImportResponse returns a decoder for responses returned by
-// the policy PolicyAutoImport endpoint. restoreBody controls whether the
-// response body should be restored after having been read.
-func DecodePolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// DecodeDeleteCapturedEvaluationsResponse returns a decoder for responses
+// returned by the policy DeleteCapturedEvaluations endpoint. restoreBody
+// controls whether the response body should be restored after having been read.
+func DecodeDeleteCapturedEvaluationsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -771,30 +2359,37 @@ func DecodePolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder
 		}
 		switch resp.StatusCode {
 		case http.StatusOK:
-			var (
-				body any
-				err  error
-			)
-			err = decoder(resp).Decode(&body)
-			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "PolicyAutoImport", err)
-			}
-			return body, nil
+			return nil, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "PolicyAutoImport", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "DeleteCapturedEvaluations", resp.StatusCode, string(body))
 		}
 	}
 }
 
-// BuildDeletePolicyAutoImportRequest instantiates a HTTP request object with
-// method and path set to call the "policy" service "DeletePolicyAutoImport"
-// endpoint
-func (c *Client) BuildDeletePolicyAutoImportRequest(ctx context.Context, v any) (*http.Request, error) {
-	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeletePolicyAutoImportPolicyPath()}
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+// BuildReplayRequest instantiates a HTTP request object with method and path
+// set to call the "policy" service "Replay" endpoint
+func (c *Client) BuildReplayRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		repository string
+		group      string
+		policyName string
+		version    string
+	)
+	{
+		p, ok := v.(*policy.ReplayRequest)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("policy", "Replay", "*policy.ReplayRequest", v)
+		}
+		repository = p.Repository
+		group = p.Group
+		policyName = p.PolicyName
+		version = p.Version
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ReplayPolicyPath(repository, group, policyName, version)}
+	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
-		return nil, goahttp.ErrInvalidURL("policy", "DeletePolicyAutoImport", u.String(), err)
+		return nil, goahttp.ErrInvalidURL("policy", "Replay", u.String(), err)
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
@@ -803,26 +2398,26 @@ func (c *Client) BuildDeletePolicyAutoImportRequest(ctx context.Context, v any)
 	return req, nil
 }
 
-// EncodeDeletePolicyAutoImportRequest returns an encoder for requests sent to
-// the policy DeletePolicyAutoImport server.
-func EncodeDeletePolicyAutoImportRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+// EncodeReplayRequest returns an encoder for requests sent to the policy
+// Replay server.
+func EncodeReplayRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
 	return func(req *http.Request, v any) error {
-		p, ok := v.(*policy.DeletePolicyAutoImportRequest)
+		p, ok := v.(*policy.ReplayRequest)
 		if !ok {
-			return goahttp.ErrInvalidType("policy", "DeletePolicyAutoImport", "*policy.DeletePolicyAutoImportRequest", v)
+			return goahttp.ErrInvalidType("policy", "Replay", "*policy.ReplayRequest", v)
 		}
-		body := NewDeletePolicyAutoImportRequestBody(p)
+		body := NewReplayRequestBody(p)
 		if err := encoder(req).Encode(&body); err != nil {
-			return goahttp.ErrEncodingError("policy", "DeletePolicyAutoImport", err)
+			return goahttp.ErrEncodingError("policy", "Replay", err)
 		}
 		return nil
 	}
 }
 
-// DecodeDeletePolicyAutoImportResponse returns a decoder for responses
-// returned by the policy DeletePolicyAutoImport endpoint. restoreBody controls
-// whether the response body should be restored after having been read.
-func DecodeDeletePolicyAutoImportResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+// DecodeReplayResponse returns a decoder for responses returned by the policy
+// Replay endpoint. restoreBody controls whether the response body should be
+// restored after having been read.
+func DecodeReplayResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
 	return func(resp *http.Response) (any, error) {
 		if restoreBody {
 			b, err := io.ReadAll(resp.Body)
@@ -839,17 +2434,22 @@ func DecodeDeletePolicyAutoImportResponse(decoder func(*http.Response) goahttp.D
 		switch resp.StatusCode {
 		case http.StatusOK:
 			var (
-				body any
+				body ReplayResponseBody
 				err  error
 			)
 			err = decoder(resp).Decode(&body)
 			if err != nil {
-				return nil, goahttp.ErrDecodingError("policy", "DeletePolicyAutoImport", err)
+				return nil, goahttp.ErrDecodingError("policy", "Replay", err)
 			}
-			return body, nil
+			err = ValidateReplayResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("policy", "Replay", err)
+			}
+			res := NewReplayReportOK(&body)
+			return res, nil
 		default:
 			body, _ := io.ReadAll(resp.Body)
-			return nil, goahttp.ErrInvalidResponse("policy", "DeletePolicyAutoImport", resp.StatusCode, string(body))
+			return nil, goahttp.ErrInvalidResponse("policy", "Replay", resp.StatusCode, string(body))
 		}
 	}
 }
@@ -937,20 +2537,197 @@ func DecodeSubscribeForPolicyChangeResponse(decoder func(*http.Response) goahttp
 	}
 }
 
+// marshalPolicyBatchEvaluateItemToBatchEvaluateItemRequestBody builds a value
+// of type *BatchEvaluateItemRequestBody from a value of type
+// *policy.BatchEvaluateItem.
+func marshalPolicyBatchEvaluateItemToBatchEvaluateItemRequestBody(v *policy.BatchEvaluateItem) *BatchEvaluateItemRequestBody {
+	res := &BatchEvaluateItemRequestBody{
+		Repository:   v.Repository,
+		Group:        v.Group,
+		PolicyName:   v.PolicyName,
+		Version:      v.Version,
+		Input:        v.Input,
+		EvaluationID: v.EvaluationID,
+		TTL:          v.TTL,
+	}
+
+	return res
+}
+
+// marshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem builds a value
+// of type *policy.BatchEvaluateItem from a value of type
+// *BatchEvaluateItemRequestBody.
+func marshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem(v *BatchEvaluateItemRequestBody) *policy.BatchEvaluateItem {
+	res := &policy.BatchEvaluateItem{
+		Repository:   v.Repository,
+		Group:        v.Group,
+		PolicyName:   v.PolicyName,
+		Version:      v.Version,
+		Input:        v.Input,
+		EvaluationID: v.EvaluationID,
+		TTL:          v.TTL,
+	}
+
+	return res
+}
+
+// unmarshalBatchEvaluateItemResultResponseBodyToPolicyBatchEvaluateItemResult
+// builds a value of type *policy.BatchEvaluateItemResult from a value of type
+// *BatchEvaluateItemResultResponseBody.
+func unmarshalBatchEvaluateItemResultResponseBodyToPolicyBatchEvaluateItemResult(v *BatchEvaluateItemResultResponseBody) *policy.BatchEvaluateItemResult {
+	res := &policy.BatchEvaluateItemResult{
+		Repository: *v.Repository,
+		Group:      *v.Group,
+		PolicyName: *v.PolicyName,
+		Version:    *v.Version,
+		Result:     v.Result,
+		ETag:       v.ETag,
+		Error:      v.Error,
+	}
+
+	return res
+}
+
+// unmarshalPolicyLockEventResponseBodyToPolicyPolicyLockEvent builds a value
+// of type *policy.PolicyLockEvent from a value of type
+// *PolicyLockEventResponseBody.
+func unmarshalPolicyLockEventResponseBodyToPolicyPolicyLockEvent(v *PolicyLockEventResponseBody) *policy.PolicyLockEvent {
+	if v == nil {
+		return nil
+	}
+	res := &policy.PolicyLockEvent{
+		Locked:    *v.Locked,
+		Reason:    v.Reason,
+		Actor:     v.Actor,
+		Source:    *v.Source,
+		UnlockAt:  v.UnlockAt,
+		Timestamp: *v.Timestamp,
+	}
+
+	return res
+}
+
+// unmarshalPolicyTestResultResponseBodyToPolicyPolicyTestResult builds a value
+// of type *policy.PolicyTestResult from a value of type
+// *PolicyTestResultResponseBody.
+func unmarshalPolicyTestResultResponseBodyToPolicyPolicyTestResult(v *PolicyTestResultResponseBody) *policy.PolicyTestResult {
+	res := &policy.PolicyTestResult{
+		Name:     *v.Name,
+		Location: *v.Location,
+		Passed:   *v.Passed,
+		Error:    v.Error,
+		Duration: *v.Duration,
+	}
+
+	return res
+}
+
 // unmarshalPolicyResponseBodyToPolicyPolicy builds a value of type
 // *policy.Policy from a value of type *PolicyResponseBody.
 func unmarshalPolicyResponseBodyToPolicyPolicy(v *PolicyResponseBody) *policy.Policy {
 	res := &policy.Policy{
+		Repository:    *v.Repository,
+		PolicyName:    *v.PolicyName,
+		Group:         *v.Group,
+		Version:       *v.Version,
+		Rego:          v.Rego,
+		Data:          v.Data,
+		DataConfig:    v.DataConfig,
+		Locked:        *v.Locked,
+		LastUpdate:    *v.LastUpdate,
+		ShadowVersion: v.ShadowVersion,
+	}
+	if v.Modules != nil {
+		res.Modules = make(map[string]string, len(v.Modules))
+		for key, val := range v.Modules {
+			tk := key
+			tv := val
+			res.Modules[tk] = tv
+		}
+	}
+	if v.Lock != nil {
+		res.Lock = unmarshalPolicyLockEventResponseBodyToPolicyPolicyLockEvent(v.Lock)
+	}
+
+	return res
+}
+
+// unmarshalDecisionResponseBodyToPolicyDecision builds a value of type
+// *policy.Decision from a value of type *DecisionResponseBody.
+func unmarshalDecisionResponseBodyToPolicyDecision(v *DecisionResponseBody) *policy.Decision {
+	res := &policy.Decision{
+		EvaluationID:     *v.EvaluationID,
+		Repository:       *v.Repository,
+		Group:            *v.Group,
+		PolicyName:       *v.PolicyName,
+		Version:          *v.Version,
+		PolicyLastUpdate: *v.PolicyLastUpdate,
+		InputHash:        *v.InputHash,
+		Input:            v.Input,
+		Result:           v.Result,
+		Error:            v.Error,
+		Duration:         *v.Duration,
+		Caller:           v.Caller,
+		ClientIP:         v.ClientIP,
+		Timestamp:        *v.Timestamp,
+		Rule:             v.Rule,
+		RequestID:        v.RequestID,
+	}
+
+	return res
+}
+
+// unmarshalAuditRecordResponseBodyToPolicyAuditRecord builds a value of type
+// *policy.AuditRecord from a value of type *AuditRecordResponseBody.
+func unmarshalAuditRecordResponseBodyToPolicyAuditRecord(v *AuditRecordResponseBody) *policy.AuditRecord {
+	res := &policy.AuditRecord{
+		Operation:     *v.Operation,
+		Actor:         v.Actor,
+		ClientIP:      v.ClientIP,
+		Repository:    v.Repository,
+		Group:         v.Group,
+		PolicyName:    v.PolicyName,
+		Version:       v.Version,
+		Target:        v.Target,
+		BeforeHash:    v.BeforeHash,
+		AfterHash:     v.AfterHash,
+		Timestamp:     *v.Timestamp,
+		BeforeVersion: v.BeforeVersion,
+		AfterVersion:  v.AfterVersion,
+	}
+
+	return res
+}
+
+// unmarshalPolicyAliasResponseBodyToPolicyPolicyAlias builds a value of type
+// *policy.PolicyAlias from a value of type *PolicyAliasResponseBody.
+func unmarshalPolicyAliasResponseBodyToPolicyPolicyAlias(v *PolicyAliasResponseBody) *policy.PolicyAlias {
+	res := &policy.PolicyAlias{
 		Repository: *v.Repository,
-		PolicyName: *v.PolicyName,
 		Group:      *v.Group,
+		PolicyName: *v.PolicyName,
+		Alias:      *v.Alias,
 		Version:    *v.Version,
-		Rego:       v.Rego,
-		Data:       v.Data,
-		DataConfig: v.DataConfig,
-		Locked:     *v.Locked,
 		LastUpdate: *v.LastUpdate,
 	}
 
 	return res
 }
+
+// unmarshalReplayDiffResponseBodyToPolicyReplayDiff builds a value of type
+// *policy.ReplayDiff from a value of type *ReplayDiffResponseBody.
+func unmarshalReplayDiffResponseBodyToPolicyReplayDiff(v *ReplayDiffResponseBody) *policy.ReplayDiff {
+	res := &policy.ReplayDiff{
+		EvaluationID:    *v.EvaluationID,
+		Rule:            v.Rule,
+		Status:          *v.Status,
+		Result:          v.Result,
+		CandidateResult: v.CandidateResult,
+		Error:           v.Error,
+		CandidateError:  v.CandidateError,
+		DurationDelta:   *v.DurationDelta,
+		Timestamp:       *v.Timestamp,
+	}
+
+	return res
+}
@@ -26,6 +26,16 @@ func EvaluatePolicyPath3(repository string, group string, policyName string, ver
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/evaluation", repository, group, policyName, version)
 }
 
+// EvaluateRulePolicyPath returns the URL path to the policy service EvaluateRule HTTP endpoint.
+func EvaluateRulePolicyPath(repository string, group string, policyName string, version string, rule string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/evaluation/%v", repository, group, policyName, version, rule)
+}
+
+// EvaluateRulePolicyPath2 returns the URL path to the policy service EvaluateRule HTTP endpoint.
+func EvaluateRulePolicyPath2(repository string, group string, policyName string, version string, rule string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/evaluation/%v", repository, group, policyName, version, rule)
+}
+
 // ValidatePolicyPath returns the URL path to the policy service Validate HTTP endpoint.
 func ValidatePolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/validation/did.json", repository, group, policyName, version)
@@ -41,6 +51,31 @@ func ValidatePolicyPath3(repository string, group string, policyName string, ver
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/validation", repository, group, policyName, version)
 }
 
+// PartialEvaluatePolicyPath returns the URL path to the policy service PartialEvaluate HTTP endpoint.
+func PartialEvaluatePolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/partial", repository, group, policyName, version)
+}
+
+// EvaluateBatchPolicyPath returns the URL path to the policy service EvaluateBatch HTTP endpoint.
+func EvaluateBatchPolicyPath() string {
+	return "/v1/evaluations/batch"
+}
+
+// EvaluateBatchStreamPolicyPath returns the URL path to the policy service EvaluateBatchStream HTTP endpoint.
+func EvaluateBatchStreamPolicyPath() string {
+	return "/v1/evaluations/batch/stream"
+}
+
+// EvaluationResultPolicyPath returns the URL path to the policy service EvaluationResult HTTP endpoint.
+func EvaluationResultPolicyPath(evaluationID string) string {
+	return fmt.Sprintf("/v1/evaluations/%v", evaluationID)
+}
+
+// JobStatusPolicyPath returns the URL path to the policy service JobStatus HTTP endpoint.
+func JobStatusPolicyPath(jobID string) string {
+	return fmt.Sprintf("/v1/jobs/%v", jobID)
+}
+
 // LockPolicyPath returns the URL path to the policy service Lock HTTP endpoint.
 func LockPolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/lock", repository, group, policyName, version)
@@ -51,6 +86,16 @@ func UnlockPolicyPath(repository string, group string, policyName string, versio
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/lock", repository, group, policyName, version)
 }
 
+// LockHistoryPolicyPath returns the URL path to the policy service LockHistory HTTP endpoint.
+func LockHistoryPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/lock", repository, group, policyName, version)
+}
+
+// PolicyTestsPolicyPath returns the URL path to the policy service PolicyTests HTTP endpoint.
+func PolicyTestsPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/policy/%v/%v/%v/%v/tests", repository, group, policyName, version)
+}
+
 // ExportBundlePolicyPath returns the URL path to the policy service ExportBundle HTTP endpoint.
 func ExportBundlePolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/export", repository, group, policyName, version)
@@ -71,6 +116,16 @@ func ListPoliciesPolicyPath() string {
 	return "/v1/policies"
 }
 
+// DecisionLogsPolicyPath returns the URL path to the policy service DecisionLogs HTTP endpoint.
+func DecisionLogsPolicyPath() string {
+	return "/v1/decisions"
+}
+
+// AuditLogPolicyPath returns the URL path to the policy service AuditLog HTTP endpoint.
+func AuditLogPolicyPath() string {
+	return "/v1/audit"
+}
+
 // SetPolicyAutoImportPolicyPath returns the URL path to the policy service SetPolicyAutoImport HTTP endpoint.
 func SetPolicyAutoImportPolicyPath() string {
 	return "/v1/policy/import/config"
@@ -86,6 +141,51 @@ func DeletePolicyAutoImportPolicyPath() string {
 	return "/v1/policy/import/config"
 }
 
+// SetPolicyAliasPolicyPath returns the URL path to the policy service SetPolicyAlias HTTP endpoint.
+func SetPolicyAliasPolicyPath(repository string, group string, policyName string, alias string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/aliases/%v", repository, group, policyName, alias)
+}
+
+// PolicyAliasesPolicyPath returns the URL path to the policy service PolicyAliases HTTP endpoint.
+func PolicyAliasesPolicyPath(repository string, group string, policyName string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/aliases", repository, group, policyName)
+}
+
+// DeletePolicyAliasPolicyPath returns the URL path to the policy service DeletePolicyAlias HTTP endpoint.
+func DeletePolicyAliasPolicyPath(repository string, group string, policyName string, alias string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/aliases/%v", repository, group, policyName, alias)
+}
+
+// SetPolicyShadowPolicyPath returns the URL path to the policy service SetPolicyShadow HTTP endpoint.
+func SetPolicyShadowPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/shadow", repository, group, policyName, version)
+}
+
+// DeletePolicyShadowPolicyPath returns the URL path to the policy service DeletePolicyShadow HTTP endpoint.
+func DeletePolicyShadowPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/shadow", repository, group, policyName, version)
+}
+
+// SetPolicyCapturePolicyPath returns the URL path to the policy service SetPolicyCapture HTTP endpoint.
+func SetPolicyCapturePolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/capture", repository, group, policyName, version)
+}
+
+// DeletePolicyCapturePolicyPath returns the URL path to the policy service DeletePolicyCapture HTTP endpoint.
+func DeletePolicyCapturePolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/capture", repository, group, policyName, version)
+}
+
+// DeleteCapturedEvaluationsPolicyPath returns the URL path to the policy service DeleteCapturedEvaluations HTTP endpoint.
+func DeleteCapturedEvaluationsPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/captures", repository, group, policyName, version)
+}
+
+// ReplayPolicyPath returns the URL path to the policy service Replay HTTP endpoint.
+func ReplayPolicyPath(repository string, group string, policyName string, version string) string {
+	return fmt.Sprintf("/v1/policy/%v/%v/%v/%v/replay", repository, group, policyName, version)
+}
+
 // SubscribeForPolicyChangePolicyPath returns the URL path to the policy service SubscribeForPolicyChange HTTP endpoint.
 func SubscribeForPolicyChangePolicyPath(repository string, group string, policyName string, version string) string {
 	return fmt.Sprintf("/policy/%v/%v/%v/%v/notifychange", repository, group, policyName, version)
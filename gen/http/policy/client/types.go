@@ -12,6 +12,43 @@ import (
 	goa "goa.design/goa/v3/pkg"
 )
 
+// PartialEvaluateRequestBody is the type of the "policy" service
+// "PartialEvaluate" endpoint HTTP request body.
+type PartialEvaluateRequestBody struct {
+	// Name of the boolean policy rule which is evaluated.
+	Rule string `form:"rule" json:"rule" xml:"rule"`
+	// References which are treated as unknown during evaluation.
+	Unknowns []string `form:"unknowns" json:"unknowns" xml:"unknowns"`
+	// Known input data passed to the policy execution runtime.
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Target representation of the residual queries. If 'mongo' is given, the
+	// queries are also translated to a MongoDB filter document.
+	Target string `form:"target" json:"target" xml:"target"`
+}
+
+// EvaluateBatchRequestBody is the type of the "policy" service "EvaluateBatch"
+// endpoint HTTP request body.
+type EvaluateBatchRequestBody struct {
+	// Policy evaluations to execute.
+	Items []*BatchEvaluateItemRequestBody `form:"items" json:"items" xml:"items"`
+}
+
+// LockRequestBody is the type of the "policy" service "Lock" endpoint HTTP
+// request body.
+type LockRequestBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64 `form:"unlockAt,omitempty" json:"unlockAt,omitempty" xml:"unlockAt,omitempty"`
+}
+
+// UnlockRequestBody is the type of the "policy" service "Unlock" endpoint HTTP
+// request body.
+type UnlockRequestBody struct {
+	// Reason for unlocking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+}
+
 // SetPolicyAutoImportRequestBody is the type of the "policy" service
 // "SetPolicyAutoImport" endpoint HTTP request body.
 type SetPolicyAutoImportRequestBody struct {
@@ -28,6 +65,45 @@ type DeletePolicyAutoImportRequestBody struct {
 	PolicyURL string `form:"policyURL" json:"policyURL" xml:"policyURL"`
 }
 
+// SetPolicyAliasRequestBody is the type of the "policy" service
+// "SetPolicyAlias" endpoint HTTP request body.
+type SetPolicyAliasRequestBody struct {
+	// Policy version referenced by the alias.
+	Version string `form:"version" json:"version" xml:"version"`
+}
+
+// SetPolicyShadowRequestBody is the type of the "policy" service
+// "SetPolicyShadow" endpoint HTTP request body.
+type SetPolicyShadowRequestBody struct {
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion string `form:"shadowVersion" json:"shadowVersion" xml:"shadowVersion"`
+}
+
+// SetPolicyCaptureRequestBody is the type of the "policy" service
+// "SetPolicyCapture" endpoint HTTP request body.
+type SetPolicyCaptureRequestBody struct {
+	// Fraction of evaluations which are recorded.
+	SampleRate float64 `form:"sampleRate" json:"sampleRate" xml:"sampleRate"`
+	// Maximum number of recorded evaluations of the policy version.
+	MaxRecords int `form:"maxRecords" json:"maxRecords" xml:"maxRecords"`
+	// Dot separated paths of input fields whose values are not recorded.
+	RedactFields []string `form:"redactFields,omitempty" json:"redactFields,omitempty" xml:"redactFields,omitempty"`
+	// Names of request headers whose values are not recorded.
+	RedactHeaders []string `form:"redactHeaders,omitempty" json:"redactHeaders,omitempty" xml:"redactHeaders,omitempty"`
+}
+
+// ReplayRequestBody is the type of the "policy" service "Replay" endpoint HTTP
+// request body.
+type ReplayRequestBody struct {
+	// Policy version which evaluates the recorded inputs.
+	CandidateVersion *string `form:"candidateVersion,omitempty" json:"candidateVersion,omitempty" xml:"candidateVersion,omitempty"`
+	// Source code of the main policy module, which replaces the module of the
+	// recorded version.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Maximum number of replayed evaluations, newest first.
+	Limit int `form:"limit" json:"limit" xml:"limit"`
+}
+
 // SubscribeForPolicyChangeRequestBody is the type of the "policy" service
 // "SubscribeForPolicyChange" endpoint HTTP request body.
 type SubscribeForPolicyChangeRequestBody struct {
@@ -37,6 +113,108 @@ type SubscribeForPolicyChangeRequestBody struct {
 	Subscriber string `form:"subscriber" json:"subscriber" xml:"subscriber"`
 }
 
+// EvaluateAcceptedResponseBody is the type of the "policy" service "Evaluate"
+// endpoint HTTP response body.
+type EvaluateAcceptedResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID *string `form:"jobID,omitempty" json:"jobID,omitempty" xml:"jobID,omitempty"`
+	// Status of the asynchronous evaluation job.
+	JobStatus *string `form:"jobStatus,omitempty" json:"jobStatus,omitempty" xml:"jobStatus,omitempty"`
+}
+
+// EvaluateRuleAcceptedResponseBody is the type of the "policy" service
+// "EvaluateRule" endpoint HTTP response body.
+type EvaluateRuleAcceptedResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID *string `form:"jobID,omitempty" json:"jobID,omitempty" xml:"jobID,omitempty"`
+	// Status of the asynchronous evaluation job.
+	JobStatus *string `form:"jobStatus,omitempty" json:"jobStatus,omitempty" xml:"jobStatus,omitempty"`
+}
+
+// PartialEvaluateResponseBody is the type of the "policy" service
+// "PartialEvaluate" endpoint HTTP response body.
+type PartialEvaluateResponseBody struct {
+	// Residual queries. The rule is true if any of the queries is true. No queries
+	// mean that the rule is never true.
+	Queries []string `form:"queries,omitempty" json:"queries,omitempty" xml:"queries,omitempty"`
+	// Support modules generated during partial evaluation.
+	Support []string `form:"support,omitempty" json:"support,omitempty" xml:"support,omitempty"`
+	// MongoDB filter document equivalent to the residual queries.
+	Filter any `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Policy version which was evaluated, after resolving aliases, 'latest',
+	// 'stable' and semver ranges.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// EvaluateBatchResponseBody is the type of the "policy" service
+// "EvaluateBatch" endpoint HTTP response body.
+type EvaluateBatchResponseBody struct {
+	// Evaluation results in the same order as the request items.
+	Results []*BatchEvaluateItemResultResponseBody `form:"results,omitempty" json:"results,omitempty" xml:"results,omitempty"`
+}
+
+// JobStatusResponseBody is the type of the "policy" service "JobStatus"
+// endpoint HTTP response body.
+type JobStatusResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID *string `form:"jobID,omitempty" json:"jobID,omitempty" xml:"jobID,omitempty"`
+	// Identifier of the policy evaluation, which can be used to retrieve the
+	// result.
+	ETag *string `form:"ETag,omitempty" json:"ETag,omitempty" xml:"ETag,omitempty"`
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Path of the evaluated rule.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Job status.
+	Status *string `form:"status,omitempty" json:"status,omitempty" xml:"status,omitempty"`
+	// Error message if the evaluation failed.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// URL receiving the evaluation result.
+	CallbackURL *string `form:"callbackURL,omitempty" json:"callbackURL,omitempty" xml:"callbackURL,omitempty"`
+	// Error message if the result couldn't be delivered to the callback URL.
+	CallbackError *string `form:"callbackError,omitempty" json:"callbackError,omitempty" xml:"callbackError,omitempty"`
+	// Creation time of the job as Unix timestamp.
+	CreatedAt *int64 `form:"createdAt,omitempty" json:"createdAt,omitempty" xml:"createdAt,omitempty"`
+	// Last update of the job as Unix timestamp.
+	UpdatedAt *int64 `form:"updatedAt,omitempty" json:"updatedAt,omitempty" xml:"updatedAt,omitempty"`
+}
+
+// LockHistoryResponseBody is the type of the "policy" service "LockHistory"
+// endpoint HTTP response body.
+type LockHistoryResponseBody struct {
+	// Locked specifies if the policy is locked.
+	Locked *bool `form:"locked,omitempty" json:"locked,omitempty" xml:"locked,omitempty"`
+	// Current lock of a locked policy.
+	Lock *PolicyLockEventResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+	// Previous lock and unlock events, oldest first.
+	History []*PolicyLockEventResponseBody `form:"history,omitempty" json:"history,omitempty" xml:"history,omitempty"`
+}
+
+// PolicyTestsResponseBody is the type of the "policy" service "PolicyTests"
+// endpoint HTTP response body.
+type PolicyTestsResponseBody struct {
+	// All tests of the policy passed.
+	Passed *bool `form:"passed,omitempty" json:"passed,omitempty" xml:"passed,omitempty"`
+	// Error which prevented the tests from running.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Number of executed tests.
+	Total *int `form:"total,omitempty" json:"total,omitempty" xml:"total,omitempty"`
+	// Number of failed tests.
+	Failed *int `form:"failed,omitempty" json:"failed,omitempty" xml:"failed,omitempty"`
+	// Percentage of the policy module lines evaluated by the tests.
+	Coverage *float64 `form:"coverage,omitempty" json:"coverage,omitempty" xml:"coverage,omitempty"`
+	// Results of the test rules and golden test cases.
+	Results []*PolicyTestResultResponseBody `form:"results,omitempty" json:"results,omitempty" xml:"results,omitempty"`
+	// Time of the test run (Unix timestamp).
+	RunAt *int64 `form:"runAt,omitempty" json:"runAt,omitempty" xml:"runAt,omitempty"`
+}
+
 // ListPoliciesResponseBody is the type of the "policy" service "ListPolicies"
 // endpoint HTTP response body.
 type ListPoliciesResponseBody struct {
@@ -44,6 +222,138 @@ type ListPoliciesResponseBody struct {
 	Policies []*PolicyResponseBody `form:"policies,omitempty" json:"policies,omitempty" xml:"policies,omitempty"`
 }
 
+// DecisionLogsResponseBody is the type of the "policy" service "DecisionLogs"
+// endpoint HTTP response body.
+type DecisionLogsResponseBody struct {
+	// JSON array of decisions.
+	Decisions []*DecisionResponseBody `form:"decisions,omitempty" json:"decisions,omitempty" xml:"decisions,omitempty"`
+}
+
+// AuditLogResponseBody is the type of the "policy" service "AuditLog" endpoint
+// HTTP response body.
+type AuditLogResponseBody struct {
+	// JSON array of audit records.
+	Records []*AuditRecordResponseBody `form:"records,omitempty" json:"records,omitempty" xml:"records,omitempty"`
+}
+
+// SetPolicyAliasResponseBody is the type of the "policy" service
+// "SetPolicyAlias" endpoint HTTP response body.
+type SetPolicyAliasResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Alias name.
+	Alias *string `form:"alias,omitempty" json:"alias,omitempty" xml:"alias,omitempty"`
+	// Policy version referenced by the alias.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Last update (Unix timestamp).
+	LastUpdate *int64 `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+}
+
+// PolicyAliasesResponseBody is the type of the "policy" service
+// "PolicyAliases" endpoint HTTP response body.
+type PolicyAliasesResponseBody struct {
+	// Named aliases of the policy.
+	Aliases []*PolicyAliasResponseBody `form:"aliases,omitempty" json:"aliases,omitempty" xml:"aliases,omitempty"`
+}
+
+// ReplayResponseBody is the type of the "policy" service "Replay" endpoint
+// HTTP response body.
+type ReplayResponseBody struct {
+	// Replayed policy version or 'rego' for an uploaded policy source.
+	Candidate *string `form:"candidate,omitempty" json:"candidate,omitempty" xml:"candidate,omitempty"`
+	// Number of replayed evaluations.
+	Total *int `form:"total,omitempty" json:"total,omitempty" xml:"total,omitempty"`
+	// Number of evaluations with the same result or error.
+	Unchanged *int `form:"unchanged,omitempty" json:"unchanged,omitempty" xml:"unchanged,omitempty"`
+	// Number of evaluations with a different result.
+	Changed *int `form:"changed,omitempty" json:"changed,omitempty" xml:"changed,omitempty"`
+	// Number of evaluations which fail only with the candidate.
+	NewErrors *int `form:"newErrors,omitempty" json:"newErrors,omitempty" xml:"newErrors,omitempty"`
+	// Number of evaluations which fail only with the recorded version.
+	FixedErrors *int `form:"fixedErrors,omitempty" json:"fixedErrors,omitempty" xml:"fixedErrors,omitempty"`
+	// Average difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	DurationDelta *float64 `form:"durationDelta,omitempty" json:"durationDelta,omitempty" xml:"durationDelta,omitempty"`
+	// Maximum difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	MaxDurationDelta *float64 `form:"maxDurationDelta,omitempty" json:"maxDurationDelta,omitempty" xml:"maxDurationDelta,omitempty"`
+	// Evaluations whose result or error differs.
+	Diffs []*ReplayDiffResponseBody `form:"diffs,omitempty" json:"diffs,omitempty" xml:"diffs,omitempty"`
+}
+
+// BatchEvaluateItemRequestBody is used to define fields on request body types.
+type BatchEvaluateItemRequestBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Input data passed to the policy execution runtime.
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Identifier created by external system and passed as parameter to overwrite
+	// the randomly generated evaluationID.
+	EvaluationID *string `form:"evaluationID,omitempty" json:"evaluationID,omitempty" xml:"evaluationID,omitempty"`
+	// TTL for storing policy result in cache
+	TTL *int `form:"ttl,omitempty" json:"ttl,omitempty" xml:"ttl,omitempty"`
+}
+
+// BatchEvaluateItemResultResponseBody is used to define fields on response
+// body types.
+type BatchEvaluateItemResultResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Arbitrary JSON response.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// ETag contains unique identifier of the policy evaluation and can be used to
+	// later retrieve the results from Cache.
+	ETag *string `form:"ETag,omitempty" json:"ETag,omitempty" xml:"ETag,omitempty"`
+	// Error message if the policy evaluation failed.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// PolicyLockEventResponseBody is used to define fields on response body types.
+type PolicyLockEventResponseBody struct {
+	// True for a lock and false for an unlock.
+	Locked *bool `form:"locked,omitempty" json:"locked,omitempty" xml:"locked,omitempty"`
+	// Reason for (un)locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Subject of the caller who (un)locked the policy.
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Source of the event.
+	Source *string `form:"source,omitempty" json:"source,omitempty" xml:"source,omitempty"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64 `form:"unlockAt,omitempty" json:"unlockAt,omitempty" xml:"unlockAt,omitempty"`
+	// Time of the event (Unix timestamp).
+	Timestamp *int64 `form:"timestamp,omitempty" json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+}
+
+// PolicyTestResultResponseBody is used to define fields on response body types.
+type PolicyTestResultResponseBody struct {
+	// Name of the test rule or golden test case.
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Location of the test.
+	Location *string `form:"location,omitempty" json:"location,omitempty" xml:"location,omitempty"`
+	// The test passed.
+	Passed *bool `form:"passed,omitempty" json:"passed,omitempty" xml:"passed,omitempty"`
+	// Error or failure message of the test.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Test duration in milliseconds.
+	Duration *int64 `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+}
+
 // PolicyResponseBody is used to define fields on response body types.
 type PolicyResponseBody struct {
 	// Policy repository.
@@ -54,7 +364,7 @@ type PolicyResponseBody struct {
 	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
 	// Policy version.
 	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
-	// Policy rego source code.
+	// Policy rego source code of the main 'policy.rego' module.
 	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
 	// Policy static data.
 	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
@@ -64,6 +374,185 @@ type PolicyResponseBody struct {
 	Locked *bool `form:"locked,omitempty" json:"locked,omitempty" xml:"locked,omitempty"`
 	// Last update (Unix timestamp).
 	LastUpdate *int64 `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+	// Policy rego modules by filename.
+	Modules map[string]string `form:"modules,omitempty" json:"modules,omitempty" xml:"modules,omitempty"`
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion *string `form:"shadowVersion,omitempty" json:"shadowVersion,omitempty" xml:"shadowVersion,omitempty"`
+	// Current lock of a locked policy.
+	Lock *PolicyLockEventResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// DecisionResponseBody is used to define fields on response body types.
+type DecisionResponseBody struct {
+	// Evaluation ID.
+	EvaluationID *string `form:"evaluationID,omitempty" json:"evaluationID,omitempty" xml:"evaluationID,omitempty"`
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Last update of the evaluated policy (Unix timestamp).
+	PolicyLastUpdate *int64 `form:"policyLastUpdate,omitempty" json:"policyLastUpdate,omitempty" xml:"policyLastUpdate,omitempty"`
+	// SHA256 hash of the evaluation input.
+	InputHash *string `form:"inputHash,omitempty" json:"inputHash,omitempty" xml:"inputHash,omitempty"`
+	// Evaluation input with redacted fields (if configured).
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Evaluation result.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// Evaluation error.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Evaluation duration in milliseconds.
+	Duration *int64 `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	// Identity of the caller.
+	Caller *string `form:"caller,omitempty" json:"caller,omitempty" xml:"caller,omitempty"`
+	// Address of the caller.
+	ClientIP *string `form:"clientIP,omitempty" json:"clientIP,omitempty" xml:"clientIP,omitempty"`
+	// Time of the evaluation (Unix timestamp).
+	Timestamp *int64 `form:"timestamp,omitempty" json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	// Evaluated rule path inside the policy package.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Request ID of the evaluated request, taken from the X-Request-Id header,
+	// e.g. set by Envoy.
+	RequestID *string `form:"requestID,omitempty" json:"requestID,omitempty" xml:"requestID,omitempty"`
+}
+
+// AuditRecordResponseBody is used to define fields on response body types.
+type AuditRecordResponseBody struct {
+	// Executed operation.
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty" xml:"operation,omitempty"`
+	// Subject of the caller or 'system' for operations of the service.
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Client IP address of the caller.
+	ClientIP *string `form:"clientIP,omitempty" json:"clientIP,omitempty" xml:"clientIP,omitempty"`
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Changed resource if it isn't a policy, e.g. a storage key or an import URL.
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+	// SHA256 hash of the resource before the operation.
+	BeforeHash *string `form:"beforeHash,omitempty" json:"beforeHash,omitempty" xml:"beforeHash,omitempty"`
+	// SHA256 hash of the resource after the operation.
+	AfterHash *string `form:"afterHash,omitempty" json:"afterHash,omitempty" xml:"afterHash,omitempty"`
+	// Time of the operation (Unix timestamp).
+	Timestamp *int64 `form:"timestamp,omitempty" json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	// Policy version referenced by the resource before the operation, e.g. the
+	// version of an alias.
+	BeforeVersion *string `form:"beforeVersion,omitempty" json:"beforeVersion,omitempty" xml:"beforeVersion,omitempty"`
+	// Policy version referenced by the resource after the operation, e.g. the
+	// version of an alias.
+	AfterVersion *string `form:"afterVersion,omitempty" json:"afterVersion,omitempty" xml:"afterVersion,omitempty"`
+}
+
+// PolicyAliasResponseBody is used to define fields on response body types.
+type PolicyAliasResponseBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Alias name.
+	Alias *string `form:"alias,omitempty" json:"alias,omitempty" xml:"alias,omitempty"`
+	// Policy version referenced by the alias.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Last update (Unix timestamp).
+	LastUpdate *int64 `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+}
+
+// ReplayDiffResponseBody is used to define fields on response body types.
+type ReplayDiffResponseBody struct {
+	// Evaluation ID of the recorded evaluation.
+	EvaluationID *string `form:"evaluationID,omitempty" json:"evaluationID,omitempty" xml:"evaluationID,omitempty"`
+	// Evaluated rule path inside the policy package.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Kind of difference.
+	Status *string `form:"status,omitempty" json:"status,omitempty" xml:"status,omitempty"`
+	// Recorded result.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// Result of the candidate.
+	CandidateResult any `form:"candidateResult,omitempty" json:"candidateResult,omitempty" xml:"candidateResult,omitempty"`
+	// Recorded error.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Error of the candidate.
+	CandidateError *string `form:"candidateError,omitempty" json:"candidateError,omitempty" xml:"candidateError,omitempty"`
+	// Difference between the candidate and the recorded evaluation duration in
+	// milliseconds, excluding extension function calls.
+	DurationDelta *float64 `form:"durationDelta,omitempty" json:"durationDelta,omitempty" xml:"durationDelta,omitempty"`
+	// Time of the recorded evaluation (Unix timestamp).
+	Timestamp *int64 `form:"timestamp,omitempty" json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+}
+
+// NewPartialEvaluateRequestBody builds the HTTP request body from the payload
+// of the "PartialEvaluate" endpoint of the "policy" service.
+func NewPartialEvaluateRequestBody(p *policy.PartialEvaluateRequest) *PartialEvaluateRequestBody {
+	body := &PartialEvaluateRequestBody{
+		Rule:   p.Rule,
+		Input:  p.Input,
+		Target: p.Target,
+	}
+	{
+		var zero string
+		if body.Rule == zero {
+			body.Rule = "allow"
+		}
+	}
+	if p.Unknowns != nil {
+		body.Unknowns = make([]string, len(p.Unknowns))
+		for i, val := range p.Unknowns {
+			body.Unknowns[i] = val
+		}
+	} else {
+		body.Unknowns = []string{}
+	}
+	{
+		var zero string
+		if body.Target == zero {
+			body.Target = "rego"
+		}
+	}
+	return body
+}
+
+// NewEvaluateBatchRequestBody builds the HTTP request body from the payload of
+// the "EvaluateBatch" endpoint of the "policy" service.
+func NewEvaluateBatchRequestBody(p *policy.BatchEvaluateRequest) *EvaluateBatchRequestBody {
+	body := &EvaluateBatchRequestBody{}
+	if p.Items != nil {
+		body.Items = make([]*BatchEvaluateItemRequestBody, len(p.Items))
+		for i, val := range p.Items {
+			body.Items[i] = marshalPolicyBatchEvaluateItemToBatchEvaluateItemRequestBody(val)
+		}
+	} else {
+		body.Items = []*BatchEvaluateItemRequestBody{}
+	}
+	return body
+}
+
+// NewLockRequestBody builds the HTTP request body from the payload of the
+// "Lock" endpoint of the "policy" service.
+func NewLockRequestBody(p *policy.LockRequest) *LockRequestBody {
+	body := &LockRequestBody{
+		Reason:   p.Reason,
+		UnlockAt: p.UnlockAt,
+	}
+	return body
+}
+
+// NewUnlockRequestBody builds the HTTP request body from the payload of the
+// "Unlock" endpoint of the "policy" service.
+func NewUnlockRequestBody(p *policy.UnlockRequest) *UnlockRequestBody {
+	body := &UnlockRequestBody{
+		Reason: p.Reason,
+	}
+	return body
 }
 
 // NewSetPolicyAutoImportRequestBody builds the HTTP request body from the
@@ -85,6 +574,75 @@ func NewDeletePolicyAutoImportRequestBody(p *policy.DeletePolicyAutoImportReques
 	return body
 }
 
+// NewSetPolicyAliasRequestBody builds the HTTP request body from the payload
+// of the "SetPolicyAlias" endpoint of the "policy" service.
+func NewSetPolicyAliasRequestBody(p *policy.SetPolicyAliasRequest) *SetPolicyAliasRequestBody {
+	body := &SetPolicyAliasRequestBody{
+		Version: p.Version,
+	}
+	return body
+}
+
+// NewSetPolicyShadowRequestBody builds the HTTP request body from the payload
+// of the "SetPolicyShadow" endpoint of the "policy" service.
+func NewSetPolicyShadowRequestBody(p *policy.SetPolicyShadowRequest) *SetPolicyShadowRequestBody {
+	body := &SetPolicyShadowRequestBody{
+		ShadowVersion: p.ShadowVersion,
+	}
+	return body
+}
+
+// NewSetPolicyCaptureRequestBody builds the HTTP request body from the payload
+// of the "SetPolicyCapture" endpoint of the "policy" service.
+func NewSetPolicyCaptureRequestBody(p *policy.SetPolicyCaptureRequest) *SetPolicyCaptureRequestBody {
+	body := &SetPolicyCaptureRequestBody{
+		SampleRate: p.SampleRate,
+		MaxRecords: p.MaxRecords,
+	}
+	{
+		var zero float64
+		if body.SampleRate == zero {
+			body.SampleRate = 1
+		}
+	}
+	{
+		var zero int
+		if body.MaxRecords == zero {
+			body.MaxRecords = 1000
+		}
+	}
+	if p.RedactFields != nil {
+		body.RedactFields = make([]string, len(p.RedactFields))
+		for i, val := range p.RedactFields {
+			body.RedactFields[i] = val
+		}
+	}
+	if p.RedactHeaders != nil {
+		body.RedactHeaders = make([]string, len(p.RedactHeaders))
+		for i, val := range p.RedactHeaders {
+			body.RedactHeaders[i] = val
+		}
+	}
+	return body
+}
+
+// NewReplayRequestBody builds the HTTP request body from the payload of the
+// "Replay" endpoint of the "policy" service.
+func NewReplayRequestBody(p *policy.ReplayRequest) *ReplayRequestBody {
+	body := &ReplayRequestBody{
+		CandidateVersion: p.CandidateVersion,
+		Rego:             p.Rego,
+		Limit:            p.Limit,
+	}
+	{
+		var zero int
+		if body.Limit == zero {
+			body.Limit = 100
+		}
+	}
+	return body
+}
+
 // NewSubscribeForPolicyChangeRequestBody builds the HTTP request body from the
 // payload of the "SubscribeForPolicyChange" endpoint of the "policy" service.
 func NewSubscribeForPolicyChangeRequestBody(p *policy.SubscribeRequest) *SubscribeForPolicyChangeRequestBody {
@@ -95,30 +653,183 @@ func NewSubscribeForPolicyChangeRequestBody(p *policy.SubscribeRequest) *Subscri
 	return body
 }
 
+// NewEvaluateResultAccepted builds a "policy" service "Evaluate" endpoint
+// result from a HTTP "Accepted" response.
+func NewEvaluateResultAccepted(body *EvaluateAcceptedResponseBody, eTag string, version string) *policy.EvaluateResult {
+	v := &policy.EvaluateResult{
+		JobID:     body.JobID,
+		JobStatus: body.JobStatus,
+	}
+	v.ETag = eTag
+	v.Version = version
+
+	return v
+}
+
 // NewEvaluateResultOK builds a "policy" service "Evaluate" endpoint result
 // from a HTTP "OK" response.
-func NewEvaluateResultOK(body any, eTag string) *policy.EvaluateResult {
+func NewEvaluateResultOK(body any, eTag string, version string) *policy.EvaluateResult {
+	v := body
+	res := &policy.EvaluateResult{
+		Result: v,
+	}
+	res.ETag = eTag
+	res.Version = version
+
+	return res
+}
+
+// NewEvaluateRuleEvaluateResultAccepted builds a "policy" service
+// "EvaluateRule" endpoint result from a HTTP "Accepted" response.
+func NewEvaluateRuleEvaluateResultAccepted(body *EvaluateRuleAcceptedResponseBody, eTag string, version string) *policy.EvaluateResult {
+	v := &policy.EvaluateResult{
+		JobID:     body.JobID,
+		JobStatus: body.JobStatus,
+	}
+	v.ETag = eTag
+	v.Version = version
+
+	return v
+}
+
+// NewEvaluateRuleEvaluateResultOK builds a "policy" service "EvaluateRule"
+// endpoint result from a HTTP "OK" response.
+func NewEvaluateRuleEvaluateResultOK(body any, eTag string, version string) *policy.EvaluateResult {
 	v := body
 	res := &policy.EvaluateResult{
 		Result: v,
 	}
 	res.ETag = eTag
+	res.Version = version
 
 	return res
 }
 
 // NewValidateEvaluateResultOK builds a "policy" service "Validate" endpoint
 // result from a HTTP "OK" response.
-func NewValidateEvaluateResultOK(body any, eTag string) *policy.EvaluateResult {
+func NewValidateEvaluateResultOK(body any, eTag string, version string) *policy.EvaluateResult {
 	v := body
 	res := &policy.EvaluateResult{
 		Result: v,
 	}
 	res.ETag = eTag
+	res.Version = version
+
+	return res
+}
+
+// NewPartialEvaluateResultOK builds a "policy" service "PartialEvaluate"
+// endpoint result from a HTTP "OK" response.
+func NewPartialEvaluateResultOK(body *PartialEvaluateResponseBody) *policy.PartialEvaluateResult {
+	v := &policy.PartialEvaluateResult{
+		Filter:  body.Filter,
+		Version: *body.Version,
+	}
+	v.Queries = make([]string, len(body.Queries))
+	for i, val := range body.Queries {
+		v.Queries[i] = val
+	}
+	if body.Support != nil {
+		v.Support = make([]string, len(body.Support))
+		for i, val := range body.Support {
+			v.Support[i] = val
+		}
+	}
+
+	return v
+}
+
+// NewEvaluateBatchBatchEvaluateResultOK builds a "policy" service
+// "EvaluateBatch" endpoint result from a HTTP "OK" response.
+func NewEvaluateBatchBatchEvaluateResultOK(body *EvaluateBatchResponseBody) *policy.BatchEvaluateResult {
+	v := &policy.BatchEvaluateResult{}
+	v.Results = make([]*policy.BatchEvaluateItemResult, len(body.Results))
+	for i, val := range body.Results {
+		v.Results[i] = unmarshalBatchEvaluateItemResultResponseBodyToPolicyBatchEvaluateItemResult(val)
+	}
+
+	return v
+}
+
+// NewEvaluateBatchStreamBatchEvaluateStreamResultOK builds a "policy" service
+// "EvaluateBatchStream" endpoint result from a HTTP "OK" response.
+func NewEvaluateBatchStreamBatchEvaluateStreamResultOK(contentType string) *policy.BatchEvaluateStreamResult {
+	v := &policy.BatchEvaluateStreamResult{}
+	v.ContentType = contentType
+
+	return v
+}
+
+// NewEvaluationResultStoredEvaluationResultOK builds a "policy" service
+// "EvaluationResult" endpoint result from a HTTP "OK" response.
+func NewEvaluationResultStoredEvaluationResultOK(body any, eTag string) *policy.StoredEvaluationResult {
+	v := body
+	res := &policy.StoredEvaluationResult{
+		Result: v,
+	}
+	res.ETag = eTag
 
 	return res
 }
 
+// NewJobStatusEvaluationJobOK builds a "policy" service "JobStatus" endpoint
+// result from a HTTP "OK" response.
+func NewJobStatusEvaluationJobOK(body *JobStatusResponseBody) *policy.EvaluationJob {
+	v := &policy.EvaluationJob{
+		JobID:         *body.JobID,
+		ETag:          *body.ETag,
+		Repository:    *body.Repository,
+		Group:         *body.Group,
+		PolicyName:    *body.PolicyName,
+		Version:       *body.Version,
+		Rule:          body.Rule,
+		Status:        *body.Status,
+		Error:         body.Error,
+		CallbackURL:   body.CallbackURL,
+		CallbackError: body.CallbackError,
+		CreatedAt:     *body.CreatedAt,
+		UpdatedAt:     *body.UpdatedAt,
+	}
+
+	return v
+}
+
+// NewLockHistoryResultOK builds a "policy" service "LockHistory" endpoint
+// result from a HTTP "OK" response.
+func NewLockHistoryResultOK(body *LockHistoryResponseBody) *policy.LockHistoryResult {
+	v := &policy.LockHistoryResult{
+		Locked: *body.Locked,
+	}
+	if body.Lock != nil {
+		v.Lock = unmarshalPolicyLockEventResponseBodyToPolicyPolicyLockEvent(body.Lock)
+	}
+	v.History = make([]*policy.PolicyLockEvent, len(body.History))
+	for i, val := range body.History {
+		v.History[i] = unmarshalPolicyLockEventResponseBodyToPolicyPolicyLockEvent(val)
+	}
+
+	return v
+}
+
+// NewPolicyTestsPolicyTestReportOK builds a "policy" service "PolicyTests"
+// endpoint result from a HTTP "OK" response.
+func NewPolicyTestsPolicyTestReportOK(body *PolicyTestsResponseBody) *policy.PolicyTestReport {
+	v := &policy.PolicyTestReport{
+		Passed:   *body.Passed,
+		Error:    body.Error,
+		Total:    *body.Total,
+		Failed:   *body.Failed,
+		Coverage: *body.Coverage,
+		RunAt:    *body.RunAt,
+	}
+	v.Results = make([]*policy.PolicyTestResult, len(body.Results))
+	for i, val := range body.Results {
+		v.Results[i] = unmarshalPolicyTestResultResponseBodyToPolicyPolicyTestResult(val)
+	}
+
+	return v
+}
+
 // NewExportBundleResultOK builds a "policy" service "ExportBundle" endpoint
 // result from a HTTP "OK" response.
 func NewExportBundleResultOK(contentType string, contentLength int, contentDisposition string) *policy.ExportBundleResult {
@@ -142,6 +853,199 @@ func NewListPoliciesPoliciesResultOK(body *ListPoliciesResponseBody) *policy.Pol
 	return v
 }
 
+// NewDecisionLogsResultOK builds a "policy" service "DecisionLogs" endpoint
+// result from a HTTP "OK" response.
+func NewDecisionLogsResultOK(body *DecisionLogsResponseBody) *policy.DecisionLogsResult {
+	v := &policy.DecisionLogsResult{}
+	v.Decisions = make([]*policy.Decision, len(body.Decisions))
+	for i, val := range body.Decisions {
+		v.Decisions[i] = unmarshalDecisionResponseBodyToPolicyDecision(val)
+	}
+
+	return v
+}
+
+// NewAuditLogResultOK builds a "policy" service "AuditLog" endpoint result
+// from a HTTP "OK" response.
+func NewAuditLogResultOK(body *AuditLogResponseBody) *policy.AuditLogResult {
+	v := &policy.AuditLogResult{}
+	v.Records = make([]*policy.AuditRecord, len(body.Records))
+	for i, val := range body.Records {
+		v.Records[i] = unmarshalAuditRecordResponseBodyToPolicyAuditRecord(val)
+	}
+
+	return v
+}
+
+// NewSetPolicyAliasPolicyAliasOK builds a "policy" service "SetPolicyAlias"
+// endpoint result from a HTTP "OK" response.
+func NewSetPolicyAliasPolicyAliasOK(body *SetPolicyAliasResponseBody) *policy.PolicyAlias {
+	v := &policy.PolicyAlias{
+		Repository: *body.Repository,
+		Group:      *body.Group,
+		PolicyName: *body.PolicyName,
+		Alias:      *body.Alias,
+		Version:    *body.Version,
+		LastUpdate: *body.LastUpdate,
+	}
+
+	return v
+}
+
+// NewPolicyAliasesResultOK builds a "policy" service "PolicyAliases" endpoint
+// result from a HTTP "OK" response.
+func NewPolicyAliasesResultOK(body *PolicyAliasesResponseBody) *policy.PolicyAliasesResult {
+	v := &policy.PolicyAliasesResult{}
+	v.Aliases = make([]*policy.PolicyAlias, len(body.Aliases))
+	for i, val := range body.Aliases {
+		v.Aliases[i] = unmarshalPolicyAliasResponseBodyToPolicyPolicyAlias(val)
+	}
+
+	return v
+}
+
+// NewReplayReportOK builds a "policy" service "Replay" endpoint result from a
+// HTTP "OK" response.
+func NewReplayReportOK(body *ReplayResponseBody) *policy.ReplayReport {
+	v := &policy.ReplayReport{
+		Candidate:        *body.Candidate,
+		Total:            *body.Total,
+		Unchanged:        *body.Unchanged,
+		Changed:          *body.Changed,
+		NewErrors:        *body.NewErrors,
+		FixedErrors:      *body.FixedErrors,
+		DurationDelta:    *body.DurationDelta,
+		MaxDurationDelta: *body.MaxDurationDelta,
+	}
+	v.Diffs = make([]*policy.ReplayDiff, len(body.Diffs))
+	for i, val := range body.Diffs {
+		v.Diffs[i] = unmarshalReplayDiffResponseBodyToPolicyReplayDiff(val)
+	}
+
+	return v
+}
+
+// ValidatePartialEvaluateResponseBody runs the validations defined on
+// PartialEvaluateResponseBody
+func ValidatePartialEvaluateResponseBody(body *PartialEvaluateResponseBody) (err error) {
+	if body.Queries == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("queries", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
+
+// ValidateEvaluateBatchResponseBody runs the validations defined on
+// EvaluateBatchResponseBody
+func ValidateEvaluateBatchResponseBody(body *EvaluateBatchResponseBody) (err error) {
+	if body.Results == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("results", "body"))
+	}
+	for _, e := range body.Results {
+		if e != nil {
+			if err2 := ValidateBatchEvaluateItemResultResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateJobStatusResponseBody runs the validations defined on
+// JobStatusResponseBody
+func ValidateJobStatusResponseBody(body *JobStatusResponseBody) (err error) {
+	if body.JobID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("jobID", "body"))
+	}
+	if body.ETag == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("ETag", "body"))
+	}
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.Status == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("status", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("createdAt", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updatedAt", "body"))
+	}
+	if body.Status != nil {
+		if !(*body.Status == "pending" || *body.Status == "running" || *body.Status == "done" || *body.Status == "failed") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.status", *body.Status, []any{"pending", "running", "done", "failed"}))
+		}
+	}
+	return
+}
+
+// ValidateLockHistoryResponseBody runs the validations defined on
+// LockHistoryResponseBody
+func ValidateLockHistoryResponseBody(body *LockHistoryResponseBody) (err error) {
+	if body.Locked == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("locked", "body"))
+	}
+	if body.History == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("history", "body"))
+	}
+	if body.Lock != nil {
+		if err2 := ValidatePolicyLockEventResponseBody(body.Lock); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	for _, e := range body.History {
+		if e != nil {
+			if err2 := ValidatePolicyLockEventResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidatePolicyTestsResponseBody runs the validations defined on
+// PolicyTestsResponseBody
+func ValidatePolicyTestsResponseBody(body *PolicyTestsResponseBody) (err error) {
+	if body.Passed == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("passed", "body"))
+	}
+	if body.Total == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("total", "body"))
+	}
+	if body.Failed == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("failed", "body"))
+	}
+	if body.Coverage == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("coverage", "body"))
+	}
+	if body.Results == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("results", "body"))
+	}
+	if body.RunAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("runAt", "body"))
+	}
+	for _, e := range body.Results {
+		if e != nil {
+			if err2 := ValidatePolicyTestResultResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
 // ValidateListPoliciesResponseBody runs the validations defined on
 // ListPoliciesResponseBody
 func ValidateListPoliciesResponseBody(body *ListPoliciesResponseBody) (err error) {
@@ -158,6 +1062,173 @@ func ValidateListPoliciesResponseBody(body *ListPoliciesResponseBody) (err error
 	return
 }
 
+// ValidateDecisionLogsResponseBody runs the validations defined on
+// DecisionLogsResponseBody
+func ValidateDecisionLogsResponseBody(body *DecisionLogsResponseBody) (err error) {
+	if body.Decisions == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("decisions", "body"))
+	}
+	for _, e := range body.Decisions {
+		if e != nil {
+			if err2 := ValidateDecisionResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateAuditLogResponseBody runs the validations defined on
+// AuditLogResponseBody
+func ValidateAuditLogResponseBody(body *AuditLogResponseBody) (err error) {
+	if body.Records == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("records", "body"))
+	}
+	for _, e := range body.Records {
+		if e != nil {
+			if err2 := ValidateAuditRecordResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateSetPolicyAliasResponseBody runs the validations defined on
+// SetPolicyAliasResponseBody
+func ValidateSetPolicyAliasResponseBody(body *SetPolicyAliasResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Alias == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("alias", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.LastUpdate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("lastUpdate", "body"))
+	}
+	return
+}
+
+// ValidatePolicyAliasesResponseBody runs the validations defined on
+// PolicyAliasesResponseBody
+func ValidatePolicyAliasesResponseBody(body *PolicyAliasesResponseBody) (err error) {
+	if body.Aliases == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("aliases", "body"))
+	}
+	for _, e := range body.Aliases {
+		if e != nil {
+			if err2 := ValidatePolicyAliasResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateReplayResponseBody runs the validations defined on ReplayResponseBody
+func ValidateReplayResponseBody(body *ReplayResponseBody) (err error) {
+	if body.Candidate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("candidate", "body"))
+	}
+	if body.Total == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("total", "body"))
+	}
+	if body.Unchanged == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("unchanged", "body"))
+	}
+	if body.Changed == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("changed", "body"))
+	}
+	if body.NewErrors == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("newErrors", "body"))
+	}
+	if body.FixedErrors == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("fixedErrors", "body"))
+	}
+	if body.DurationDelta == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("durationDelta", "body"))
+	}
+	if body.MaxDurationDelta == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("maxDurationDelta", "body"))
+	}
+	if body.Diffs == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("diffs", "body"))
+	}
+	for _, e := range body.Diffs {
+		if e != nil {
+			if err2 := ValidateReplayDiffResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateBatchEvaluateItemResultResponseBody runs the validations defined on
+// BatchEvaluateItemResultResponseBody
+func ValidateBatchEvaluateItemResultResponseBody(body *BatchEvaluateItemResultResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
+
+// ValidatePolicyLockEventResponseBody runs the validations defined on
+// PolicyLockEventResponseBody
+func ValidatePolicyLockEventResponseBody(body *PolicyLockEventResponseBody) (err error) {
+	if body.Locked == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("locked", "body"))
+	}
+	if body.Source == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("source", "body"))
+	}
+	if body.Timestamp == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("timestamp", "body"))
+	}
+	if body.Source != nil {
+		if !(*body.Source == "manual" || *body.Source == "validation" || *body.Source == "import" || *body.Source == "expiry") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.source", *body.Source, []any{"manual", "validation", "import", "expiry"}))
+		}
+	}
+	return
+}
+
+// ValidatePolicyTestResultResponseBody runs the validations defined on
+// PolicyTestResultResponseBody
+func ValidatePolicyTestResultResponseBody(body *PolicyTestResultResponseBody) (err error) {
+	if body.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "body"))
+	}
+	if body.Location == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("location", "body"))
+	}
+	if body.Passed == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("passed", "body"))
+	}
+	if body.Duration == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("duration", "body"))
+	}
+	return
+}
+
 // ValidatePolicyResponseBody runs the validations defined on PolicyResponseBody
 func ValidatePolicyResponseBody(body *PolicyResponseBody) (err error) {
 	if body.Repository == nil {
@@ -178,5 +1249,102 @@ func ValidatePolicyResponseBody(body *PolicyResponseBody) (err error) {
 	if body.LastUpdate == nil {
 		err = goa.MergeErrors(err, goa.MissingFieldError("lastUpdate", "body"))
 	}
+	if body.Lock != nil {
+		if err2 := ValidatePolicyLockEventResponseBody(body.Lock); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateDecisionResponseBody runs the validations defined on
+// DecisionResponseBody
+func ValidateDecisionResponseBody(body *DecisionResponseBody) (err error) {
+	if body.EvaluationID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("evaluationID", "body"))
+	}
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.PolicyLastUpdate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyLastUpdate", "body"))
+	}
+	if body.InputHash == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("inputHash", "body"))
+	}
+	if body.Duration == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("duration", "body"))
+	}
+	if body.Timestamp == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("timestamp", "body"))
+	}
+	return
+}
+
+// ValidateAuditRecordResponseBody runs the validations defined on
+// AuditRecordResponseBody
+func ValidateAuditRecordResponseBody(body *AuditRecordResponseBody) (err error) {
+	if body.Operation == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("operation", "body"))
+	}
+	if body.Timestamp == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("timestamp", "body"))
+	}
+	return
+}
+
+// ValidatePolicyAliasResponseBody runs the validations defined on
+// PolicyAliasResponseBody
+func ValidatePolicyAliasResponseBody(body *PolicyAliasResponseBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Alias == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("alias", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.LastUpdate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("lastUpdate", "body"))
+	}
+	return
+}
+
+// ValidateReplayDiffResponseBody runs the validations defined on
+// ReplayDiffResponseBody
+func ValidateReplayDiffResponseBody(body *ReplayDiffResponseBody) (err error) {
+	if body.EvaluationID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("evaluationID", "body"))
+	}
+	if body.Status == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("status", "body"))
+	}
+	if body.DurationDelta == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("durationDelta", "body"))
+	}
+	if body.Timestamp == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("timestamp", "body"))
+	}
+	if body.Status != nil {
+		if !(*body.Status == "changed" || *body.Status == "new_error" || *body.Status == "fixed_error") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.status", *body.Status, []any{"changed", "new_error", "fixed_error"}))
+		}
+	}
 	return
 }
@@ -24,9 +24,18 @@ import (
 func EncodeEvaluateResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
 		res, _ := v.(*policy.EvaluateResult)
+		if res.JobStatus != nil && *res.JobStatus == "pending" {
+			enc := encoder(ctx, w)
+			body := NewEvaluateAcceptedResponseBody(res)
+			w.Header().Set("Etag", res.ETag)
+			w.Header().Set("X-Policy-Version", res.Version)
+			w.WriteHeader(http.StatusAccepted)
+			return enc.Encode(body)
+		}
 		enc := encoder(ctx, w)
 		body := res.Result
 		w.Header().Set("Etag", res.ETag)
+		w.Header().Set("X-Policy-Version", res.Version)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
@@ -58,8 +67,11 @@ func DecodeEvaluateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			group        string
 			policyName   string
 			version      string
+			explain      *string
+			async        *bool
 			evaluationID *string
 			ttl          *int
+			callbackURL  *string
 
 			params = mux.Vars(r)
 		)
@@ -67,6 +79,26 @@ func DecodeEvaluateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
+		qp := r.URL.Query()
+		explainRaw := qp.Get("explain")
+		if explainRaw != "" {
+			explain = &explainRaw
+		}
+		if explain != nil {
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+		}
+		{
+			asyncRaw := qp.Get("async")
+			if asyncRaw != "" {
+				v, err2 := strconv.ParseBool(asyncRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("async", asyncRaw, "boolean"))
+				}
+				async = &v
+			}
+		}
 		evaluationIDRaw := r.Header.Get("x-evaluation-id")
 		if evaluationIDRaw != "" {
 			evaluationID = &evaluationIDRaw
@@ -82,31 +114,47 @@ func DecodeEvaluateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 				ttl = &pv
 			}
 		}
+		callbackURLRaw := r.Header.Get("x-callback-url")
+		if callbackURLRaw != "" {
+			callbackURL = &callbackURLRaw
+		}
+		if callbackURL != nil {
+			err = goa.MergeErrors(err, goa.ValidateFormat("callbackURL", *callbackURL, goa.FormatURI))
+		}
 		if err != nil {
 			return nil, err
 		}
-		payload := NewEvaluateRequest(body, repository, group, policyName, version, evaluationID, ttl)
+		payload := NewEvaluateRequest(body, repository, group, policyName, version, explain, async, evaluationID, ttl, callbackURL)
 
 		return payload, nil
 	}
 }
 
-// EncodeValidateResponse returns an encoder for responses returned by the
-// policy Validate endpoint.
-func EncodeValidateResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeEvaluateRuleResponse returns an encoder for responses returned by the
+// policy EvaluateRule endpoint.
+func EncodeEvaluateRuleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
 		res, _ := v.(*policy.EvaluateResult)
+		if res.JobStatus != nil && *res.JobStatus == "pending" {
+			enc := encoder(ctx, w)
+			body := NewEvaluateRuleAcceptedResponseBody(res)
+			w.Header().Set("Etag", res.ETag)
+			w.Header().Set("X-Policy-Version", res.Version)
+			w.WriteHeader(http.StatusAccepted)
+			return enc.Encode(body)
+		}
 		enc := encoder(ctx, w)
 		body := res.Result
 		w.Header().Set("Etag", res.ETag)
+		w.Header().Set("X-Policy-Version", res.Version)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodeValidateRequest returns a decoder for requests sent to the policy
-// Validate endpoint.
-func DecodeValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeEvaluateRuleRequest returns a decoder for requests sent to the policy
+// EvaluateRule endpoint.
+func DecodeEvaluateRuleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
 			body any
@@ -130,8 +178,12 @@ func DecodeValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			group        string
 			policyName   string
 			version      string
+			rule         string
+			explain      *string
+			async        *bool
 			evaluationID *string
 			ttl          *int
+			callbackURL  *string
 
 			params = mux.Vars(r)
 		)
@@ -139,6 +191,27 @@ func DecodeValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
+		rule = params["rule"]
+		qp := r.URL.Query()
+		explainRaw := qp.Get("explain")
+		if explainRaw != "" {
+			explain = &explainRaw
+		}
+		if explain != nil {
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+		}
+		{
+			asyncRaw := qp.Get("async")
+			if asyncRaw != "" {
+				v, err2 := strconv.ParseBool(asyncRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("async", asyncRaw, "boolean"))
+				}
+				async = &v
+			}
+		}
 		evaluationIDRaw := r.Header.Get("x-evaluation-id")
 		if evaluationIDRaw != "" {
 			evaluationID = &evaluationIDRaw
@@ -154,33 +227,67 @@ func DecodeValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 				ttl = &pv
 			}
 		}
+		callbackURLRaw := r.Header.Get("x-callback-url")
+		if callbackURLRaw != "" {
+			callbackURL = &callbackURLRaw
+		}
+		if callbackURL != nil {
+			err = goa.MergeErrors(err, goa.ValidateFormat("callbackURL", *callbackURL, goa.FormatURI))
+		}
 		if err != nil {
 			return nil, err
 		}
-		payload := NewValidateEvaluateRequest(body, repository, group, policyName, version, evaluationID, ttl)
+		payload := NewEvaluateRuleEvaluateRequest(body, repository, group, policyName, version, rule, explain, async, evaluationID, ttl, callbackURL)
 
 		return payload, nil
 	}
 }
 
-// EncodeLockResponse returns an encoder for responses returned by the policy
-// Lock endpoint.
-func EncodeLockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeValidateResponse returns an encoder for responses returned by the
+// policy Validate endpoint.
+func EncodeValidateResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.EvaluateResult)
+		enc := encoder(ctx, w)
+		body := res.Result
+		w.Header().Set("Etag", res.ETag)
+		w.Header().Set("X-Policy-Version", res.Version)
 		w.WriteHeader(http.StatusOK)
-		return nil
+		return enc.Encode(body)
 	}
 }
 
-// DecodeLockRequest returns a decoder for requests sent to the policy Lock
-// endpoint.
-func DecodeLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeValidateRequest returns a decoder for requests sent to the policy
+// Validate endpoint.
+func DecodeValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			repository string
-			group      string
-			policyName string
-			version    string
+			body any
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			} else {
+				var gerr *goa.ServiceError
+				if errors.As(err, &gerr) {
+					return nil, gerr
+				}
+				return nil, goa.DecodePayloadError(err.Error())
+			}
+		}
+
+		var (
+			repository   string
+			group        string
+			policyName   string
+			version      string
+			explain      *string
+			report       *bool
+			coerce       *bool
+			evaluationID *string
+			ttl          *int
 
 			params = mux.Vars(r)
 		)
@@ -188,64 +295,96 @@ func DecodeLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.De
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
-		payload := NewLockRequest(repository, group, policyName, version)
+		qp := r.URL.Query()
+		explainRaw := qp.Get("explain")
+		if explainRaw != "" {
+			explain = &explainRaw
+		}
+		if explain != nil {
+			if !(*explain == "off" || *explain == "notes" || *explain == "fails" || *explain == "full") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("explain", *explain, []any{"off", "notes", "fails", "full"}))
+			}
+		}
+		{
+			reportRaw := qp.Get("report")
+			if reportRaw != "" {
+				v, err2 := strconv.ParseBool(reportRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("report", reportRaw, "boolean"))
+				}
+				report = &v
+			}
+		}
+		{
+			coerceRaw := qp.Get("coerce")
+			if coerceRaw != "" {
+				v, err2 := strconv.ParseBool(coerceRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("coerce", coerceRaw, "boolean"))
+				}
+				coerce = &v
+			}
+		}
+		evaluationIDRaw := r.Header.Get("x-evaluation-id")
+		if evaluationIDRaw != "" {
+			evaluationID = &evaluationIDRaw
+		}
+		{
+			ttlRaw := r.Header.Get("x-cache-ttl")
+			if ttlRaw != "" {
+				v, err2 := strconv.ParseInt(ttlRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ttl", ttlRaw, "integer"))
+				}
+				pv := int(v)
+				ttl = &pv
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewValidateEvaluateRequest(body, repository, group, policyName, version, explain, report, coerce, evaluationID, ttl)
 
 		return payload, nil
 	}
 }
 
-// EncodeUnlockResponse returns an encoder for responses returned by the policy
-// Unlock endpoint.
-func EncodeUnlockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodePartialEvaluateResponse returns an encoder for responses returned by
+// the policy PartialEvaluate endpoint.
+func EncodePartialEvaluateResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PartialEvaluateResult)
+		enc := encoder(ctx, w)
+		body := NewPartialEvaluateResponseBody(res)
 		w.WriteHeader(http.StatusOK)
-		return nil
+		return enc.Encode(body)
 	}
 }
 
-// DecodeUnlockRequest returns a decoder for requests sent to the policy Unlock
-// endpoint.
-func DecodeUnlockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodePartialEvaluateRequest returns a decoder for requests sent to the
+// policy PartialEvaluate endpoint.
+func DecodePartialEvaluateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			repository string
-			group      string
-			policyName string
-			version    string
-
-			params = mux.Vars(r)
+			body PartialEvaluateRequestBody
+			err  error
 		)
-		repository = params["repository"]
-		group = params["group"]
-		policyName = params["policyName"]
-		version = params["version"]
-		payload := NewUnlockRequest(repository, group, policyName, version)
-
-		return payload, nil
-	}
-}
-
-// EncodeExportBundleResponse returns an encoder for responses returned by the
-// policy ExportBundle endpoint.
-func EncodeExportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
-	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(*policy.ExportBundleResult)
-		w.Header().Set("Content-Type", res.ContentType)
-		{
-			val := res.ContentLength
-			contentLengths := strconv.Itoa(val)
-			w.Header().Set("Content-Length", contentLengths)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidatePartialEvaluateRequestBody(&body)
+		if err != nil {
+			return nil, err
 		}
-		w.Header().Set("Content-Disposition", res.ContentDisposition)
-		w.WriteHeader(http.StatusOK)
-		return nil
-	}
-}
 
-// DecodeExportBundleRequest returns a decoder for requests sent to the policy
-// ExportBundle endpoint.
-func DecodeExportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
-	return func(r *http.Request) (any, error) {
 		var (
 			repository string
 			group      string
@@ -258,182 +397,1046 @@ func DecodeExportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) go
 		group = params["group"]
 		policyName = params["policyName"]
 		version = params["version"]
-		payload := NewExportBundleRequest(repository, group, policyName, version)
+		payload := NewPartialEvaluateRequest(&body, repository, group, policyName, version)
 
 		return payload, nil
 	}
 }
 
-// EncodePolicyPublicKeyResponse returns an encoder for responses returned by
-// the policy PolicyPublicKey endpoint.
-func EncodePolicyPublicKeyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeEvaluateBatchResponse returns an encoder for responses returned by the
+// policy EvaluateBatch endpoint.
+func EncodeEvaluateBatchResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(any)
+		res, _ := v.(*policy.BatchEvaluateResult)
 		enc := encoder(ctx, w)
-		body := res
+		body := NewEvaluateBatchResponseBody(res)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodePolicyPublicKeyRequest returns a decoder for requests sent to the
-// policy PolicyPublicKey endpoint.
-func DecodePolicyPublicKeyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeEvaluateBatchRequest returns a decoder for requests sent to the policy
+// EvaluateBatch endpoint.
+func DecodeEvaluateBatchRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			repository string
-			group      string
-			policyName string
-			version    string
-
-			params = mux.Vars(r)
+			body EvaluateBatchRequestBody
+			err  error
 		)
-		repository = params["repository"]
-		group = params["group"]
-		policyName = params["policyName"]
-		version = params["version"]
-		payload := NewPolicyPublicKeyRequest(repository, group, policyName, version)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateEvaluateBatchRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewEvaluateBatchBatchEvaluateRequest(&body)
 
 		return payload, nil
 	}
 }
 
-// EncodeImportBundleResponse returns an encoder for responses returned by the
-// policy ImportBundle endpoint.
-func EncodeImportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeEvaluateBatchStreamResponse returns an encoder for responses returned
+// by the policy EvaluateBatchStream endpoint.
+func EncodeEvaluateBatchStreamResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(any)
+		res, _ := v.(*policy.BatchEvaluateStreamResult)
+		w.Header().Set("Content-Type", res.ContentType)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// EncodeEvaluationResultResponse returns an encoder for responses returned by
+// the policy EvaluationResult endpoint.
+func EncodeEvaluationResultResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.StoredEvaluationResult)
 		enc := encoder(ctx, w)
-		body := res
+		body := res.Result
+		w.Header().Set("Etag", res.ETag)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodeImportBundleRequest returns a decoder for requests sent to the policy
-// ImportBundle endpoint.
-func DecodeImportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeEvaluationResultRequest returns a decoder for requests sent to the
+// policy EvaluationResult endpoint.
+func DecodeEvaluationResultRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			length *int
-			err    error
+			evaluationID string
+			wait         *int
+			err          error
+
+			params = mux.Vars(r)
 		)
+		evaluationID = params["evaluationID"]
 		{
-			lengthRaw := r.Header.Get("Content-Length")
-			if lengthRaw != "" {
-				v, err2 := strconv.ParseInt(lengthRaw, 10, strconv.IntSize)
+			waitRaw := r.URL.Query().Get("wait")
+			if waitRaw != "" {
+				v, err2 := strconv.ParseInt(waitRaw, 10, strconv.IntSize)
 				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("length", lengthRaw, "integer"))
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("wait", waitRaw, "integer"))
 				}
 				pv := int(v)
-				length = &pv
+				wait = &pv
+			}
+		}
+		if wait != nil {
+			if *wait < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("wait", *wait, 0, true))
+			}
+		}
+		if wait != nil {
+			if *wait > 60 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("wait", *wait, 60, false))
 			}
 		}
 		if err != nil {
 			return nil, err
 		}
-		payload := NewImportBundlePayload(length)
+		payload := NewEvaluationResultRequest(evaluationID, wait)
 
 		return payload, nil
 	}
 }
 
-// EncodeListPoliciesResponse returns an encoder for responses returned by the
-// policy ListPolicies endpoint.
-func EncodeListPoliciesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeJobStatusResponse returns an encoder for responses returned by the
+// policy JobStatus endpoint.
+func EncodeJobStatusResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(*policy.PoliciesResult)
+		res, _ := v.(*policy.EvaluationJob)
 		enc := encoder(ctx, w)
-		body := NewListPoliciesResponseBody(res)
+		body := NewJobStatusResponseBody(res)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodeListPoliciesRequest returns a decoder for requests sent to the policy
-// ListPolicies endpoint.
-func DecodeListPoliciesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeJobStatusRequest returns a decoder for requests sent to the policy
+// JobStatus endpoint.
+func DecodeJobStatusRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			locked     *bool
-			policyName *string
-			rego       *bool
-			data       *bool
-			dataConfig *bool
-			err        error
+			jobID string
+
+			params = mux.Vars(r)
 		)
-		qp := r.URL.Query()
-		{
-			lockedRaw := qp.Get("locked")
-			if lockedRaw != "" {
-				v, err2 := strconv.ParseBool(lockedRaw)
-				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("locked", lockedRaw, "boolean"))
-				}
-				locked = &v
-			}
+		jobID = params["jobID"]
+		payload := NewJobStatusRequest(jobID)
+
+		return payload, nil
+	}
+}
+
+// EncodeLockResponse returns an encoder for responses returned by the policy
+// Lock endpoint.
+func EncodeLockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeLockRequest returns a decoder for requests sent to the policy Lock
+// endpoint.
+func DecodeLockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body LockRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewLockRequest(&body, repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeUnlockResponse returns an encoder for responses returned by the policy
+// Unlock endpoint.
+func EncodeUnlockResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeUnlockRequest returns a decoder for requests sent to the policy Unlock
+// endpoint.
+func DecodeUnlockRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body UnlockRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewUnlockRequest(&body, repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeLockHistoryResponse returns an encoder for responses returned by the
+// policy LockHistory endpoint.
+func EncodeLockHistoryResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.LockHistoryResult)
+		enc := encoder(ctx, w)
+		body := NewLockHistoryResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeLockHistoryRequest returns a decoder for requests sent to the policy
+// LockHistory endpoint.
+func DecodeLockHistoryRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewLockHistoryRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyTestsResponse returns an encoder for responses returned by the
+// policy PolicyTests endpoint.
+func EncodePolicyTestsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyTestReport)
+		enc := encoder(ctx, w)
+		body := NewPolicyTestsResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyTestsRequest returns a decoder for requests sent to the policy
+// PolicyTests endpoint.
+func DecodePolicyTestsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewPolicyTestsRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeExportBundleResponse returns an encoder for responses returned by the
+// policy ExportBundle endpoint.
+func EncodeExportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.ExportBundleResult)
+		w.Header().Set("Content-Type", res.ContentType)
+		{
+			val := res.ContentLength
+			contentLengths := strconv.Itoa(val)
+			w.Header().Set("Content-Length", contentLengths)
+		}
+		w.Header().Set("Content-Disposition", res.ContentDisposition)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeExportBundleRequest returns a decoder for requests sent to the policy
+// ExportBundle endpoint.
+func DecodeExportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewExportBundleRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyPublicKeyResponse returns an encoder for responses returned by
+// the policy PolicyPublicKey endpoint.
+func EncodePolicyPublicKeyResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyPublicKeyRequest returns a decoder for requests sent to the
+// policy PolicyPublicKey endpoint.
+func DecodePolicyPublicKeyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewPolicyPublicKeyRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeImportBundleResponse returns an encoder for responses returned by the
+// policy ImportBundle endpoint.
+func EncodeImportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeImportBundleRequest returns a decoder for requests sent to the policy
+// ImportBundle endpoint.
+func DecodeImportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			length *int
+			err    error
+		)
+		{
+			lengthRaw := r.Header.Get("Content-Length")
+			if lengthRaw != "" {
+				v, err2 := strconv.ParseInt(lengthRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("length", lengthRaw, "integer"))
+				}
+				pv := int(v)
+				length = &pv
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewImportBundlePayload(length)
+
+		return payload, nil
+	}
+}
+
+// EncodeListPoliciesResponse returns an encoder for responses returned by the
+// policy ListPolicies endpoint.
+func EncodeListPoliciesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PoliciesResult)
+		enc := encoder(ctx, w)
+		body := NewListPoliciesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListPoliciesRequest returns a decoder for requests sent to the policy
+// ListPolicies endpoint.
+func DecodeListPoliciesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			locked     *bool
+			policyName *string
+			rego       *bool
+			data       *bool
+			dataConfig *bool
+			err        error
+		)
+		qp := r.URL.Query()
+		{
+			lockedRaw := qp.Get("locked")
+			if lockedRaw != "" {
+				v, err2 := strconv.ParseBool(lockedRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("locked", lockedRaw, "boolean"))
+				}
+				locked = &v
+			}
+		}
+		policyNameRaw := qp.Get("policyName")
+		if policyNameRaw != "" {
+			policyName = &policyNameRaw
+		}
+		{
+			regoRaw := qp.Get("rego")
+			if regoRaw != "" {
+				v, err2 := strconv.ParseBool(regoRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("rego", regoRaw, "boolean"))
+				}
+				rego = &v
+			}
+		}
+		{
+			dataRaw := qp.Get("data")
+			if dataRaw != "" {
+				v, err2 := strconv.ParseBool(dataRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("data", dataRaw, "boolean"))
+				}
+				data = &v
+			}
+		}
+		{
+			dataConfigRaw := qp.Get("dataConfig")
+			if dataConfigRaw != "" {
+				v, err2 := strconv.ParseBool(dataConfigRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("dataConfig", dataConfigRaw, "boolean"))
+				}
+				dataConfig = &v
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewListPoliciesPoliciesRequest(locked, policyName, rego, data, dataConfig)
+
+		return payload, nil
+	}
+}
+
+// EncodeDecisionLogsResponse returns an encoder for responses returned by the
+// policy DecisionLogs endpoint.
+func EncodeDecisionLogsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.DecisionLogsResult)
+		enc := encoder(ctx, w)
+		body := NewDecisionLogsResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeDecisionLogsRequest returns a decoder for requests sent to the policy
+// DecisionLogs endpoint.
+func DecodeDecisionLogsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository   *string
+			group        *string
+			policyName   *string
+			version      *string
+			evaluationID *string
+			caller       *string
+			from         *int64
+			to           *int64
+			limit        int
+			offset       *int
+			err          error
+		)
+		qp := r.URL.Query()
+		repositoryRaw := qp.Get("repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		groupRaw := qp.Get("group")
+		if groupRaw != "" {
+			group = &groupRaw
 		}
 		policyNameRaw := qp.Get("policyName")
 		if policyNameRaw != "" {
 			policyName = &policyNameRaw
 		}
+		versionRaw := qp.Get("version")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		evaluationIDRaw := qp.Get("evaluationID")
+		if evaluationIDRaw != "" {
+			evaluationID = &evaluationIDRaw
+		}
+		callerRaw := qp.Get("caller")
+		if callerRaw != "" {
+			caller = &callerRaw
+		}
 		{
-			regoRaw := qp.Get("rego")
-			if regoRaw != "" {
-				v, err2 := strconv.ParseBool(regoRaw)
+			fromRaw := qp.Get("from")
+			if fromRaw != "" {
+				v, err2 := strconv.ParseInt(fromRaw, 10, 64)
 				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("rego", regoRaw, "boolean"))
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("from", fromRaw, "integer"))
 				}
-				rego = &v
+				from = &v
 			}
 		}
 		{
-			dataRaw := qp.Get("data")
-			if dataRaw != "" {
-				v, err2 := strconv.ParseBool(dataRaw)
+			toRaw := qp.Get("to")
+			if toRaw != "" {
+				v, err2 := strconv.ParseInt(toRaw, 10, 64)
 				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("data", dataRaw, "boolean"))
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("to", toRaw, "integer"))
 				}
-				data = &v
+				to = &v
+			}
+		}
+		{
+			limitRaw := qp.Get("limit")
+			if limitRaw == "" {
+				limit = 100
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+		}
+		{
+			offsetRaw := qp.Get("offset")
+			if offsetRaw != "" {
+				v, err2 := strconv.ParseInt(offsetRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("offset", offsetRaw, "integer"))
+				}
+				pv := int(v)
+				offset = &pv
+			}
+		}
+		if offset != nil {
+			if *offset < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("offset", *offset, 0, true))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewDecisionLogsRequest(repository, group, policyName, version, evaluationID, caller, from, to, limit, offset)
+
+		return payload, nil
+	}
+}
+
+// EncodeAuditLogResponse returns an encoder for responses returned by the
+// policy AuditLog endpoint.
+func EncodeAuditLogResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.AuditLogResult)
+		enc := encoder(ctx, w)
+		body := NewAuditLogResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeAuditLogRequest returns a decoder for requests sent to the policy
+// AuditLog endpoint.
+func DecodeAuditLogRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			operation  *string
+			actor      *string
+			repository *string
+			group      *string
+			policyName *string
+			version    *string
+			from       *int64
+			to         *int64
+			limit      int
+			offset     *int
+			err        error
+		)
+		qp := r.URL.Query()
+		operationRaw := qp.Get("operation")
+		if operationRaw != "" {
+			operation = &operationRaw
+		}
+		actorRaw := qp.Get("actor")
+		if actorRaw != "" {
+			actor = &actorRaw
+		}
+		repositoryRaw := qp.Get("repository")
+		if repositoryRaw != "" {
+			repository = &repositoryRaw
+		}
+		groupRaw := qp.Get("group")
+		if groupRaw != "" {
+			group = &groupRaw
+		}
+		policyNameRaw := qp.Get("policyName")
+		if policyNameRaw != "" {
+			policyName = &policyNameRaw
+		}
+		versionRaw := qp.Get("version")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		{
+			fromRaw := qp.Get("from")
+			if fromRaw != "" {
+				v, err2 := strconv.ParseInt(fromRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("from", fromRaw, "integer"))
+				}
+				from = &v
+			}
+		}
+		{
+			toRaw := qp.Get("to")
+			if toRaw != "" {
+				v, err2 := strconv.ParseInt(toRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("to", toRaw, "integer"))
+				}
+				to = &v
+			}
+		}
+		{
+			limitRaw := qp.Get("limit")
+			if limitRaw == "" {
+				limit = 100
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+		}
+		{
+			offsetRaw := qp.Get("offset")
+			if offsetRaw != "" {
+				v, err2 := strconv.ParseInt(offsetRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("offset", offsetRaw, "integer"))
+				}
+				pv := int(v)
+				offset = &pv
+			}
+		}
+		if offset != nil {
+			if *offset < 0 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("offset", *offset, 0, true))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewAuditLogRequest(operation, actor, repository, group, policyName, version, from, to, limit, offset)
+
+		return payload, nil
+	}
+}
+
+// EncodeSetPolicyAutoImportResponse returns an encoder for responses returned
+// by the policy SetPolicyAutoImport endpoint.
+func EncodeSetPolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeSetPolicyAutoImportRequest returns a decoder for requests sent to the
+// policy SetPolicyAutoImport endpoint.
+func DecodeSetPolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body SetPolicyAutoImportRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateSetPolicyAutoImportRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewSetPolicyAutoImportRequest(&body)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyAutoImportResponse returns an encoder for responses returned by
+// the policy PolicyAutoImport endpoint.
+func EncodePolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// EncodeDeletePolicyAutoImportResponse returns an encoder for responses
+// returned by the policy DeletePolicyAutoImport endpoint.
+func EncodeDeletePolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(any)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeDeletePolicyAutoImportRequest returns a decoder for requests sent to
+// the policy DeletePolicyAutoImport endpoint.
+func DecodeDeletePolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body DeletePolicyAutoImportRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateDeletePolicyAutoImportRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewDeletePolicyAutoImportRequest(&body)
+
+		return payload, nil
+	}
+}
+
+// EncodeSetPolicyAliasResponse returns an encoder for responses returned by
+// the policy SetPolicyAlias endpoint.
+func EncodeSetPolicyAliasResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyAlias)
+		enc := encoder(ctx, w)
+		body := NewSetPolicyAliasResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeSetPolicyAliasRequest returns a decoder for requests sent to the
+// policy SetPolicyAlias endpoint.
+func DecodeSetPolicyAliasRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body SetPolicyAliasRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateSetPolicyAliasRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			repository string
+			group      string
+			policyName string
+			alias      string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		alias = params["alias"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("alias", alias, "^[a-zA-Z][a-zA-Z0-9._-]*$"))
+		if err != nil {
+			return nil, err
+		}
+		payload := NewSetPolicyAliasRequest(&body, repository, group, policyName, alias)
+
+		return payload, nil
+	}
+}
+
+// EncodePolicyAliasesResponse returns an encoder for responses returned by the
+// policy PolicyAliases endpoint.
+func EncodePolicyAliasesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.PolicyAliasesResult)
+		enc := encoder(ctx, w)
+		body := NewPolicyAliasesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePolicyAliasesRequest returns a decoder for requests sent to the policy
+// PolicyAliases endpoint.
+func DecodePolicyAliasesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		payload := NewPolicyAliasesRequest(repository, group, policyName)
+
+		return payload, nil
+	}
+}
+
+// EncodeDeletePolicyAliasResponse returns an encoder for responses returned by
+// the policy DeletePolicyAlias endpoint.
+func EncodeDeletePolicyAliasResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeDeletePolicyAliasRequest returns a decoder for requests sent to the
+// policy DeletePolicyAlias endpoint.
+func DecodeDeletePolicyAliasRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			alias      string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		alias = params["alias"]
+		payload := NewDeletePolicyAliasRequest(repository, group, policyName, alias)
+
+		return payload, nil
+	}
+}
+
+// EncodeSetPolicyShadowResponse returns an encoder for responses returned by
+// the policy SetPolicyShadow endpoint.
+func EncodeSetPolicyShadowResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeSetPolicyShadowRequest returns a decoder for requests sent to the
+// policy SetPolicyShadow endpoint.
+func DecodeSetPolicyShadowRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body SetPolicyShadowRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
 			}
-		}
-		{
-			dataConfigRaw := qp.Get("dataConfig")
-			if dataConfigRaw != "" {
-				v, err2 := strconv.ParseBool(dataConfigRaw)
-				if err2 != nil {
-					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("dataConfig", dataConfigRaw, "boolean"))
-				}
-				dataConfig = &v
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
 			}
+			return nil, goa.DecodePayloadError(err.Error())
 		}
+		err = ValidateSetPolicyShadowRequestBody(&body)
 		if err != nil {
 			return nil, err
 		}
-		payload := NewListPoliciesPoliciesRequest(locked, policyName, rego, data, dataConfig)
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewSetPolicyShadowRequest(&body, repository, group, policyName, version)
 
 		return payload, nil
 	}
 }
 
-// EncodeSetPolicyAutoImportResponse returns an encoder for responses returned
-// by the policy SetPolicyAutoImport endpoint.
-func EncodeSetPolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeDeletePolicyShadowResponse returns an encoder for responses returned
+// by the policy DeletePolicyShadow endpoint.
+func EncodeDeletePolicyShadowResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(any)
-		enc := encoder(ctx, w)
-		body := res
 		w.WriteHeader(http.StatusOK)
-		return enc.Encode(body)
+		return nil
 	}
 }
 
-// DecodeSetPolicyAutoImportRequest returns a decoder for requests sent to the
-// policy SetPolicyAutoImport endpoint.
-func DecodeSetPolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeDeletePolicyShadowRequest returns a decoder for requests sent to the
+// policy DeletePolicyShadow endpoint.
+func DecodeDeletePolicyShadowRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			body SetPolicyAutoImportRequestBody
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewDeletePolicyShadowRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeSetPolicyCaptureResponse returns an encoder for responses returned by
+// the policy SetPolicyCapture endpoint.
+func EncodeSetPolicyCaptureResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeSetPolicyCaptureRequest returns a decoder for requests sent to the
+// policy SetPolicyCapture endpoint.
+func DecodeSetPolicyCaptureRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			body SetPolicyCaptureRequestBody
 			err  error
 		)
 		err = decoder(r).Decode(&body)
@@ -447,46 +1450,109 @@ func DecodeSetPolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.Requ
 			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
-		err = ValidateSetPolicyAutoImportRequestBody(&body)
+		err = ValidateSetPolicyCaptureRequestBody(&body)
 		if err != nil {
 			return nil, err
 		}
-		payload := NewSetPolicyAutoImportRequest(&body)
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewSetPolicyCaptureRequest(&body, repository, group, policyName, version)
 
 		return payload, nil
 	}
 }
 
-// EncodePolicyAutoImportResponse returns an encoder for responses returned by
-// the policy PolicyAutoImport endpoint.
-func EncodePolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeDeletePolicyCaptureResponse returns an encoder for responses returned
+// by the policy DeletePolicyCapture endpoint.
+func EncodeDeletePolicyCaptureResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(any)
-		enc := encoder(ctx, w)
-		body := res
 		w.WriteHeader(http.StatusOK)
-		return enc.Encode(body)
+		return nil
 	}
 }
 
-// EncodeDeletePolicyAutoImportResponse returns an encoder for responses
-// returned by the policy DeletePolicyAutoImport endpoint.
-func EncodeDeletePolicyAutoImportResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// DecodeDeletePolicyCaptureRequest returns a decoder for requests sent to the
+// policy DeletePolicyCapture endpoint.
+func DecodeDeletePolicyCaptureRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewDeletePolicyCaptureRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeDeleteCapturedEvaluationsResponse returns an encoder for responses
+// returned by the policy DeleteCapturedEvaluations endpoint.
+func EncodeDeleteCapturedEvaluationsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(any)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodeDeleteCapturedEvaluationsRequest returns a decoder for requests sent
+// to the policy DeleteCapturedEvaluations endpoint.
+func DecodeDeleteCapturedEvaluationsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewDeleteCapturedEvaluationsRequest(repository, group, policyName, version)
+
+		return payload, nil
+	}
+}
+
+// EncodeReplayResponse returns an encoder for responses returned by the policy
+// Replay endpoint.
+func EncodeReplayResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*policy.ReplayReport)
 		enc := encoder(ctx, w)
-		body := res
+		body := NewReplayResponseBody(res)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodeDeletePolicyAutoImportRequest returns a decoder for requests sent to
-// the policy DeletePolicyAutoImport endpoint.
-func DecodeDeletePolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeReplayRequest returns a decoder for requests sent to the policy Replay
+// endpoint.
+func DecodeReplayRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			body DeletePolicyAutoImportRequestBody
+			body ReplayRequestBody
 			err  error
 		)
 		err = decoder(r).Decode(&body)
@@ -500,11 +1566,24 @@ func DecodeDeletePolicyAutoImportRequest(mux goahttp.Muxer, decoder func(*http.R
 			}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
-		err = ValidateDeletePolicyAutoImportRequestBody(&body)
+		err = ValidateReplayRequestBody(&body)
 		if err != nil {
 			return nil, err
 		}
-		payload := NewDeletePolicyAutoImportRequest(&body)
+
+		var (
+			repository string
+			group      string
+			policyName string
+			version    string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		group = params["group"]
+		policyName = params["policyName"]
+		version = params["version"]
+		payload := NewReplayRequest(&body, repository, group, policyName, version)
 
 		return payload, nil
 	}
@@ -564,20 +1643,180 @@ func DecodeSubscribeForPolicyChangeRequest(mux goahttp.Muxer, decoder func(*http
 	}
 }
 
+// unmarshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem builds a
+// value of type *policy.BatchEvaluateItem from a value of type
+// *BatchEvaluateItemRequestBody.
+func unmarshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem(v *BatchEvaluateItemRequestBody) *policy.BatchEvaluateItem {
+	res := &policy.BatchEvaluateItem{
+		Repository:   *v.Repository,
+		Group:        *v.Group,
+		PolicyName:   *v.PolicyName,
+		Version:      *v.Version,
+		Input:        v.Input,
+		EvaluationID: v.EvaluationID,
+		TTL:          v.TTL,
+	}
+
+	return res
+}
+
+// marshalPolicyBatchEvaluateItemResultToBatchEvaluateItemResultResponseBody
+// builds a value of type *BatchEvaluateItemResultResponseBody from a value of
+// type *policy.BatchEvaluateItemResult.
+func marshalPolicyBatchEvaluateItemResultToBatchEvaluateItemResultResponseBody(v *policy.BatchEvaluateItemResult) *BatchEvaluateItemResultResponseBody {
+	res := &BatchEvaluateItemResultResponseBody{
+		Repository: v.Repository,
+		Group:      v.Group,
+		PolicyName: v.PolicyName,
+		Version:    v.Version,
+		Result:     v.Result,
+		ETag:       v.ETag,
+		Error:      v.Error,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyLockEventToPolicyLockEventResponseBody builds a value of
+// type *PolicyLockEventResponseBody from a value of type
+// *policy.PolicyLockEvent.
+func marshalPolicyPolicyLockEventToPolicyLockEventResponseBody(v *policy.PolicyLockEvent) *PolicyLockEventResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &PolicyLockEventResponseBody{
+		Locked:    v.Locked,
+		Reason:    v.Reason,
+		Actor:     v.Actor,
+		Source:    v.Source,
+		UnlockAt:  v.UnlockAt,
+		Timestamp: v.Timestamp,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyTestResultToPolicyTestResultResponseBody builds a value
+// of type *PolicyTestResultResponseBody from a value of type
+// *policy.PolicyTestResult.
+func marshalPolicyPolicyTestResultToPolicyTestResultResponseBody(v *policy.PolicyTestResult) *PolicyTestResultResponseBody {
+	res := &PolicyTestResultResponseBody{
+		Name:     v.Name,
+		Location: v.Location,
+		Passed:   v.Passed,
+		Error:    v.Error,
+		Duration: v.Duration,
+	}
+
+	return res
+}
+
 // marshalPolicyPolicyToPolicyResponseBody builds a value of type
 // *PolicyResponseBody from a value of type *policy.Policy.
 func marshalPolicyPolicyToPolicyResponseBody(v *policy.Policy) *PolicyResponseBody {
 	res := &PolicyResponseBody{
+		Repository:    v.Repository,
+		PolicyName:    v.PolicyName,
+		Group:         v.Group,
+		Version:       v.Version,
+		Rego:          v.Rego,
+		Data:          v.Data,
+		DataConfig:    v.DataConfig,
+		Locked:        v.Locked,
+		LastUpdate:    v.LastUpdate,
+		ShadowVersion: v.ShadowVersion,
+	}
+	if v.Modules != nil {
+		res.Modules = make(map[string]string, len(v.Modules))
+		for key, val := range v.Modules {
+			tk := key
+			tv := val
+			res.Modules[tk] = tv
+		}
+	}
+	if v.Lock != nil {
+		res.Lock = marshalPolicyPolicyLockEventToPolicyLockEventResponseBody(v.Lock)
+	}
+
+	return res
+}
+
+// marshalPolicyDecisionToDecisionResponseBody builds a value of type
+// *DecisionResponseBody from a value of type *policy.Decision.
+func marshalPolicyDecisionToDecisionResponseBody(v *policy.Decision) *DecisionResponseBody {
+	res := &DecisionResponseBody{
+		EvaluationID:     v.EvaluationID,
+		Repository:       v.Repository,
+		Group:            v.Group,
+		PolicyName:       v.PolicyName,
+		Version:          v.Version,
+		PolicyLastUpdate: v.PolicyLastUpdate,
+		InputHash:        v.InputHash,
+		Input:            v.Input,
+		Result:           v.Result,
+		Error:            v.Error,
+		Duration:         v.Duration,
+		Caller:           v.Caller,
+		ClientIP:         v.ClientIP,
+		Timestamp:        v.Timestamp,
+		Rule:             v.Rule,
+		RequestID:        v.RequestID,
+	}
+
+	return res
+}
+
+// marshalPolicyAuditRecordToAuditRecordResponseBody builds a value of type
+// *AuditRecordResponseBody from a value of type *policy.AuditRecord.
+func marshalPolicyAuditRecordToAuditRecordResponseBody(v *policy.AuditRecord) *AuditRecordResponseBody {
+	res := &AuditRecordResponseBody{
+		Operation:     v.Operation,
+		Actor:         v.Actor,
+		ClientIP:      v.ClientIP,
+		Repository:    v.Repository,
+		Group:         v.Group,
+		PolicyName:    v.PolicyName,
+		Version:       v.Version,
+		Target:        v.Target,
+		BeforeHash:    v.BeforeHash,
+		AfterHash:     v.AfterHash,
+		Timestamp:     v.Timestamp,
+		BeforeVersion: v.BeforeVersion,
+		AfterVersion:  v.AfterVersion,
+	}
+
+	return res
+}
+
+// marshalPolicyPolicyAliasToPolicyAliasResponseBody builds a value of type
+// *PolicyAliasResponseBody from a value of type *policy.PolicyAlias.
+func marshalPolicyPolicyAliasToPolicyAliasResponseBody(v *policy.PolicyAlias) *PolicyAliasResponseBody {
+	res := &PolicyAliasResponseBody{
 		Repository: v.Repository,
-		PolicyName: v.PolicyName,
 		Group:      v.Group,
+		PolicyName: v.PolicyName,
+		Alias:      v.Alias,
 		Version:    v.Version,
-		Rego:       v.Rego,
-		Data:       v.Data,
-		DataConfig: v.DataConfig,
-		Locked:     v.Locked,
 		LastUpdate: v.LastUpdate,
 	}
 
 	return res
 }
+
+// marshalPolicyReplayDiffToReplayDiffResponseBody builds a value of type
+// *ReplayDiffResponseBody from a value of type *policy.ReplayDiff.
+func marshalPolicyReplayDiffToReplayDiffResponseBody(v *policy.ReplayDiff) *ReplayDiffResponseBody {
+	res := &ReplayDiffResponseBody{
+		EvaluationID:    v.EvaluationID,
+		Rule:            v.Rule,
+		Status:          v.Status,
+		Result:          v.Result,
+		CandidateResult: v.CandidateResult,
+		Error:           v.Error,
+		CandidateError:  v.CandidateError,
+		DurationDelta:   v.DurationDelta,
+		Timestamp:       v.Timestamp,
+	}
+
+	return res
+}
@@ -20,19 +20,38 @@ import (
 
 // Server lists the policy service endpoint HTTP handlers.
 type Server struct {
-	Mounts                   []*MountPoint
-	Evaluate                 http.Handler
-	Validate                 http.Handler
-	Lock                     http.Handler
-	Unlock                   http.Handler
-	ExportBundle             http.Handler
-	PolicyPublicKey          http.Handler
-	ImportBundle             http.Handler
-	ListPolicies             http.Handler
-	SetPolicyAutoImport      http.Handler
-	PolicyAutoImport         http.Handler
-	DeletePolicyAutoImport   http.Handler
-	SubscribeForPolicyChange http.Handler
+	Mounts                    []*MountPoint
+	Evaluate                  http.Handler
+	EvaluateRule              http.Handler
+	Validate                  http.Handler
+	PartialEvaluate           http.Handler
+	EvaluateBatch             http.Handler
+	EvaluateBatchStream       http.Handler
+	EvaluationResult          http.Handler
+	JobStatus                 http.Handler
+	Lock                      http.Handler
+	Unlock                    http.Handler
+	LockHistory               http.Handler
+	PolicyTests               http.Handler
+	ExportBundle              http.Handler
+	PolicyPublicKey           http.Handler
+	ImportBundle              http.Handler
+	ListPolicies              http.Handler
+	DecisionLogs              http.Handler
+	AuditLog                  http.Handler
+	SetPolicyAutoImport       http.Handler
+	PolicyAutoImport          http.Handler
+	DeletePolicyAutoImport    http.Handler
+	SetPolicyAlias            http.Handler
+	PolicyAliases             http.Handler
+	DeletePolicyAlias         http.Handler
+	SetPolicyShadow           http.Handler
+	DeletePolicyShadow        http.Handler
+	SetPolicyCapture          http.Handler
+	DeletePolicyCapture       http.Handler
+	DeleteCapturedEvaluations http.Handler
+	Replay                    http.Handler
+	SubscribeForPolicyChange  http.Handler
 }
 
 // MountPoint holds information about the mounted endpoints.
@@ -65,32 +84,71 @@ func New(
 			{"Evaluate", "GET", "/policy/{repository}/{group}/{policyName}/{version}/evaluation/did.json"},
 			{"Evaluate", "GET", "/policy/{repository}/{group}/{policyName}/{version}/evaluation"},
 			{"Evaluate", "POST", "/policy/{repository}/{group}/{policyName}/{version}/evaluation"},
+			{"EvaluateRule", "GET", "/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}"},
+			{"EvaluateRule", "POST", "/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}"},
 			{"Validate", "GET", "/policy/{repository}/{group}/{policyName}/{version}/validation/did.json"},
 			{"Validate", "GET", "/policy/{repository}/{group}/{policyName}/{version}/validation"},
 			{"Validate", "POST", "/policy/{repository}/{group}/{policyName}/{version}/validation"},
+			{"PartialEvaluate", "POST", "/policy/{repository}/{group}/{policyName}/{version}/partial"},
+			{"EvaluateBatch", "POST", "/v1/evaluations/batch"},
+			{"EvaluateBatchStream", "POST", "/v1/evaluations/batch/stream"},
+			{"EvaluationResult", "GET", "/v1/evaluations/{evaluationID}"},
+			{"JobStatus", "GET", "/v1/jobs/{jobID}"},
 			{"Lock", "POST", "/policy/{repository}/{group}/{policyName}/{version}/lock"},
 			{"Unlock", "DELETE", "/policy/{repository}/{group}/{policyName}/{version}/lock"},
+			{"LockHistory", "GET", "/policy/{repository}/{group}/{policyName}/{version}/lock"},
+			{"PolicyTests", "GET", "/policy/{repository}/{group}/{policyName}/{version}/tests"},
 			{"ExportBundle", "GET", "/policy/{repository}/{group}/{policyName}/{version}/export"},
 			{"PolicyPublicKey", "GET", "/policy/{repository}/{group}/{policyName}/{version}/key"},
 			{"ImportBundle", "POST", "/v1/policy/import"},
 			{"ListPolicies", "GET", "/v1/policies"},
+			{"DecisionLogs", "GET", "/v1/decisions"},
+			{"AuditLog", "GET", "/v1/audit"},
 			{"SetPolicyAutoImport", "POST", "/v1/policy/import/config"},
 			{"PolicyAutoImport", "GET", "/v1/policy/import/config"},
 			{"DeletePolicyAutoImport", "DELETE", "/v1/policy/import/config"},
+			{"SetPolicyAlias", "PUT", "/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}"},
+			{"PolicyAliases", "GET", "/v1/policy/{repository}/{group}/{policyName}/aliases"},
+			{"DeletePolicyAlias", "DELETE", "/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}"},
+			{"SetPolicyShadow", "PUT", "/v1/policy/{repository}/{group}/{policyName}/{version}/shadow"},
+			{"DeletePolicyShadow", "DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/shadow"},
+			{"SetPolicyCapture", "PUT", "/v1/policy/{repository}/{group}/{policyName}/{version}/capture"},
+			{"DeletePolicyCapture", "DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/capture"},
+			{"DeleteCapturedEvaluations", "DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/captures"},
+			{"Replay", "POST", "/v1/policy/{repository}/{group}/{policyName}/{version}/replay"},
 			{"SubscribeForPolicyChange", "POST", "/policy/{repository}/{group}/{policyName}/{version}/notifychange"},
 		},
-		Evaluate:                 NewEvaluateHandler(e.Evaluate, mux, decoder, encoder, errhandler, formatter),
-		Validate:                 NewValidateHandler(e.Validate, mux, decoder, encoder, errhandler, formatter),
-		Lock:                     NewLockHandler(e.Lock, mux, decoder, encoder, errhandler, formatter),
-		Unlock:                   NewUnlockHandler(e.Unlock, mux, decoder, encoder, errhandler, formatter),
-		ExportBundle:             NewExportBundleHandler(e.ExportBundle, mux, decoder, encoder, errhandler, formatter),
-		PolicyPublicKey:          NewPolicyPublicKeyHandler(e.PolicyPublicKey, mux, decoder, encoder, errhandler, formatter),
-		ImportBundle:             NewImportBundleHandler(e.ImportBundle, mux, decoder, encoder, errhandler, formatter),
-		ListPolicies:             NewListPoliciesHandler(e.ListPolicies, mux, decoder, encoder, errhandler, formatter),
-		SetPolicyAutoImport:      NewSetPolicyAutoImportHandler(e.SetPolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
-		PolicyAutoImport:         NewPolicyAutoImportHandler(e.PolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
-		DeletePolicyAutoImport:   NewDeletePolicyAutoImportHandler(e.DeletePolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
-		SubscribeForPolicyChange: NewSubscribeForPolicyChangeHandler(e.SubscribeForPolicyChange, mux, decoder, encoder, errhandler, formatter),
+		Evaluate:                  NewEvaluateHandler(e.Evaluate, mux, decoder, encoder, errhandler, formatter),
+		EvaluateRule:              NewEvaluateRuleHandler(e.EvaluateRule, mux, decoder, encoder, errhandler, formatter),
+		Validate:                  NewValidateHandler(e.Validate, mux, decoder, encoder, errhandler, formatter),
+		PartialEvaluate:           NewPartialEvaluateHandler(e.PartialEvaluate, mux, decoder, encoder, errhandler, formatter),
+		EvaluateBatch:             NewEvaluateBatchHandler(e.EvaluateBatch, mux, decoder, encoder, errhandler, formatter),
+		EvaluateBatchStream:       NewEvaluateBatchStreamHandler(e.EvaluateBatchStream, mux, decoder, encoder, errhandler, formatter),
+		EvaluationResult:          NewEvaluationResultHandler(e.EvaluationResult, mux, decoder, encoder, errhandler, formatter),
+		JobStatus:                 NewJobStatusHandler(e.JobStatus, mux, decoder, encoder, errhandler, formatter),
+		Lock:                      NewLockHandler(e.Lock, mux, decoder, encoder, errhandler, formatter),
+		Unlock:                    NewUnlockHandler(e.Unlock, mux, decoder, encoder, errhandler, formatter),
+		LockHistory:               NewLockHistoryHandler(e.LockHistory, mux, decoder, encoder, errhandler, formatter),
+		PolicyTests:               NewPolicyTestsHandler(e.PolicyTests, mux, decoder, encoder, errhandler, formatter),
+		ExportBundle:              NewExportBundleHandler(e.ExportBundle, mux, decoder, encoder, errhandler, formatter),
+		PolicyPublicKey:           NewPolicyPublicKeyHandler(e.PolicyPublicKey, mux, decoder, encoder, errhandler, formatter),
+		ImportBundle:              NewImportBundleHandler(e.ImportBundle, mux, decoder, encoder, errhandler, formatter),
+		ListPolicies:              NewListPoliciesHandler(e.ListPolicies, mux, decoder, encoder, errhandler, formatter),
+		DecisionLogs:              NewDecisionLogsHandler(e.DecisionLogs, mux, decoder, encoder, errhandler, formatter),
+		AuditLog:                  NewAuditLogHandler(e.AuditLog, mux, decoder, encoder, errhandler, formatter),
+		SetPolicyAutoImport:       NewSetPolicyAutoImportHandler(e.SetPolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
+		PolicyAutoImport:          NewPolicyAutoImportHandler(e.PolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
+		DeletePolicyAutoImport:    NewDeletePolicyAutoImportHandler(e.DeletePolicyAutoImport, mux, decoder, encoder, errhandler, formatter),
+		SetPolicyAlias:            NewSetPolicyAliasHandler(e.SetPolicyAlias, mux, decoder, encoder, errhandler, formatter),
+		PolicyAliases:             NewPolicyAliasesHandler(e.PolicyAliases, mux, decoder, encoder, errhandler, formatter),
+		DeletePolicyAlias:         NewDeletePolicyAliasHandler(e.DeletePolicyAlias, mux, decoder, encoder, errhandler, formatter),
+		SetPolicyShadow:           NewSetPolicyShadowHandler(e.SetPolicyShadow, mux, decoder, encoder, errhandler, formatter),
+		DeletePolicyShadow:        NewDeletePolicyShadowHandler(e.DeletePolicyShadow, mux, decoder, encoder, errhandler, formatter),
+		SetPolicyCapture:          NewSetPolicyCaptureHandler(e.SetPolicyCapture, mux, decoder, encoder, errhandler, formatter),
+		DeletePolicyCapture:       NewDeletePolicyCaptureHandler(e.DeletePolicyCapture, mux, decoder, encoder, errhandler, formatter),
+		DeleteCapturedEvaluations: NewDeleteCapturedEvaluationsHandler(e.DeleteCapturedEvaluations, mux, decoder, encoder, errhandler, formatter),
+		Replay:                    NewReplayHandler(e.Replay, mux, decoder, encoder, errhandler, formatter),
+		SubscribeForPolicyChange:  NewSubscribeForPolicyChangeHandler(e.SubscribeForPolicyChange, mux, decoder, encoder, errhandler, formatter),
 	}
 }
 
@@ -100,16 +158,35 @@ func (s *Server) Service() string { return "policy" }
 // Use wraps the server handlers with the given middleware.
 func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.Evaluate = m(s.Evaluate)
+	s.EvaluateRule = m(s.EvaluateRule)
 	s.Validate = m(s.Validate)
+	s.PartialEvaluate = m(s.PartialEvaluate)
+	s.EvaluateBatch = m(s.EvaluateBatch)
+	s.EvaluateBatchStream = m(s.EvaluateBatchStream)
+	s.EvaluationResult = m(s.EvaluationResult)
+	s.JobStatus = m(s.JobStatus)
 	s.Lock = m(s.Lock)
 	s.Unlock = m(s.Unlock)
+	s.LockHistory = m(s.LockHistory)
+	s.PolicyTests = m(s.PolicyTests)
 	s.ExportBundle = m(s.ExportBundle)
 	s.PolicyPublicKey = m(s.PolicyPublicKey)
 	s.ImportBundle = m(s.ImportBundle)
 	s.ListPolicies = m(s.ListPolicies)
+	s.DecisionLogs = m(s.DecisionLogs)
+	s.AuditLog = m(s.AuditLog)
 	s.SetPolicyAutoImport = m(s.SetPolicyAutoImport)
 	s.PolicyAutoImport = m(s.PolicyAutoImport)
 	s.DeletePolicyAutoImport = m(s.DeletePolicyAutoImport)
+	s.SetPolicyAlias = m(s.SetPolicyAlias)
+	s.PolicyAliases = m(s.PolicyAliases)
+	s.DeletePolicyAlias = m(s.DeletePolicyAlias)
+	s.SetPolicyShadow = m(s.SetPolicyShadow)
+	s.DeletePolicyShadow = m(s.DeletePolicyShadow)
+	s.SetPolicyCapture = m(s.SetPolicyCapture)
+	s.DeletePolicyCapture = m(s.DeletePolicyCapture)
+	s.DeleteCapturedEvaluations = m(s.DeleteCapturedEvaluations)
+	s.Replay = m(s.Replay)
 	s.SubscribeForPolicyChange = m(s.SubscribeForPolicyChange)
 }
 
@@ -119,16 +196,35 @@ func (s *Server) MethodNames() []string { return policy.MethodNames[:] }
 // Mount configures the mux to serve the policy endpoints.
 func Mount(mux goahttp.Muxer, h *Server) {
 	MountEvaluateHandler(mux, h.Evaluate)
+	MountEvaluateRuleHandler(mux, h.EvaluateRule)
 	MountValidateHandler(mux, h.Validate)
+	MountPartialEvaluateHandler(mux, h.PartialEvaluate)
+	MountEvaluateBatchHandler(mux, h.EvaluateBatch)
+	MountEvaluateBatchStreamHandler(mux, h.EvaluateBatchStream)
+	MountEvaluationResultHandler(mux, h.EvaluationResult)
+	MountJobStatusHandler(mux, h.JobStatus)
 	MountLockHandler(mux, h.Lock)
 	MountUnlockHandler(mux, h.Unlock)
+	MountLockHistoryHandler(mux, h.LockHistory)
+	MountPolicyTestsHandler(mux, h.PolicyTests)
 	MountExportBundleHandler(mux, h.ExportBundle)
 	MountPolicyPublicKeyHandler(mux, h.PolicyPublicKey)
 	MountImportBundleHandler(mux, h.ImportBundle)
 	MountListPoliciesHandler(mux, h.ListPolicies)
+	MountDecisionLogsHandler(mux, h.DecisionLogs)
+	MountAuditLogHandler(mux, h.AuditLog)
 	MountSetPolicyAutoImportHandler(mux, h.SetPolicyAutoImport)
 	MountPolicyAutoImportHandler(mux, h.PolicyAutoImport)
 	MountDeletePolicyAutoImportHandler(mux, h.DeletePolicyAutoImport)
+	MountSetPolicyAliasHandler(mux, h.SetPolicyAlias)
+	MountPolicyAliasesHandler(mux, h.PolicyAliases)
+	MountDeletePolicyAliasHandler(mux, h.DeletePolicyAlias)
+	MountSetPolicyShadowHandler(mux, h.SetPolicyShadow)
+	MountDeletePolicyShadowHandler(mux, h.DeletePolicyShadow)
+	MountSetPolicyCaptureHandler(mux, h.SetPolicyCapture)
+	MountDeletePolicyCaptureHandler(mux, h.DeletePolicyCapture)
+	MountDeleteCapturedEvaluationsHandler(mux, h.DeleteCapturedEvaluations)
+	MountReplayHandler(mux, h.Replay)
 	MountSubscribeForPolicyChangeHandler(mux, h.SubscribeForPolicyChange)
 }
 
@@ -190,6 +286,58 @@ func NewEvaluateHandler(
 	})
 }
 
+// MountEvaluateRuleHandler configures the mux to serve the "policy" service
+// "EvaluateRule" endpoint.
+func MountEvaluateRuleHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}", f)
+	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}/evaluation/{*rule}", f)
+}
+
+// NewEvaluateRuleHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "EvaluateRule" endpoint.
+func NewEvaluateRuleHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeEvaluateRuleRequest(mux, decoder)
+		encodeResponse = EncodeEvaluateRuleResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "EvaluateRule")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountValidateHandler configures the mux to serve the "policy" service
 // "Validate" endpoint.
 func MountValidateHandler(mux goahttp.Muxer, h http.Handler) {
@@ -243,21 +391,21 @@ func NewValidateHandler(
 	})
 }
 
-// MountLockHandler configures the mux to serve the "policy" service "Lock"
-// endpoint.
-func MountLockHandler(mux goahttp.Muxer, h http.Handler) {
+// MountPartialEvaluateHandler configures the mux to serve the "policy" service
+// "PartialEvaluate" endpoint.
+func MountPartialEvaluateHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}/lock", f)
+	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}/partial", f)
 }
 
-// NewLockHandler creates a HTTP handler which loads the HTTP request and calls
-// the "policy" service "Lock" endpoint.
-func NewLockHandler(
+// NewPartialEvaluateHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "PartialEvaluate" endpoint.
+func NewPartialEvaluateHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -266,13 +414,13 @@ func NewLockHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeLockRequest(mux, decoder)
-		encodeResponse = EncodeLockResponse(encoder)
+		decodeRequest  = DecodePartialEvaluateRequest(mux, decoder)
+		encodeResponse = EncodePartialEvaluateResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "Lock")
+		ctx = context.WithValue(ctx, goa.MethodKey, "PartialEvaluate")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -294,21 +442,21 @@ func NewLockHandler(
 	})
 }
 
-// MountUnlockHandler configures the mux to serve the "policy" service "Unlock"
-// endpoint.
-func MountUnlockHandler(mux goahttp.Muxer, h http.Handler) {
+// MountEvaluateBatchHandler configures the mux to serve the "policy" service
+// "EvaluateBatch" endpoint.
+func MountEvaluateBatchHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("DELETE", "/policy/{repository}/{group}/{policyName}/{version}/lock", f)
+	mux.Handle("POST", "/v1/evaluations/batch", f)
 }
 
-// NewUnlockHandler creates a HTTP handler which loads the HTTP request and
-// calls the "policy" service "Unlock" endpoint.
-func NewUnlockHandler(
+// NewEvaluateBatchHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "EvaluateBatch" endpoint.
+func NewEvaluateBatchHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -317,13 +465,13 @@ func NewUnlockHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeUnlockRequest(mux, decoder)
-		encodeResponse = EncodeUnlockResponse(encoder)
+		decodeRequest  = DecodeEvaluateBatchRequest(mux, decoder)
+		encodeResponse = EncodeEvaluateBatchResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "Unlock")
+		ctx = context.WithValue(ctx, goa.MethodKey, "EvaluateBatch")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -345,21 +493,21 @@ func NewUnlockHandler(
 	})
 }
 
-// MountExportBundleHandler configures the mux to serve the "policy" service
-// "ExportBundle" endpoint.
-func MountExportBundleHandler(mux goahttp.Muxer, h http.Handler) {
+// MountEvaluateBatchStreamHandler configures the mux to serve the "policy"
+// service "EvaluateBatchStream" endpoint.
+func MountEvaluateBatchStreamHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/export", f)
+	mux.Handle("POST", "/v1/evaluations/batch/stream", f)
 }
 
-// NewExportBundleHandler creates a HTTP handler which loads the HTTP request
-// and calls the "policy" service "ExportBundle" endpoint.
-func NewExportBundleHandler(
+// NewEvaluateBatchStreamHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "EvaluateBatchStream" endpoint.
+func NewEvaluateBatchStreamHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -368,29 +516,23 @@ func NewExportBundleHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeExportBundleRequest(mux, decoder)
-		encodeResponse = EncodeExportBundleResponse(encoder)
+		encodeResponse = EncodeEvaluateBatchStreamResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "ExportBundle")
+		ctx = context.WithValue(ctx, goa.MethodKey, "EvaluateBatchStream")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
-		payload, err := decodeRequest(r)
-		if err != nil {
-			if err := encodeError(ctx, w, err); err != nil {
-				errhandler(ctx, w, err)
-			}
-			return
-		}
-		res, err := endpoint(ctx, payload)
+		var err error
+		data := &policy.EvaluateBatchStreamRequestData{Body: r.Body}
+		res, err := endpoint(ctx, data)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
-		o := res.(*policy.ExportBundleResponseData)
+		o := res.(*policy.EvaluateBatchStreamResponseData)
 		defer o.Body.Close()
 		if wt, ok := o.Body.(io.WriterTo); ok {
 			if err := encodeResponse(ctx, w, o.Result); err != nil {
@@ -433,21 +575,21 @@ func NewExportBundleHandler(
 	})
 }
 
-// MountPolicyPublicKeyHandler configures the mux to serve the "policy" service
-// "PolicyPublicKey" endpoint.
-func MountPolicyPublicKeyHandler(mux goahttp.Muxer, h http.Handler) {
+// MountEvaluationResultHandler configures the mux to serve the "policy"
+// service "EvaluationResult" endpoint.
+func MountEvaluationResultHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/key", f)
+	mux.Handle("GET", "/v1/evaluations/{evaluationID}", f)
 }
 
-// NewPolicyPublicKeyHandler creates a HTTP handler which loads the HTTP
-// request and calls the "policy" service "PolicyPublicKey" endpoint.
-func NewPolicyPublicKeyHandler(
+// NewEvaluationResultHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "EvaluationResult" endpoint.
+func NewEvaluationResultHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -456,13 +598,13 @@ func NewPolicyPublicKeyHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodePolicyPublicKeyRequest(mux, decoder)
-		encodeResponse = EncodePolicyPublicKeyResponse(encoder)
+		decodeRequest  = DecodeEvaluationResultRequest(mux, decoder)
+		encodeResponse = EncodeEvaluationResultResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyPublicKey")
+		ctx = context.WithValue(ctx, goa.MethodKey, "EvaluationResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -484,21 +626,21 @@ func NewPolicyPublicKeyHandler(
 	})
 }
 
-// MountImportBundleHandler configures the mux to serve the "policy" service
-// "ImportBundle" endpoint.
-func MountImportBundleHandler(mux goahttp.Muxer, h http.Handler) {
+// MountJobStatusHandler configures the mux to serve the "policy" service
+// "JobStatus" endpoint.
+func MountJobStatusHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("POST", "/v1/policy/import", f)
+	mux.Handle("GET", "/v1/jobs/{jobID}", f)
 }
 
-// NewImportBundleHandler creates a HTTP handler which loads the HTTP request
-// and calls the "policy" service "ImportBundle" endpoint.
-func NewImportBundleHandler(
+// NewJobStatusHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "JobStatus" endpoint.
+func NewJobStatusHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -507,13 +649,13 @@ func NewImportBundleHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeImportBundleRequest(mux, decoder)
-		encodeResponse = EncodeImportBundleResponse(encoder)
+		decodeRequest  = DecodeJobStatusRequest(mux, decoder)
+		encodeResponse = EncodeJobStatusResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "ImportBundle")
+		ctx = context.WithValue(ctx, goa.MethodKey, "JobStatus")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -522,8 +664,7 @@ func NewImportBundleHandler(
 			}
 			return
 		}
-		data := &policy.ImportBundleRequestData{Payload: payload.(*policy.ImportBundlePayload), Body: r.Body}
-		res, err := endpoint(ctx, data)
+		res, err := endpoint(ctx, payload)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -536,21 +677,21 @@ func NewImportBundleHandler(
 	})
 }
 
-// MountListPoliciesHandler configures the mux to serve the "policy" service
-// "ListPolicies" endpoint.
-func MountListPoliciesHandler(mux goahttp.Muxer, h http.Handler) {
+// MountLockHandler configures the mux to serve the "policy" service "Lock"
+// endpoint.
+func MountLockHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("GET", "/v1/policies", f)
+	mux.Handle("POST", "/policy/{repository}/{group}/{policyName}/{version}/lock", f)
 }
 
-// NewListPoliciesHandler creates a HTTP handler which loads the HTTP request
-// and calls the "policy" service "ListPolicies" endpoint.
-func NewListPoliciesHandler(
+// NewLockHandler creates a HTTP handler which loads the HTTP request and calls
+// the "policy" service "Lock" endpoint.
+func NewLockHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -559,13 +700,13 @@ func NewListPoliciesHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeListPoliciesRequest(mux, decoder)
-		encodeResponse = EncodeListPoliciesResponse(encoder)
+		decodeRequest  = DecodeLockRequest(mux, decoder)
+		encodeResponse = EncodeLockResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "ListPolicies")
+		ctx = context.WithValue(ctx, goa.MethodKey, "Lock")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -587,21 +728,21 @@ func NewListPoliciesHandler(
 	})
 }
 
-// MountSetPolicyAutoImportHandler configures the mux to serve the "policy"
-// service "SetPolicyAutoImport" endpoint.
-func MountSetPolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+// MountUnlockHandler configures the mux to serve the "policy" service "Unlock"
+// endpoint.
+func MountUnlockHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("POST", "/v1/policy/import/config", f)
+	mux.Handle("DELETE", "/policy/{repository}/{group}/{policyName}/{version}/lock", f)
 }
 
-// NewSetPolicyAutoImportHandler creates a HTTP handler which loads the HTTP
-// request and calls the "policy" service "SetPolicyAutoImport" endpoint.
-func NewSetPolicyAutoImportHandler(
+// NewUnlockHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "Unlock" endpoint.
+func NewUnlockHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -610,13 +751,13 @@ func NewSetPolicyAutoImportHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeSetPolicyAutoImportRequest(mux, decoder)
-		encodeResponse = EncodeSetPolicyAutoImportResponse(encoder)
+		decodeRequest  = DecodeUnlockRequest(mux, decoder)
+		encodeResponse = EncodeUnlockResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "SetPolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.MethodKey, "Unlock")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
@@ -638,21 +779,21 @@ func NewSetPolicyAutoImportHandler(
 	})
 }
 
-// MountPolicyAutoImportHandler configures the mux to serve the "policy"
-// service "PolicyAutoImport" endpoint.
-func MountPolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+// MountLockHistoryHandler configures the mux to serve the "policy" service
+// "LockHistory" endpoint.
+func MountLockHistoryHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("GET", "/v1/policy/import/config", f)
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/lock", f)
 }
 
-// NewPolicyAutoImportHandler creates a HTTP handler which loads the HTTP
-// request and calls the "policy" service "PolicyAutoImport" endpoint.
-func NewPolicyAutoImportHandler(
+// NewLockHistoryHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "LockHistory" endpoint.
+func NewLockHistoryHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -661,15 +802,22 @@ func NewPolicyAutoImportHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		encodeResponse = EncodePolicyAutoImportResponse(encoder)
+		decodeRequest  = DecodeLockHistoryRequest(mux, decoder)
+		encodeResponse = EncodeLockHistoryResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.MethodKey, "LockHistory")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
-		var err error
-		res, err := endpoint(ctx, nil)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -682,21 +830,21 @@ func NewPolicyAutoImportHandler(
 	})
 }
 
-// MountDeletePolicyAutoImportHandler configures the mux to serve the "policy"
-// service "DeletePolicyAutoImport" endpoint.
-func MountDeletePolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+// MountPolicyTestsHandler configures the mux to serve the "policy" service
+// "PolicyTests" endpoint.
+func MountPolicyTestsHandler(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	mux.Handle("DELETE", "/v1/policy/import/config", f)
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/tests", f)
 }
 
-// NewDeletePolicyAutoImportHandler creates a HTTP handler which loads the HTTP
-// request and calls the "policy" service "DeletePolicyAutoImport" endpoint.
-func NewDeletePolicyAutoImportHandler(
+// NewPolicyTestsHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "PolicyTests" endpoint.
+func NewPolicyTestsHandler(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
 	decoder func(*http.Request) goahttp.Decoder,
@@ -705,13 +853,963 @@ func NewDeletePolicyAutoImportHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) http.Handler {
 	var (
-		decodeRequest  = DecodeDeletePolicyAutoImportRequest(mux, decoder)
-		encodeResponse = EncodeDeletePolicyAutoImportResponse(encoder)
+		decodeRequest  = DecodePolicyTestsRequest(mux, decoder)
+		encodeResponse = EncodePolicyTestsResponse(encoder)
 		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
-		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyTests")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountExportBundleHandler configures the mux to serve the "policy" service
+// "ExportBundle" endpoint.
+func MountExportBundleHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/export", f)
+}
+
+// NewExportBundleHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "ExportBundle" endpoint.
+func NewExportBundleHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeExportBundleRequest(mux, decoder)
+		encodeResponse = EncodeExportBundleResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ExportBundle")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		o := res.(*policy.ExportBundleResponseData)
+		defer o.Body.Close()
+		if wt, ok := o.Body.(io.WriterTo); ok {
+			if err := encodeResponse(ctx, w, o.Result); err != nil {
+				errhandler(ctx, w, err)
+				return
+			}
+			n, err := wt.WriteTo(w)
+			if err != nil {
+				if n == 0 {
+					if err := encodeError(ctx, w, err); err != nil {
+						errhandler(ctx, w, err)
+					}
+				} else {
+					if f, ok := w.(http.Flusher); ok {
+						f.Flush()
+					}
+					panic(http.ErrAbortHandler) // too late to write an error
+				}
+			}
+			return
+		}
+		// handle immediate read error like a returned error
+		buf := bufio.NewReader(o.Body)
+		if _, err := buf.Peek(1); err != nil && err != io.EOF {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, o.Result); err != nil {
+			errhandler(ctx, w, err)
+			return
+		}
+		if _, err := io.Copy(w, buf); err != nil {
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			panic(http.ErrAbortHandler) // too late to write an error
+		}
+	})
+}
+
+// MountPolicyPublicKeyHandler configures the mux to serve the "policy" service
+// "PolicyPublicKey" endpoint.
+func MountPolicyPublicKeyHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/policy/{repository}/{group}/{policyName}/{version}/key", f)
+}
+
+// NewPolicyPublicKeyHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "PolicyPublicKey" endpoint.
+func NewPolicyPublicKeyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePolicyPublicKeyRequest(mux, decoder)
+		encodeResponse = EncodePolicyPublicKeyResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyPublicKey")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountImportBundleHandler configures the mux to serve the "policy" service
+// "ImportBundle" endpoint.
+func MountImportBundleHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/policy/import", f)
+}
+
+// NewImportBundleHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "ImportBundle" endpoint.
+func NewImportBundleHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeImportBundleRequest(mux, decoder)
+		encodeResponse = EncodeImportBundleResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ImportBundle")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		data := &policy.ImportBundleRequestData{Payload: payload.(*policy.ImportBundlePayload), Body: r.Body}
+		res, err := endpoint(ctx, data)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountListPoliciesHandler configures the mux to serve the "policy" service
+// "ListPolicies" endpoint.
+func MountListPoliciesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/policies", f)
+}
+
+// NewListPoliciesHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "ListPolicies" endpoint.
+func NewListPoliciesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListPoliciesRequest(mux, decoder)
+		encodeResponse = EncodeListPoliciesResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ListPolicies")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDecisionLogsHandler configures the mux to serve the "policy" service
+// "DecisionLogs" endpoint.
+func MountDecisionLogsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/decisions", f)
+}
+
+// NewDecisionLogsHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "DecisionLogs" endpoint.
+func NewDecisionLogsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDecisionLogsRequest(mux, decoder)
+		encodeResponse = EncodeDecisionLogsResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DecisionLogs")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountAuditLogHandler configures the mux to serve the "policy" service
+// "AuditLog" endpoint.
+func MountAuditLogHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/audit", f)
+}
+
+// NewAuditLogHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "AuditLog" endpoint.
+func NewAuditLogHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeAuditLogRequest(mux, decoder)
+		encodeResponse = EncodeAuditLogResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "AuditLog")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountSetPolicyAutoImportHandler configures the mux to serve the "policy"
+// service "SetPolicyAutoImport" endpoint.
+func MountSetPolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/policy/import/config", f)
+}
+
+// NewSetPolicyAutoImportHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "SetPolicyAutoImport" endpoint.
+func NewSetPolicyAutoImportHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSetPolicyAutoImportRequest(mux, decoder)
+		encodeResponse = EncodeSetPolicyAutoImportResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SetPolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountPolicyAutoImportHandler configures the mux to serve the "policy"
+// service "PolicyAutoImport" endpoint.
+func MountPolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/policy/import/config", f)
+}
+
+// NewPolicyAutoImportHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "PolicyAutoImport" endpoint.
+func NewPolicyAutoImportHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeResponse = EncodePolicyAutoImportResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		var err error
+		res, err := endpoint(ctx, nil)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeletePolicyAutoImportHandler configures the mux to serve the "policy"
+// service "DeletePolicyAutoImport" endpoint.
+func MountDeletePolicyAutoImportHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/policy/import/config", f)
+}
+
+// NewDeletePolicyAutoImportHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "DeletePolicyAutoImport" endpoint.
+func NewDeletePolicyAutoImportHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeletePolicyAutoImportRequest(mux, decoder)
+		encodeResponse = EncodeDeletePolicyAutoImportResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicyAutoImport")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountSetPolicyAliasHandler configures the mux to serve the "policy" service
+// "SetPolicyAlias" endpoint.
+func MountSetPolicyAliasHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}", f)
+}
+
+// NewSetPolicyAliasHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "SetPolicyAlias" endpoint.
+func NewSetPolicyAliasHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSetPolicyAliasRequest(mux, decoder)
+		encodeResponse = EncodeSetPolicyAliasResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SetPolicyAlias")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountPolicyAliasesHandler configures the mux to serve the "policy" service
+// "PolicyAliases" endpoint.
+func MountPolicyAliasesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/policy/{repository}/{group}/{policyName}/aliases", f)
+}
+
+// NewPolicyAliasesHandler creates a HTTP handler which loads the HTTP request
+// and calls the "policy" service "PolicyAliases" endpoint.
+func NewPolicyAliasesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePolicyAliasesRequest(mux, decoder)
+		encodeResponse = EncodePolicyAliasesResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PolicyAliases")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeletePolicyAliasHandler configures the mux to serve the "policy"
+// service "DeletePolicyAlias" endpoint.
+func MountDeletePolicyAliasHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/policy/{repository}/{group}/{policyName}/aliases/{alias}", f)
+}
+
+// NewDeletePolicyAliasHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "DeletePolicyAlias" endpoint.
+func NewDeletePolicyAliasHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeletePolicyAliasRequest(mux, decoder)
+		encodeResponse = EncodeDeletePolicyAliasResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicyAlias")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountSetPolicyShadowHandler configures the mux to serve the "policy" service
+// "SetPolicyShadow" endpoint.
+func MountSetPolicyShadowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/v1/policy/{repository}/{group}/{policyName}/{version}/shadow", f)
+}
+
+// NewSetPolicyShadowHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "SetPolicyShadow" endpoint.
+func NewSetPolicyShadowHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSetPolicyShadowRequest(mux, decoder)
+		encodeResponse = EncodeSetPolicyShadowResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SetPolicyShadow")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeletePolicyShadowHandler configures the mux to serve the "policy"
+// service "DeletePolicyShadow" endpoint.
+func MountDeletePolicyShadowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/shadow", f)
+}
+
+// NewDeletePolicyShadowHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "DeletePolicyShadow" endpoint.
+func NewDeletePolicyShadowHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeletePolicyShadowRequest(mux, decoder)
+		encodeResponse = EncodeDeletePolicyShadowResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicyShadow")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountSetPolicyCaptureHandler configures the mux to serve the "policy"
+// service "SetPolicyCapture" endpoint.
+func MountSetPolicyCaptureHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/v1/policy/{repository}/{group}/{policyName}/{version}/capture", f)
+}
+
+// NewSetPolicyCaptureHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "SetPolicyCapture" endpoint.
+func NewSetPolicyCaptureHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSetPolicyCaptureRequest(mux, decoder)
+		encodeResponse = EncodeSetPolicyCaptureResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SetPolicyCapture")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeletePolicyCaptureHandler configures the mux to serve the "policy"
+// service "DeletePolicyCapture" endpoint.
+func MountDeletePolicyCaptureHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/capture", f)
+}
+
+// NewDeletePolicyCaptureHandler creates a HTTP handler which loads the HTTP
+// request and calls the "policy" service "DeletePolicyCapture" endpoint.
+func NewDeletePolicyCaptureHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeletePolicyCaptureRequest(mux, decoder)
+		encodeResponse = EncodeDeletePolicyCaptureResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeletePolicyCapture")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountDeleteCapturedEvaluationsHandler configures the mux to serve the
+// "policy" service "DeleteCapturedEvaluations" endpoint.
+func MountDeleteCapturedEvaluationsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/policy/{repository}/{group}/{policyName}/{version}/captures", f)
+}
+
+// NewDeleteCapturedEvaluationsHandler creates a HTTP handler which loads the
+// HTTP request and calls the "policy" service "DeleteCapturedEvaluations"
+// endpoint.
+func NewDeleteCapturedEvaluationsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeleteCapturedEvaluationsRequest(mux, decoder)
+		encodeResponse = EncodeDeleteCapturedEvaluationsResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeleteCapturedEvaluations")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountReplayHandler configures the mux to serve the "policy" service "Replay"
+// endpoint.
+func MountReplayHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/policy/{repository}/{group}/{policyName}/{version}/replay", f)
+}
+
+// NewReplayHandler creates a HTTP handler which loads the HTTP request and
+// calls the "policy" service "Replay" endpoint.
+func NewReplayHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeReplayRequest(mux, decoder)
+		encodeResponse = EncodeReplayResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Replay")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
 		payload, err := decodeRequest(r)
 		if err != nil {
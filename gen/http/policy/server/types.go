@@ -14,6 +14,43 @@ import (
 	goa "goa.design/goa/v3/pkg"
 )
 
+// PartialEvaluateRequestBody is the type of the "policy" service
+// "PartialEvaluate" endpoint HTTP request body.
+type PartialEvaluateRequestBody struct {
+	// Name of the boolean policy rule which is evaluated.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// References which are treated as unknown during evaluation.
+	Unknowns []string `form:"unknowns,omitempty" json:"unknowns,omitempty" xml:"unknowns,omitempty"`
+	// Known input data passed to the policy execution runtime.
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Target representation of the residual queries. If 'mongo' is given, the
+	// queries are also translated to a MongoDB filter document.
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+}
+
+// EvaluateBatchRequestBody is the type of the "policy" service "EvaluateBatch"
+// endpoint HTTP request body.
+type EvaluateBatchRequestBody struct {
+	// Policy evaluations to execute.
+	Items []*BatchEvaluateItemRequestBody `form:"items,omitempty" json:"items,omitempty" xml:"items,omitempty"`
+}
+
+// LockRequestBody is the type of the "policy" service "Lock" endpoint HTTP
+// request body.
+type LockRequestBody struct {
+	// Reason for locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64 `form:"unlockAt,omitempty" json:"unlockAt,omitempty" xml:"unlockAt,omitempty"`
+}
+
+// UnlockRequestBody is the type of the "policy" service "Unlock" endpoint HTTP
+// request body.
+type UnlockRequestBody struct {
+	// Reason for unlocking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+}
+
 // SetPolicyAutoImportRequestBody is the type of the "policy" service
 // "SetPolicyAutoImport" endpoint HTTP request body.
 type SetPolicyAutoImportRequestBody struct {
@@ -30,6 +67,45 @@ type DeletePolicyAutoImportRequestBody struct {
 	PolicyURL *string `form:"policyURL,omitempty" json:"policyURL,omitempty" xml:"policyURL,omitempty"`
 }
 
+// SetPolicyAliasRequestBody is the type of the "policy" service
+// "SetPolicyAlias" endpoint HTTP request body.
+type SetPolicyAliasRequestBody struct {
+	// Policy version referenced by the alias.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// SetPolicyShadowRequestBody is the type of the "policy" service
+// "SetPolicyShadow" endpoint HTTP request body.
+type SetPolicyShadowRequestBody struct {
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion *string `form:"shadowVersion,omitempty" json:"shadowVersion,omitempty" xml:"shadowVersion,omitempty"`
+}
+
+// SetPolicyCaptureRequestBody is the type of the "policy" service
+// "SetPolicyCapture" endpoint HTTP request body.
+type SetPolicyCaptureRequestBody struct {
+	// Fraction of evaluations which are recorded.
+	SampleRate *float64 `form:"sampleRate,omitempty" json:"sampleRate,omitempty" xml:"sampleRate,omitempty"`
+	// Maximum number of recorded evaluations of the policy version.
+	MaxRecords *int `form:"maxRecords,omitempty" json:"maxRecords,omitempty" xml:"maxRecords,omitempty"`
+	// Dot separated paths of input fields whose values are not recorded.
+	RedactFields []string `form:"redactFields,omitempty" json:"redactFields,omitempty" xml:"redactFields,omitempty"`
+	// Names of request headers whose values are not recorded.
+	RedactHeaders []string `form:"redactHeaders,omitempty" json:"redactHeaders,omitempty" xml:"redactHeaders,omitempty"`
+}
+
+// ReplayRequestBody is the type of the "policy" service "Replay" endpoint HTTP
+// request body.
+type ReplayRequestBody struct {
+	// Policy version which evaluates the recorded inputs.
+	CandidateVersion *string `form:"candidateVersion,omitempty" json:"candidateVersion,omitempty" xml:"candidateVersion,omitempty"`
+	// Source code of the main policy module, which replaces the module of the
+	// recorded version.
+	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
+	// Maximum number of replayed evaluations, newest first.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty" xml:"limit,omitempty"`
+}
+
 // SubscribeForPolicyChangeRequestBody is the type of the "policy" service
 // "SubscribeForPolicyChange" endpoint HTTP request body.
 type SubscribeForPolicyChangeRequestBody struct {
@@ -39,6 +115,108 @@ type SubscribeForPolicyChangeRequestBody struct {
 	Subscriber *string `form:"subscriber,omitempty" json:"subscriber,omitempty" xml:"subscriber,omitempty"`
 }
 
+// EvaluateAcceptedResponseBody is the type of the "policy" service "Evaluate"
+// endpoint HTTP response body.
+type EvaluateAcceptedResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID *string `form:"jobID,omitempty" json:"jobID,omitempty" xml:"jobID,omitempty"`
+	// Status of the asynchronous evaluation job.
+	JobStatus *string `form:"jobStatus,omitempty" json:"jobStatus,omitempty" xml:"jobStatus,omitempty"`
+}
+
+// EvaluateRuleAcceptedResponseBody is the type of the "policy" service
+// "EvaluateRule" endpoint HTTP response body.
+type EvaluateRuleAcceptedResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID *string `form:"jobID,omitempty" json:"jobID,omitempty" xml:"jobID,omitempty"`
+	// Status of the asynchronous evaluation job.
+	JobStatus *string `form:"jobStatus,omitempty" json:"jobStatus,omitempty" xml:"jobStatus,omitempty"`
+}
+
+// PartialEvaluateResponseBody is the type of the "policy" service
+// "PartialEvaluate" endpoint HTTP response body.
+type PartialEvaluateResponseBody struct {
+	// Residual queries. The rule is true if any of the queries is true. No queries
+	// mean that the rule is never true.
+	Queries []string `form:"queries" json:"queries" xml:"queries"`
+	// Support modules generated during partial evaluation.
+	Support []string `form:"support,omitempty" json:"support,omitempty" xml:"support,omitempty"`
+	// MongoDB filter document equivalent to the residual queries.
+	Filter any `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Policy version which was evaluated, after resolving aliases, 'latest',
+	// 'stable' and semver ranges.
+	Version string `form:"version" json:"version" xml:"version"`
+}
+
+// EvaluateBatchResponseBody is the type of the "policy" service
+// "EvaluateBatch" endpoint HTTP response body.
+type EvaluateBatchResponseBody struct {
+	// Evaluation results in the same order as the request items.
+	Results []*BatchEvaluateItemResultResponseBody `form:"results" json:"results" xml:"results"`
+}
+
+// JobStatusResponseBody is the type of the "policy" service "JobStatus"
+// endpoint HTTP response body.
+type JobStatusResponseBody struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID string `form:"jobID" json:"jobID" xml:"jobID"`
+	// Identifier of the policy evaluation, which can be used to retrieve the
+	// result.
+	ETag string `form:"ETag" json:"ETag" xml:"ETag"`
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Path of the evaluated rule.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Job status.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Error message if the evaluation failed.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// URL receiving the evaluation result.
+	CallbackURL *string `form:"callbackURL,omitempty" json:"callbackURL,omitempty" xml:"callbackURL,omitempty"`
+	// Error message if the result couldn't be delivered to the callback URL.
+	CallbackError *string `form:"callbackError,omitempty" json:"callbackError,omitempty" xml:"callbackError,omitempty"`
+	// Creation time of the job as Unix timestamp.
+	CreatedAt int64 `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Last update of the job as Unix timestamp.
+	UpdatedAt int64 `form:"updatedAt" json:"updatedAt" xml:"updatedAt"`
+}
+
+// LockHistoryResponseBody is the type of the "policy" service "LockHistory"
+// endpoint HTTP response body.
+type LockHistoryResponseBody struct {
+	// Locked specifies if the policy is locked.
+	Locked bool `form:"locked" json:"locked" xml:"locked"`
+	// Current lock of a locked policy.
+	Lock *PolicyLockEventResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+	// Previous lock and unlock events, oldest first.
+	History []*PolicyLockEventResponseBody `form:"history" json:"history" xml:"history"`
+}
+
+// PolicyTestsResponseBody is the type of the "policy" service "PolicyTests"
+// endpoint HTTP response body.
+type PolicyTestsResponseBody struct {
+	// All tests of the policy passed.
+	Passed bool `form:"passed" json:"passed" xml:"passed"`
+	// Error which prevented the tests from running.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Number of executed tests.
+	Total int `form:"total" json:"total" xml:"total"`
+	// Number of failed tests.
+	Failed int `form:"failed" json:"failed" xml:"failed"`
+	// Percentage of the policy module lines evaluated by the tests.
+	Coverage float64 `form:"coverage" json:"coverage" xml:"coverage"`
+	// Results of the test rules and golden test cases.
+	Results []*PolicyTestResultResponseBody `form:"results" json:"results" xml:"results"`
+	// Time of the test run (Unix timestamp).
+	RunAt int64 `form:"runAt" json:"runAt" xml:"runAt"`
+}
+
 // ListPoliciesResponseBody is the type of the "policy" service "ListPolicies"
 // endpoint HTTP response body.
 type ListPoliciesResponseBody struct {
@@ -46,6 +224,119 @@ type ListPoliciesResponseBody struct {
 	Policies []*PolicyResponseBody `form:"policies" json:"policies" xml:"policies"`
 }
 
+// DecisionLogsResponseBody is the type of the "policy" service "DecisionLogs"
+// endpoint HTTP response body.
+type DecisionLogsResponseBody struct {
+	// JSON array of decisions.
+	Decisions []*DecisionResponseBody `form:"decisions" json:"decisions" xml:"decisions"`
+}
+
+// AuditLogResponseBody is the type of the "policy" service "AuditLog" endpoint
+// HTTP response body.
+type AuditLogResponseBody struct {
+	// JSON array of audit records.
+	Records []*AuditRecordResponseBody `form:"records" json:"records" xml:"records"`
+}
+
+// SetPolicyAliasResponseBody is the type of the "policy" service
+// "SetPolicyAlias" endpoint HTTP response body.
+type SetPolicyAliasResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Alias name.
+	Alias string `form:"alias" json:"alias" xml:"alias"`
+	// Policy version referenced by the alias.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Last update (Unix timestamp).
+	LastUpdate int64 `form:"lastUpdate" json:"lastUpdate" xml:"lastUpdate"`
+}
+
+// PolicyAliasesResponseBody is the type of the "policy" service
+// "PolicyAliases" endpoint HTTP response body.
+type PolicyAliasesResponseBody struct {
+	// Named aliases of the policy.
+	Aliases []*PolicyAliasResponseBody `form:"aliases" json:"aliases" xml:"aliases"`
+}
+
+// ReplayResponseBody is the type of the "policy" service "Replay" endpoint
+// HTTP response body.
+type ReplayResponseBody struct {
+	// Replayed policy version or 'rego' for an uploaded policy source.
+	Candidate string `form:"candidate" json:"candidate" xml:"candidate"`
+	// Number of replayed evaluations.
+	Total int `form:"total" json:"total" xml:"total"`
+	// Number of evaluations with the same result or error.
+	Unchanged int `form:"unchanged" json:"unchanged" xml:"unchanged"`
+	// Number of evaluations with a different result.
+	Changed int `form:"changed" json:"changed" xml:"changed"`
+	// Number of evaluations which fail only with the candidate.
+	NewErrors int `form:"newErrors" json:"newErrors" xml:"newErrors"`
+	// Number of evaluations which fail only with the recorded version.
+	FixedErrors int `form:"fixedErrors" json:"fixedErrors" xml:"fixedErrors"`
+	// Average difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	DurationDelta float64 `form:"durationDelta" json:"durationDelta" xml:"durationDelta"`
+	// Maximum difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	MaxDurationDelta float64 `form:"maxDurationDelta" json:"maxDurationDelta" xml:"maxDurationDelta"`
+	// Evaluations whose result or error differs.
+	Diffs []*ReplayDiffResponseBody `form:"diffs" json:"diffs" xml:"diffs"`
+}
+
+// BatchEvaluateItemResultResponseBody is used to define fields on response
+// body types.
+type BatchEvaluateItemResultResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Arbitrary JSON response.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// ETag contains unique identifier of the policy evaluation and can be used to
+	// later retrieve the results from Cache.
+	ETag *string `form:"ETag,omitempty" json:"ETag,omitempty" xml:"ETag,omitempty"`
+	// Error message if the policy evaluation failed.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// PolicyLockEventResponseBody is used to define fields on response body types.
+type PolicyLockEventResponseBody struct {
+	// True for a lock and false for an unlock.
+	Locked bool `form:"locked" json:"locked" xml:"locked"`
+	// Reason for (un)locking the policy.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Subject of the caller who (un)locked the policy.
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Source of the event.
+	Source string `form:"source" json:"source" xml:"source"`
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64 `form:"unlockAt,omitempty" json:"unlockAt,omitempty" xml:"unlockAt,omitempty"`
+	// Time of the event (Unix timestamp).
+	Timestamp int64 `form:"timestamp" json:"timestamp" xml:"timestamp"`
+}
+
+// PolicyTestResultResponseBody is used to define fields on response body types.
+type PolicyTestResultResponseBody struct {
+	// Name of the test rule or golden test case.
+	Name string `form:"name" json:"name" xml:"name"`
+	// Location of the test.
+	Location string `form:"location" json:"location" xml:"location"`
+	// The test passed.
+	Passed bool `form:"passed" json:"passed" xml:"passed"`
+	// Error or failure message of the test.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Test duration in milliseconds.
+	Duration int64 `form:"duration" json:"duration" xml:"duration"`
+}
+
 // PolicyResponseBody is used to define fields on response body types.
 type PolicyResponseBody struct {
 	// Policy repository.
@@ -56,7 +347,7 @@ type PolicyResponseBody struct {
 	Group string `form:"group" json:"group" xml:"group"`
 	// Policy version.
 	Version string `form:"version" json:"version" xml:"version"`
-	// Policy rego source code.
+	// Policy rego source code of the main 'policy.rego' module.
 	Rego *string `form:"rego,omitempty" json:"rego,omitempty" xml:"rego,omitempty"`
 	// Policy static data.
 	Data *string `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
@@ -66,6 +357,261 @@ type PolicyResponseBody struct {
 	Locked bool `form:"locked" json:"locked" xml:"locked"`
 	// Last update (Unix timestamp).
 	LastUpdate int64 `form:"lastUpdate" json:"lastUpdate" xml:"lastUpdate"`
+	// Policy rego modules by filename.
+	Modules map[string]string `form:"modules,omitempty" json:"modules,omitempty" xml:"modules,omitempty"`
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion *string `form:"shadowVersion,omitempty" json:"shadowVersion,omitempty" xml:"shadowVersion,omitempty"`
+	// Current lock of a locked policy.
+	Lock *PolicyLockEventResponseBody `form:"lock,omitempty" json:"lock,omitempty" xml:"lock,omitempty"`
+}
+
+// DecisionResponseBody is used to define fields on response body types.
+type DecisionResponseBody struct {
+	// Evaluation ID.
+	EvaluationID string `form:"evaluationID" json:"evaluationID" xml:"evaluationID"`
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Policy version.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Last update of the evaluated policy (Unix timestamp).
+	PolicyLastUpdate int64 `form:"policyLastUpdate" json:"policyLastUpdate" xml:"policyLastUpdate"`
+	// SHA256 hash of the evaluation input.
+	InputHash string `form:"inputHash" json:"inputHash" xml:"inputHash"`
+	// Evaluation input with redacted fields (if configured).
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Evaluation result.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// Evaluation error.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Evaluation duration in milliseconds.
+	Duration int64 `form:"duration" json:"duration" xml:"duration"`
+	// Identity of the caller.
+	Caller *string `form:"caller,omitempty" json:"caller,omitempty" xml:"caller,omitempty"`
+	// Address of the caller.
+	ClientIP *string `form:"clientIP,omitempty" json:"clientIP,omitempty" xml:"clientIP,omitempty"`
+	// Time of the evaluation (Unix timestamp).
+	Timestamp int64 `form:"timestamp" json:"timestamp" xml:"timestamp"`
+	// Evaluated rule path inside the policy package.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Request ID of the evaluated request, taken from the X-Request-Id header,
+	// e.g. set by Envoy.
+	RequestID *string `form:"requestID,omitempty" json:"requestID,omitempty" xml:"requestID,omitempty"`
+}
+
+// AuditRecordResponseBody is used to define fields on response body types.
+type AuditRecordResponseBody struct {
+	// Executed operation.
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Subject of the caller or 'system' for operations of the service.
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Client IP address of the caller.
+	ClientIP *string `form:"clientIP,omitempty" json:"clientIP,omitempty" xml:"clientIP,omitempty"`
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Changed resource if it isn't a policy, e.g. a storage key or an import URL.
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+	// SHA256 hash of the resource before the operation.
+	BeforeHash *string `form:"beforeHash,omitempty" json:"beforeHash,omitempty" xml:"beforeHash,omitempty"`
+	// SHA256 hash of the resource after the operation.
+	AfterHash *string `form:"afterHash,omitempty" json:"afterHash,omitempty" xml:"afterHash,omitempty"`
+	// Time of the operation (Unix timestamp).
+	Timestamp int64 `form:"timestamp" json:"timestamp" xml:"timestamp"`
+	// Policy version referenced by the resource before the operation, e.g. the
+	// version of an alias.
+	BeforeVersion *string `form:"beforeVersion,omitempty" json:"beforeVersion,omitempty" xml:"beforeVersion,omitempty"`
+	// Policy version referenced by the resource after the operation, e.g. the
+	// version of an alias.
+	AfterVersion *string `form:"afterVersion,omitempty" json:"afterVersion,omitempty" xml:"afterVersion,omitempty"`
+}
+
+// PolicyAliasResponseBody is used to define fields on response body types.
+type PolicyAliasResponseBody struct {
+	// Policy repository.
+	Repository string `form:"repository" json:"repository" xml:"repository"`
+	// Policy group.
+	Group string `form:"group" json:"group" xml:"group"`
+	// Policy name.
+	PolicyName string `form:"policyName" json:"policyName" xml:"policyName"`
+	// Alias name.
+	Alias string `form:"alias" json:"alias" xml:"alias"`
+	// Policy version referenced by the alias.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Last update (Unix timestamp).
+	LastUpdate int64 `form:"lastUpdate" json:"lastUpdate" xml:"lastUpdate"`
+}
+
+// ReplayDiffResponseBody is used to define fields on response body types.
+type ReplayDiffResponseBody struct {
+	// Evaluation ID of the recorded evaluation.
+	EvaluationID string `form:"evaluationID" json:"evaluationID" xml:"evaluationID"`
+	// Evaluated rule path inside the policy package.
+	Rule *string `form:"rule,omitempty" json:"rule,omitempty" xml:"rule,omitempty"`
+	// Kind of difference.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Recorded result.
+	Result any `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	// Result of the candidate.
+	CandidateResult any `form:"candidateResult,omitempty" json:"candidateResult,omitempty" xml:"candidateResult,omitempty"`
+	// Recorded error.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Error of the candidate.
+	CandidateError *string `form:"candidateError,omitempty" json:"candidateError,omitempty" xml:"candidateError,omitempty"`
+	// Difference between the candidate and the recorded evaluation duration in
+	// milliseconds, excluding extension function calls.
+	DurationDelta float64 `form:"durationDelta" json:"durationDelta" xml:"durationDelta"`
+	// Time of the recorded evaluation (Unix timestamp).
+	Timestamp int64 `form:"timestamp" json:"timestamp" xml:"timestamp"`
+}
+
+// BatchEvaluateItemRequestBody is used to define fields on request body types.
+type BatchEvaluateItemRequestBody struct {
+	// Policy repository.
+	Repository *string `form:"repository,omitempty" json:"repository,omitempty" xml:"repository,omitempty"`
+	// Policy group.
+	Group *string `form:"group,omitempty" json:"group,omitempty" xml:"group,omitempty"`
+	// Policy name.
+	PolicyName *string `form:"policyName,omitempty" json:"policyName,omitempty" xml:"policyName,omitempty"`
+	// Policy version.
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Input data passed to the policy execution runtime.
+	Input any `form:"input,omitempty" json:"input,omitempty" xml:"input,omitempty"`
+	// Identifier created by external system and passed as parameter to overwrite
+	// the randomly generated evaluationID.
+	EvaluationID *string `form:"evaluationID,omitempty" json:"evaluationID,omitempty" xml:"evaluationID,omitempty"`
+	// TTL for storing policy result in cache
+	TTL *int `form:"ttl,omitempty" json:"ttl,omitempty" xml:"ttl,omitempty"`
+}
+
+// NewEvaluateAcceptedResponseBody builds the HTTP response body from the
+// result of the "Evaluate" endpoint of the "policy" service.
+func NewEvaluateAcceptedResponseBody(res *policy.EvaluateResult) *EvaluateAcceptedResponseBody {
+	body := &EvaluateAcceptedResponseBody{
+		JobID:     res.JobID,
+		JobStatus: res.JobStatus,
+	}
+	return body
+}
+
+// NewEvaluateRuleAcceptedResponseBody builds the HTTP response body from the
+// result of the "EvaluateRule" endpoint of the "policy" service.
+func NewEvaluateRuleAcceptedResponseBody(res *policy.EvaluateResult) *EvaluateRuleAcceptedResponseBody {
+	body := &EvaluateRuleAcceptedResponseBody{
+		JobID:     res.JobID,
+		JobStatus: res.JobStatus,
+	}
+	return body
+}
+
+// NewPartialEvaluateResponseBody builds the HTTP response body from the result
+// of the "PartialEvaluate" endpoint of the "policy" service.
+func NewPartialEvaluateResponseBody(res *policy.PartialEvaluateResult) *PartialEvaluateResponseBody {
+	body := &PartialEvaluateResponseBody{
+		Filter:  res.Filter,
+		Version: res.Version,
+	}
+	if res.Queries != nil {
+		body.Queries = make([]string, len(res.Queries))
+		for i, val := range res.Queries {
+			body.Queries[i] = val
+		}
+	} else {
+		body.Queries = []string{}
+	}
+	if res.Support != nil {
+		body.Support = make([]string, len(res.Support))
+		for i, val := range res.Support {
+			body.Support[i] = val
+		}
+	}
+	return body
+}
+
+// NewEvaluateBatchResponseBody builds the HTTP response body from the result
+// of the "EvaluateBatch" endpoint of the "policy" service.
+func NewEvaluateBatchResponseBody(res *policy.BatchEvaluateResult) *EvaluateBatchResponseBody {
+	body := &EvaluateBatchResponseBody{}
+	if res.Results != nil {
+		body.Results = make([]*BatchEvaluateItemResultResponseBody, len(res.Results))
+		for i, val := range res.Results {
+			body.Results[i] = marshalPolicyBatchEvaluateItemResultToBatchEvaluateItemResultResponseBody(val)
+		}
+	} else {
+		body.Results = []*BatchEvaluateItemResultResponseBody{}
+	}
+	return body
+}
+
+// NewJobStatusResponseBody builds the HTTP response body from the result of
+// the "JobStatus" endpoint of the "policy" service.
+func NewJobStatusResponseBody(res *policy.EvaluationJob) *JobStatusResponseBody {
+	body := &JobStatusResponseBody{
+		JobID:         res.JobID,
+		ETag:          res.ETag,
+		Repository:    res.Repository,
+		Group:         res.Group,
+		PolicyName:    res.PolicyName,
+		Version:       res.Version,
+		Rule:          res.Rule,
+		Status:        res.Status,
+		Error:         res.Error,
+		CallbackURL:   res.CallbackURL,
+		CallbackError: res.CallbackError,
+		CreatedAt:     res.CreatedAt,
+		UpdatedAt:     res.UpdatedAt,
+	}
+	return body
+}
+
+// NewLockHistoryResponseBody builds the HTTP response body from the result of
+// the "LockHistory" endpoint of the "policy" service.
+func NewLockHistoryResponseBody(res *policy.LockHistoryResult) *LockHistoryResponseBody {
+	body := &LockHistoryResponseBody{
+		Locked: res.Locked,
+	}
+	if res.Lock != nil {
+		body.Lock = marshalPolicyPolicyLockEventToPolicyLockEventResponseBody(res.Lock)
+	}
+	if res.History != nil {
+		body.History = make([]*PolicyLockEventResponseBody, len(res.History))
+		for i, val := range res.History {
+			body.History[i] = marshalPolicyPolicyLockEventToPolicyLockEventResponseBody(val)
+		}
+	} else {
+		body.History = []*PolicyLockEventResponseBody{}
+	}
+	return body
+}
+
+// NewPolicyTestsResponseBody builds the HTTP response body from the result of
+// the "PolicyTests" endpoint of the "policy" service.
+func NewPolicyTestsResponseBody(res *policy.PolicyTestReport) *PolicyTestsResponseBody {
+	body := &PolicyTestsResponseBody{
+		Passed:   res.Passed,
+		Error:    res.Error,
+		Total:    res.Total,
+		Failed:   res.Failed,
+		Coverage: res.Coverage,
+		RunAt:    res.RunAt,
+	}
+	if res.Results != nil {
+		body.Results = make([]*PolicyTestResultResponseBody, len(res.Results))
+		for i, val := range res.Results {
+			body.Results[i] = marshalPolicyPolicyTestResultToPolicyTestResultResponseBody(val)
+		}
+	} else {
+		body.Results = []*PolicyTestResultResponseBody{}
+	}
+	return body
 }
 
 // NewListPoliciesResponseBody builds the HTTP response body from the result of
@@ -83,8 +629,111 @@ func NewListPoliciesResponseBody(res *policy.PoliciesResult) *ListPoliciesRespon
 	return body
 }
 
+// NewDecisionLogsResponseBody builds the HTTP response body from the result of
+// the "DecisionLogs" endpoint of the "policy" service.
+func NewDecisionLogsResponseBody(res *policy.DecisionLogsResult) *DecisionLogsResponseBody {
+	body := &DecisionLogsResponseBody{}
+	if res.Decisions != nil {
+		body.Decisions = make([]*DecisionResponseBody, len(res.Decisions))
+		for i, val := range res.Decisions {
+			body.Decisions[i] = marshalPolicyDecisionToDecisionResponseBody(val)
+		}
+	} else {
+		body.Decisions = []*DecisionResponseBody{}
+	}
+	return body
+}
+
+// NewAuditLogResponseBody builds the HTTP response body from the result of the
+// "AuditLog" endpoint of the "policy" service.
+func NewAuditLogResponseBody(res *policy.AuditLogResult) *AuditLogResponseBody {
+	body := &AuditLogResponseBody{}
+	if res.Records != nil {
+		body.Records = make([]*AuditRecordResponseBody, len(res.Records))
+		for i, val := range res.Records {
+			body.Records[i] = marshalPolicyAuditRecordToAuditRecordResponseBody(val)
+		}
+	} else {
+		body.Records = []*AuditRecordResponseBody{}
+	}
+	return body
+}
+
+// NewSetPolicyAliasResponseBody builds the HTTP response body from the result
+// of the "SetPolicyAlias" endpoint of the "policy" service.
+func NewSetPolicyAliasResponseBody(res *policy.PolicyAlias) *SetPolicyAliasResponseBody {
+	body := &SetPolicyAliasResponseBody{
+		Repository: res.Repository,
+		Group:      res.Group,
+		PolicyName: res.PolicyName,
+		Alias:      res.Alias,
+		Version:    res.Version,
+		LastUpdate: res.LastUpdate,
+	}
+	return body
+}
+
+// NewPolicyAliasesResponseBody builds the HTTP response body from the result
+// of the "PolicyAliases" endpoint of the "policy" service.
+func NewPolicyAliasesResponseBody(res *policy.PolicyAliasesResult) *PolicyAliasesResponseBody {
+	body := &PolicyAliasesResponseBody{}
+	if res.Aliases != nil {
+		body.Aliases = make([]*PolicyAliasResponseBody, len(res.Aliases))
+		for i, val := range res.Aliases {
+			body.Aliases[i] = marshalPolicyPolicyAliasToPolicyAliasResponseBody(val)
+		}
+	} else {
+		body.Aliases = []*PolicyAliasResponseBody{}
+	}
+	return body
+}
+
+// NewReplayResponseBody builds the HTTP response body from the result of the
+// "Replay" endpoint of the "policy" service.
+func NewReplayResponseBody(res *policy.ReplayReport) *ReplayResponseBody {
+	body := &ReplayResponseBody{
+		Candidate:        res.Candidate,
+		Total:            res.Total,
+		Unchanged:        res.Unchanged,
+		Changed:          res.Changed,
+		NewErrors:        res.NewErrors,
+		FixedErrors:      res.FixedErrors,
+		DurationDelta:    res.DurationDelta,
+		MaxDurationDelta: res.MaxDurationDelta,
+	}
+	if res.Diffs != nil {
+		body.Diffs = make([]*ReplayDiffResponseBody, len(res.Diffs))
+		for i, val := range res.Diffs {
+			body.Diffs[i] = marshalPolicyReplayDiffToReplayDiffResponseBody(val)
+		}
+	} else {
+		body.Diffs = []*ReplayDiffResponseBody{}
+	}
+	return body
+}
+
 // NewEvaluateRequest builds a policy service Evaluate endpoint payload.
-func NewEvaluateRequest(body any, repository string, group string, policyName string, version string, evaluationID *string, ttl *int) *policy.EvaluateRequest {
+func NewEvaluateRequest(body any, repository string, group string, policyName string, version string, explain *string, async *bool, evaluationID *string, ttl *int, callbackURL *string) *policy.EvaluateRequest {
+	v := body
+	res := &policy.EvaluateRequest{
+		Input: &v,
+	}
+	res.Repository = repository
+	res.Group = group
+	res.PolicyName = policyName
+	res.Version = version
+	res.Explain = explain
+	res.Async = async
+	res.EvaluationID = evaluationID
+	res.TTL = ttl
+	res.CallbackURL = callbackURL
+
+	return res
+}
+
+// NewEvaluateRuleEvaluateRequest builds a policy service EvaluateRule endpoint
+// payload.
+func NewEvaluateRuleEvaluateRequest(body any, repository string, group string, policyName string, version string, rule string, explain *string, async *bool, evaluationID *string, ttl *int, callbackURL *string) *policy.EvaluateRequest {
 	v := body
 	res := &policy.EvaluateRequest{
 		Input: &v,
@@ -93,14 +742,18 @@ func NewEvaluateRequest(body any, repository string, group string, policyName st
 	res.Group = group
 	res.PolicyName = policyName
 	res.Version = version
+	res.Rule = &rule
+	res.Explain = explain
+	res.Async = async
 	res.EvaluationID = evaluationID
 	res.TTL = ttl
+	res.CallbackURL = callbackURL
 
 	return res
 }
 
 // NewValidateEvaluateRequest builds a policy service Validate endpoint payload.
-func NewValidateEvaluateRequest(body any, repository string, group string, policyName string, version string, evaluationID *string, ttl *int) *policy.EvaluateRequest {
+func NewValidateEvaluateRequest(body any, repository string, group string, policyName string, version string, explain *string, report *bool, coerce *bool, evaluationID *string, ttl *int) *policy.EvaluateRequest {
 	v := body
 	res := &policy.EvaluateRequest{
 		Input: &v,
@@ -109,15 +762,81 @@ func NewValidateEvaluateRequest(body any, repository string, group string, polic
 	res.Group = group
 	res.PolicyName = policyName
 	res.Version = version
+	res.Explain = explain
+	res.Report = report
+	res.Coerce = coerce
 	res.EvaluationID = evaluationID
 	res.TTL = ttl
 
 	return res
 }
 
+// NewPartialEvaluateRequest builds a policy service PartialEvaluate endpoint
+// payload.
+func NewPartialEvaluateRequest(body *PartialEvaluateRequestBody, repository string, group string, policyName string, version string) *policy.PartialEvaluateRequest {
+	v := &policy.PartialEvaluateRequest{
+		Input: body.Input,
+	}
+	if body.Rule != nil {
+		v.Rule = *body.Rule
+	}
+	if body.Target != nil {
+		v.Target = *body.Target
+	}
+	if body.Rule == nil {
+		v.Rule = "allow"
+	}
+	v.Unknowns = make([]string, len(body.Unknowns))
+	for i, val := range body.Unknowns {
+		v.Unknowns[i] = val
+	}
+	if body.Target == nil {
+		v.Target = "rego"
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewEvaluateBatchBatchEvaluateRequest builds a policy service EvaluateBatch
+// endpoint payload.
+func NewEvaluateBatchBatchEvaluateRequest(body *EvaluateBatchRequestBody) *policy.BatchEvaluateRequest {
+	v := &policy.BatchEvaluateRequest{}
+	v.Items = make([]*policy.BatchEvaluateItem, len(body.Items))
+	for i, val := range body.Items {
+		v.Items[i] = unmarshalBatchEvaluateItemRequestBodyToPolicyBatchEvaluateItem(val)
+	}
+
+	return v
+}
+
+// NewEvaluationResultRequest builds a policy service EvaluationResult endpoint
+// payload.
+func NewEvaluationResultRequest(evaluationID string, wait *int) *policy.EvaluationResultRequest {
+	v := &policy.EvaluationResultRequest{}
+	v.EvaluationID = evaluationID
+	v.Wait = wait
+
+	return v
+}
+
+// NewJobStatusRequest builds a policy service JobStatus endpoint payload.
+func NewJobStatusRequest(jobID string) *policy.JobStatusRequest {
+	v := &policy.JobStatusRequest{}
+	v.JobID = jobID
+
+	return v
+}
+
 // NewLockRequest builds a policy service Lock endpoint payload.
-func NewLockRequest(repository string, group string, policyName string, version string) *policy.LockRequest {
-	v := &policy.LockRequest{}
+func NewLockRequest(body *LockRequestBody, repository string, group string, policyName string, version string) *policy.LockRequest {
+	v := &policy.LockRequest{
+		Reason:   body.Reason,
+		UnlockAt: body.UnlockAt,
+	}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -127,8 +846,32 @@ func NewLockRequest(repository string, group string, policyName string, version
 }
 
 // NewUnlockRequest builds a policy service Unlock endpoint payload.
-func NewUnlockRequest(repository string, group string, policyName string, version string) *policy.UnlockRequest {
-	v := &policy.UnlockRequest{}
+func NewUnlockRequest(body *UnlockRequestBody, repository string, group string, policyName string, version string) *policy.UnlockRequest {
+	v := &policy.UnlockRequest{
+		Reason: body.Reason,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewLockHistoryRequest builds a policy service LockHistory endpoint payload.
+func NewLockHistoryRequest(repository string, group string, policyName string, version string) *policy.LockHistoryRequest {
+	v := &policy.LockHistoryRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewPolicyTestsRequest builds a policy service PolicyTests endpoint payload.
+func NewPolicyTestsRequest(repository string, group string, policyName string, version string) *policy.PolicyTestsRequest {
+	v := &policy.PolicyTestsRequest{}
 	v.Repository = repository
 	v.Group = group
 	v.PolicyName = policyName
@@ -181,6 +924,40 @@ func NewListPoliciesPoliciesRequest(locked *bool, policyName *string, rego *bool
 	return v
 }
 
+// NewDecisionLogsRequest builds a policy service DecisionLogs endpoint payload.
+func NewDecisionLogsRequest(repository *string, group *string, policyName *string, version *string, evaluationID *string, caller *string, from *int64, to *int64, limit int, offset *int) *policy.DecisionLogsRequest {
+	v := &policy.DecisionLogsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.EvaluationID = evaluationID
+	v.Caller = caller
+	v.From = from
+	v.To = to
+	v.Limit = limit
+	v.Offset = offset
+
+	return v
+}
+
+// NewAuditLogRequest builds a policy service AuditLog endpoint payload.
+func NewAuditLogRequest(operation *string, actor *string, repository *string, group *string, policyName *string, version *string, from *int64, to *int64, limit int, offset *int) *policy.AuditLogRequest {
+	v := &policy.AuditLogRequest{}
+	v.Operation = operation
+	v.Actor = actor
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+	v.From = from
+	v.To = to
+	v.Limit = limit
+	v.Offset = offset
+
+	return v
+}
+
 // NewSetPolicyAutoImportRequest builds a policy service SetPolicyAutoImport
 // endpoint payload.
 func NewSetPolicyAutoImportRequest(body *SetPolicyAutoImportRequestBody) *policy.SetPolicyAutoImportRequest {
@@ -202,6 +979,149 @@ func NewDeletePolicyAutoImportRequest(body *DeletePolicyAutoImportRequestBody) *
 	return v
 }
 
+// NewSetPolicyAliasRequest builds a policy service SetPolicyAlias endpoint
+// payload.
+func NewSetPolicyAliasRequest(body *SetPolicyAliasRequestBody, repository string, group string, policyName string, alias string) *policy.SetPolicyAliasRequest {
+	v := &policy.SetPolicyAliasRequest{
+		Version: *body.Version,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Alias = alias
+
+	return v
+}
+
+// NewPolicyAliasesRequest builds a policy service PolicyAliases endpoint
+// payload.
+func NewPolicyAliasesRequest(repository string, group string, policyName string) *policy.PolicyAliasesRequest {
+	v := &policy.PolicyAliasesRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+
+	return v
+}
+
+// NewDeletePolicyAliasRequest builds a policy service DeletePolicyAlias
+// endpoint payload.
+func NewDeletePolicyAliasRequest(repository string, group string, policyName string, alias string) *policy.DeletePolicyAliasRequest {
+	v := &policy.DeletePolicyAliasRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Alias = alias
+
+	return v
+}
+
+// NewSetPolicyShadowRequest builds a policy service SetPolicyShadow endpoint
+// payload.
+func NewSetPolicyShadowRequest(body *SetPolicyShadowRequestBody, repository string, group string, policyName string, version string) *policy.SetPolicyShadowRequest {
+	v := &policy.SetPolicyShadowRequest{
+		ShadowVersion: *body.ShadowVersion,
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewDeletePolicyShadowRequest builds a policy service DeletePolicyShadow
+// endpoint payload.
+func NewDeletePolicyShadowRequest(repository string, group string, policyName string, version string) *policy.DeletePolicyShadowRequest {
+	v := &policy.DeletePolicyShadowRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewSetPolicyCaptureRequest builds a policy service SetPolicyCapture endpoint
+// payload.
+func NewSetPolicyCaptureRequest(body *SetPolicyCaptureRequestBody, repository string, group string, policyName string, version string) *policy.SetPolicyCaptureRequest {
+	v := &policy.SetPolicyCaptureRequest{}
+	if body.SampleRate != nil {
+		v.SampleRate = *body.SampleRate
+	}
+	if body.MaxRecords != nil {
+		v.MaxRecords = *body.MaxRecords
+	}
+	if body.SampleRate == nil {
+		v.SampleRate = 1
+	}
+	if body.MaxRecords == nil {
+		v.MaxRecords = 1000
+	}
+	if body.RedactFields != nil {
+		v.RedactFields = make([]string, len(body.RedactFields))
+		for i, val := range body.RedactFields {
+			v.RedactFields[i] = val
+		}
+	}
+	if body.RedactHeaders != nil {
+		v.RedactHeaders = make([]string, len(body.RedactHeaders))
+		for i, val := range body.RedactHeaders {
+			v.RedactHeaders[i] = val
+		}
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewDeletePolicyCaptureRequest builds a policy service DeletePolicyCapture
+// endpoint payload.
+func NewDeletePolicyCaptureRequest(repository string, group string, policyName string, version string) *policy.DeletePolicyCaptureRequest {
+	v := &policy.DeletePolicyCaptureRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewDeleteCapturedEvaluationsRequest builds a policy service
+// DeleteCapturedEvaluations endpoint payload.
+func NewDeleteCapturedEvaluationsRequest(repository string, group string, policyName string, version string) *policy.DeleteCapturedEvaluationsRequest {
+	v := &policy.DeleteCapturedEvaluationsRequest{}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
+// NewReplayRequest builds a policy service Replay endpoint payload.
+func NewReplayRequest(body *ReplayRequestBody, repository string, group string, policyName string, version string) *policy.ReplayRequest {
+	v := &policy.ReplayRequest{
+		CandidateVersion: body.CandidateVersion,
+		Rego:             body.Rego,
+	}
+	if body.Limit != nil {
+		v.Limit = *body.Limit
+	}
+	if body.Limit == nil {
+		v.Limit = 100
+	}
+	v.Repository = repository
+	v.Group = group
+	v.PolicyName = policyName
+	v.Version = version
+
+	return v
+}
+
 // NewSubscribeForPolicyChangeSubscribeRequest builds a policy service
 // SubscribeForPolicyChange endpoint payload.
 func NewSubscribeForPolicyChangeSubscribeRequest(body *SubscribeForPolicyChangeRequestBody, repository string, group string, policyName string, version string) *policy.SubscribeRequest {
@@ -217,6 +1137,45 @@ func NewSubscribeForPolicyChangeSubscribeRequest(body *SubscribeForPolicyChangeR
 	return v
 }
 
+// ValidatePartialEvaluateRequestBody runs the validations defined on
+// PartialEvaluateRequestBody
+func ValidatePartialEvaluateRequestBody(body *PartialEvaluateRequestBody) (err error) {
+	if body.Unknowns == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("unknowns", "body"))
+	}
+	if body.Rule != nil {
+		err = goa.MergeErrors(err, goa.ValidatePattern("body.rule", *body.Rule, "^[a-zA-Z_][a-zA-Z0-9_]*$"))
+	}
+	if len(body.Unknowns) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("body.unknowns", body.Unknowns, len(body.Unknowns), 1, true))
+	}
+	if body.Target != nil {
+		if !(*body.Target == "rego" || *body.Target == "mongo") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.target", *body.Target, []any{"rego", "mongo"}))
+		}
+	}
+	return
+}
+
+// ValidateEvaluateBatchRequestBody runs the validations defined on
+// EvaluateBatchRequestBody
+func ValidateEvaluateBatchRequestBody(body *EvaluateBatchRequestBody) (err error) {
+	if body.Items == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("items", "body"))
+	}
+	if len(body.Items) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("body.items", body.Items, len(body.Items), 1, true))
+	}
+	for _, e := range body.Items {
+		if e != nil {
+			if err2 := ValidateBatchEvaluateItemRequestBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
 // ValidateSetPolicyAutoImportRequestBody runs the validations defined on
 // SetPolicyAutoImportRequestBody
 func ValidateSetPolicyAutoImportRequestBody(body *SetPolicyAutoImportRequestBody) (err error) {
@@ -249,6 +1208,60 @@ func ValidateDeletePolicyAutoImportRequestBody(body *DeletePolicyAutoImportReque
 	return
 }
 
+// ValidateSetPolicyAliasRequestBody runs the validations defined on
+// SetPolicyAliasRequestBody
+func ValidateSetPolicyAliasRequestBody(body *SetPolicyAliasRequestBody) (err error) {
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
+
+// ValidateSetPolicyShadowRequestBody runs the validations defined on
+// SetPolicyShadowRequestBody
+func ValidateSetPolicyShadowRequestBody(body *SetPolicyShadowRequestBody) (err error) {
+	if body.ShadowVersion == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("shadowVersion", "body"))
+	}
+	return
+}
+
+// ValidateSetPolicyCaptureRequestBody runs the validations defined on
+// SetPolicyCaptureRequestBody
+func ValidateSetPolicyCaptureRequestBody(body *SetPolicyCaptureRequestBody) (err error) {
+	if body.SampleRate != nil {
+		if *body.SampleRate < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.sampleRate", *body.SampleRate, 0, true))
+		}
+	}
+	if body.SampleRate != nil {
+		if *body.SampleRate > 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.sampleRate", *body.SampleRate, 1, false))
+		}
+	}
+	if body.MaxRecords != nil {
+		if *body.MaxRecords < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.maxRecords", *body.MaxRecords, 1, true))
+		}
+	}
+	return
+}
+
+// ValidateReplayRequestBody runs the validations defined on ReplayRequestBody
+func ValidateReplayRequestBody(body *ReplayRequestBody) (err error) {
+	if body.Limit != nil {
+		if *body.Limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.limit", *body.Limit, 1, true))
+		}
+	}
+	if body.Limit != nil {
+		if *body.Limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.limit", *body.Limit, 1000, false))
+		}
+	}
+	return
+}
+
 // ValidateSubscribeForPolicyChangeRequestBody runs the validations defined on
 // SubscribeForPolicyChangeRequestBody
 func ValidateSubscribeForPolicyChangeRequestBody(body *SubscribeForPolicyChangeRequestBody) (err error) {
@@ -273,3 +1286,21 @@ func ValidateSubscribeForPolicyChangeRequestBody(body *SubscribeForPolicyChangeR
 	}
 	return
 }
+
+// ValidateBatchEvaluateItemRequestBody runs the validations defined on
+// BatchEvaluateItemRequestBody
+func ValidateBatchEvaluateItemRequestBody(body *BatchEvaluateItemRequestBody) (err error) {
+	if body.Repository == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("repository", "body"))
+	}
+	if body.Group == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("group", "body"))
+	}
+	if body.PolicyName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("policyName", "body"))
+	}
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	return
+}
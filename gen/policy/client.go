@@ -16,35 +16,73 @@ import (
 
 // Client is the "policy" service client.
 type Client struct {
-	EvaluateEndpoint                 goa.Endpoint
-	ValidateEndpoint                 goa.Endpoint
-	LockEndpoint                     goa.Endpoint
-	UnlockEndpoint                   goa.Endpoint
-	ExportBundleEndpoint             goa.Endpoint
-	PolicyPublicKeyEndpoint          goa.Endpoint
-	ImportBundleEndpoint             goa.Endpoint
-	ListPoliciesEndpoint             goa.Endpoint
-	SetPolicyAutoImportEndpoint      goa.Endpoint
-	PolicyAutoImportEndpoint         goa.Endpoint
-	DeletePolicyAutoImportEndpoint   goa.Endpoint
-	SubscribeForPolicyChangeEndpoint goa.Endpoint
+	EvaluateEndpoint                  goa.Endpoint
+	EvaluateRuleEndpoint              goa.Endpoint
+	ValidateEndpoint                  goa.Endpoint
+	PartialEvaluateEndpoint           goa.Endpoint
+	EvaluateBatchEndpoint             goa.Endpoint
+	EvaluateBatchStreamEndpoint       goa.Endpoint
+	EvaluationResultEndpoint          goa.Endpoint
+	JobStatusEndpoint                 goa.Endpoint
+	LockEndpoint                      goa.Endpoint
+	UnlockEndpoint                    goa.Endpoint
+	LockHistoryEndpoint               goa.Endpoint
+	PolicyTestsEndpoint               goa.Endpoint
+	ExportBundleEndpoint              goa.Endpoint
+	PolicyPublicKeyEndpoint           goa.Endpoint
+	ImportBundleEndpoint              goa.Endpoint
+	ListPoliciesEndpoint              goa.Endpoint
+	DecisionLogsEndpoint              goa.Endpoint
+	AuditLogEndpoint                  goa.Endpoint
+	SetPolicyAutoImportEndpoint       goa.Endpoint
+	PolicyAutoImportEndpoint          goa.Endpoint
+	DeletePolicyAutoImportEndpoint    goa.Endpoint
+	SetPolicyAliasEndpoint            goa.Endpoint
+	PolicyAliasesEndpoint             goa.Endpoint
+	DeletePolicyAliasEndpoint         goa.Endpoint
+	SetPolicyShadowEndpoint           goa.Endpoint
+	DeletePolicyShadowEndpoint        goa.Endpoint
+	SetPolicyCaptureEndpoint          goa.Endpoint
+	DeletePolicyCaptureEndpoint       goa.Endpoint
+	DeleteCapturedEvaluationsEndpoint goa.Endpoint
+	ReplayEndpoint                    goa.Endpoint
+	SubscribeForPolicyChangeEndpoint  goa.Endpoint
 }
 
 // NewClient initializes a "policy" service client given the endpoints.
-func NewClient(evaluate, validate, lock, unlock, exportBundle, policyPublicKey, importBundle, listPolicies, setPolicyAutoImport, policyAutoImport, deletePolicyAutoImport, subscribeForPolicyChange goa.Endpoint) *Client {
+func NewClient(evaluate, evaluateRule, validate, partialEvaluate, evaluateBatch, evaluateBatchStream, evaluationResult, jobStatus, lock, unlock, lockHistory, policyTests, exportBundle, policyPublicKey, importBundle, listPolicies, decisionLogs, auditLog, setPolicyAutoImport, policyAutoImport, deletePolicyAutoImport, setPolicyAlias, policyAliases, deletePolicyAlias, setPolicyShadow, deletePolicyShadow, setPolicyCapture, deletePolicyCapture, deleteCapturedEvaluations, replay, subscribeForPolicyChange goa.Endpoint) *Client {
 	return &Client{
-		EvaluateEndpoint:                 evaluate,
-		ValidateEndpoint:                 validate,
-		LockEndpoint:                     lock,
-		UnlockEndpoint:                   unlock,
-		ExportBundleEndpoint:             exportBundle,
-		PolicyPublicKeyEndpoint:          policyPublicKey,
-		ImportBundleEndpoint:             importBundle,
-		ListPoliciesEndpoint:             listPolicies,
-		SetPolicyAutoImportEndpoint:      setPolicyAutoImport,
-		PolicyAutoImportEndpoint:         policyAutoImport,
-		DeletePolicyAutoImportEndpoint:   deletePolicyAutoImport,
-		SubscribeForPolicyChangeEndpoint: subscribeForPolicyChange,
+		EvaluateEndpoint:                  evaluate,
+		EvaluateRuleEndpoint:              evaluateRule,
+		ValidateEndpoint:                  validate,
+		PartialEvaluateEndpoint:           partialEvaluate,
+		EvaluateBatchEndpoint:             evaluateBatch,
+		EvaluateBatchStreamEndpoint:       evaluateBatchStream,
+		EvaluationResultEndpoint:          evaluationResult,
+		JobStatusEndpoint:                 jobStatus,
+		LockEndpoint:                      lock,
+		UnlockEndpoint:                    unlock,
+		LockHistoryEndpoint:               lockHistory,
+		PolicyTestsEndpoint:               policyTests,
+		ExportBundleEndpoint:              exportBundle,
+		PolicyPublicKeyEndpoint:           policyPublicKey,
+		ImportBundleEndpoint:              importBundle,
+		ListPoliciesEndpoint:              listPolicies,
+		DecisionLogsEndpoint:              decisionLogs,
+		AuditLogEndpoint:                  auditLog,
+		SetPolicyAutoImportEndpoint:       setPolicyAutoImport,
+		PolicyAutoImportEndpoint:          policyAutoImport,
+		DeletePolicyAutoImportEndpoint:    deletePolicyAutoImport,
+		SetPolicyAliasEndpoint:            setPolicyAlias,
+		PolicyAliasesEndpoint:             policyAliases,
+		DeletePolicyAliasEndpoint:         deletePolicyAlias,
+		SetPolicyShadowEndpoint:           setPolicyShadow,
+		DeletePolicyShadowEndpoint:        deletePolicyShadow,
+		SetPolicyCaptureEndpoint:          setPolicyCapture,
+		DeletePolicyCaptureEndpoint:       deletePolicyCapture,
+		DeleteCapturedEvaluationsEndpoint: deleteCapturedEvaluations,
+		ReplayEndpoint:                    replay,
+		SubscribeForPolicyChangeEndpoint:  subscribeForPolicyChange,
 	}
 }
 
@@ -58,6 +96,16 @@ func (c *Client) Evaluate(ctx context.Context, p *EvaluateRequest) (res *Evaluat
 	return ires.(*EvaluateResult), nil
 }
 
+// EvaluateRule calls the "EvaluateRule" endpoint of the "policy" service.
+func (c *Client) EvaluateRule(ctx context.Context, p *EvaluateRequest) (res *EvaluateResult, err error) {
+	var ires any
+	ires, err = c.EvaluateRuleEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*EvaluateResult), nil
+}
+
 // Validate calls the "Validate" endpoint of the "policy" service.
 func (c *Client) Validate(ctx context.Context, p *EvaluateRequest) (res *EvaluateResult, err error) {
 	var ires any
@@ -68,6 +116,59 @@ func (c *Client) Validate(ctx context.Context, p *EvaluateRequest) (res *Evaluat
 	return ires.(*EvaluateResult), nil
 }
 
+// PartialEvaluate calls the "PartialEvaluate" endpoint of the "policy" service.
+func (c *Client) PartialEvaluate(ctx context.Context, p *PartialEvaluateRequest) (res *PartialEvaluateResult, err error) {
+	var ires any
+	ires, err = c.PartialEvaluateEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PartialEvaluateResult), nil
+}
+
+// EvaluateBatch calls the "EvaluateBatch" endpoint of the "policy" service.
+func (c *Client) EvaluateBatch(ctx context.Context, p *BatchEvaluateRequest) (res *BatchEvaluateResult, err error) {
+	var ires any
+	ires, err = c.EvaluateBatchEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*BatchEvaluateResult), nil
+}
+
+// EvaluateBatchStream calls the "EvaluateBatchStream" endpoint of the "policy"
+// service.
+func (c *Client) EvaluateBatchStream(ctx context.Context, req io.ReadCloser) (res *BatchEvaluateStreamResult, resp io.ReadCloser, err error) {
+	var ires any
+	ires, err = c.EvaluateBatchStreamEndpoint(ctx, &EvaluateBatchStreamRequestData{Body: req})
+	if err != nil {
+		return
+	}
+	o := ires.(*EvaluateBatchStreamResponseData)
+	return o.Result, o.Body, nil
+}
+
+// EvaluationResult calls the "EvaluationResult" endpoint of the "policy"
+// service.
+func (c *Client) EvaluationResult(ctx context.Context, p *EvaluationResultRequest) (res *StoredEvaluationResult, err error) {
+	var ires any
+	ires, err = c.EvaluationResultEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*StoredEvaluationResult), nil
+}
+
+// JobStatus calls the "JobStatus" endpoint of the "policy" service.
+func (c *Client) JobStatus(ctx context.Context, p *JobStatusRequest) (res *EvaluationJob, err error) {
+	var ires any
+	ires, err = c.JobStatusEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*EvaluationJob), nil
+}
+
 // Lock calls the "Lock" endpoint of the "policy" service.
 func (c *Client) Lock(ctx context.Context, p *LockRequest) (err error) {
 	_, err = c.LockEndpoint(ctx, p)
@@ -80,6 +181,26 @@ func (c *Client) Unlock(ctx context.Context, p *UnlockRequest) (err error) {
 	return
 }
 
+// LockHistory calls the "LockHistory" endpoint of the "policy" service.
+func (c *Client) LockHistory(ctx context.Context, p *LockHistoryRequest) (res *LockHistoryResult, err error) {
+	var ires any
+	ires, err = c.LockHistoryEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*LockHistoryResult), nil
+}
+
+// PolicyTests calls the "PolicyTests" endpoint of the "policy" service.
+func (c *Client) PolicyTests(ctx context.Context, p *PolicyTestsRequest) (res *PolicyTestReport, err error) {
+	var ires any
+	ires, err = c.PolicyTestsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyTestReport), nil
+}
+
 // ExportBundle calls the "ExportBundle" endpoint of the "policy" service.
 func (c *Client) ExportBundle(ctx context.Context, p *ExportBundleRequest) (res *ExportBundleResult, resp io.ReadCloser, err error) {
 	var ires any
@@ -121,6 +242,26 @@ func (c *Client) ListPolicies(ctx context.Context, p *PoliciesRequest) (res *Pol
 	return ires.(*PoliciesResult), nil
 }
 
+// DecisionLogs calls the "DecisionLogs" endpoint of the "policy" service.
+func (c *Client) DecisionLogs(ctx context.Context, p *DecisionLogsRequest) (res *DecisionLogsResult, err error) {
+	var ires any
+	ires, err = c.DecisionLogsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*DecisionLogsResult), nil
+}
+
+// AuditLog calls the "AuditLog" endpoint of the "policy" service.
+func (c *Client) AuditLog(ctx context.Context, p *AuditLogRequest) (res *AuditLogResult, err error) {
+	var ires any
+	ires, err = c.AuditLogEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*AuditLogResult), nil
+}
+
 // SetPolicyAutoImport calls the "SetPolicyAutoImport" endpoint of the "policy"
 // service.
 func (c *Client) SetPolicyAutoImport(ctx context.Context, p *SetPolicyAutoImportRequest) (res any, err error) {
@@ -154,6 +295,77 @@ func (c *Client) DeletePolicyAutoImport(ctx context.Context, p *DeletePolicyAuto
 	return ires.(any), nil
 }
 
+// SetPolicyAlias calls the "SetPolicyAlias" endpoint of the "policy" service.
+func (c *Client) SetPolicyAlias(ctx context.Context, p *SetPolicyAliasRequest) (res *PolicyAlias, err error) {
+	var ires any
+	ires, err = c.SetPolicyAliasEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyAlias), nil
+}
+
+// PolicyAliases calls the "PolicyAliases" endpoint of the "policy" service.
+func (c *Client) PolicyAliases(ctx context.Context, p *PolicyAliasesRequest) (res *PolicyAliasesResult, err error) {
+	var ires any
+	ires, err = c.PolicyAliasesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PolicyAliasesResult), nil
+}
+
+// DeletePolicyAlias calls the "DeletePolicyAlias" endpoint of the "policy"
+// service.
+func (c *Client) DeletePolicyAlias(ctx context.Context, p *DeletePolicyAliasRequest) (err error) {
+	_, err = c.DeletePolicyAliasEndpoint(ctx, p)
+	return
+}
+
+// SetPolicyShadow calls the "SetPolicyShadow" endpoint of the "policy" service.
+func (c *Client) SetPolicyShadow(ctx context.Context, p *SetPolicyShadowRequest) (err error) {
+	_, err = c.SetPolicyShadowEndpoint(ctx, p)
+	return
+}
+
+// DeletePolicyShadow calls the "DeletePolicyShadow" endpoint of the "policy"
+// service.
+func (c *Client) DeletePolicyShadow(ctx context.Context, p *DeletePolicyShadowRequest) (err error) {
+	_, err = c.DeletePolicyShadowEndpoint(ctx, p)
+	return
+}
+
+// SetPolicyCapture calls the "SetPolicyCapture" endpoint of the "policy"
+// service.
+func (c *Client) SetPolicyCapture(ctx context.Context, p *SetPolicyCaptureRequest) (err error) {
+	_, err = c.SetPolicyCaptureEndpoint(ctx, p)
+	return
+}
+
+// DeletePolicyCapture calls the "DeletePolicyCapture" endpoint of the "policy"
+// service.
+func (c *Client) DeletePolicyCapture(ctx context.Context, p *DeletePolicyCaptureRequest) (err error) {
+	_, err = c.DeletePolicyCaptureEndpoint(ctx, p)
+	return
+}
+
+// DeleteCapturedEvaluations calls the "DeleteCapturedEvaluations" endpoint of
+// the "policy" service.
+func (c *Client) DeleteCapturedEvaluations(ctx context.Context, p *DeleteCapturedEvaluationsRequest) (err error) {
+	_, err = c.DeleteCapturedEvaluationsEndpoint(ctx, p)
+	return
+}
+
+// Replay calls the "Replay" endpoint of the "policy" service.
+func (c *Client) Replay(ctx context.Context, p *ReplayRequest) (res *ReplayReport, err error) {
+	var ires any
+	ires, err = c.ReplayEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ReplayReport), nil
+}
+
 // SubscribeForPolicyChange calls the "SubscribeForPolicyChange" endpoint of
 // the "policy" service.
 func (c *Client) SubscribeForPolicyChange(ctx context.Context, p *SubscribeRequest) (res any, err error) {
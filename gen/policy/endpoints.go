@@ -16,18 +16,53 @@ import (
 
 // Endpoints wraps the "policy" service endpoints.
 type Endpoints struct {
-	Evaluate                 goa.Endpoint
-	Validate                 goa.Endpoint
-	Lock                     goa.Endpoint
-	Unlock                   goa.Endpoint
-	ExportBundle             goa.Endpoint
-	PolicyPublicKey          goa.Endpoint
-	ImportBundle             goa.Endpoint
-	ListPolicies             goa.Endpoint
-	SetPolicyAutoImport      goa.Endpoint
-	PolicyAutoImport         goa.Endpoint
-	DeletePolicyAutoImport   goa.Endpoint
-	SubscribeForPolicyChange goa.Endpoint
+	Evaluate                  goa.Endpoint
+	EvaluateRule              goa.Endpoint
+	Validate                  goa.Endpoint
+	PartialEvaluate           goa.Endpoint
+	EvaluateBatch             goa.Endpoint
+	EvaluateBatchStream       goa.Endpoint
+	EvaluationResult          goa.Endpoint
+	JobStatus                 goa.Endpoint
+	Lock                      goa.Endpoint
+	Unlock                    goa.Endpoint
+	LockHistory               goa.Endpoint
+	PolicyTests               goa.Endpoint
+	ExportBundle              goa.Endpoint
+	PolicyPublicKey           goa.Endpoint
+	ImportBundle              goa.Endpoint
+	ListPolicies              goa.Endpoint
+	DecisionLogs              goa.Endpoint
+	AuditLog                  goa.Endpoint
+	SetPolicyAutoImport       goa.Endpoint
+	PolicyAutoImport          goa.Endpoint
+	DeletePolicyAutoImport    goa.Endpoint
+	SetPolicyAlias            goa.Endpoint
+	PolicyAliases             goa.Endpoint
+	DeletePolicyAlias         goa.Endpoint
+	SetPolicyShadow           goa.Endpoint
+	DeletePolicyShadow        goa.Endpoint
+	SetPolicyCapture          goa.Endpoint
+	DeletePolicyCapture       goa.Endpoint
+	DeleteCapturedEvaluations goa.Endpoint
+	Replay                    goa.Endpoint
+	SubscribeForPolicyChange  goa.Endpoint
+}
+
+// EvaluateBatchStreamRequestData holds both the payload and the HTTP request
+// body reader of the "EvaluateBatchStream" method.
+type EvaluateBatchStreamRequestData struct {
+	// Body streams the HTTP request body.
+	Body io.ReadCloser
+}
+
+// EvaluateBatchStreamResponseData holds both the result and the HTTP response
+// body reader of the "EvaluateBatchStream" method.
+type EvaluateBatchStreamResponseData struct {
+	// Result is the method result.
+	Result *BatchEvaluateStreamResult
+	// Body streams the HTTP response body.
+	Body io.ReadCloser
 }
 
 // ExportBundleResponseData holds both the result and the HTTP response body
@@ -51,34 +86,72 @@ type ImportBundleRequestData struct {
 // NewEndpoints wraps the methods of the "policy" service with endpoints.
 func NewEndpoints(s Service) *Endpoints {
 	return &Endpoints{
-		Evaluate:                 NewEvaluateEndpoint(s),
-		Validate:                 NewValidateEndpoint(s),
-		Lock:                     NewLockEndpoint(s),
-		Unlock:                   NewUnlockEndpoint(s),
-		ExportBundle:             NewExportBundleEndpoint(s),
-		PolicyPublicKey:          NewPolicyPublicKeyEndpoint(s),
-		ImportBundle:             NewImportBundleEndpoint(s),
-		ListPolicies:             NewListPoliciesEndpoint(s),
-		SetPolicyAutoImport:      NewSetPolicyAutoImportEndpoint(s),
-		PolicyAutoImport:         NewPolicyAutoImportEndpoint(s),
-		DeletePolicyAutoImport:   NewDeletePolicyAutoImportEndpoint(s),
-		SubscribeForPolicyChange: NewSubscribeForPolicyChangeEndpoint(s),
+		Evaluate:                  NewEvaluateEndpoint(s),
+		EvaluateRule:              NewEvaluateRuleEndpoint(s),
+		Validate:                  NewValidateEndpoint(s),
+		PartialEvaluate:           NewPartialEvaluateEndpoint(s),
+		EvaluateBatch:             NewEvaluateBatchEndpoint(s),
+		EvaluateBatchStream:       NewEvaluateBatchStreamEndpoint(s),
+		EvaluationResult:          NewEvaluationResultEndpoint(s),
+		JobStatus:                 NewJobStatusEndpoint(s),
+		Lock:                      NewLockEndpoint(s),
+		Unlock:                    NewUnlockEndpoint(s),
+		LockHistory:               NewLockHistoryEndpoint(s),
+		PolicyTests:               NewPolicyTestsEndpoint(s),
+		ExportBundle:              NewExportBundleEndpoint(s),
+		PolicyPublicKey:           NewPolicyPublicKeyEndpoint(s),
+		ImportBundle:              NewImportBundleEndpoint(s),
+		ListPolicies:              NewListPoliciesEndpoint(s),
+		DecisionLogs:              NewDecisionLogsEndpoint(s),
+		AuditLog:                  NewAuditLogEndpoint(s),
+		SetPolicyAutoImport:       NewSetPolicyAutoImportEndpoint(s),
+		PolicyAutoImport:          NewPolicyAutoImportEndpoint(s),
+		DeletePolicyAutoImport:    NewDeletePolicyAutoImportEndpoint(s),
+		SetPolicyAlias:            NewSetPolicyAliasEndpoint(s),
+		PolicyAliases:             NewPolicyAliasesEndpoint(s),
+		DeletePolicyAlias:         NewDeletePolicyAliasEndpoint(s),
+		SetPolicyShadow:           NewSetPolicyShadowEndpoint(s),
+		DeletePolicyShadow:        NewDeletePolicyShadowEndpoint(s),
+		SetPolicyCapture:          NewSetPolicyCaptureEndpoint(s),
+		DeletePolicyCapture:       NewDeletePolicyCaptureEndpoint(s),
+		DeleteCapturedEvaluations: NewDeleteCapturedEvaluationsEndpoint(s),
+		Replay:                    NewReplayEndpoint(s),
+		SubscribeForPolicyChange:  NewSubscribeForPolicyChangeEndpoint(s),
 	}
 }
 
 // Use applies the given middleware to all the "policy" service endpoints.
 func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.Evaluate = m(e.Evaluate)
+	e.EvaluateRule = m(e.EvaluateRule)
 	e.Validate = m(e.Validate)
+	e.PartialEvaluate = m(e.PartialEvaluate)
+	e.EvaluateBatch = m(e.EvaluateBatch)
+	e.EvaluateBatchStream = m(e.EvaluateBatchStream)
+	e.EvaluationResult = m(e.EvaluationResult)
+	e.JobStatus = m(e.JobStatus)
 	e.Lock = m(e.Lock)
 	e.Unlock = m(e.Unlock)
+	e.LockHistory = m(e.LockHistory)
+	e.PolicyTests = m(e.PolicyTests)
 	e.ExportBundle = m(e.ExportBundle)
 	e.PolicyPublicKey = m(e.PolicyPublicKey)
 	e.ImportBundle = m(e.ImportBundle)
 	e.ListPolicies = m(e.ListPolicies)
+	e.DecisionLogs = m(e.DecisionLogs)
+	e.AuditLog = m(e.AuditLog)
 	e.SetPolicyAutoImport = m(e.SetPolicyAutoImport)
 	e.PolicyAutoImport = m(e.PolicyAutoImport)
 	e.DeletePolicyAutoImport = m(e.DeletePolicyAutoImport)
+	e.SetPolicyAlias = m(e.SetPolicyAlias)
+	e.PolicyAliases = m(e.PolicyAliases)
+	e.DeletePolicyAlias = m(e.DeletePolicyAlias)
+	e.SetPolicyShadow = m(e.SetPolicyShadow)
+	e.DeletePolicyShadow = m(e.DeletePolicyShadow)
+	e.SetPolicyCapture = m(e.SetPolicyCapture)
+	e.DeletePolicyCapture = m(e.DeletePolicyCapture)
+	e.DeleteCapturedEvaluations = m(e.DeleteCapturedEvaluations)
+	e.Replay = m(e.Replay)
 	e.SubscribeForPolicyChange = m(e.SubscribeForPolicyChange)
 }
 
@@ -91,6 +164,15 @@ func NewEvaluateEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewEvaluateRuleEndpoint returns an endpoint function that calls the method
+// "EvaluateRule" of service "policy".
+func NewEvaluateRuleEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*EvaluateRequest)
+		return s.EvaluateRule(ctx, p)
+	}
+}
+
 // NewValidateEndpoint returns an endpoint function that calls the method
 // "Validate" of service "policy".
 func NewValidateEndpoint(s Service) goa.Endpoint {
@@ -100,6 +182,55 @@ func NewValidateEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewPartialEvaluateEndpoint returns an endpoint function that calls the
+// method "PartialEvaluate" of service "policy".
+func NewPartialEvaluateEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PartialEvaluateRequest)
+		return s.PartialEvaluate(ctx, p)
+	}
+}
+
+// NewEvaluateBatchEndpoint returns an endpoint function that calls the method
+// "EvaluateBatch" of service "policy".
+func NewEvaluateBatchEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*BatchEvaluateRequest)
+		return s.EvaluateBatch(ctx, p)
+	}
+}
+
+// NewEvaluateBatchStreamEndpoint returns an endpoint function that calls the
+// method "EvaluateBatchStream" of service "policy".
+func NewEvaluateBatchStreamEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		ep := req.(*EvaluateBatchStreamRequestData)
+		res, body, err := s.EvaluateBatchStream(ctx, ep.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &EvaluateBatchStreamResponseData{Result: res, Body: body}, nil
+	}
+}
+
+// NewEvaluationResultEndpoint returns an endpoint function that calls the
+// method "EvaluationResult" of service "policy".
+func NewEvaluationResultEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*EvaluationResultRequest)
+		return s.EvaluationResult(ctx, p)
+	}
+}
+
+// NewJobStatusEndpoint returns an endpoint function that calls the method
+// "JobStatus" of service "policy".
+func NewJobStatusEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*JobStatusRequest)
+		return s.JobStatus(ctx, p)
+	}
+}
+
 // NewLockEndpoint returns an endpoint function that calls the method "Lock" of
 // service "policy".
 func NewLockEndpoint(s Service) goa.Endpoint {
@@ -118,6 +249,24 @@ func NewUnlockEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewLockHistoryEndpoint returns an endpoint function that calls the method
+// "LockHistory" of service "policy".
+func NewLockHistoryEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*LockHistoryRequest)
+		return s.LockHistory(ctx, p)
+	}
+}
+
+// NewPolicyTestsEndpoint returns an endpoint function that calls the method
+// "PolicyTests" of service "policy".
+func NewPolicyTestsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyTestsRequest)
+		return s.PolicyTests(ctx, p)
+	}
+}
+
 // NewExportBundleEndpoint returns an endpoint function that calls the method
 // "ExportBundle" of service "policy".
 func NewExportBundleEndpoint(s Service) goa.Endpoint {
@@ -158,6 +307,24 @@ func NewListPoliciesEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewDecisionLogsEndpoint returns an endpoint function that calls the method
+// "DecisionLogs" of service "policy".
+func NewDecisionLogsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DecisionLogsRequest)
+		return s.DecisionLogs(ctx, p)
+	}
+}
+
+// NewAuditLogEndpoint returns an endpoint function that calls the method
+// "AuditLog" of service "policy".
+func NewAuditLogEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*AuditLogRequest)
+		return s.AuditLog(ctx, p)
+	}
+}
+
 // NewSetPolicyAutoImportEndpoint returns an endpoint function that calls the
 // method "SetPolicyAutoImport" of service "policy".
 func NewSetPolicyAutoImportEndpoint(s Service) goa.Endpoint {
@@ -184,6 +351,87 @@ func NewDeletePolicyAutoImportEndpoint(s Service) goa.Endpoint {
 	}
 }
 
+// NewSetPolicyAliasEndpoint returns an endpoint function that calls the method
+// "SetPolicyAlias" of service "policy".
+func NewSetPolicyAliasEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SetPolicyAliasRequest)
+		return s.SetPolicyAlias(ctx, p)
+	}
+}
+
+// NewPolicyAliasesEndpoint returns an endpoint function that calls the method
+// "PolicyAliases" of service "policy".
+func NewPolicyAliasesEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PolicyAliasesRequest)
+		return s.PolicyAliases(ctx, p)
+	}
+}
+
+// NewDeletePolicyAliasEndpoint returns an endpoint function that calls the
+// method "DeletePolicyAlias" of service "policy".
+func NewDeletePolicyAliasEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeletePolicyAliasRequest)
+		return nil, s.DeletePolicyAlias(ctx, p)
+	}
+}
+
+// NewSetPolicyShadowEndpoint returns an endpoint function that calls the
+// method "SetPolicyShadow" of service "policy".
+func NewSetPolicyShadowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SetPolicyShadowRequest)
+		return nil, s.SetPolicyShadow(ctx, p)
+	}
+}
+
+// NewDeletePolicyShadowEndpoint returns an endpoint function that calls the
+// method "DeletePolicyShadow" of service "policy".
+func NewDeletePolicyShadowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeletePolicyShadowRequest)
+		return nil, s.DeletePolicyShadow(ctx, p)
+	}
+}
+
+// NewSetPolicyCaptureEndpoint returns an endpoint function that calls the
+// method "SetPolicyCapture" of service "policy".
+func NewSetPolicyCaptureEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SetPolicyCaptureRequest)
+		return nil, s.SetPolicyCapture(ctx, p)
+	}
+}
+
+// NewDeletePolicyCaptureEndpoint returns an endpoint function that calls the
+// method "DeletePolicyCapture" of service "policy".
+func NewDeletePolicyCaptureEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeletePolicyCaptureRequest)
+		return nil, s.DeletePolicyCapture(ctx, p)
+	}
+}
+
+// NewDeleteCapturedEvaluationsEndpoint returns an endpoint function that calls
+// the method "DeleteCapturedEvaluations" of service "policy".
+func NewDeleteCapturedEvaluationsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeleteCapturedEvaluationsRequest)
+		return nil, s.DeleteCapturedEvaluations(ctx, p)
+	}
+}
+
+// NewReplayEndpoint returns an endpoint function that calls the method
+// "Replay" of service "policy".
+func NewReplayEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ReplayRequest)
+		return s.Replay(ctx, p)
+	}
+}
+
 // NewSubscribeForPolicyChangeEndpoint returns an endpoint function that calls
 // the method "SubscribeForPolicyChange" of service "policy".
 func NewSubscribeForPolicyChangeEndpoint(s Service) goa.Endpoint {
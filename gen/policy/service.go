@@ -16,13 +16,40 @@ import (
 type Service interface {
 	// Evaluate executes a policy with the given 'data' as input.
 	Evaluate(context.Context, *EvaluateRequest) (res *EvaluateResult, err error)
+	// EvaluateRule executes a policy with the given 'data' as input and returns
+	// only the value of the given rule path.
+	EvaluateRule(context.Context, *EvaluateRequest) (res *EvaluateResult, err error)
 	// Validate executes a policy with the given 'data' as input and validates the
 	// output schema.
 	Validate(context.Context, *EvaluateRequest) (res *EvaluateResult, err error)
+	// PartialEvaluate executes partial evaluation of a policy rule with the given
+	// unknowns and returns the residual queries.
+	PartialEvaluate(context.Context, *PartialEvaluateRequest) (res *PartialEvaluateResult, err error)
+	// EvaluateBatch executes multiple policies with their inputs concurrently and
+	// returns a result for each item.
+	EvaluateBatch(context.Context, *BatchEvaluateRequest) (res *BatchEvaluateResult, err error)
+	// EvaluateBatchStream executes policies for a stream of newline delimited JSON
+	// items and streams back newline delimited JSON results.
+
+	// If body implements [io.WriterTo], that implementation will be used instead.
+	// Consider [goa.design/goa/v3/pkg.SkipResponseWriter] to adapt existing
+	// implementations.
+	EvaluateBatchStream(context.Context, io.ReadCloser) (res *BatchEvaluateStreamResult, body io.ReadCloser, err error)
+	// EvaluationResult returns the stored result of a policy evaluation by its
+	// evaluationID (ETag). If 'wait' is set, the request is held until the result
+	// is available or the wait time is over.
+	EvaluationResult(context.Context, *EvaluationResultRequest) (res *StoredEvaluationResult, err error)
+	// JobStatus returns the status of an asynchronous evaluation job.
+	JobStatus(context.Context, *JobStatusRequest) (res *EvaluationJob, err error)
 	// Lock a policy so that it cannot be evaluated.
 	Lock(context.Context, *LockRequest) (err error)
 	// Unlock a policy so it can be evaluated again.
 	Unlock(context.Context, *UnlockRequest) (err error)
+	// LockHistory returns the current lock and the previous lock and unlock events
+	// of a policy.
+	LockHistory(context.Context, *LockHistoryRequest) (res *LockHistoryResult, err error)
+	// PolicyTests returns the report of the last test run of a policy.
+	PolicyTests(context.Context, *PolicyTestsRequest) (res *PolicyTestReport, err error)
 	// Export a signed policy bundle.
 
 	// If body implements [io.WriterTo], that implementation will be used instead.
@@ -36,6 +63,11 @@ type Service interface {
 	ImportBundle(context.Context, *ImportBundlePayload, io.ReadCloser) (res any, err error)
 	// List policies from storage with optional filters.
 	ListPolicies(context.Context, *PoliciesRequest) (res *PoliciesResult, err error)
+	// DecisionLogs returns the recorded decisions of policy evaluations, newest
+	// first.
+	DecisionLogs(context.Context, *DecisionLogsRequest) (res *DecisionLogsResult, err error)
+	// AuditLog returns the records of state-changing operations, newest first.
+	AuditLog(context.Context, *AuditLogRequest) (res *AuditLogResult, err error)
 	// SetPolicyAutoImport enables automatic import of policy bundle on a given
 	// time interval.
 	SetPolicyAutoImport(context.Context, *SetPolicyAutoImportRequest) (res any, err error)
@@ -43,6 +75,30 @@ type Service interface {
 	PolicyAutoImport(context.Context) (res any, err error)
 	// DeletePolicyAutoImport removes a single automatic import configuration.
 	DeletePolicyAutoImport(context.Context, *DeletePolicyAutoImportRequest) (res any, err error)
+	// SetPolicyAlias pins a named alias to a policy version, so that the alias can
+	// be used instead of the version in policy URLs.
+	SetPolicyAlias(context.Context, *SetPolicyAliasRequest) (res *PolicyAlias, err error)
+	// PolicyAliases returns all named aliases of a policy.
+	PolicyAliases(context.Context, *PolicyAliasesRequest) (res *PolicyAliasesResult, err error)
+	// DeletePolicyAlias removes a named alias of a policy.
+	DeletePolicyAlias(context.Context, *DeletePolicyAliasRequest) (err error)
+	// SetPolicyShadow configures a candidate version, which is evaluated in shadow
+	// with the same input whenever the policy version is evaluated.
+	SetPolicyShadow(context.Context, *SetPolicyShadowRequest) (err error)
+	// DeletePolicyShadow disables the shadow evaluation of a policy version.
+	DeletePolicyShadow(context.Context, *DeletePolicyShadowRequest) (err error)
+	// SetPolicyCapture enables the recording of evaluation inputs, headers and
+	// results of a policy version, which can be replayed against another version.
+	SetPolicyCapture(context.Context, *SetPolicyCaptureRequest) (err error)
+	// DeletePolicyCapture disables the recording of evaluations of a policy
+	// version. Recorded evaluations are kept.
+	DeletePolicyCapture(context.Context, *DeletePolicyCaptureRequest) (err error)
+	// DeleteCapturedEvaluations removes the recorded evaluations of a policy
+	// version.
+	DeleteCapturedEvaluations(context.Context, *DeleteCapturedEvaluationsRequest) (err error)
+	// Replay evaluates the recorded evaluations of a policy version with another
+	// version or with an uploaded policy source and returns the differences.
+	Replay(context.Context, *ReplayRequest) (res *ReplayReport, err error)
 	// Subscribe for policy change notifications by registering webhook callbacks
 	// which the policy service will call.
 	SubscribeForPolicyChange(context.Context, *SubscribeRequest) (res any, err error)
@@ -62,7 +118,199 @@ const ServiceName = "policy"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [12]string{"Evaluate", "Validate", "Lock", "Unlock", "ExportBundle", "PolicyPublicKey", "ImportBundle", "ListPolicies", "SetPolicyAutoImport", "PolicyAutoImport", "DeletePolicyAutoImport", "SubscribeForPolicyChange"}
+var MethodNames = [31]string{"Evaluate", "EvaluateRule", "Validate", "PartialEvaluate", "EvaluateBatch", "EvaluateBatchStream", "EvaluationResult", "JobStatus", "Lock", "Unlock", "LockHistory", "PolicyTests", "ExportBundle", "PolicyPublicKey", "ImportBundle", "ListPolicies", "DecisionLogs", "AuditLog", "SetPolicyAutoImport", "PolicyAutoImport", "DeletePolicyAutoImport", "SetPolicyAlias", "PolicyAliases", "DeletePolicyAlias", "SetPolicyShadow", "DeletePolicyShadow", "SetPolicyCapture", "DeletePolicyCapture", "DeleteCapturedEvaluations", "Replay", "SubscribeForPolicyChange"}
+
+// AuditLogRequest is the payload type of the policy service AuditLog method.
+type AuditLogRequest struct {
+	Operation  *string
+	Actor      *string
+	Repository *string
+	Group      *string
+	PolicyName *string
+	Version    *string
+	From       *int64
+	To         *int64
+	Limit      int
+	Offset     *int
+}
+
+// AuditLogResult is the result type of the policy service AuditLog method.
+type AuditLogResult struct {
+	// JSON array of audit records.
+	Records []*AuditRecord
+}
+
+type AuditRecord struct {
+	// Executed operation.
+	Operation string
+	// Subject of the caller or 'system' for operations of the service.
+	Actor *string
+	// Client IP address of the caller.
+	ClientIP *string
+	// Policy repository.
+	Repository *string
+	// Policy group.
+	Group *string
+	// Policy name.
+	PolicyName *string
+	// Policy version.
+	Version *string
+	// Changed resource if it isn't a policy, e.g. a storage key or an import URL.
+	Target *string
+	// SHA256 hash of the resource before the operation.
+	BeforeHash *string
+	// SHA256 hash of the resource after the operation.
+	AfterHash *string
+	// Time of the operation (Unix timestamp).
+	Timestamp int64
+	// Policy version referenced by the resource before the operation, e.g. the
+	// version of an alias.
+	BeforeVersion *string
+	// Policy version referenced by the resource after the operation, e.g. the
+	// version of an alias.
+	AfterVersion *string
+}
+
+type BatchEvaluateItem struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Input data passed to the policy execution runtime.
+	Input any
+	// Identifier created by external system and passed as parameter to overwrite
+	// the randomly generated evaluationID.
+	EvaluationID *string
+	// TTL for storing policy result in cache
+	TTL *int
+}
+
+type BatchEvaluateItemResult struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Arbitrary JSON response.
+	Result any
+	// ETag contains unique identifier of the policy evaluation and can be used to
+	// later retrieve the results from Cache.
+	ETag *string
+	// Error message if the policy evaluation failed.
+	Error *string
+}
+
+// BatchEvaluateRequest is the payload type of the policy service EvaluateBatch
+// method.
+type BatchEvaluateRequest struct {
+	// Policy evaluations to execute.
+	Items []*BatchEvaluateItem
+}
+
+// BatchEvaluateResult is the result type of the policy service EvaluateBatch
+// method.
+type BatchEvaluateResult struct {
+	// Evaluation results in the same order as the request items.
+	Results []*BatchEvaluateItemResult
+}
+
+// BatchEvaluateStreamResult is the result type of the policy service
+// EvaluateBatchStream method.
+type BatchEvaluateStreamResult struct {
+	// Content-Type response header.
+	ContentType string
+}
+
+type Decision struct {
+	// Evaluation ID.
+	EvaluationID string
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Last update of the evaluated policy (Unix timestamp).
+	PolicyLastUpdate int64
+	// SHA256 hash of the evaluation input.
+	InputHash string
+	// Evaluation input with redacted fields (if configured).
+	Input any
+	// Evaluation result.
+	Result any
+	// Evaluation error.
+	Error *string
+	// Evaluation duration in milliseconds.
+	Duration int64
+	// Identity of the caller.
+	Caller *string
+	// Address of the caller.
+	ClientIP *string
+	// Time of the evaluation (Unix timestamp).
+	Timestamp int64
+	// Evaluated rule path inside the policy package.
+	Rule *string
+	// Request ID of the evaluated request, taken from the X-Request-Id header,
+	// e.g. set by Envoy.
+	RequestID *string
+}
+
+// DecisionLogsRequest is the payload type of the policy service DecisionLogs
+// method.
+type DecisionLogsRequest struct {
+	Repository   *string
+	Group        *string
+	PolicyName   *string
+	Version      *string
+	EvaluationID *string
+	Caller       *string
+	From         *int64
+	To           *int64
+	Limit        int
+	Offset       *int
+}
+
+// DecisionLogsResult is the result type of the policy service DecisionLogs
+// method.
+type DecisionLogsResult struct {
+	// JSON array of decisions.
+	Decisions []*Decision
+}
+
+// DeleteCapturedEvaluationsRequest is the payload type of the policy service
+// DeleteCapturedEvaluations method.
+type DeleteCapturedEvaluationsRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// DeletePolicyAliasRequest is the payload type of the policy service
+// DeletePolicyAlias method.
+type DeletePolicyAliasRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Alias name.
+	Alias string
+}
 
 // DeletePolicyAutoImportRequest is the payload type of the policy service
 // DeletePolicyAutoImport method.
@@ -71,6 +319,32 @@ type DeletePolicyAutoImportRequest struct {
 	PolicyURL string
 }
 
+// DeletePolicyCaptureRequest is the payload type of the policy service
+// DeletePolicyCapture method.
+type DeletePolicyCaptureRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// DeletePolicyShadowRequest is the payload type of the policy service
+// DeletePolicyShadow method.
+type DeletePolicyShadowRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
 // EvaluateRequest is the payload type of the policy service Evaluate method.
 type EvaluateRequest struct {
 	// Policy repository.
@@ -88,6 +362,28 @@ type EvaluateRequest struct {
 	EvaluationID *string
 	// TTL for storing policy result in cache
 	TTL *int
+	// Explain returns the evaluation trace and the output of print() calls
+	// together with the result. The result is then wrapped in a JSON object with
+	// 'result', 'explanation' and 'print' fields. Allowed only for admin callers.
+	Explain *string
+	// Path of a rule inside the policy package, e.g. 'allow' or
+	// 'violations/critical'. If it's given, only the value of the rule is returned
+	// instead of the whole package.
+	Rule *string
+	// Report returns the validation verdict together with the result instead of an
+	// error, when the policy output doesn't conform to the output schema. The
+	// result is then wrapped in a JSON object with 'result', 'valid' and 'errors'
+	// fields. Used only for validation.
+	Report *bool
+	// Coerce applies the default values of the output schema to the policy output
+	// before it's validated. Used only for validation.
+	Coerce *bool
+	// Async executes the evaluation in the background. The response only contains
+	// the job ID and the ETag, which can be used to query the job status and the
+	// evaluation result.
+	Async *bool
+	// URL receiving the result of an asynchronous evaluation with a POST request.
+	CallbackURL *string
 }
 
 // EvaluateResult is the result type of the policy service Evaluate method.
@@ -97,6 +393,54 @@ type EvaluateResult struct {
 	// ETag contains unique identifier of the policy evaluation and can be used to
 	// later retrieve the results from Cache.
 	ETag string
+	// Policy version which was evaluated, after resolving aliases, 'latest',
+	// 'stable' and semver ranges.
+	Version string
+	// Identifier of the asynchronous evaluation job.
+	JobID *string
+	// Status of the asynchronous evaluation job.
+	JobStatus *string
+}
+
+// EvaluationJob is the result type of the policy service JobStatus method.
+type EvaluationJob struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID string
+	// Identifier of the policy evaluation, which can be used to retrieve the
+	// result.
+	ETag string
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Path of the evaluated rule.
+	Rule *string
+	// Job status.
+	Status string
+	// Error message if the evaluation failed.
+	Error *string
+	// URL receiving the evaluation result.
+	CallbackURL *string
+	// Error message if the result couldn't be delivered to the callback URL.
+	CallbackError *string
+	// Creation time of the job as Unix timestamp.
+	CreatedAt int64
+	// Last update of the job as Unix timestamp.
+	UpdatedAt int64
+}
+
+// EvaluationResultRequest is the payload type of the policy service
+// EvaluationResult method.
+type EvaluationResultRequest struct {
+	// Identifier of the policy evaluation returned as ETag or set with the
+	// x-evaluation-id header.
+	EvaluationID string
+	// Seconds to wait for the result if it's not yet available (long-polling).
+	Wait *int
 }
 
 // ExportBundleRequest is the payload type of the policy service ExportBundle
@@ -129,6 +473,36 @@ type ImportBundlePayload struct {
 	Length *int
 }
 
+// JobStatusRequest is the payload type of the policy service JobStatus method.
+type JobStatusRequest struct {
+	// Identifier of the asynchronous evaluation job.
+	JobID string
+}
+
+// LockHistoryRequest is the payload type of the policy service LockHistory
+// method.
+type LockHistoryRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+// LockHistoryResult is the result type of the policy service LockHistory
+// method.
+type LockHistoryResult struct {
+	// Locked specifies if the policy is locked.
+	Locked bool
+	// Current lock of a locked policy.
+	Lock *PolicyLockEvent
+	// Previous lock and unlock events, oldest first.
+	History []*PolicyLockEvent
+}
+
 // LockRequest is the payload type of the policy service Lock method.
 type LockRequest struct {
 	// Policy repository.
@@ -139,6 +513,47 @@ type LockRequest struct {
 	PolicyName string
 	// Policy version.
 	Version string
+	// Reason for locking the policy.
+	Reason *string
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64
+}
+
+// PartialEvaluateRequest is the payload type of the policy service
+// PartialEvaluate method.
+type PartialEvaluateRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Name of the boolean policy rule which is evaluated.
+	Rule string
+	// References which are treated as unknown during evaluation.
+	Unknowns []string
+	// Known input data passed to the policy execution runtime.
+	Input any
+	// Target representation of the residual queries. If 'mongo' is given, the
+	// queries are also translated to a MongoDB filter document.
+	Target string
+}
+
+// PartialEvaluateResult is the result type of the policy service
+// PartialEvaluate method.
+type PartialEvaluateResult struct {
+	// Residual queries. The rule is true if any of the queries is true. No queries
+	// mean that the rule is never true.
+	Queries []string
+	// Support modules generated during partial evaluation.
+	Support []string
+	// MongoDB filter document equivalent to the residual queries.
+	Filter any
+	// Policy version which was evaluated, after resolving aliases, 'latest',
+	// 'stable' and semver ranges.
+	Version string
 }
 
 // PoliciesRequest is the payload type of the policy service ListPolicies
@@ -166,7 +581,7 @@ type Policy struct {
 	Group string
 	// Policy version.
 	Version string
-	// Policy rego source code.
+	// Policy rego source code of the main 'policy.rego' module.
 	Rego *string
 	// Policy static data.
 	Data *string
@@ -176,6 +591,61 @@ type Policy struct {
 	Locked bool
 	// Last update (Unix timestamp).
 	LastUpdate int64
+	// Policy rego modules by filename.
+	Modules map[string]string
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion *string
+	// Current lock of a locked policy.
+	Lock *PolicyLockEvent
+}
+
+// PolicyAlias is the result type of the policy service SetPolicyAlias method.
+type PolicyAlias struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Alias name.
+	Alias string
+	// Policy version referenced by the alias.
+	Version string
+	// Last update (Unix timestamp).
+	LastUpdate int64
+}
+
+// PolicyAliasesRequest is the payload type of the policy service PolicyAliases
+// method.
+type PolicyAliasesRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+}
+
+// PolicyAliasesResult is the result type of the policy service PolicyAliases
+// method.
+type PolicyAliasesResult struct {
+	// Named aliases of the policy.
+	Aliases []*PolicyAlias
+}
+
+type PolicyLockEvent struct {
+	// True for a lock and false for an unlock.
+	Locked bool
+	// Reason for (un)locking the policy.
+	Reason *string
+	// Subject of the caller who (un)locked the policy.
+	Actor *string
+	// Source of the event.
+	Source string
+	// Time when the policy is unlocked automatically (Unix timestamp).
+	UnlockAt *int64
+	// Time of the event (Unix timestamp).
+	Timestamp int64
 }
 
 // PolicyPublicKeyRequest is the payload type of the policy service
@@ -191,6 +661,130 @@ type PolicyPublicKeyRequest struct {
 	Version string
 }
 
+// PolicyTestReport is the result type of the policy service PolicyTests method.
+type PolicyTestReport struct {
+	// All tests of the policy passed.
+	Passed bool
+	// Error which prevented the tests from running.
+	Error *string
+	// Number of executed tests.
+	Total int
+	// Number of failed tests.
+	Failed int
+	// Percentage of the policy module lines evaluated by the tests.
+	Coverage float64
+	// Results of the test rules and golden test cases.
+	Results []*PolicyTestResult
+	// Time of the test run (Unix timestamp).
+	RunAt int64
+}
+
+type PolicyTestResult struct {
+	// Name of the test rule or golden test case.
+	Name string
+	// Location of the test.
+	Location string
+	// The test passed.
+	Passed bool
+	// Error or failure message of the test.
+	Error *string
+	// Test duration in milliseconds.
+	Duration int64
+}
+
+// PolicyTestsRequest is the payload type of the policy service PolicyTests
+// method.
+type PolicyTestsRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+}
+
+type ReplayDiff struct {
+	// Evaluation ID of the recorded evaluation.
+	EvaluationID string
+	// Evaluated rule path inside the policy package.
+	Rule *string
+	// Kind of difference.
+	Status string
+	// Recorded result.
+	Result any
+	// Result of the candidate.
+	CandidateResult any
+	// Recorded error.
+	Error *string
+	// Error of the candidate.
+	CandidateError *string
+	// Difference between the candidate and the recorded evaluation duration in
+	// milliseconds, excluding extension function calls.
+	DurationDelta float64
+	// Time of the recorded evaluation (Unix timestamp).
+	Timestamp int64
+}
+
+// ReplayReport is the result type of the policy service Replay method.
+type ReplayReport struct {
+	// Replayed policy version or 'rego' for an uploaded policy source.
+	Candidate string
+	// Number of replayed evaluations.
+	Total int
+	// Number of evaluations with the same result or error.
+	Unchanged int
+	// Number of evaluations with a different result.
+	Changed int
+	// Number of evaluations which fail only with the candidate.
+	NewErrors int
+	// Number of evaluations which fail only with the recorded version.
+	FixedErrors int
+	// Average difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	DurationDelta float64
+	// Maximum difference between the candidate and the recorded evaluation
+	// duration in milliseconds, excluding extension function calls.
+	MaxDurationDelta float64
+	// Evaluations whose result or error differs.
+	Diffs []*ReplayDiff
+}
+
+// ReplayRequest is the payload type of the policy service Replay method.
+type ReplayRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version whose recorded evaluations are replayed.
+	Version string
+	// Policy version which evaluates the recorded inputs.
+	CandidateVersion *string
+	// Source code of the main policy module, which replaces the module of the
+	// recorded version.
+	Rego *string
+	// Maximum number of replayed evaluations, newest first.
+	Limit int
+}
+
+// SetPolicyAliasRequest is the payload type of the policy service
+// SetPolicyAlias method.
+type SetPolicyAliasRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Alias name which can be used instead of the policy version.
+	Alias string
+	// Policy version referenced by the alias.
+	Version string
+}
+
 // SetPolicyAutoImportRequest is the payload type of the policy service
 // SetPolicyAutoImport method.
 type SetPolicyAutoImportRequest struct {
@@ -200,6 +794,51 @@ type SetPolicyAutoImportRequest struct {
 	Interval string
 }
 
+// SetPolicyCaptureRequest is the payload type of the policy service
+// SetPolicyCapture method.
+type SetPolicyCaptureRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Fraction of evaluations which are recorded.
+	SampleRate float64
+	// Maximum number of recorded evaluations of the policy version.
+	MaxRecords int
+	// Dot separated paths of input fields whose values are not recorded.
+	RedactFields []string
+	// Names of request headers whose values are not recorded.
+	RedactHeaders []string
+}
+
+// SetPolicyShadowRequest is the payload type of the policy service
+// SetPolicyShadow method.
+type SetPolicyShadowRequest struct {
+	// Policy repository.
+	Repository string
+	// Policy group.
+	Group string
+	// Policy name.
+	PolicyName string
+	// Policy version.
+	Version string
+	// Candidate policy version which is evaluated in shadow.
+	ShadowVersion string
+}
+
+// StoredEvaluationResult is the result type of the policy service
+// EvaluationResult method.
+type StoredEvaluationResult struct {
+	// Arbitrary JSON response.
+	Result any
+	// ETag contains unique identifier of the policy evaluation.
+	ETag string
+}
+
 // SubscribeRequest is the payload type of the policy service
 // SubscribeForPolicyChange method.
 type SubscribeRequest struct {
@@ -227,4 +866,6 @@ type UnlockRequest struct {
 	PolicyName string
 	// Policy version.
 	Version string
+	// Reason for unlocking the policy.
+	Reason *string
 }
@@ -0,0 +1,133 @@
+// Package audit records the state-changing operations of the service in
+// an append-only audit log. Every record contains the actor and client IP
+// of the caller and the hashes of the changed resource before and after
+// the operation, so that auditors can trace every change.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+//go:generate counterfeiter . Storage
+
+const (
+	// SystemActor is the actor of operations
+	// which the service executes by itself.
+	SystemActor = "system"
+
+	// AnonymousActor is the actor of operations whose
+	// caller has no verified bearer token.
+	AnonymousActor = "anonymous"
+
+	// saveTimeout limits the duration of saving an audit record.
+	saveTimeout = 10 * time.Second
+)
+
+// Storage persists audit records. Records are never updated or deleted.
+type Storage interface {
+	SaveAuditRecord(ctx context.Context, record *storage.AuditRecord) error
+	AuditRecords(ctx context.Context, filter *storage.AuditFilter) ([]*storage.AuditRecord, error)
+}
+
+type Log struct {
+	storage Storage
+	logger  *zap.Logger
+}
+
+func New(storage Storage, logger *zap.Logger) *Log {
+	return &Log{storage: storage, logger: logger}
+}
+
+// Record saves the audit record of an operation. The actor and client IP
+// are taken from the caller in the context, unless the record sets the actor.
+// Only the subject of a verified bearer token is recorded as actor, otherwise
+// the actor is anonymous. Errors are only logged, because the operation has
+// already been executed.
+func (l *Log) Record(ctx context.Context, r *storage.AuditRecord) {
+	if r.Actor == "" {
+		r.Actor = AnonymousActor
+		if c, ok := caller.FromContext(ctx); ok {
+			if c.Verified && c.Subject != "" {
+				r.Actor = c.Subject
+			}
+			r.ClientIP = c.ClientIP
+		}
+	}
+	if r.Timestamp.IsZero() {
+		r.Timestamp = time.Now()
+	}
+
+	// the record is saved even if the request is cancelled after the operation
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), saveTimeout)
+	defer cancel()
+
+	if err := l.storage.SaveAuditRecord(ctx, r); err != nil {
+		l.logger.Error("error saving audit record",
+			zap.String("operation", r.Operation),
+			zap.String("actor", r.Actor),
+			zap.Error(err),
+		)
+	}
+}
+
+// Records returns the audit records matching the filter, newest first.
+func (l *Log) Records(ctx context.Context, filter *storage.AuditFilter) ([]*storage.AuditRecord, error) {
+	return l.storage.AuditRecords(ctx, filter)
+}
+
+// Hash returns the SHA256 hash of the JSON encoded value.
+// Nil values have an empty hash.
+func Hash(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// PolicyHash returns the hash of the content and the lock state of a policy.
+// Metadata which changes without changing the policy, like the time of the
+// last update, isn't included. Nil policies have an empty hash.
+func PolicyHash(p *storage.Policy) string {
+	if p == nil {
+		return ""
+	}
+
+	return Hash(struct {
+		Modules       []storage.Module
+		Data          string
+		DataConfig    string
+		OutputSchema  string
+		InputSchema   string
+		ExportConfig  string
+		RuntimeConfig string
+		Tests         []storage.Module
+		TestCases     string
+		Locked        bool
+	}{
+		Modules:       p.Modules,
+		Data:          p.Data,
+		DataConfig:    p.DataConfig,
+		OutputSchema:  p.OutputSchema,
+		InputSchema:   p.InputSchema,
+		ExportConfig:  p.ExportConfig,
+		RuntimeConfig: p.RuntimeConfig,
+		Tests:         p.Tests,
+		TestCases:     p.TestCases,
+		Locked:        p.Locked,
+	})
+}
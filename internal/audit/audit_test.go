@@ -0,0 +1,126 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit/auditfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func TestLog_Record(t *testing.T) {
+	token, err := jwt.NewBuilder().Subject("admin").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.RemoteAddr = "8.8.8.8:1234"
+	r.Header.Set("Authorization", "Bearer "+string(signed))
+	ctx := caller.ToContext(context.Background(), r, caller.WithVerifiedTokens())
+
+	t.Run("actor and client IP are taken from the caller", func(t *testing.T) {
+		s := &auditfakes.FakeStorage{}
+		audit.New(s, zap.NewNop()).Record(ctx, &storage.AuditRecord{Operation: storage.AuditLock})
+
+		require.Equal(t, 1, s.SaveAuditRecordCallCount())
+		_, record := s.SaveAuditRecordArgsForCall(0)
+		assert.Equal(t, storage.AuditLock, record.Operation)
+		assert.Equal(t, "admin", record.Actor)
+		assert.Equal(t, "8.8.8.8", record.ClientIP)
+		assert.False(t, record.Timestamp.IsZero())
+	})
+
+	t.Run("caller with unverified token is anonymous", func(t *testing.T) {
+		s := &auditfakes.FakeStorage{}
+		r := r.Clone(context.Background())
+		r.Header.Set("X-Forwarded-For", "1.2.3.4")
+		audit.New(s, zap.NewNop()).Record(caller.ToContext(context.Background(), r), &storage.AuditRecord{Operation: storage.AuditLock})
+
+		_, record := s.SaveAuditRecordArgsForCall(0)
+		assert.Equal(t, audit.AnonymousActor, record.Actor)
+		assert.Equal(t, "8.8.8.8", record.ClientIP)
+	})
+
+	t.Run("actor of the record isn't replaced", func(t *testing.T) {
+		s := &auditfakes.FakeStorage{}
+		timestamp := time.Unix(1700000000, 0)
+		audit.New(s, zap.NewNop()).Record(ctx, &storage.AuditRecord{
+			Operation: storage.AuditUnlock,
+			Actor:     audit.SystemActor,
+			Timestamp: timestamp,
+		})
+
+		_, record := s.SaveAuditRecordArgsForCall(0)
+		assert.Equal(t, audit.SystemActor, record.Actor)
+		assert.Empty(t, record.ClientIP)
+		assert.Equal(t, timestamp, record.Timestamp)
+	})
+
+	t.Run("record is saved after the request is cancelled", func(t *testing.T) {
+		var saveErr error
+		s := &auditfakes.FakeStorage{SaveAuditRecordStub: func(ctx context.Context, _ *storage.AuditRecord) error {
+			saveErr = ctx.Err()
+			return saveErr
+		}}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		audit.New(s, zap.NewNop()).Record(ctx, &storage.AuditRecord{Operation: storage.AuditLock})
+		assert.Equal(t, 1, s.SaveAuditRecordCallCount())
+		assert.NoError(t, saveErr)
+	})
+
+	t.Run("storage error isn't returned", func(t *testing.T) {
+		s := &auditfakes.FakeStorage{SaveAuditRecordStub: func(context.Context, *storage.AuditRecord) error {
+			return fmt.Errorf("some error")
+		}}
+		audit.New(s, zap.NewNop()).Record(context.Background(), &storage.AuditRecord{Operation: storage.AuditLock})
+		assert.Equal(t, 1, s.SaveAuditRecordCallCount())
+	})
+}
+
+func TestPolicyHash(t *testing.T) {
+	p := &storage.Policy{
+		Name:       "example",
+		Modules:    []storage.Module{{Filename: "policy.rego", Rego: "package example"}},
+		Data:       `{"key":"value"}`,
+		LastUpdate: time.Now(),
+	}
+
+	assert.Empty(t, audit.PolicyHash(nil))
+	assert.Len(t, audit.PolicyHash(p), 64)
+
+	// metadata doesn't change the hash
+	updated := *p
+	updated.LastUpdate = time.Now().Add(time.Hour)
+	assert.Equal(t, audit.PolicyHash(p), audit.PolicyHash(&updated))
+
+	// content and the lock state change the hash
+	updated.Data = `{"key":"other"}`
+	assert.NotEqual(t, audit.PolicyHash(p), audit.PolicyHash(&updated))
+
+	locked := *p
+	locked.Locked = true
+	assert.NotEqual(t, audit.PolicyHash(p), audit.PolicyHash(&locked))
+}
+
+func TestHash(t *testing.T) {
+	assert.Empty(t, audit.Hash(nil))
+	assert.Equal(t,
+		audit.Hash(map[string]any{"a": 1, "b": "c"}),
+		audit.Hash(map[string]any{"b": "c", "a": 1}),
+	)
+	assert.NotEqual(t, audit.Hash(map[string]any{"a": 1}), audit.Hash(map[string]any{"a": 2}))
+}
@@ -0,0 +1,196 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package auditfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type FakeStorage struct {
+	AuditRecordsStub        func(context.Context, *storage.AuditFilter) ([]*storage.AuditRecord, error)
+	auditRecordsMutex       sync.RWMutex
+	auditRecordsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.AuditFilter
+	}
+	auditRecordsReturns struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}
+	auditRecordsReturnsOnCall map[int]struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}
+	SaveAuditRecordStub        func(context.Context, *storage.AuditRecord) error
+	saveAuditRecordMutex       sync.RWMutex
+	saveAuditRecordArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.AuditRecord
+	}
+	saveAuditRecordReturns struct {
+		result1 error
+	}
+	saveAuditRecordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStorage) AuditRecords(arg1 context.Context, arg2 *storage.AuditFilter) ([]*storage.AuditRecord, error) {
+	fake.auditRecordsMutex.Lock()
+	ret, specificReturn := fake.auditRecordsReturnsOnCall[len(fake.auditRecordsArgsForCall)]
+	fake.auditRecordsArgsForCall = append(fake.auditRecordsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.AuditFilter
+	}{arg1, arg2})
+	stub := fake.AuditRecordsStub
+	fakeReturns := fake.auditRecordsReturns
+	fake.recordInvocation("AuditRecords", []interface{}{arg1, arg2})
+	fake.auditRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) AuditRecordsCallCount() int {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	return len(fake.auditRecordsArgsForCall)
+}
+
+func (fake *FakeStorage) AuditRecordsCalls(stub func(context.Context, *storage.AuditFilter) ([]*storage.AuditRecord, error)) {
+	fake.auditRecordsMutex.Lock()
+	defer fake.auditRecordsMutex.Unlock()
+	fake.AuditRecordsStub = stub
+}
+
+func (fake *FakeStorage) AuditRecordsArgsForCall(i int) (context.Context, *storage.AuditFilter) {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	argsForCall := fake.auditRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) AuditRecordsReturns(result1 []*storage.AuditRecord, result2 error) {
+	fake.auditRecordsMutex.Lock()
+	defer fake.auditRecordsMutex.Unlock()
+	fake.AuditRecordsStub = nil
+	fake.auditRecordsReturns = struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) AuditRecordsReturnsOnCall(i int, result1 []*storage.AuditRecord, result2 error) {
+	fake.auditRecordsMutex.Lock()
+	defer fake.auditRecordsMutex.Unlock()
+	fake.AuditRecordsStub = nil
+	if fake.auditRecordsReturnsOnCall == nil {
+		fake.auditRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.AuditRecord
+			result2 error
+		})
+	}
+	fake.auditRecordsReturnsOnCall[i] = struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SaveAuditRecord(arg1 context.Context, arg2 *storage.AuditRecord) error {
+	fake.saveAuditRecordMutex.Lock()
+	ret, specificReturn := fake.saveAuditRecordReturnsOnCall[len(fake.saveAuditRecordArgsForCall)]
+	fake.saveAuditRecordArgsForCall = append(fake.saveAuditRecordArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.AuditRecord
+	}{arg1, arg2})
+	stub := fake.SaveAuditRecordStub
+	fakeReturns := fake.saveAuditRecordReturns
+	fake.recordInvocation("SaveAuditRecord", []interface{}{arg1, arg2})
+	fake.saveAuditRecordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SaveAuditRecordCallCount() int {
+	fake.saveAuditRecordMutex.RLock()
+	defer fake.saveAuditRecordMutex.RUnlock()
+	return len(fake.saveAuditRecordArgsForCall)
+}
+
+func (fake *FakeStorage) SaveAuditRecordCalls(stub func(context.Context, *storage.AuditRecord) error) {
+	fake.saveAuditRecordMutex.Lock()
+	defer fake.saveAuditRecordMutex.Unlock()
+	fake.SaveAuditRecordStub = stub
+}
+
+func (fake *FakeStorage) SaveAuditRecordArgsForCall(i int) (context.Context, *storage.AuditRecord) {
+	fake.saveAuditRecordMutex.RLock()
+	defer fake.saveAuditRecordMutex.RUnlock()
+	argsForCall := fake.saveAuditRecordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SaveAuditRecordReturns(result1 error) {
+	fake.saveAuditRecordMutex.Lock()
+	defer fake.saveAuditRecordMutex.Unlock()
+	fake.SaveAuditRecordStub = nil
+	fake.saveAuditRecordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SaveAuditRecordReturnsOnCall(i int, result1 error) {
+	fake.saveAuditRecordMutex.Lock()
+	defer fake.saveAuditRecordMutex.Unlock()
+	fake.SaveAuditRecordStub = nil
+	if fake.saveAuditRecordReturnsOnCall == nil {
+		fake.saveAuditRecordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveAuditRecordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	fake.saveAuditRecordMutex.RLock()
+	defer fake.saveAuditRecordMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStorage) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ audit.Storage = new(FakeStorage)
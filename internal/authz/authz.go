@@ -0,0 +1,208 @@
+// Package authz authorizes the requests to the service's own HTTP and gRPC API
+// with a stored policy. The policy is evaluated before every Goa endpoint and
+// gRPC method with the method name, the path parameters and the identity of
+// the caller as input.
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+	goa "goa.design/goa/v3/pkg"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	policysvc "github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+type pathParamsKey struct{}
+
+// evaluateMethod is the method as which the items of batch evaluations are authorized.
+const evaluateMethod = "Evaluate"
+
+// Evaluator evaluates the authorization policy without storing the result.
+type Evaluator interface {
+	Authorize(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error)
+}
+
+// Policy is the stored policy which authorizes the API requests.
+type Policy struct {
+	Repository string
+	Group      string
+	Name       string
+	Version    string
+	// Rule is the path of the rule inside the policy package, whose value is
+	// the decision. If it's empty, the value of the whole package is the decision.
+	Rule string
+}
+
+// Authorizer evaluates the authorization policy for the API requests.
+type Authorizer struct {
+	evaluator Evaluator
+	policy    Policy
+	logger    *zap.Logger
+}
+
+// decision is the structured result of the authorization policy.
+type decision struct {
+	Allowed *bool  `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+func New(evaluator Evaluator, pol Policy, logger *zap.Logger) *Authorizer {
+	return &Authorizer{
+		evaluator: evaluator,
+		policy:    pol,
+		logger:    logger,
+	}
+}
+
+// PathParams is an HTTP server middleware that adds the path parameters of
+// the request to the context, so that they are available to the policy.
+// It must be applied to the servers mounted on the mux whose vars are given.
+func PathParams(vars func(*http.Request) map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), pathParamsKey{}, vars(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Endpoint is a Goa endpoint middleware that evaluates the authorization policy
+// before the endpoint. A denied request returns a Forbidden error. The items of
+// batch evaluations are additionally authorized as Evaluate with the policy version
+// of the item as path parameters, and denied items report the error in their result.
+func (a *Authorizer) Endpoint(next goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		service, _ := ctx.Value(goa.ServiceKey).(string)
+		method, _ := ctx.Value(goa.MethodKey).(string)
+
+		if err := a.Authorize(ctx, service, method, pathParams(ctx)); err != nil {
+			return nil, err
+		}
+
+		ctx = policysvc.WithItemAuthorizer(ctx, func(ctx context.Context, params map[string]string) error {
+			return a.Authorize(ctx, service, evaluateMethod, params)
+		})
+
+		return next(ctx, req)
+	}
+}
+
+// Authorize evaluates the authorization policy with the request attributes as input:
+//
+//	{
+//	  "service": "policy",
+//	  "method": "Unlock",
+//	  "pathParams": {"repository": "policies", "group": "example", ...},
+//	  "subject": "user-1",
+//	  "claims": {"sub": "user-1", "groups": ["ops"], ...},
+//	  "clientIP": "8.8.8.8"
+//	}
+//
+// The subject and claims are only set if the bearer token is verified by the
+// authentication middleware. The result is either a boolean or an object with
+// "allowed" and an optional "reason". An undefined result denies the request.
+func (a *Authorizer) Authorize(ctx context.Context, service, method string, params map[string]string) error {
+	logger := a.logger.With(
+		zap.String("operation", "authorize"),
+		zap.String("service", service),
+		zap.String("method", method),
+	)
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	input := map[string]interface{}{
+		"service":    service,
+		"method":     method,
+		"pathParams": params,
+		"claims":     map[string]interface{}{},
+	}
+	if c, ok := caller.FromContext(ctx); ok {
+		input["clientIP"] = c.ClientIP
+		if c.Verified {
+			input["subject"] = c.Subject
+			if c.Claims != nil {
+				input["claims"] = c.Claims
+			}
+		}
+	}
+
+	req := &policy.EvaluateRequest{
+		Repository: a.policy.Repository,
+		Group:      a.policy.Group,
+		PolicyName: a.policy.Name,
+		Version:    a.policy.Version,
+		Input:      input,
+	}
+	if a.policy.Rule != "" {
+		req.Rule = ptr.String(a.policy.Rule)
+	}
+
+	res, err := a.evaluator.Authorize(ctx, req)
+	if err != nil {
+		if policysvc.IsUndefined(err) {
+			return errors.New(errors.Forbidden, "access denied")
+		}
+		logger.Error("error evaluating authorization policy", zap.Error(err))
+		return errors.New(errors.Internal, "error evaluating authorization policy", err)
+	}
+
+	d, err := toDecision(res.Result)
+	if err != nil {
+		logger.Error("invalid authorization policy result", zap.Error(err))
+		return errors.New(errors.Internal, "invalid authorization policy result", err)
+	}
+
+	if !*d.Allowed {
+		msg := "access denied"
+		if d.Reason != "" {
+			msg += ": " + d.Reason
+		}
+		return errors.New(errors.Forbidden, msg)
+	}
+
+	return nil
+}
+
+func pathParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(pathParamsKey{}).(map[string]string)
+	if params == nil {
+		return map[string]string{}
+	}
+	return params
+}
+
+func toDecision(result any) (*decision, error) {
+	if allowed, ok := result.(bool); ok {
+		return &decision{Allowed: &allowed}, nil
+	}
+
+	if _, ok := result.(map[string]any); !ok {
+		return nil, fmt.Errorf("result must be a boolean or an object, got %T", result)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var d decision
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+
+	if d.Allowed == nil {
+		return nil, fmt.Errorf("result doesn't contain boolean 'allowed'")
+	}
+
+	return &d, nil
+}
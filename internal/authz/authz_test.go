@@ -0,0 +1,285 @@
+package authz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	goa "goa.design/goa/v3/pkg"
+
+	goapolicy "github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/authz"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policyfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const authzPolicy = `package api.authz
+
+default allow = false
+
+# only the ops group may unlock policies
+allow {
+	input.method == "Unlock"
+	input.claims.groups[_] == "ops"
+}
+
+# partner X may only evaluate the policies of group gaiax
+allow {
+	input.service == "policy"
+	input.method == "Evaluate"
+	input.subject == "partner-x"
+	input.pathParams.group == "gaiax"
+}
+
+# partner X may evaluate batches, whose items are authorized as Evaluate
+allow {
+	input.method == "EvaluateBatch"
+	input.subject == "partner-x"
+}
+
+decision = {"allowed": false, "reason": "address is blocked"} {
+	input.clientIP == "10.0.0.1"
+}
+
+decision = {"allowed": true} {
+	input.clientIP != "10.0.0.1"
+}
+
+invalid = "yes"
+`
+
+func TestAuthorizer_Endpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       string
+		method     string
+		subject    string
+		groups     []string
+		clientIP   string
+		pathParams map[string]string
+		unverified bool
+
+		errKind errors.Kind
+		errText string
+	}{
+		{
+			name:    "ops group may unlock",
+			rule:    "allow",
+			method:  "Unlock",
+			subject: "user-1",
+			groups:  []string{"dev", "ops"},
+		},
+		{
+			name:    "other groups may not unlock",
+			rule:    "allow",
+			method:  "Unlock",
+			subject: "user-1",
+			groups:  []string{"dev"},
+			errKind: errors.Forbidden,
+			errText: "access denied",
+		},
+		{
+			name:       "partner may evaluate group gaiax",
+			rule:       "allow",
+			method:     "Evaluate",
+			subject:    "partner-x",
+			pathParams: map[string]string{"repository": "policies", "group": "gaiax", "policyName": "example", "version": "1.0"},
+		},
+		{
+			name:       "unverified token has no subject",
+			rule:       "allow",
+			method:     "Evaluate",
+			subject:    "partner-x",
+			pathParams: map[string]string{"repository": "policies", "group": "gaiax", "policyName": "example", "version": "1.0"},
+			unverified: true,
+			errKind:    errors.Forbidden,
+			errText:    "access denied",
+		},
+		{
+			name:       "partner may not evaluate other groups",
+			rule:       "allow",
+			method:     "Evaluate",
+			subject:    "partner-x",
+			pathParams: map[string]string{"repository": "policies", "group": "example", "policyName": "example", "version": "1.0"},
+			errKind:    errors.Forbidden,
+			errText:    "access denied",
+		},
+		{
+			name:     "denied decision returns the reason",
+			rule:     "decision",
+			method:   "Lock",
+			clientIP: "10.0.0.1",
+			errKind:  errors.Forbidden,
+			errText:  "access denied: address is blocked",
+		},
+		{
+			name:     "allowed decision",
+			rule:     "decision",
+			method:   "Lock",
+			clientIP: "10.0.0.2",
+		},
+		{
+			name:    "undefined result denies the request",
+			rule:    "missing",
+			method:  "Lock",
+			errKind: errors.Forbidden,
+			errText: "access denied",
+		},
+		{
+			name:    "invalid result fails the request",
+			rule:    "invalid",
+			method:  "Lock",
+			errKind: errors.Internal,
+			errText: "invalid authorization policy result",
+		},
+	}
+
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: authzPolicy}},
+			}, nil
+		},
+	}
+	cache := &policyfakes.FakeCache{}
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorizer := authz.New(svc, authz.Policy{
+				Repository: "policies",
+				Group:      "api",
+				Name:       "authz",
+				Version:    "1.0",
+				Rule:       test.rule,
+			}, zap.NewNop())
+
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if test.clientIP != "" {
+				r.RemoteAddr = test.clientIP + ":1234"
+			}
+			if test.subject != "" {
+				builder := jwt.NewBuilder().Subject(test.subject).Expiration(time.Now().Add(time.Hour))
+				if test.groups != nil {
+					builder = builder.Claim("groups", test.groups)
+				}
+				token, err := builder.Build()
+				require.NoError(t, err)
+				signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+				require.NoError(t, err)
+				r.Header.Set("Authorization", "Bearer "+string(signed))
+			}
+
+			// the path parameters are added by the HTTP middleware
+			var ctx context.Context
+			authz.PathParams(func(*http.Request) map[string]string {
+				return test.pathParams
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx = r.Context()
+			})).ServeHTTP(httptest.NewRecorder(), r)
+
+			var callerOpts []caller.Option
+			if !test.unverified {
+				callerOpts = append(callerOpts, caller.WithVerifiedTokens())
+			}
+			ctx = caller.ToContext(ctx, r, callerOpts...)
+			ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+			ctx = context.WithValue(ctx, goa.MethodKey, test.method)
+
+			called := false
+			endpoint := authorizer.Endpoint(func(ctx context.Context, req any) (any, error) {
+				called = true
+				return "result", nil
+			})
+
+			res, err := endpoint(ctx, nil)
+			if test.errText != "" {
+				require.Error(t, err)
+				assert.False(t, called)
+				assert.Nil(t, res)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, test.errKind, e.Kind)
+				assert.Equal(t, test.errText, e.Message)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, "result", res)
+		})
+	}
+
+	// results of the authorization policy aren't stored in cache
+	assert.Equal(t, 0, cache.SetCallCount())
+}
+
+func TestAuthorizer_EndpointBatch(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			rego := authzPolicy
+			if group != "api" {
+				rego = fmt.Sprintf("package %s.%s allow := true", group, name)
+			}
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: rego}},
+			}, nil
+		},
+	}
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+	authorizer := authz.New(svc, authz.Policy{
+		Repository: "policies",
+		Group:      "api",
+		Name:       "authz",
+		Version:    "1.0",
+		Rule:       "allow",
+	}, zap.NewNop())
+
+	token, err := jwt.NewBuilder().Subject("partner-x").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+string(signed))
+
+	ctx := caller.ToContext(context.Background(), r, caller.WithVerifiedTokens())
+	ctx = context.WithValue(ctx, goa.ServiceKey, "policy")
+	ctx = context.WithValue(ctx, goa.MethodKey, "EvaluateBatch")
+
+	endpoint := authorizer.Endpoint(func(ctx context.Context, req any) (any, error) {
+		return svc.EvaluateBatch(ctx, req.(*goapolicy.BatchEvaluateRequest))
+	})
+
+	res, err := endpoint(ctx, &goapolicy.BatchEvaluateRequest{Items: []*goapolicy.BatchEvaluateItem{
+		{Repository: "policies", Group: "gaiax", PolicyName: "example", Version: "1.0"},
+		{Repository: "policies", Group: "example", PolicyName: "example", Version: "1.0"},
+	}})
+	require.NoError(t, err)
+
+	// every item is authorized with its own policy
+	results := res.(*goapolicy.BatchEvaluateResult).Results
+	require.Len(t, results, 2)
+	assert.Nil(t, results[0].Error)
+	assert.Equal(t, map[string]interface{}{"allow": true}, results[0].Result)
+	require.NotNil(t, results[1].Error)
+	assert.Contains(t, *results[1].Error, "access denied")
+}
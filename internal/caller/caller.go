@@ -0,0 +1,166 @@
+// Package caller extracts the identity of the client making an HTTP
+// request and makes it available to the services through the request
+// context.
+package caller
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/tomasen/realip"
+)
+
+type key string
+
+const callerKey key = "caller"
+
+// Caller describes the client of a request.
+type Caller struct {
+	// Subject is the 'sub' claim of the bearer JWT token.
+	Subject string
+	// Claims contains all claims of the bearer JWT token.
+	Claims map[string]interface{}
+	// Verified reports whether the bearer token is verified by the
+	// authentication middleware. The subject and claims of unverified
+	// tokens can be chosen by the client and must not be trusted.
+	Verified bool
+	// ClientIP is the remote address of the connection, or the address
+	// taken from the request headers if the connection is from a trusted proxy.
+	ClientIP string
+}
+
+// Option configures how the caller is taken from a request.
+type Option func(*options)
+
+type options struct {
+	verified       bool
+	trustedProxies []netip.Prefix
+}
+
+// WithVerifiedTokens marks the bearer tokens as verified. It must only be
+// used when the authentication middleware verifies the tokens of all requests.
+func WithVerifiedTokens() Option {
+	return func(o *options) {
+		o.verified = true
+	}
+}
+
+// WithTrustedProxies sets the proxies whose X-Forwarded-For and
+// X-Real-IP headers are used to get the client IP.
+func WithTrustedProxies(proxies ...netip.Prefix) Option {
+	return func(o *options) {
+		o.trustedProxies = proxies
+	}
+}
+
+// ParseProxies parses a list of IP addresses and CIDR ranges of proxies.
+func ParseProxies(proxies []string) ([]netip.Prefix, error) {
+	res := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		if addr, err := netip.ParseAddr(p); err == nil {
+			res = append(res, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, prefix.Masked())
+	}
+
+	return res, nil
+}
+
+// Middleware is an HTTP server middleware that gets the caller identity
+// and adds it to a request context value.
+//
+// The bearer token is parsed without signature verification, because
+// verification is the responsibility of the authentication middleware.
+func Middleware(opts ...Option) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ToContext(r.Context(), r, opts...)
+			req := r.WithContext(ctx)
+
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+func ToContext(ctx context.Context, r *http.Request, opts ...Option) context.Context {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Caller{ClientIP: clientIP(r, o.trustedProxies)}
+
+	auth := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(auth) == 2 && auth[0] == "Bearer" {
+		if token, err := jwt.ParseInsecure([]byte(auth[1])); err == nil {
+			c.Subject = token.Subject()
+			c.Claims, _ = token.AsMap(ctx)
+			c.Verified = o.verified
+		}
+	}
+
+	return context.WithValue(ctx, callerKey, c)
+}
+
+// clientIP returns the host of the remote address. The request headers
+// are only used if the remote address is one of the trusted proxies.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(addr.Unmap()) {
+			return realip.FromRequest(r)
+		}
+	}
+
+	return host
+}
+
+// WithCaller returns a context with the given caller, e.g. when
+// a stored request is executed without an HTTP request.
+func WithCaller(ctx context.Context, c *Caller) context.Context {
+	return context.WithValue(ctx, callerKey, c)
+}
+
+func FromContext(ctx context.Context) (*Caller, bool) {
+	c, ok := ctx.Value(callerKey).(*Caller)
+	return c, ok
+}
+
+// HasSubject returns a check reporting whether the caller in the
+// context has one of the given subjects. The check always fails if
+// no subjects are given or the token of the caller is not verified.
+func HasSubject(subjects ...string) func(ctx context.Context) bool {
+	return func(ctx context.Context) bool {
+		c, ok := FromContext(ctx)
+		if !ok || !c.Verified || c.Subject == "" {
+			return false
+		}
+
+		for _, s := range subjects {
+			if s == c.Subject {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package caller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+)
+
+func TestMiddleware(t *testing.T) {
+	token, err := jwt.NewBuilder().Subject("user-1").Claim("groups", []string{"ops"}).Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/example", nil)
+	req.RemoteAddr = "8.8.8.8:1234"
+	req.Header = http.Header{"Authorization": []string{"Bearer " + string(signed)}}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := caller.FromContext(r.Context())
+		require.True(t, ok)
+		assert.Equal(t, "user-1", c.Subject)
+		assert.True(t, c.Verified)
+		assert.Equal(t, "8.8.8.8", c.ClientIP)
+		assert.Equal(t, []interface{}{"ops"}, c.Claims["groups"])
+	})
+
+	middleware := caller.Middleware(caller.WithVerifiedTokens())
+	handlerToTest := middleware(nextHandler)
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestMiddleware_WithoutToken(t *testing.T) {
+	req := httptest.NewRequest("POST", "/example", nil)
+	req.RemoteAddr = "8.8.8.8:1234"
+	req.Header = http.Header{"X-Forwarded-For": []string{"1.2.3.4"}}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := caller.FromContext(r.Context())
+		require.True(t, ok)
+		assert.Empty(t, c.Subject)
+		assert.Nil(t, c.Claims)
+		assert.False(t, c.Verified)
+		assert.Equal(t, "8.8.8.8", c.ClientIP)
+	})
+
+	middleware := caller.Middleware()
+	handlerToTest := middleware(nextHandler)
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestMiddleware_UnverifiedToken(t *testing.T) {
+	token, err := jwt.NewBuilder().Subject("user-1").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/example", nil)
+	req.Header = http.Header{"Authorization": []string{"Bearer " + string(signed)}}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := caller.FromContext(r.Context())
+		require.True(t, ok)
+		assert.Equal(t, "user-1", c.Subject)
+		assert.False(t, c.Verified)
+	})
+
+	middleware := caller.Middleware()
+	handlerToTest := middleware(nextHandler)
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestMiddleware_TrustedProxies(t *testing.T) {
+	proxies, err := caller.ParseProxies([]string{"10.0.0.1", "192.168.0.0/16"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		clientIP   string
+	}{
+		{
+			name:       "proxy address",
+			remoteAddr: "10.0.0.1:1234",
+			clientIP:   "1.2.3.4",
+		},
+		{
+			name:       "proxy range",
+			remoteAddr: "192.168.1.1:1234",
+			clientIP:   "1.2.3.4",
+		},
+		{
+			name:       "untrusted address",
+			remoteAddr: "10.0.0.2:1234",
+			clientIP:   "10.0.0.2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/example", nil)
+			req.RemoteAddr = test.remoteAddr
+			req.Header = http.Header{"X-Forwarded-For": []string{"1.2.3.4"}}
+
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c, ok := caller.FromContext(r.Context())
+				require.True(t, ok)
+				assert.Equal(t, test.clientIP, c.ClientIP)
+			})
+
+			middleware := caller.Middleware(caller.WithTrustedProxies(proxies...))
+			handlerToTest := middleware(nextHandler)
+			handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
+
+func TestParseProxies(t *testing.T) {
+	_, err := caller.ParseProxies([]string{"proxy.example.com"})
+	assert.Error(t, err)
+}
+
+func TestHasSubject(t *testing.T) {
+	token, err := jwt.NewBuilder().Subject("admin").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/example", nil)
+	req.Header = http.Header{"Authorization": []string{"Bearer " + string(signed)}}
+	ctx := caller.ToContext(context.Background(), req, caller.WithVerifiedTokens())
+
+	assert.True(t, caller.HasSubject("user", "admin")(ctx))
+	assert.False(t, caller.HasSubject("user")(ctx))
+	assert.False(t, caller.HasSubject()(ctx))
+	assert.False(t, caller.HasSubject("admin")(context.Background()))
+
+	unverified := caller.ToContext(context.Background(), req)
+	assert.False(t, caller.HasSubject("admin")(unverified))
+}
@@ -69,14 +69,15 @@ func (c *Client) Get(ctx context.Context, key, namespace, scope string) ([]byte,
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", c.addr+"/v1/cache", nil)
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header = http.Header{
 		"x-cache-key":       []string{key},
 		"x-cache-namespace": []string{namespace},
 		"x-cache-scope":     []string{scope},
 	}
-	if err != nil {
-		return nil, err
-	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -15,11 +15,12 @@ import (
 const eventType = "update_policy"
 
 type Client struct {
-	sender *nats.Sender
-	events cloudevents.Client
+	sender    *nats.Sender
+	events    cloudevents.Client
+	eventType string
 }
 
-func New(addr, subject string) (*Client, error) {
+func New(addr, subject string, opts ...Option) (*Client, error) {
 	// create cloudevents nats sender
 	// other protocol implementations: https://github.com/cloudevents/sdk-go/tree/main/protocol
 	sender, err := nats.NewSender(addr, subject, nats.NatsOptions())
@@ -33,14 +34,20 @@ func New(addr, subject string) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
-		sender: sender,
-		events: eventsClient,
-	}, nil
+	c := &Client{
+		sender:    sender,
+		events:    eventsClient,
+		eventType: eventType,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
 func (c *Client) Send(ctx context.Context, data any) error {
-	e, err := newEvent(data)
+	e, err := newEvent(c.eventType, data)
 	if err != nil {
 		return err
 	}
@@ -57,7 +64,7 @@ func (c *Client) Close(ctx context.Context) error {
 	return c.sender.Close(ctx)
 }
 
-func newEvent(data any) (*event.Event, error) {
+func newEvent(eventType string, data any) (*event.Event, error) {
 	e := cloudevents.NewEvent()
 	e.SetID(uuid.NewString()) // required field
 	e.SetSource("policy")     // required field
@@ -0,0 +1,11 @@
+package nats
+
+type Option func(*Client)
+
+// WithEventType overwrites the type of the sent cloud events,
+// which is 'update_policy' by default.
+func WithEventType(t string) Option {
+	return func(c *Client) {
+		c.eventType = t
+	}
+}
@@ -18,20 +18,31 @@ import (
 )
 
 const (
-	pathSeperator        = string(os.PathSeparator)
-	policyFilename       = "policy.rego"
-	cloneFolder          = "temp"
-	dataFilename         = "data.json"
-	dataConfigFilename   = "data-config.json"
-	jsonSchemaFilename   = "output-schema.json"
-	exportConfigFilename = "export-config.json"
+	pathSeperator         = string(os.PathSeparator)
+	policyFilename        = "policy.rego"
+	cloneFolder           = "temp"
+	dataFilename          = "data.json"
+	dataConfigFilename    = "data-config.json"
+	jsonSchemaFilename    = "output-schema.json"
+	inputSchemaFilename   = "input-schema.json"
+	exportConfigFilename  = "export-config.json"
+	runtimeConfigFilename = "policy-config.json"
+	testCasesFilename     = "tests.json"
+	regoExtension         = ".rego"
+	regoTestSuffix        = "_test.rego"
 )
 
 type Cloner struct {
+	// folder is the local folder where repositories are cloned.
+	folder string
 }
 
-func New() (*Cloner, error) {
-	c := &Cloner{}
+func New(opts ...Option) (*Cloner, error) {
+	c := &Cloner{folder: cloneFolder}
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	if err := c.Cleanup(); err != nil {
 		return nil, err
 	}
@@ -39,10 +50,10 @@ func New() (*Cloner, error) {
 }
 
 func (c *Cloner) Cleanup() error {
-	return os.RemoveAll(cloneFolder)
+	return os.RemoveAll(c.folder)
 }
 
-// Clone clones a Policy repository to cloneFolder and returns
+// Clone clones a Policy repository to the clone folder and returns
 // the repository name
 func (c *Cloner) Clone(ctx context.Context, cloneURL, user, pass, branch string) (string, error) {
 	opts := &git.CloneOptions{
@@ -62,7 +73,7 @@ func (c *Cloner) Clone(ctx context.Context, cloneURL, user, pass, branch string)
 		opts.SingleBranch = true
 	}
 
-	_, err := git.PlainCloneContext(ctx, cloneFolder, false, opts)
+	_, err := git.PlainCloneContext(ctx, c.folder, false, opts)
 
 	return getRepoName(cloneURL), err
 }
@@ -71,9 +82,9 @@ func (c *Cloner) Clone(ctx context.Context, cloneURL, user, pass, branch string)
 // of Policy structs
 func (c *Cloner) IterateRepo(repoFolder, repository string) (map[string]*storage.Policy, error) {
 	if repoFolder == "" {
-		repoFolder = cloneFolder
+		repoFolder = c.folder
 	} else {
-		repoFolder = filepath.Join(cloneFolder, repoFolder)
+		repoFolder = filepath.Join(c.folder, repoFolder)
 	}
 
 	policies := make(map[string]*storage.Policy)
@@ -112,11 +123,18 @@ func createPolicy(p, repository string) (*storage.Policy, error) {
 	version := ss[len(ss)-2] // second last element is the version
 	name := ss[len(ss)-3]    // third last element is the policy name
 	group := ss[len(ss)-4]   // fourth last element is the policy group
-	bytes, err := os.ReadFile(p)
+
+	// all rego files in the policy folder are compiled together
+	modules, err := readModules(filepath.Dir(p), false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// test modules are only compiled when the policy tests are executed
+	tests, err := readModules(filepath.Dir(p), false, true)
 	if err != nil {
 		return nil, err
 	}
-	regoSrc := string(bytes)
 
 	// generate policy filename for DB from pattern {group}/{name}/{version}/policy.rego
 	dbFilename := group + "/" + name + "/" + version + "/" + policyFilename
@@ -144,27 +162,104 @@ func createPolicy(p, repository string) (*storage.Policy, error) {
 		return nil, err
 	}
 
+	// check if there is an input-schema.json file in the same folder as the policy
+	inputSchemaBytes, err := os.ReadFile(strings.TrimSuffix(p, policyFilename) + inputSchemaFilename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
 	// check if there is policy export configuration in the same folder as the policy
 	exportConfigBytes, err := os.ReadFile(strings.TrimSuffix(p, policyFilename) + exportConfigFilename)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
 
+	// check if there is policy runtime configuration in the same folder as the policy
+	runtimeConfigBytes, err := os.ReadFile(strings.TrimSuffix(p, policyFilename) + runtimeConfigFilename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// check if there are golden test cases in the same folder as the policy
+	testCasesBytes, err := os.ReadFile(strings.TrimSuffix(p, policyFilename) + testCasesFilename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
 	return &storage.Policy{
-		Repository:   repository,
-		Filename:     dbFilename,
-		Name:         name,
-		Group:        group,
-		Version:      version,
-		Rego:         regoSrc,
-		Data:         string(dataBytes),
-		DataConfig:   string(configBytes),
-		OutputSchema: string(schemaBytes),
-		ExportConfig: string(exportConfigBytes),
-		Locked:       false,
+		Repository:    repository,
+		Filename:      dbFilename,
+		Name:          name,
+		Group:         group,
+		Version:       version,
+		Modules:       modules,
+		Data:          string(dataBytes),
+		DataConfig:    string(configBytes),
+		OutputSchema:  string(schemaBytes),
+		InputSchema:   string(inputSchemaBytes),
+		ExportConfig:  string(exportConfigBytes),
+		RuntimeConfig: string(runtimeConfigBytes),
+		Tests:         tests,
+		TestCases:     string(testCasesBytes),
+		Locked:        false,
 	}, nil
 }
 
+// Modules returns all rego modules inside the given folder of the cloned
+// repository and its subfolders. It's used for loading shared libraries.
+func (c *Cloner) Modules(repoFolder string) ([]storage.Module, error) {
+	return LoadModules(filepath.Join(c.folder, repoFolder))
+}
+
+// LoadModules returns all rego modules inside a local folder and its
+// subfolders. Module filenames are relative to the folder and test
+// modules (ending with '_test.rego') are skipped.
+func LoadModules(folder string) ([]storage.Module, error) {
+	return readModules(folder, true, false)
+}
+
+// readModules reads the rego files in a folder. Subfolders are read only
+// if recursive is true. If tests is true, only the test modules are read,
+// otherwise the test modules are skipped.
+func readModules(folder string, recursive, tests bool) ([]storage.Module, error) {
+	var modules []storage.Module
+	err := filepath.WalkDir(folder, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if p != folder && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(p) != regoExtension || strings.HasSuffix(p, regoTestSuffix) != tests {
+			return nil
+		}
+
+		bytes, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		filename, err := filepath.Rel(folder, p)
+		if err != nil {
+			return err
+		}
+
+		modules = append(modules, storage.Module{
+			Filename: filepath.ToSlash(filename),
+			Rego:     string(bytes),
+		})
+
+		return nil
+	})
+
+	return modules, err
+}
+
 func (c *Cloner) ConstructKey(repo, group, name, version string) string {
 	return fmt.Sprintf("%s.%s.%s.%s", repo, group, name, version)
 }
@@ -0,0 +1,82 @@
+package clone_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/clone"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+}
+
+func TestCloner_IterateRepo(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "repo")
+	cloner, err := clone.New(clone.WithFolder(dir))
+	require.NoError(t, err)
+
+	writeFiles(t, dir, map[string]string{
+		"example/test/1.0/policy.rego":         "package example.test",
+		"example/test/1.0/helpers.rego":        "package example.test",
+		"example/test/1.0/policy_test.rego":    "package example.test",
+		"example/test/1.0/data.json":           `{"hello":"world"}`,
+		"example/test/1.0/policy-config.json":  `{"timeout":"5s"}`,
+		"example/test/1.0/input-schema.json":   `{"type":"object"}`,
+		"example/test/1.0/tests.json":          `[{"name":"allow"}]`,
+		"example/test/1.0/nested/ignored.rego": "package example.nested",
+		"lib/strings.rego":                     "package lib.strings",
+	})
+
+	policies, err := cloner.IterateRepo("", "repo")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+
+	p := policies[cloner.ConstructKey("repo", "example", "test", "1.0")]
+	require.NotNil(t, p)
+	assert.Equal(t, "example/test/1.0/policy.rego", p.Filename)
+	assert.Equal(t, []storage.Module{
+		{Filename: "helpers.rego", Rego: "package example.test"},
+		{Filename: "policy.rego", Rego: "package example.test"},
+	}, p.Modules)
+	assert.Equal(t, `{"timeout":"5s"}`, p.RuntimeConfig)
+	assert.Equal(t, `{"type":"object"}`, p.InputSchema)
+	assert.Equal(t, []storage.Module{
+		{Filename: "policy_test.rego", Rego: "package example.test"},
+	}, p.Tests)
+	assert.Equal(t, `[{"name":"allow"}]`, p.TestCases)
+}
+
+func TestCloner_Modules(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "library")
+	cloner, err := clone.New(clone.WithFolder(dir))
+	require.NoError(t, err)
+
+	writeFiles(t, dir, map[string]string{
+		"lib/strings.rego":        "package lib.strings",
+		"lib/time/time.rego":      "package lib.time",
+		"lib/strings_test.rego":   "package lib.strings",
+		"lib/README.md":           "# library",
+		"other/ignored.rego":      "package other",
+		"lib/time/time_test.rego": "package lib.time",
+	})
+
+	modules, err := cloner.Modules("lib")
+	require.NoError(t, err)
+	assert.Equal(t, []storage.Module{
+		{Filename: "strings.rego", Rego: "package lib.strings"},
+		{Filename: "time/time.rego", Rego: "package lib.time"},
+	}, modules)
+
+	_, err = clone.LoadModules(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
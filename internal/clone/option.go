@@ -0,0 +1,11 @@
+package clone
+
+type Option func(*Cloner)
+
+// WithFolder sets the local folder where repositories are cloned,
+// so that multiple repositories can be cloned at the same time.
+func WithFolder(folder string) Option {
+	return func(c *Cloner) {
+		c.folder = folder
+	}
+}
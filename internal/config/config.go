@@ -4,6 +4,9 @@ import "time"
 
 type Config struct {
 	HTTP        httpConfig
+	GRPC        grpcConfig
+	ExtAuthz    extAuthzConfig
+	APIAuthz    apiAuthzConfig
 	Mongo       mongoConfig
 	Cache       cacheConfig
 	Task        taskConfig
@@ -17,7 +20,10 @@ type Config struct {
 	IPFilter    ipFilterConfig
 	Nats        natsConfig
 	Policy      policyConfig
+	DataAPI     dataAPIConfig
 	AutoImport  autoimportConfig
+	DecisionLog decisionLogConfig
+	Tracing     tracingConfig
 
 	// ExternalAddr specifies the external address where
 	// the policy service could be reached, so that
@@ -35,6 +41,54 @@ type httpConfig struct {
 	IdleTimeout  time.Duration `envconfig:"HTTP_IDLE_TIMEOUT" default:"120s"`
 	ReadTimeout  time.Duration `envconfig:"HTTP_READ_TIMEOUT" default:"10s"`
 	WriteTimeout time.Duration `envconfig:"HTTP_WRITE_TIMEOUT" default:"10s"`
+
+	// TrustedProxies lists the IP addresses and CIDR ranges of the proxies, whose
+	// X-Forwarded-For and X-Real-IP headers are used to get the client IP.
+	// Otherwise the client IP is the remote address of the connection.
+	TrustedProxies []string `envconfig:"HTTP_TRUSTED_PROXIES"`
+}
+
+// gRPC server configuration
+type grpcConfig struct {
+	// Enabled specifies whether the policy service is exposed over gRPC
+	// on its own listener next to the HTTP server.
+	Enabled bool   `envconfig:"GRPC_ENABLED" default:"false"`
+	Host    string `envconfig:"GRPC_HOST"`
+	Port    string `envconfig:"GRPC_PORT" default:"9090"`
+}
+
+// Envoy External Authorization server configuration
+type extAuthzConfig struct {
+	// Enabled specifies whether the Envoy External Authorization gRPC
+	// server is started on its own listener. The server doesn't pass the
+	// authentication and IP filter, so the port must only be reachable
+	// by the proxy and must not be exposed publicly.
+	Enabled bool   `envconfig:"EXT_AUTHZ_ENABLED" default:"false"`
+	Host    string `envconfig:"EXT_AUTHZ_HOST"`
+	Port    string `envconfig:"EXT_AUTHZ_PORT" default:"9191"`
+
+	// The stored policy which decides the authorization checks. If Rule
+	// is empty, the value of the whole policy package is the decision.
+	PolicyRepository string `envconfig:"EXT_AUTHZ_POLICY_REPOSITORY"`
+	PolicyGroup      string `envconfig:"EXT_AUTHZ_POLICY_GROUP"`
+	PolicyName       string `envconfig:"EXT_AUTHZ_POLICY_NAME"`
+	PolicyVersion    string `envconfig:"EXT_AUTHZ_POLICY_VERSION" default:"latest"`
+	PolicyRule       string `envconfig:"EXT_AUTHZ_POLICY_RULE" default:"allow"`
+}
+
+// Authorization of the service's own HTTP API
+type apiAuthzConfig struct {
+	// Enabled specifies whether the stored policy is evaluated
+	// before every endpoint of the policy and data services.
+	Enabled bool `envconfig:"API_AUTHZ_ENABLED" default:"false"`
+
+	// The stored policy which authorizes the requests. If Rule
+	// is empty, the value of the whole policy package is the decision.
+	PolicyRepository string `envconfig:"API_AUTHZ_POLICY_REPOSITORY"`
+	PolicyGroup      string `envconfig:"API_AUTHZ_POLICY_GROUP"`
+	PolicyName       string `envconfig:"API_AUTHZ_POLICY_NAME"`
+	PolicyVersion    string `envconfig:"API_AUTHZ_POLICY_VERSION" default:"latest"`
+	PolicyRule       string `envconfig:"API_AUTHZ_POLICY_RULE" default:"allow"`
 }
 
 type cacheConfig struct {
@@ -82,11 +136,91 @@ type policyConfig struct {
 	// LockOnValidationFailure indicates whether a policy must be locked for execution
 	// if the policy output fails the schema validation.
 	LockOnValidationFailure bool `envconfig:"POLICY_LOCK_ON_VALIDATION_FAILURE" default:"false"`
+
+	// CacheSize limits the number of policies and their compiled queries
+	// kept in memory, every policy and every compiled query counts as one.
+	// When the limit is reached, the least recently used policies are
+	// evicted. Zero value means that the cache is unbounded.
+	CacheSize int `envconfig:"POLICY_CACHE_SIZE" default:"0"`
+
+	// BatchConcurrency limits the number of policy evaluations
+	// executed concurrently for a single batch evaluation request.
+	BatchConcurrency int `envconfig:"POLICY_BATCH_CONCURRENCY" default:"10"`
+
+	// BatchMaxSize limits the number of items of a single
+	// batch evaluation request. Larger batches are rejected.
+	BatchMaxSize int `envconfig:"POLICY_BATCH_MAX_SIZE" default:"1000"`
+
+	// ShadowConcurrency limits the number of shadow evaluations of candidate
+	// policy versions executed concurrently. Shadow evaluations exceeding
+	// the limit are skipped.
+	ShadowConcurrency int `envconfig:"POLICY_SHADOW_CONCURRENCY" default:"10"`
+
+	// ResultMaxWait limits the time a request for a stored evaluation result
+	// waits for the result to become available. It must be lower than
+	// HTTP_WRITE_TIMEOUT, otherwise the long-polling requests are aborted.
+	ResultMaxWait time.Duration `envconfig:"POLICY_RESULT_MAX_WAIT" default:"8s"`
+
+	// JobWorkers is the number of workers executing asynchronous evaluation
+	// jobs. Zero value disables asynchronous evaluation. At most JobQueueSize
+	// jobs wait for execution and finished jobs are kept for JobRetention.
+	// A job running longer than JobTimeout is cancelled.
+	JobWorkers   int           `envconfig:"POLICY_JOB_WORKERS" default:"10"`
+	JobQueueSize int           `envconfig:"POLICY_JOB_QUEUE_SIZE" default:"1000"`
+	JobRetention time.Duration `envconfig:"POLICY_JOB_RETENTION" default:"24h"`
+	JobTimeout   time.Duration `envconfig:"POLICY_JOB_TIMEOUT" default:"5m"`
+
+	// JobCallbackHosts lists the hosts allowed in job callback URLs. If it's
+	// empty, callback URLs of hosts resolving to loopback, link-local or
+	// private addresses are rejected.
+	JobCallbackHosts []string `envconfig:"POLICY_JOB_CALLBACK_HOSTS"`
+
+	// ExplainAdmins lists the subjects of bearer tokens which are allowed
+	// to request explained evaluations with traces and print() output.
+	// It requires enabled authentication, because otherwise token subjects
+	// are not verified, and the service refuses to start without it.
+	ExplainAdmins []string `envconfig:"POLICY_EXPLAIN_ADMINS"`
+
+	// LibraryFolder contains shared rego modules, which are compiled together
+	// with every policy, so that policies can import them. If LibraryCloneURL
+	// is set, the folder is relative to the root of the library repository,
+	// otherwise it's a local folder. Repository credentials are shared with
+	// the policy repository.
+	LibraryFolder   string `envconfig:"POLICY_LIBRARY_FOLDER"`
+	LibraryCloneURL string `envconfig:"POLICY_LIBRARY_CLONE_URL"`
+	LibraryBranch   string `envconfig:"POLICY_LIBRARY_BRANCH"`
+}
+
+// OPA Data API configuration
+type dataAPIConfig struct {
+	// Repository and Version select the policies evaluated through the
+	// Data API. They can be overwritten per request with the headers
+	// 'x-policy-repository' and 'x-policy-version'.
+	Repository string `envconfig:"DATA_API_REPOSITORY"`
+	Version    string `envconfig:"DATA_API_VERSION" default:"latest"`
 }
 
 type metricsConfig struct {
 	// Addr specifies the address of the metrics endpoint.
 	Addr string `envconfig:"METRICS_ADDR" default:":2112"`
+
+	// MaxPolicies limits the number of distinct policy versions used as
+	// metric labels. Metrics of further policy versions are labeled as 'other'.
+	// Zero value means that the number is not limited.
+	MaxPolicies int `envconfig:"METRICS_MAX_POLICIES" default:"1000"`
+}
+
+// Tracing configuration
+type tracingConfig struct {
+	// Exporter of the trace spans: 'otlp', 'stdout' or 'none'. When it's 'none',
+	// spans are not exported, but the W3C trace context of incoming requests
+	// is still propagated to the downstream services.
+	Exporter string `envconfig:"TRACING_EXPORTER" default:"none"`
+	// OTLPEndpoint is the host and port of the OTLP HTTP collector, e.g. 'otel-collector:4318'.
+	OTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool   `envconfig:"TRACING_OTLP_INSECURE" default:"false"`
+	// SampleRatio is the ratio of sampled traces which are started by the service.
+	SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
 }
 
 type ocmConfig struct {
@@ -131,3 +265,36 @@ type autoimportConfig struct {
 	// PollInterval specifies the interval between two policy bundle autoimport runs.
 	PollInterval time.Duration `envconfig:"AUTO_IMPORT_POLL_INTERVAL" default:"10s"`
 }
+
+type decisionLogConfig struct {
+	// Sink specifies where the decisions of policy evaluations are recorded.
+	// Supported values are 'mongo', 'file' and 'nats'. The decision log
+	// is disabled if no sink is configured.
+	Sink string `envconfig:"DECISION_LOG_SINK"`
+
+	// FilePath, FileMaxSize and FileMaxBackups configure the rotating file sink.
+	FilePath       string `envconfig:"DECISION_LOG_FILE_PATH" default:"decisions.log"`
+	FileMaxSize    int64  `envconfig:"DECISION_LOG_FILE_MAX_SIZE" default:"104857600"`
+	FileMaxBackups int    `envconfig:"DECISION_LOG_FILE_MAX_BACKUPS" default:"5"`
+
+	// NatsSubject specifies the NATS subject for publishing decisions.
+	NatsSubject string `envconfig:"DECISION_LOG_NATS_SUBJECT" default:"policy_decisions"`
+
+	// BufferSize is the number of decisions kept in memory before
+	// new decisions are dropped because the sink can't keep up.
+	BufferSize    int           `envconfig:"DECISION_LOG_BUFFER_SIZE" default:"10000"`
+	BatchSize     int           `envconfig:"DECISION_LOG_BATCH_SIZE" default:"100"`
+	FlushInterval time.Duration `envconfig:"DECISION_LOG_FLUSH_INTERVAL" default:"5s"`
+
+	// SampleRate is the fraction (0 to 1) of evaluations which are recorded.
+	SampleRate float64 `envconfig:"DECISION_LOG_SAMPLE_RATE" default:"1"`
+	// PolicySampleRates overwrites the sample rate for specific
+	// policies, e.g. 'example/returnDID:0.1,example/test:1'.
+	PolicySampleRates map[string]float64 `envconfig:"DECISION_LOG_POLICY_SAMPLE_RATES"`
+
+	// IncludeInput specifies whether a copy of the evaluation input is
+	// recorded besides its hash. Values of RedactFields (dot separated
+	// paths, e.g. 'user.email') are replaced in the recorded copy.
+	IncludeInput bool     `envconfig:"DECISION_LOG_INCLUDE_INPUT" default:"false"`
+	RedactFields []string `envconfig:"DECISION_LOG_REDACT_FIELDS"`
+}
@@ -0,0 +1,211 @@
+// Package decisionlog records the decisions of policy evaluations, so that
+// they can be audited later. Records are buffered in memory and written in
+// batches to a pluggable Sink by a background process, so that a slow sink
+// doesn't slow down policy evaluations.
+package decisionlog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+//go:generate counterfeiter . Sink
+
+// RedactedValue replaces the values of redacted fields.
+const RedactedValue = "[REDACTED]"
+
+// Sink persists decision log records.
+type Sink interface {
+	SaveDecisions(ctx context.Context, decisions []*storage.Decision) error
+}
+
+type Logger struct {
+	sink      Sink
+	decisions chan *storage.Decision
+	dropped   atomic.Int64
+
+	bufferSize    int
+	batchSize     int
+	flushInterval time.Duration
+
+	// sampleRate is the fraction of evaluations which are recorded.
+	sampleRate float64
+	// policySampleRates overwrites the sampleRate for specific
+	// policies. Keys are in the format 'group/name'.
+	policySampleRates map[string]float64
+
+	// includeInput specifies whether a copy of the evaluation input
+	// with redacted fields is stored besides the input hash.
+	includeInput bool
+	redactFields [][]string
+
+	logger *zap.Logger
+}
+
+func New(sink Sink, logger *zap.Logger, opts ...Option) *Logger {
+	l := &Logger{
+		sink:          sink,
+		bufferSize:    10000,
+		batchSize:     100,
+		flushInterval: 5 * time.Second,
+		sampleRate:    1,
+		logger:        logger,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.decisions = make(chan *storage.Decision, l.bufferSize)
+
+	return l
+}
+
+// Log enqueues a decision for writing to the sink. It never blocks: if the
+// buffer is full because the sink can't keep up, the decision is dropped.
+func (l *Logger) Log(d *storage.Decision) {
+	if !l.sampled(d.Group, d.Name) {
+		return
+	}
+
+	select {
+	case l.decisions <- d:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Start writes the buffered decisions to the sink until the context is done.
+// Decisions are written when a batch is full or when the flush interval elapses.
+func (l *Logger) Start(ctx context.Context) error {
+	defer l.logger.Info("decision logger stopped")
+
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*storage.Decision, 0, l.batchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			// write the remaining decisions before stopping
+			for len(l.decisions) > 0 {
+				batch = append(batch, l.prepare(<-l.decisions))
+			}
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			l.flush(flushCtx, batch)
+			cancel()
+			return ctx.Err()
+		case d := <-l.decisions:
+			batch = append(batch, l.prepare(d))
+			if len(batch) >= l.batchSize {
+				l.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			l.flush(ctx, batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (l *Logger) flush(ctx context.Context, batch []*storage.Decision) {
+	if dropped := l.dropped.Swap(0); dropped > 0 {
+		l.logger.Warn("decision log records are dropped", zap.Int64("count", dropped))
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := l.sink.SaveDecisions(ctx, batch); err != nil {
+		l.logger.Error("error writing decision log records", zap.Error(err), zap.Int("count", len(batch)))
+	}
+}
+
+// prepare replaces the evaluation input of the decision with its hash
+// and optionally with a redacted copy of the input.
+func (l *Logger) prepare(d *storage.Decision) *storage.Decision {
+	if inputJSON, err := json.Marshal(d.Input); err == nil {
+		hash := sha256.Sum256(inputJSON)
+		d.InputHash = hex.EncodeToString(hash[:])
+	}
+
+	if !l.includeInput {
+		d.Input = nil
+		return d
+	}
+
+	for _, path := range l.redactFields {
+		d.Input = redact(d.Input, path)
+	}
+
+	return d
+}
+
+func (l *Logger) sampled(group, name string) bool {
+	rate, ok := l.policySampleRates[group+"/"+name]
+	if !ok {
+		rate = l.sampleRate
+	}
+
+	if rate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < rate //nolint:gosec
+}
+
+// Redact returns a copy of v where the values of the given fields
+// (dot separated paths, e.g. 'user.email') are replaced.
+func Redact(v any, fields []string) any {
+	for _, path := range splitFieldPaths(fields) {
+		v = redact(v, path)
+	}
+	return v
+}
+
+// redact returns a copy of v where the value on the given path is replaced.
+// Only the objects along the path are copied, the rest is shared with v.
+func redact(v any, path []string) any {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(path) == 0 {
+		return v
+	}
+
+	field, ok := m[path[0]]
+	if !ok {
+		return v
+	}
+
+	cpy := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		cpy[k] = v
+	}
+
+	if len(path) == 1 {
+		cpy[path[0]] = RedactedValue
+	} else {
+		cpy[path[0]] = redact(field, path[1:])
+	}
+
+	return cpy
+}
+
+func splitFieldPaths(fields []string) [][]string {
+	paths := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			paths = append(paths, strings.Split(f, "."))
+		}
+	}
+	return paths
+}
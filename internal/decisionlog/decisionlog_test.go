@@ -0,0 +1,187 @@
+package decisionlog_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/decisionlog"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/decisionlog/decisionlogfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func TestNew(t *testing.T) {
+	l := decisionlog.New(&decisionlogfakes.FakeSink{}, zap.NewNop())
+	assert.Implements(t, (*policy.DecisionLog)(nil), l)
+}
+
+// collectingSink records all saved decisions.
+type collectingSink struct {
+	mu        sync.Mutex
+	decisions []*storage.Decision
+	batches   int
+}
+
+func (s *collectingSink) SaveDecisions(_ context.Context, decisions []*storage.Decision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decisions = append(s.decisions, decisions...)
+	s.batches++
+	return nil
+}
+
+func (s *collectingSink) saved() ([]*storage.Decision, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.decisions, s.batches
+}
+
+func TestLogger_Start(t *testing.T) {
+	t.Run("decisions are written in batches", func(t *testing.T) {
+		sink := &collectingSink{}
+		l := decisionlog.New(sink, zap.NewNop(), decisionlog.WithBatchSize(2), decisionlog.WithFlushInterval(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- l.Start(ctx) }()
+
+		for i := 0; i < 5; i++ {
+			l.Log(&storage.Decision{EvaluationID: "id", Input: map[string]interface{}{"msg": "yes"}})
+		}
+
+		assert.Eventually(t, func() bool {
+			decisions, _ := sink.saved()
+			return len(decisions) == 4
+		}, time.Second, 10*time.Millisecond)
+
+		// the remaining decision is written on shutdown
+		cancel()
+		<-done
+
+		decisions, batches := sink.saved()
+		require.Len(t, decisions, 5)
+		assert.Equal(t, 3, batches)
+		for _, d := range decisions {
+			assert.NotEmpty(t, d.InputHash)
+			assert.Nil(t, d.Input)
+		}
+	})
+
+	t.Run("decisions are written on flush interval", func(t *testing.T) {
+		sink := &collectingSink{}
+		l := decisionlog.New(sink, zap.NewNop(), decisionlog.WithFlushInterval(10*time.Millisecond))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go l.Start(ctx) //nolint:errcheck
+
+		l.Log(&storage.Decision{EvaluationID: "id"})
+
+		assert.Eventually(t, func() bool {
+			decisions, _ := sink.saved()
+			return len(decisions) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("decisions are dropped when the buffer is full", func(t *testing.T) {
+		sink := &collectingSink{}
+		l := decisionlog.New(sink, zap.NewNop(), decisionlog.WithBufferSize(2))
+
+		for i := 0; i < 5; i++ {
+			l.Log(&storage.Decision{EvaluationID: "id"})
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = l.Start(ctx)
+
+		decisions, _ := sink.saved()
+		assert.Len(t, decisions, 2)
+	})
+
+	t.Run("decisions are sampled per policy", func(t *testing.T) {
+		sink := &collectingSink{}
+		l := decisionlog.New(sink, zap.NewNop(), decisionlog.WithSampleRate(1, map[string]float64{"example/skipped": 0}))
+
+		l.Log(&storage.Decision{Group: "example", Name: "skipped"})
+		l.Log(&storage.Decision{Group: "example", Name: "recorded"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = l.Start(ctx)
+
+		decisions, _ := sink.saved()
+		require.Len(t, decisions, 1)
+		assert.Equal(t, "recorded", decisions[0].Name)
+	})
+
+	t.Run("input fields are redacted", func(t *testing.T) {
+		sink := &collectingSink{}
+		l := decisionlog.New(sink, zap.NewNop(), decisionlog.WithInput([]string{"user.email", "token", "missing.field"}))
+
+		input := map[string]interface{}{
+			"user":  map[string]interface{}{"name": "John", "email": "john@example.com"},
+			"token": "secret",
+		}
+		l.Log(&storage.Decision{Input: input})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = l.Start(ctx)
+
+		decisions, _ := sink.saved()
+		require.Len(t, decisions, 1)
+		assert.NotEmpty(t, decisions[0].InputHash)
+		assert.Equal(t, map[string]interface{}{
+			"user":  map[string]interface{}{"name": "John", "email": "[REDACTED]"},
+			"token": "[REDACTED]",
+		}, decisions[0].Input)
+
+		// the original input is not modified
+		assert.Equal(t, "secret", input["token"])
+	})
+}
+
+func TestFileSink_SaveDecisions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.log")
+
+	sink, err := decisionlog.NewFileSink(path, 150, 2)
+	require.NoError(t, err)
+	defer sink.Close() //nolint:errcheck
+
+	for i := 0; i < 4; i++ {
+		err := sink.SaveDecisions(context.Background(), []*storage.Decision{{EvaluationID: "id"}})
+		require.NoError(t, err)
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 1)
+		assert.Contains(t, string(data), `"evaluationID":"id"`)
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEventsSink_SaveDecisions(t *testing.T) {
+	events := &decisionlogfakes.FakeEvents{}
+	sink := decisionlog.NewEventsSink(events)
+
+	err := sink.SaveDecisions(context.Background(), []*storage.Decision{{EvaluationID: "1"}, {EvaluationID: "2"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, events.SendCallCount())
+
+	_, data := events.SendArgsForCall(1)
+	assert.Equal(t, "2", data.(*storage.Decision).EvaluationID)
+}
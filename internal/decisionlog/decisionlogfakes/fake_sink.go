@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package decisionlogfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/decisionlog"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type FakeSink struct {
+	SaveDecisionsStub        func(context.Context, []*storage.Decision) error
+	saveDecisionsMutex       sync.RWMutex
+	saveDecisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*storage.Decision
+	}
+	saveDecisionsReturns struct {
+		result1 error
+	}
+	saveDecisionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSink) SaveDecisions(arg1 context.Context, arg2 []*storage.Decision) error {
+	var arg2Copy []*storage.Decision
+	if arg2 != nil {
+		arg2Copy = make([]*storage.Decision, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveDecisionsMutex.Lock()
+	ret, specificReturn := fake.saveDecisionsReturnsOnCall[len(fake.saveDecisionsArgsForCall)]
+	fake.saveDecisionsArgsForCall = append(fake.saveDecisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*storage.Decision
+	}{arg1, arg2Copy})
+	stub := fake.SaveDecisionsStub
+	fakeReturns := fake.saveDecisionsReturns
+	fake.recordInvocation("SaveDecisions", []interface{}{arg1, arg2Copy})
+	fake.saveDecisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSink) SaveDecisionsCallCount() int {
+	fake.saveDecisionsMutex.RLock()
+	defer fake.saveDecisionsMutex.RUnlock()
+	return len(fake.saveDecisionsArgsForCall)
+}
+
+func (fake *FakeSink) SaveDecisionsCalls(stub func(context.Context, []*storage.Decision) error) {
+	fake.saveDecisionsMutex.Lock()
+	defer fake.saveDecisionsMutex.Unlock()
+	fake.SaveDecisionsStub = stub
+}
+
+func (fake *FakeSink) SaveDecisionsArgsForCall(i int) (context.Context, []*storage.Decision) {
+	fake.saveDecisionsMutex.RLock()
+	defer fake.saveDecisionsMutex.RUnlock()
+	argsForCall := fake.saveDecisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSink) SaveDecisionsReturns(result1 error) {
+	fake.saveDecisionsMutex.Lock()
+	defer fake.saveDecisionsMutex.Unlock()
+	fake.SaveDecisionsStub = nil
+	fake.saveDecisionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSink) SaveDecisionsReturnsOnCall(i int, result1 error) {
+	fake.saveDecisionsMutex.Lock()
+	defer fake.saveDecisionsMutex.Unlock()
+	fake.SaveDecisionsStub = nil
+	if fake.saveDecisionsReturnsOnCall == nil {
+		fake.saveDecisionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveDecisionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSink) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.saveDecisionsMutex.RLock()
+	defer fake.saveDecisionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSink) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ decisionlog.Sink = new(FakeSink)
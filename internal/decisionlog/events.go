@@ -0,0 +1,35 @@
+package decisionlog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+//go:generate counterfeiter . Events
+
+type Events interface {
+	Send(ctx context.Context, data any) error
+}
+
+// EventsSink publishes every decision as a separate event,
+// e.g. through the NATS events client.
+type EventsSink struct {
+	events Events
+}
+
+func NewEventsSink(events Events) *EventsSink {
+	return &EventsSink{events: events}
+}
+
+func (e *EventsSink) SaveDecisions(ctx context.Context, decisions []*storage.Decision) error {
+	var errs []error
+	for _, d := range decisions {
+		if err := e.events.Send(ctx, d); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+package decisionlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+// FileSink writes decisions as JSON lines to a local file. When the file
+// reaches the maximum size, it's rotated and the previous files are kept
+// as backups with numeric suffixes, e.g. 'decisions.log.1'.
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	f := &FileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *FileSink) SaveDecisions(_ context.Context, decisions []*storage.Decision) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, d := range decisions {
+		line, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+
+		if f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+			if err := f.rotate(); err != nil {
+				return fmt.Errorf("error rotating decision log file: %v", err)
+			}
+		}
+
+		n, err := f.file.Write(line)
+		f.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *FileSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+func (f *FileSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// rotate shifts the backup files by one and starts a new file.
+// The oldest backup is overwritten when maxBackups is reached.
+func (f *FileSink) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.maxBackups > 0 {
+		for i := f.maxBackups - 1; i > 0; i-- {
+			err := os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(f.path, f.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return err
+	}
+
+	return f.open()
+}
@@ -0,0 +1,53 @@
+package decisionlog
+
+import "time"
+
+type Option func(*Logger)
+
+// WithBufferSize sets the number of decisions which can be buffered
+// before new decisions are dropped.
+func WithBufferSize(size int) Option {
+	return func(l *Logger) {
+		if size > 0 {
+			l.bufferSize = size
+		}
+	}
+}
+
+// WithBatchSize sets the maximum number of decisions written to the sink at once.
+func WithBatchSize(size int) Option {
+	return func(l *Logger) {
+		if size > 0 {
+			l.batchSize = size
+		}
+	}
+}
+
+// WithFlushInterval sets the maximum time a decision stays in the buffer
+// before it's written to the sink.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(l *Logger) {
+		if interval > 0 {
+			l.flushInterval = interval
+		}
+	}
+}
+
+// WithSampleRate sets the fraction (0 to 1) of evaluations which are recorded.
+// The policyRates map overwrites the rate for policies given as 'group/name' keys.
+func WithSampleRate(rate float64, policyRates map[string]float64) Option {
+	return func(l *Logger) {
+		l.sampleRate = rate
+		l.policySampleRates = policyRates
+	}
+}
+
+// WithInput includes a copy of the evaluation input in the records.
+// The values of the given fields (dot separated paths, e.g. 'user.email')
+// are replaced in the stored copy.
+func WithInput(redactFields []string) Option {
+	return func(l *Logger) {
+		l.includeInput = true
+		l.redactFields = splitFieldPaths(redactFields)
+	}
+}
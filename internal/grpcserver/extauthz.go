@@ -0,0 +1,314 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"go.uber.org/zap"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	policysvc "github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// partialBodyHeader is set by Envoy when the request body sent
+// for authorization was truncated to the configured maximum size.
+const partialBodyHeader = "x-envoy-auth-partial-body"
+
+// AuthorizationPolicy is the stored policy which decides
+// the authorization checks of the Envoy proxy.
+type AuthorizationPolicy struct {
+	Repository string
+	Group      string
+	Name       string
+	Version    string
+	// Rule is the path of the rule inside the policy package, whose value is
+	// the decision. If it's empty, the value of the whole package is the decision.
+	Rule string
+}
+
+// Evaluator evaluates the authorization policy of the checks. The results
+// aren't stored in cache, as they are never retrieved by the evaluation ID.
+type Evaluator interface {
+	EvaluateUncached(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error)
+}
+
+// AuthorizationServer implements the Envoy External Authorization service,
+// so that the stored policies can authorize the requests at the edge.
+type AuthorizationServer struct {
+	authv3.UnimplementedAuthorizationServer
+
+	svc    Evaluator
+	policy AuthorizationPolicy
+	logger *zap.Logger
+}
+
+// authzDecision is the structured result of an authorization policy.
+// Headers are added to the upstream request if the request is allowed,
+// otherwise they are added to the response returned to the client.
+type authzDecision struct {
+	Allowed                *bool             `json:"allowed"`
+	Headers                map[string]string `json:"headers"`
+	RequestHeadersToRemove []string          `json:"request_headers_to_remove"`
+	ResponseHeadersToAdd   map[string]string `json:"response_headers_to_add"`
+	Body                   string            `json:"body"`
+	HTTPStatus             int               `json:"http_status"`
+}
+
+// NewAuthorization creates an Envoy External Authorization server,
+// which evaluates the given policy for every authorization check.
+func NewAuthorization(svc Evaluator, pol AuthorizationPolicy, logger *zap.Logger) *AuthorizationServer {
+	return &AuthorizationServer{
+		svc:    svc,
+		policy: pol,
+		logger: logger,
+	}
+}
+
+// Check evaluates the authorization policy with the attributes of the checked
+// request as input. The input has the same structure as the input of the OPA
+// Envoy plugin, so that existing authorization policies can be reused. The
+// request headers are also available to the policy with external.http.header().
+// An undefined policy result denies the request. Every check gets a new evaluation
+// ID and the request ID generated by Envoy is recorded in the decision log. The
+// results aren't stored in cache.
+func (s *AuthorizationServer) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	logger := s.logger.With(
+		zap.String("operation", "check"),
+		zap.String("repository", s.policy.Repository),
+		zap.String("group", s.policy.Group),
+		zap.String("name", s.policy.Name),
+		zap.String("version", s.policy.Version),
+	)
+
+	input, err := checkInput(req)
+	if err != nil {
+		logger.Error("error creating policy input", zap.Error(err))
+		return nil, status.Errorf(codes.InvalidArgument, "error creating policy input: %v", err)
+	}
+
+	r := checkedRequest(req)
+	ctx = header.ToContext(ctx, r)
+	ctx = caller.ToContext(ctx, r)
+
+	evalReq := &policy.EvaluateRequest{
+		Repository: s.policy.Repository,
+		Group:      s.policy.Group,
+		PolicyName: s.policy.Name,
+		Version:    s.policy.Version,
+		Input:      input,
+	}
+	if s.policy.Rule != "" {
+		evalReq.Rule = ptr.String(s.policy.Rule)
+	}
+
+	res, err := s.svc.EvaluateUncached(ctx, evalReq)
+	if err != nil {
+		if policysvc.IsUndefined(err) {
+			return deniedResponse(&authzDecision{}), nil
+		}
+		logger.Error("error evaluating authorization policy", zap.Error(err))
+		return nil, toStatus(err)
+	}
+
+	decision, err := toDecision(res.Result)
+	if err != nil {
+		logger.Error("invalid authorization policy result", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "invalid authorization policy result: %v", err)
+	}
+
+	if !*decision.Allowed {
+		return deniedResponse(decision), nil
+	}
+
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: &authv3.OkHttpResponse{
+				Headers:              headerOptions(decision.Headers),
+				HeadersToRemove:      decision.RequestHeadersToRemove,
+				ResponseHeadersToAdd: headerOptions(decision.ResponseHeadersToAdd),
+			},
+		},
+	}, nil
+}
+
+// checkInput converts the attributes of the checked request to the policy input.
+// Besides the attributes, it contains the path segments, the query parameters
+// and the body parsed from JSON or form encoding.
+func checkInput(req *authv3.CheckRequest) (map[string]any, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(req.GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes any
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+
+	path, query, _ := strings.Cut(httpReq.GetPath(), "?")
+	if httpReq.GetQuery() != "" {
+		query = httpReq.GetQuery()
+	}
+	parsedQuery, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	parsedPath := []string{}
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		parsedPath = append(parsedPath, segment)
+	}
+
+	input := map[string]any{
+		"attributes":     attributes,
+		"parsed_path":    parsedPath,
+		"parsed_query":   parsedQuery,
+		"truncated_body": httpReq.GetHeaders()[partialBodyHeader] == "true",
+	}
+
+	body := httpReq.GetBody()
+	if body == "" {
+		body = string(httpReq.GetRawBody())
+	}
+	if body != "" {
+		if parsedBody, ok := parseBody(httpReq.GetHeaders()["content-type"], body); ok {
+			input["parsed_body"] = parsedBody
+		}
+	}
+
+	return input, nil
+}
+
+// parseBody parses a JSON or form encoded body. Other
+// content types and malformed bodies are not parsed.
+func parseBody(contentType, body string) (any, bool) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var v any
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			return nil, false
+		}
+		return v, true
+	case mediaType == "application/x-www-form-urlencoded":
+		v, err := url.ParseQuery(body)
+		if err != nil {
+			return nil, false
+		}
+		return v, true
+	}
+
+	return nil, false
+}
+
+// checkedRequest returns an HTTP request with the headers and the client
+// address of the checked request, so that they can be added to the context.
+func checkedRequest(req *authv3.CheckRequest) *http.Request {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+
+	r := &http.Request{
+		Method: httpReq.GetMethod(),
+		Host:   httpReq.GetHost(),
+		Header: make(http.Header, len(httpReq.GetHeaders())),
+	}
+	for name, value := range httpReq.GetHeaders() {
+		// skip HTTP/2 pseudo headers
+		if strings.HasPrefix(name, ":") {
+			continue
+		}
+		r.Header.Set(name, value)
+	}
+
+	if addr := req.GetAttributes().GetSource().GetAddress().GetSocketAddress(); addr != nil {
+		r.RemoteAddr = net.JoinHostPort(addr.GetAddress(), strconv.Itoa(int(addr.GetPortValue())))
+	}
+
+	return r
+}
+
+// toDecision converts a boolean or structured policy result to a decision.
+func toDecision(result any) (*authzDecision, error) {
+	if allowed, ok := result.(bool); ok {
+		return &authzDecision{Allowed: &allowed}, nil
+	}
+
+	if _, ok := result.(map[string]any); !ok {
+		return nil, fmt.Errorf("result must be a boolean or an object, got %T", result)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var decision authzDecision
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return nil, err
+	}
+
+	if decision.Allowed == nil {
+		return nil, fmt.Errorf("result doesn't contain boolean 'allowed'")
+	}
+
+	return &decision, nil
+}
+
+func deniedResponse(decision *authzDecision) *authv3.CheckResponse {
+	code := typev3.StatusCode_Forbidden
+	if decision.HTTPStatus != 0 {
+		code = typev3.StatusCode(decision.HTTPStatus)
+	}
+
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status:  &typev3.HttpStatus{Code: code},
+				Headers: headerOptions(decision.Headers),
+				Body:    decision.Body,
+			},
+		},
+	}
+}
+
+func headerOptions(headers map[string]string) []*corev3.HeaderValueOption {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	options := make([]*corev3.HeaderValueOption, 0, len(headers))
+	for name, value := range headers {
+		options = append(options, &corev3.HeaderValueOption{
+			Header:       &corev3.HeaderValue{Key: name, Value: value},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	return options
+}
@@ -0,0 +1,216 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/grpcserver"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policyfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+const authzPolicy = `package envoy.authz
+
+default allow = false
+
+allow {
+	input.attributes.request.http.method == "GET"
+	input.parsed_path == ["api", "public"]
+	input.parsed_query.lang == ["en"]
+}
+
+decision = {"allowed": true, "headers": {"x-user": external.http.header("X-User")}} {
+	input.parsed_body.user == "alice"
+}
+
+decision = {"allowed": false, "http_status": 401, "body": "unauthorized", "headers": {"www-authenticate": "Bearer"}} {
+	not input.parsed_body.user
+}
+
+invalid = "yes"
+`
+
+func checkRequest(method, path, body string, headers map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Source: &authv3.AttributeContext_Peer{
+				Address: &corev3.Address{Address: &corev3.Address_SocketAddress{
+					SocketAddress: &corev3.SocketAddress{Address: "10.0.0.1", PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: 5000}},
+				}},
+			},
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{
+					Method:  method,
+					Path:    path,
+					Host:    "example.com",
+					Headers: headers,
+					Body:    body,
+				},
+			},
+		},
+	}
+}
+
+func TestAuthorizationServer_Check(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		req  *authv3.CheckRequest
+
+		allowed bool
+		status  typev3.StatusCode
+		body    string
+		headers map[string]string
+		code    codes.Code
+	}{
+		{
+			name:    "boolean result allows request",
+			rule:    "allow",
+			req:     checkRequest("GET", "/api/public?lang=en", "", nil),
+			allowed: true,
+		},
+		{
+			name:   "boolean result denies request",
+			rule:   "allow",
+			req:    checkRequest("POST", "/api/public?lang=en", "", nil),
+			status: typev3.StatusCode_Forbidden,
+		},
+		{
+			name:    "structured result allows request and adds headers",
+			rule:    "decision",
+			req:     checkRequest("POST", "/api/private", `{"user":"alice"}`, map[string]string{"content-type": "application/json", "x-user": "alice"}),
+			allowed: true,
+			headers: map[string]string{"x-user": "alice"},
+		},
+		{
+			name:    "structured result denies request with status and body",
+			rule:    "decision",
+			req:     checkRequest("POST", "/api/private", `{}`, map[string]string{"content-type": "application/json"}),
+			status:  typev3.StatusCode_Unauthorized,
+			body:    "unauthorized",
+			headers: map[string]string{"www-authenticate": "Bearer"},
+		},
+		{
+			name:   "undefined result denies request",
+			rule:   "decision",
+			req:    checkRequest("POST", "/api/private", `{"user":"bob"}`, map[string]string{"content-type": "application/json"}),
+			status: typev3.StatusCode_Forbidden,
+		},
+		{
+			name: "invalid result fails the check",
+			rule: "invalid",
+			req:  checkRequest("GET", "/", "", nil),
+			code: codes.Internal,
+		},
+	}
+
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: authzPolicy}},
+			}, nil
+		},
+	}
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := grpc.NewServer()
+			authv3.RegisterAuthorizationServer(srv, grpcserver.NewAuthorization(svc, grpcserver.AuthorizationPolicy{
+				Repository: "policies",
+				Group:      "envoy",
+				Name:       "authz",
+				Version:    "1.0",
+				Rule:       test.rule,
+			}, zap.NewNop()))
+			client := authv3.NewAuthorizationClient(dial(t, srv))
+
+			res, err := client.Check(context.Background(), test.req)
+			if test.code != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, test.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			headers := map[string]string{}
+			if test.allowed {
+				assert.Equal(t, int32(codes.OK), res.GetStatus().GetCode())
+				for _, h := range res.GetOkResponse().GetHeaders() {
+					headers[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+				}
+			} else {
+				assert.Equal(t, int32(codes.PermissionDenied), res.GetStatus().GetCode())
+				assert.Equal(t, test.status, res.GetDeniedResponse().GetStatus().GetCode())
+				assert.Equal(t, test.body, res.GetDeniedResponse().GetBody())
+				for _, h := range res.GetDeniedResponse().GetHeaders() {
+					headers[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+				}
+			}
+			if test.headers == nil {
+				test.headers = map[string]string{}
+			}
+			assert.Equal(t, test.headers, headers)
+		})
+	}
+}
+
+func TestAuthorizationServer_CheckRequestID(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: authzPolicy}},
+			}, nil
+		},
+	}
+	cache := &policyfakes.FakeCache{}
+	decisionLog := &policyfakes.FakeDecisionLog{}
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionLog(decisionLog))
+
+	srv := grpc.NewServer()
+	authv3.RegisterAuthorizationServer(srv, grpcserver.NewAuthorization(svc, grpcserver.AuthorizationPolicy{
+		Repository: "policies",
+		Group:      "envoy",
+		Name:       "authz",
+		Version:    "1.0",
+		Rule:       "allow",
+	}, zap.NewNop()))
+	client := authv3.NewAuthorizationClient(dial(t, srv))
+
+	_, err := client.Check(context.Background(), checkRequest("GET", "/api/public?lang=en", "", map[string]string{"x-request-id": "request-1"}))
+	require.NoError(t, err)
+
+	// the results of the checks aren't stored in cache
+	assert.Equal(t, 0, cache.SetCallCount())
+
+	// the request ID is not used as evaluation ID
+	require.Eventually(t, func() bool {
+		return decisionLog.LogCallCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	decision := decisionLog.LogArgsForCall(0)
+	assert.NotEmpty(t, decision.EvaluationID)
+	assert.NotEqual(t, "request-1", decision.EvaluationID)
+	assert.Equal(t, "request-1", decision.RequestID)
+}
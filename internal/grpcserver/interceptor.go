@@ -0,0 +1,237 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	policyv1 "github.com/eclipse-xfsc/custom-policy-agent/proto/policy/v1"
+)
+
+// Middleware is an HTTP server middleware, e.g. the authentication or the IP filter.
+type Middleware func(http.Handler) http.Handler
+
+// UnaryInterceptor returns an interceptor which applies the HTTP middlewares to
+// the gRPC requests, so that they have the same semantics as for HTTP requests.
+// The middlewares are applied like with the Use method of the goa HTTP server,
+// which means that the last middleware is executed first.
+func UnaryInterceptor(middlewares ...Middleware) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := applyMiddlewares(ctx, info.FullMethod, middlewares)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns an interceptor which applies the HTTP middlewares
+// to the gRPC streams, the same as UnaryInterceptor.
+func StreamInterceptor(middlewares ...Middleware) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := applyMiddlewares(ss.Context(), info.FullMethod, middlewares)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// Authorizer authorizes a request to the API with the names of the Goa service
+// and method and the path parameters of the equivalent HTTP request.
+type Authorizer interface {
+	Authorize(ctx context.Context, service, method string, params map[string]string) error
+}
+
+// policyRequest is implemented by the gRPC requests which refer to a policy version.
+type policyRequest interface {
+	GetRepository() string
+	GetGroup() string
+	GetPolicyName() string
+	GetVersion() string
+}
+
+// AuthorizeUnaryInterceptor returns an interceptor which authorizes the gRPC requests
+// the same as the requests of the HTTP API. A gRPC method is authorized as the method
+// of the policy service with the same name, and the policy version of the request is
+// passed as path parameters. Evaluations of a rule are authorized as EvaluateRule
+// with the rule as path parameter. It must be chained after the middleware
+// interceptor, which adds the caller to the context.
+func AuthorizeUnaryInterceptor(a Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.Authorize(ctx, policy.ServiceName, methodName(info.FullMethod, req), pathParams(req)); err != nil {
+			return nil, toStatus(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeStreamInterceptor returns an interceptor which authorizes the gRPC streams
+// the same as AuthorizeUnaryInterceptor. Streams are authorized without path parameters
+// before the first message is received. Every received evaluation request is authorized
+// as Evaluate or EvaluateRule with its own policy version, the same as the items of the
+// batch evaluation over HTTP, and a denied request fails the stream.
+func AuthorizeStreamInterceptor(a Authorizer) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.Authorize(ss.Context(), policy.ServiceName, methodName(info.FullMethod, nil), nil); err != nil {
+			return toStatus(err)
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, authorizer: a})
+	}
+}
+
+// authorizedStream authorizes the evaluation requests received from a gRPC stream.
+type authorizedStream struct {
+	grpc.ServerStream
+	authorizer Authorizer
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if err := s.authorizer.Authorize(s.Context(), policy.ServiceName, methodName("Evaluate", m), pathParams(m)); err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+// methodName returns the name of the policy service method of a gRPC request, e.g.
+// 'Evaluate' of '/policy.v1.PolicyService/Evaluate'. Evaluations of a rule are
+// executed by the EvaluateRule method, so they're authorized as EvaluateRule.
+func methodName(fullMethod string, req any) string {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if r, ok := req.(*policyv1.EvaluateRequest); ok && method == "Evaluate" && r.GetRule() != "" {
+		return "EvaluateRule"
+	}
+	return method
+}
+
+// pathParams returns the policy version and the rule of
+// a request as the path parameters of the HTTP API.
+func pathParams(req any) map[string]string {
+	r, ok := req.(policyRequest)
+	if !ok {
+		return nil
+	}
+
+	params := map[string]string{
+		"repository": r.GetRepository(),
+		"group":      r.GetGroup(),
+		"policyName": r.GetPolicyName(),
+		"version":    r.GetVersion(),
+	}
+	if r, ok := req.(*policyv1.EvaluateRequest); ok && r.GetRule() != "" {
+		params["rule"] = r.GetRule()
+	}
+
+	return params
+}
+
+// serverStream replaces the context of a gRPC stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// applyMiddlewares passes an HTTP request with the metadata of the gRPC request
+// through the middlewares. If a middleware rejects the request, its response is
+// converted to a gRPC status error, otherwise the context of the request, which
+// contains the values added by the middlewares, is returned.
+func applyMiddlewares(ctx context.Context, method string, middlewares []Middleware) (context.Context, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, method, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for name, values := range md {
+		// skip HTTP/2 pseudo headers
+		if strings.HasPrefix(name, ":") {
+			continue
+		}
+		for _, v := range values {
+			r.Header.Add(name, v)
+		}
+	}
+	if authority := md.Get(":authority"); len(authority) > 0 {
+		r.Host = authority[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		r.RemoteAddr = p.Addr.String()
+	}
+
+	var next context.Context
+	var h http.Handler = http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		next = r.Context()
+	})
+	for _, m := range middlewares {
+		h = m(h)
+	}
+
+	rec := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+	h.ServeHTTP(rec, r)
+
+	if next == nil {
+		msg := strings.TrimSpace(rec.body.String())
+		if msg == "" {
+			msg = http.StatusText(rec.status)
+		}
+		return nil, status.Error(statusCode(rec.status), msg)
+	}
+
+	return next, nil
+}
+
+// statusCode returns the gRPC code of an HTTP status code.
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// responseRecorder records the response of a middleware which rejects a request.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
@@ -0,0 +1,348 @@
+// Package grpcserver exposes the policy service over gRPC. The gRPC methods
+// are translated to the methods of the policy service, so that they have the
+// same semantics as the HTTP endpoints. It also implements the Envoy External
+// Authorization service, which evaluates a stored policy for every check.
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"io"
+	"net"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	policyv1 "github.com/eclipse-xfsc/custom-policy-agent/proto/policy/v1"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+const defaultBatchConcurrency = 10
+
+// Server implements the gRPC policy service.
+type Server struct {
+	policyv1.UnimplementedPolicyServiceServer
+
+	svc    policy.Service
+	logger *zap.Logger
+
+	// batchConcurrency limits the number of concurrently
+	// executed evaluations of a batch stream.
+	batchConcurrency int
+}
+
+// New creates a gRPC server of the policy service. Non-positive batch
+// concurrency is replaced with the default value.
+func New(svc policy.Service, batchConcurrency int, logger *zap.Logger) *Server {
+	if batchConcurrency <= 0 {
+		batchConcurrency = defaultBatchConcurrency
+	}
+
+	return &Server{
+		svc:              svc,
+		logger:           logger,
+		batchConcurrency: batchConcurrency,
+	}
+}
+
+func (s *Server) Evaluate(ctx context.Context, req *policyv1.EvaluateRequest) (*policyv1.EvaluateResponse, error) {
+	var res *policy.EvaluateResult
+	var err error
+	if req.GetRule() != "" {
+		res, err = s.svc.EvaluateRule(ctx, evaluateRequest(req))
+	} else {
+		res, err = s.svc.Evaluate(ctx, evaluateRequest(req))
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return evaluateResponse(res)
+}
+
+func (s *Server) Validate(ctx context.Context, req *policyv1.EvaluateRequest) (*policyv1.EvaluateResponse, error) {
+	res, err := s.svc.Validate(ctx, evaluateRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return evaluateResponse(res)
+}
+
+// EvaluateBatch evaluates the received requests concurrently and sends
+// the results in the order of the requests. A failed evaluation doesn't
+// fail the stream, but its error is reported in the item response.
+func (s *Server) EvaluateBatch(stream policyv1.PolicyService_EvaluateBatchServer) error {
+	ctx := stream.Context()
+
+	pending := make(chan chan *policyv1.EvaluateBatchResponse, s.batchConcurrency)
+
+	var g errgroup.Group
+	g.Go(func() error {
+		defer close(pending)
+
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if goerrors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+
+			ch := make(chan *policyv1.EvaluateBatchResponse, 1)
+			select {
+			case pending <- ch:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			go func() {
+				ch <- s.evaluateBatchItem(ctx, req)
+			}()
+		}
+	})
+
+	var sendErr error
+	for ch := range pending {
+		res := <-ch
+		if sendErr != nil {
+			// drain the remaining evaluations so that the receiver is not blocked
+			continue
+		}
+		if err := stream.Send(res); err != nil {
+			s.logger.Error("error sending batch evaluation result", zap.Error(err), zap.String("operation", "evaluateBatch"))
+			sendErr = err
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+func (s *Server) evaluateBatchItem(ctx context.Context, req *policyv1.EvaluateRequest) *policyv1.EvaluateBatchResponse {
+	res := &policyv1.EvaluateBatchResponse{
+		Repository: req.GetRepository(),
+		Group:      req.GetGroup(),
+		PolicyName: req.GetPolicyName(),
+		Version:    req.GetVersion(),
+	}
+
+	evalRes, err := s.Evaluate(ctx, req)
+	if err != nil {
+		res.Error = status.Convert(err).Message()
+		return res
+	}
+
+	res.Result = evalRes.Result
+	res.Etag = evalRes.Etag
+	res.Version = evalRes.Version
+
+	return res
+}
+
+func (s *Server) Lock(ctx context.Context, req *policyv1.LockRequest) (*policyv1.LockResponse, error) {
+	lockReq := &policy.LockRequest{
+		Repository: req.GetRepository(),
+		Group:      req.GetGroup(),
+		PolicyName: req.GetPolicyName(),
+		Version:    req.GetVersion(),
+		UnlockAt:   req.UnlockAt,
+	}
+	if req.GetReason() != "" {
+		lockReq.Reason = ptr.String(req.GetReason())
+	}
+
+	if err := s.svc.Lock(ctx, lockReq); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &policyv1.LockResponse{}, nil
+}
+
+func (s *Server) Unlock(ctx context.Context, req *policyv1.UnlockRequest) (*policyv1.UnlockResponse, error) {
+	unlockReq := &policy.UnlockRequest{
+		Repository: req.GetRepository(),
+		Group:      req.GetGroup(),
+		PolicyName: req.GetPolicyName(),
+		Version:    req.GetVersion(),
+	}
+	if req.GetReason() != "" {
+		unlockReq.Reason = ptr.String(req.GetReason())
+	}
+
+	if err := s.svc.Unlock(ctx, unlockReq); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &policyv1.UnlockResponse{}, nil
+}
+
+func (s *Server) ListPolicies(ctx context.Context, req *policyv1.ListPoliciesRequest) (*policyv1.ListPoliciesResponse, error) {
+	res, err := s.svc.ListPolicies(ctx, &policy.PoliciesRequest{
+		Locked:     req.Locked,
+		PolicyName: req.PolicyName,
+		Rego:       ptr.Bool(req.GetRego()),
+		Data:       ptr.Bool(req.GetData()),
+		DataConfig: ptr.Bool(req.GetDataConfig()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	policies := make([]*policyv1.Policy, 0, len(res.Policies))
+	for _, p := range res.Policies {
+		policies = append(policies, &policyv1.Policy{
+			Repository:    p.Repository,
+			PolicyName:    p.PolicyName,
+			Group:         p.Group,
+			Version:       p.Version,
+			Rego:          stringValue(p.Rego),
+			Data:          stringValue(p.Data),
+			DataConfig:    stringValue(p.DataConfig),
+			Locked:        p.Locked,
+			LastUpdate:    p.LastUpdate,
+			Modules:       p.Modules,
+			ShadowVersion: stringValue(p.ShadowVersion),
+			Lock:          lockEvent(p.Lock),
+		})
+	}
+
+	return &policyv1.ListPoliciesResponse{Policies: policies}, nil
+}
+
+func lockEvent(e *policy.PolicyLockEvent) *policyv1.PolicyLockEvent {
+	if e == nil {
+		return nil
+	}
+
+	return &policyv1.PolicyLockEvent{
+		Locked:    e.Locked,
+		Reason:    stringValue(e.Reason),
+		Actor:     stringValue(e.Actor),
+		Source:    e.Source,
+		UnlockAt:  e.UnlockAt,
+		Timestamp: e.Timestamp,
+	}
+}
+
+func evaluateRequest(req *policyv1.EvaluateRequest) *policy.EvaluateRequest {
+	r := &policy.EvaluateRequest{
+		Repository: req.GetRepository(),
+		Group:      req.GetGroup(),
+		PolicyName: req.GetPolicyName(),
+		Version:    req.GetVersion(),
+		Input:      req.GetInput().AsInterface(),
+		Report:     ptr.Bool(req.GetReport()),
+		Coerce:     ptr.Bool(req.GetCoerce()),
+	}
+	if req.EvaluationId != "" {
+		r.EvaluationID = ptr.String(req.EvaluationId)
+	}
+	if req.Ttl != nil {
+		r.TTL = ptr.Int(int(*req.Ttl))
+	}
+	if req.Rule != "" {
+		r.Rule = ptr.String(req.Rule)
+	}
+
+	return r
+}
+
+func evaluateResponse(res *policy.EvaluateResult) (*policyv1.EvaluateResponse, error) {
+	result, err := toValue(res.Result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding policy result: %v", err)
+	}
+
+	return &policyv1.EvaluateResponse{
+		Result:  result,
+		Etag:    res.ETag,
+		Version: res.Version,
+	}, nil
+}
+
+// toValue converts a JSON value to its protobuf representation. The value is
+// converted through its JSON encoding, because policy results contain
+// json.Number and other types which aren't supported by structpb.NewValue.
+func toValue(v any) (*structpb.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value structpb.Value
+	if err := protojson.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// toStatus converts a service error to a gRPC status error.
+func toStatus(err error) error {
+	var e *errors.Error
+	if !goerrors.As(err, &e) {
+		return status.Error(codes.Unknown, err.Error())
+	}
+
+	code := codes.Unknown
+	switch e.Kind {
+	case errors.BadRequest:
+		code = codes.InvalidArgument
+	case errors.Unauthorized:
+		code = codes.Unauthenticated
+	case errors.Forbidden:
+		code = codes.PermissionDenied
+	case errors.Exist:
+		code = codes.AlreadyExists
+	case errors.NotFound:
+		code = codes.NotFound
+	case errors.Timeout:
+		code = codes.DeadlineExceeded
+	case errors.Internal:
+		code = codes.Internal
+	case errors.ServiceUnavailable:
+		code = codes.Unavailable
+	}
+
+	return status.Error(code, e.Error())
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Serve accepts gRPC connections on the address until the context is
+// cancelled. Then the server is stopped gracefully.
+func Serve(ctx context.Context, srv *grpc.Server, addr string, logger *zap.Logger) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Info("starting gRPC server", zap.String("addr", addr))
+	if err := srv.Serve(ln); err != nil {
+		return err
+	}
+
+	return goerrors.New("gRPC server stopped successfully")
+}
@@ -0,0 +1,383 @@
+package grpcserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	goapolicy "github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/grpcserver"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	policyv1 "github.com/eclipse-xfsc/custom-policy-agent/proto/policy/v1"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// policyService implements the evaluation of the policy service for the tests.
+type policyService struct {
+	goapolicy.Service
+	evaluate func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+}
+
+func (s *policyService) Evaluate(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	return s.evaluate(ctx, req)
+}
+
+func (s *policyService) EvaluateRule(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+	return s.evaluate(ctx, req)
+}
+
+func newClient(t *testing.T, svc goapolicy.Service, middlewares ...grpcserver.Middleware) policyv1.PolicyServiceClient {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcserver.UnaryInterceptor(middlewares...)),
+		grpc.ChainStreamInterceptor(grpcserver.StreamInterceptor(middlewares...)),
+	)
+	policyv1.RegisterPolicyServiceServer(srv, grpcserver.New(svc, 2, zap.NewNop()))
+
+	return policyv1.NewPolicyServiceClient(dial(t, srv))
+}
+
+// dial starts the server on an in-memory listener and returns a client connection.
+func dial(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	ln := bufconn.Listen(1024 * 1024)
+	go srv.Serve(ln) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+	return conn
+}
+
+func TestServer_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		evaluate func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error)
+		result   string
+		code     codes.Code
+	}{
+		{
+			name: "evaluation error",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				return nil, errors.New("error evaluating policy", errors.New(errors.NotFound, "policy not found"))
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "successful evaluation",
+			evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+				return &goapolicy.EvaluateResult{
+					Result:  map[string]any{"allow": true, "input": req.Input, "count": json.Number("3")},
+					ETag:    *req.EvaluationID,
+					Version: req.Version,
+				}, nil
+			},
+			result: `{"allow":true,"input":{"msg":"yes"},"count":3}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newClient(t, &policyService{evaluate: test.evaluate})
+
+			input, err := structpb.NewValue(map[string]any{"msg": "yes"})
+			require.NoError(t, err)
+
+			res, err := client.Evaluate(context.Background(), &policyv1.EvaluateRequest{
+				Repository:   "policies",
+				Group:        "example",
+				PolicyName:   "test",
+				Version:      "1.0",
+				Input:        input,
+				EvaluationId: "123",
+			})
+			if test.code != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, test.code, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "123", res.Etag)
+			assert.Equal(t, "1.0", res.Version)
+			result, err := res.Result.MarshalJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, test.result, string(result))
+		})
+	}
+}
+
+func TestServer_EvaluateBatch(t *testing.T) {
+	svc := &policyService{
+		evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+			if req.PolicyName == "missing" {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			// later items finish first, but the results must keep the order
+			delay := req.Input.(map[string]any)["delay"].(float64)
+			time.Sleep(time.Duration(delay) * time.Millisecond)
+			return &goapolicy.EvaluateResult{Result: req.Input, ETag: "etag-" + req.PolicyName, Version: req.Version}, nil
+		},
+	}
+	client := newClient(t, svc)
+
+	stream, err := client.EvaluateBatch(context.Background())
+	require.NoError(t, err)
+
+	names := []string{"a", "missing", "b", "c"}
+	for i, name := range names {
+		input, err := structpb.NewValue(map[string]any{"delay": float64((len(names) - i) * 10)})
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&policyv1.EvaluateRequest{PolicyName: name, Version: "1.0", Input: input}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var results []*policyv1.EvaluateBatchResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		results = append(results, res)
+	}
+
+	require.Len(t, results, len(names))
+	for i, name := range names {
+		assert.Equal(t, name, results[i].PolicyName)
+	}
+	assert.Equal(t, "etag-a", results[0].Etag)
+	assert.Contains(t, results[1].Error, "policy not found")
+	assert.Equal(t, "etag-c", results[3].Etag)
+}
+
+// lockService implements the policy locking of the policy service for the tests.
+type lockService struct {
+	goapolicy.Service
+	lockReq   *goapolicy.LockRequest
+	unlockReq *goapolicy.UnlockRequest
+	policies  []*goapolicy.Policy
+}
+
+func (s *lockService) Lock(_ context.Context, req *goapolicy.LockRequest) error {
+	s.lockReq = req
+	return nil
+}
+
+func (s *lockService) Unlock(_ context.Context, req *goapolicy.UnlockRequest) error {
+	s.unlockReq = req
+	return nil
+}
+
+func (s *lockService) ListPolicies(_ context.Context, _ *goapolicy.PoliciesRequest) (*goapolicy.PoliciesResult, error) {
+	return &goapolicy.PoliciesResult{Policies: s.policies}, nil
+}
+
+func TestServer_Lock(t *testing.T) {
+	svc := &lockService{
+		policies: []*goapolicy.Policy{
+			{
+				Repository: "policies",
+				PolicyName: "test",
+				Group:      "example",
+				Version:    "1.0",
+				Locked:     true,
+				Lock: &goapolicy.PolicyLockEvent{
+					Locked:    true,
+					Reason:    ptr.String("incident"),
+					Actor:     ptr.String("alice"),
+					Source:    "api",
+					UnlockAt:  ptr.Int64(1700003600),
+					Timestamp: 1700000000,
+				},
+			},
+			{Repository: "policies", PolicyName: "test", Group: "example", Version: "2.0"},
+		},
+	}
+	client := newClient(t, svc)
+
+	unlockAt := int64(1700003600)
+	_, err := client.Lock(context.Background(), &policyv1.LockRequest{
+		Repository: "policies",
+		Group:      "example",
+		PolicyName: "test",
+		Version:    "1.0",
+		Reason:     "incident",
+		UnlockAt:   &unlockAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, svc.lockReq)
+	assert.Equal(t, "incident", *svc.lockReq.Reason)
+	assert.Equal(t, unlockAt, *svc.lockReq.UnlockAt)
+
+	_, err = client.Unlock(context.Background(), &policyv1.UnlockRequest{
+		Repository: "policies",
+		Group:      "example",
+		PolicyName: "test",
+		Version:    "1.0",
+		Reason:     "resolved",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, svc.unlockReq)
+	assert.Equal(t, "resolved", *svc.unlockReq.Reason)
+
+	res, err := client.ListPolicies(context.Background(), &policyv1.ListPoliciesRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Policies, 2)
+	lock := res.Policies[0].Lock
+	require.NotNil(t, lock)
+	assert.True(t, lock.Locked)
+	assert.Equal(t, "incident", lock.Reason)
+	assert.Equal(t, "alice", lock.Actor)
+	assert.Equal(t, "api", lock.Source)
+	assert.Equal(t, unlockAt, lock.GetUnlockAt())
+	assert.Equal(t, int64(1700000000), lock.Timestamp)
+	assert.Nil(t, res.Policies[1].Lock)
+}
+
+func TestInterceptor(t *testing.T) {
+	svc := &policyService{
+		evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+			headers, _ := header.FromContext(ctx)
+			return &goapolicy.EvaluateResult{Result: headers["X-Tenant"], ETag: "1", Version: req.Version}, nil
+		},
+	}
+	authenticate := func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "invalid authorization header", http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+	client := newClient(t, svc, header.Middleware(), authenticate)
+
+	// request without token is rejected
+	_, err := client.Evaluate(context.Background(), &policyv1.EvaluateRequest{Version: "1.0"})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "invalid authorization header", status.Convert(err).Message())
+
+	// request metadata is available as request headers
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token", "x-tenant", "acme")
+	res, err := client.Evaluate(ctx, &policyv1.EvaluateRequest{Version: "1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", res.Result.GetStringValue())
+
+	// streams are rejected too
+	stream, err := client.EvaluateBatch(context.Background())
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// authorizer records the authorized requests and denies the Lock
+// method and the requests of the policies of the group 'denied'.
+type authorizer struct {
+	mu      sync.Mutex
+	methods []string
+	params  []map[string]string
+}
+
+func (a *authorizer) Authorize(_ context.Context, service, method string, params map[string]string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.methods = append(a.methods, service+"."+method)
+	a.params = append(a.params, params)
+	if method == "Lock" || params["group"] == "denied" {
+		return errors.New(errors.Forbidden, "access denied")
+	}
+	return nil
+}
+
+func TestAuthorizeInterceptor(t *testing.T) {
+	svc := &policyService{
+		evaluate: func(ctx context.Context, req *goapolicy.EvaluateRequest) (*goapolicy.EvaluateResult, error) {
+			return &goapolicy.EvaluateResult{Result: true, ETag: "1", Version: req.Version}, nil
+		},
+	}
+	authz := &authorizer{}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcserver.AuthorizeUnaryInterceptor(authz)),
+		grpc.ChainStreamInterceptor(grpcserver.AuthorizeStreamInterceptor(authz)),
+	)
+	policyv1.RegisterPolicyServiceServer(srv, grpcserver.New(svc, 2, zap.NewNop()))
+	client := policyv1.NewPolicyServiceClient(dial(t, srv))
+
+	// the policy of the request is passed as path parameters
+	_, err := client.Evaluate(context.Background(), &policyv1.EvaluateRequest{
+		Repository: "policies",
+		Group:      "example",
+		PolicyName: "example",
+		Version:    "1.0",
+	})
+	require.NoError(t, err)
+
+	// evaluation of a rule is authorized as EvaluateRule
+	_, err = client.Evaluate(context.Background(), &policyv1.EvaluateRequest{
+		Repository: "policies",
+		Group:      "example",
+		PolicyName: "example",
+		Version:    "1.0",
+		Rule:       "allow",
+	})
+	require.NoError(t, err)
+
+	// denied request returns the error of the authorizer
+	_, err = client.Lock(context.Background(), &policyv1.LockRequest{Version: "1.0"})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the requests of streams are authorized with their own path parameters
+	stream, err := client.EvaluateBatch(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&policyv1.EvaluateRequest{Group: "example", Version: "1.0"}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Empty(t, res.Error)
+
+	// denied request fails the stream
+	require.NoError(t, stream.Send(&policyv1.EvaluateRequest{Group: "denied", Version: "1.0"}))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	authz.mu.Lock()
+	defer authz.mu.Unlock()
+	assert.Equal(t, []string{
+		"policy.Evaluate",
+		"policy.EvaluateRule",
+		"policy.Lock",
+		"policy.EvaluateBatch",
+		"policy.Evaluate",
+		"policy.Evaluate",
+	}, authz.methods)
+	assert.Equal(t, map[string]string{"repository": "policies", "group": "example", "policyName": "example", "version": "1.0"}, authz.params[0])
+	assert.Equal(t, "allow", authz.params[1]["rule"])
+	assert.Nil(t, authz.params[3])
+	assert.Equal(t, "example", authz.params[4]["group"])
+}
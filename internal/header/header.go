@@ -3,12 +3,17 @@ package header
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 type key string
 
 const headerKey key = "header"
 
+// CredentialHeaders carry the credentials of the client.
+// They are removed from headers which are stored.
+var CredentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
 // Middleware is an HTTP server middleware that gets all HTTP headers
 // and adds them to a request context value.
 func Middleware() func(http.Handler) http.Handler {
@@ -31,6 +36,12 @@ func ToContext(ctx context.Context, r *http.Request) context.Context {
 	// add Host header to headers map
 	headers["Host"] = r.Host
 
+	return WithHeaders(ctx, headers)
+}
+
+// WithHeaders returns a context with the given headers, e.g. when
+// a recorded evaluation is repeated without an HTTP request.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
 	return context.WithValue(ctx, headerKey, headers)
 }
 
@@ -38,3 +49,31 @@ func FromContext(ctx context.Context) (map[string]string, bool) {
 	header, ok := ctx.Value(headerKey).(map[string]string)
 	return header, ok
 }
+
+// Strip returns a copy of the headers without the credential headers and
+// the given additional headers. The names are compared case-insensitively.
+func Strip(headers map[string]string, names ...string) map[string]string {
+	res := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if !Stripped(name, names...) {
+			res[name] = value
+		}
+	}
+	return res
+}
+
+// Stripped reports whether the header is removed by Strip
+// with the given additional headers.
+func Stripped(name string, names ...string) bool {
+	for _, n := range CredentialHeaders {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	for _, n := range names {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
@@ -26,3 +26,19 @@ func TestMiddleware(t *testing.T) {
 	handlerToTest := middleware(nextHandler)
 	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
 }
+
+func TestStrip(t *testing.T) {
+	headers := map[string]string{
+		"Authorization":       "Bearer token",
+		"Proxy-Authorization": "Basic secret",
+		"Cookie":              "session=1",
+		"X-Api-Key":           "key",
+		"X-Client-Id":         "client",
+		"Host":                "example.com",
+	}
+
+	res := header.Strip(headers, "x-api-key")
+	assert.Equal(t, map[string]string{"X-Client-Id": "client", "Host": "example.com"}, res)
+	// the headers aren't changed
+	assert.Len(t, headers, 6)
+}
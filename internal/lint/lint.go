@@ -0,0 +1,146 @@
+// Package lint validates policies before they are stored, so that invalid
+// policies are rejected when they are synchronized instead of failing at
+// evaluation time.
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/format"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+// libraryFolder is the folder of the shared library modules
+// in the compiled module filenames, like in the policy service.
+const libraryFolder = "library"
+
+// Linter checks that policies can be compiled and evaluated by the policy service.
+type Linter struct {
+	libraries []storage.Module
+	builtins  map[string]*ast.Builtin
+}
+
+// New creates a Linter. Policies are compiled together with the library
+// modules and may call the given extension functions.
+func New(libraries []storage.Module, functions []*rego.Function) *Linter {
+	builtins := make(map[string]*ast.Builtin, len(functions))
+	for _, f := range functions {
+		builtins[f.Name] = &ast.Builtin{Name: f.Name, Decl: f.Decl}
+	}
+
+	return &Linter{
+		libraries: libraries,
+		builtins:  builtins,
+	}
+}
+
+// Check returns all problems of a policy. The policy is valid if no problems are
+// returned. The modules must be formatted like with 'opa fmt' and compile in strict
+// mode, the package of the main module must be 'group.name' and the data, schema
+// and configuration files must contain valid JSON.
+func (l *Linter) Check(p *storage.Policy) []error {
+	var problems []error
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"data.json", p.Data},
+		{"data-config.json", p.DataConfig},
+		{"output-schema.json", p.OutputSchema},
+		{"input-schema.json", p.InputSchema},
+		{"export-config.json", p.ExportConfig},
+		{"policy-config.json", p.RuntimeConfig},
+	}
+	for _, f := range files {
+		if f.content != "" && !json.Valid([]byte(f.content)) {
+			problems = append(problems, fmt.Errorf("%s: invalid JSON", f.name))
+		}
+	}
+
+	if _, ok := p.Module(storage.PolicyFilename); !ok {
+		return append(problems, fmt.Errorf("%s: main module is missing", storage.PolicyFilename))
+	}
+
+	// module filenames are the same as in the policy service,
+	// so that the problems show where the failing module is located
+	policyFolder := path.Dir(p.Filename)
+	if p.Filename == "" {
+		policyFolder = path.Join(p.Group, p.Name, p.Version)
+	}
+
+	modules := make(map[string]*ast.Module, len(p.Modules)+len(l.libraries))
+	for _, m := range p.Modules {
+		filename := path.Join(policyFolder, m.Filename)
+
+		module, err := ast.ParseModule(filename, m.Rego)
+		if err != nil {
+			problems = append(problems, parseErrors(err)...)
+			continue
+		}
+		modules[filename] = module
+
+		formatted, err := format.Source(filename, []byte(m.Rego))
+		if err == nil && !bytes.Equal(formatted, []byte(m.Rego)) {
+			problems = append(problems, fmt.Errorf("%s: module is not formatted, run 'opa fmt'", filename))
+		}
+
+		if m.Filename == storage.PolicyFilename {
+			expected := ast.DefaultRootRef.Append(ast.StringTerm(p.Group)).Append(ast.StringTerm(p.Name))
+			if !module.Package.Path.Equal(expected) {
+				problems = append(problems, fmt.Errorf("%s: package must be '%s.%s', got '%s'", filename, p.Group, p.Name, packageName(module)))
+			}
+		}
+	}
+
+	// modules which can't be parsed aren't compiled
+	if len(modules) < len(p.Modules) {
+		return problems
+	}
+
+	for _, m := range l.libraries {
+		filename := path.Join(libraryFolder, m.Filename)
+		module, err := ast.ParseModule(filename, m.Rego)
+		if err != nil {
+			return append(problems, fmt.Errorf("error parsing library module: %w", err))
+		}
+		modules[filename] = module
+	}
+
+	compiler := ast.NewCompiler().
+		WithStrict(true).
+		WithBuiltins(l.builtins).
+		WithEnablePrintStatements(true)
+	compiler.Compile(modules)
+	for _, err := range compiler.Errors {
+		problems = append(problems, err)
+	}
+
+	return problems
+}
+
+// parseErrors splits the errors of a module parser, so that they're reported separately.
+func parseErrors(err error) []error {
+	errs, ok := err.(ast.Errors)
+	if !ok {
+		return []error{err}
+	}
+
+	problems := make([]error, 0, len(errs))
+	for _, e := range errs {
+		problems = append(problems, e)
+	}
+	return problems
+}
+
+// packageName returns the package of a module without the 'data.' prefix.
+func packageName(module *ast.Module) string {
+	return strings.TrimPrefix(module.Package.Path.String(), "data.")
+}
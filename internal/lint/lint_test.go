@@ -0,0 +1,87 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/lint"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func TestLinter_Check(t *testing.T) {
+	policy := func(data string, modules ...storage.Module) *storage.Policy {
+		return &storage.Policy{
+			Filename: "example/example/1.0/policy.rego",
+			Group:    "example",
+			Name:     "example",
+			Version:  "1.0",
+			Modules:  modules,
+			Data:     data,
+		}
+	}
+	module := func(filename, rego string) storage.Module {
+		return storage.Module{Filename: filename, Rego: rego}
+	}
+
+	libraries := []storage.Module{
+		module("lib/strings.rego", "package lib.strings\n\nupper_msg(msg) := upper(msg)\n"),
+	}
+
+	tests := []struct {
+		name     string
+		policy   *storage.Policy
+		problems []string
+	}{
+		{
+			name: "valid policy",
+			policy: policy(`{"allowed": ["did:web:example.com"]}`,
+				module("policy.rego", "package example.example\n\nimport data.lib.strings\n\nallow {\n\tdid := did_to_url(input.did)\n\tdid != \"\"\n\tmsg := strings.upper_msg(external.http.header(\"X-Msg\"))\n\tmsg == \"YES\"\n}\n"),
+				module("helpers.rego", "package example.helpers\n\nx := 1\n"),
+			),
+		},
+		{
+			name:     "main module is missing",
+			policy:   policy("", module("helpers.rego", "package example.helpers\n\nx := 1\n")),
+			problems: []string{"policy.rego: main module is missing"},
+		},
+		{
+			name:     "invalid data and syntax error",
+			policy:   policy(`{"a":`, module("policy.rego", "package example.example\n\nallow {\n")),
+			problems: []string{"data.json: invalid JSON", "example/example/1.0/policy.rego:4: rego_parse_error"},
+		},
+		{
+			name:     "wrong package",
+			policy:   policy("", module("policy.rego", "package example.other\n\nallow := true\n")),
+			problems: []string{"package must be 'example.example', got 'example.other'"},
+		},
+		{
+			name:     "module is not formatted",
+			policy:   policy("", module("policy.rego", "package example.example\nallow  :=   true\n")),
+			problems: []string{"example/example/1.0/policy.rego: module is not formatted"},
+		},
+		{
+			name:     "unknown function",
+			policy:   policy("", module("policy.rego", "package example.example\n\nallow := unknown.func(input.x)\n")),
+			problems: []string{"undefined function unknown.func"},
+		},
+		{
+			name:     "strict mode",
+			policy:   policy("", module("policy.rego", "package example.example\n\nimport data.unused\n\nallow := true\n")),
+			problems: []string{"import data.unused unused"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := lint.New(libraries, regofunc.Declarations()).Check(test.policy)
+			if !assert.Len(t, problems, len(test.problems), "%v", problems) {
+				return
+			}
+			for i, problem := range test.problems {
+				assert.Contains(t, problems[i].Error(), problem)
+			}
+		})
+	}
+}
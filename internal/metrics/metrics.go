@@ -0,0 +1,238 @@
+// Package metrics defines the Prometheus metrics of policy evaluations
+// and of the Rego extension functions. All metrics are registered in
+// the default Prometheus registry, which is exposed by the service.
+package metrics
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const (
+	namespace = "policy"
+
+	// DefaultMaxPolicies is the default limit of distinct
+	// policy versions which are used as metric labels.
+	DefaultMaxPolicies = 1000
+
+	// OtherLabel replaces the policy labels of policy versions
+	// exceeding the limit of distinct policy versions.
+	OtherLabel = "other"
+)
+
+var policyLabelNames = []string{"repository", "group", "policy", "version"}
+
+var (
+	// EvaluationDuration observes the duration of policy evaluations.
+	EvaluationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "evaluation_duration_seconds",
+		Help:      "Duration of policy evaluations.",
+		Buckets:   prometheus.DefBuckets,
+	}, policyLabelNames)
+
+	// PrepareDuration observes the duration of compiling
+	// policy modules and preparing their queries.
+	PrepareDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "prepare_duration_seconds",
+		Help:      "Duration of compiling policies and preparing their queries for evaluation.",
+		Buckets:   prometheus.DefBuckets,
+	}, policyLabelNames)
+
+	// Evaluations counts policy evaluations.
+	Evaluations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evaluations_total",
+		Help:      "Number of policy evaluations.",
+	}, policyLabelNames)
+
+	// EvaluationErrors counts failed policy evaluations by error kind.
+	EvaluationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evaluation_errors_total",
+		Help:      "Number of failed policy evaluations by error kind.",
+	}, append(policyLabelNames, "kind"))
+
+	// LockRejections counts evaluations rejected because the policy is locked.
+	LockRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lock_rejections_total",
+		Help:      "Number of policy evaluations rejected because the policy is locked.",
+	}, policyLabelNames)
+
+	// ValidationFailures counts policy inputs and outputs
+	// which don't conform to the JSON schema of the policy.
+	ValidationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "validation_failures_total",
+		Help:      "Number of policy inputs and outputs failing the JSON schema validation.",
+	}, append(policyLabelNames, "schema"))
+
+	// CacheHits counts policies and prepared queries found in the policy cache.
+	CacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_hits_total",
+		Help:      "Number of policies and prepared queries found in the policy cache.",
+	}, append(policyLabelNames, "entry"))
+
+	// CacheMisses counts policies and prepared queries missing in the policy cache.
+	CacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_misses_total",
+		Help:      "Number of policies and prepared queries missing in the policy cache.",
+	}, append(policyLabelNames, "entry"))
+
+	// ShadowEvaluations counts shadow evaluations of candidate policy versions.
+	ShadowEvaluations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shadow_evaluations_total",
+		Help:      "Number of shadow evaluations of candidate policy versions.",
+	}, append(policyLabelNames, "shadow_version"))
+
+	// ShadowDivergences counts shadow evaluations with divergent results.
+	ShadowDivergences = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shadow_divergences_total",
+		Help:      "Number of shadow evaluations whose candidate policy version returned a different result.",
+	}, append(policyLabelNames, "shadow_version"))
+
+	// BuiltinCalls counts calls of the Rego extension functions.
+	BuiltinCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "builtin_calls_total",
+		Help:      "Number of Rego extension function calls.",
+	}, []string{"builtin"})
+
+	// BuiltinErrors counts failed calls of the Rego extension functions.
+	BuiltinErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "builtin_errors_total",
+		Help:      "Number of failed Rego extension function calls.",
+	}, []string{"builtin"})
+
+	// BuiltinDuration observes the duration of Rego extension function calls.
+	BuiltinDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "builtin_duration_seconds",
+		Help:      "Duration of Rego extension function calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"builtin"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		EvaluationDuration,
+		PrepareDuration,
+		Evaluations,
+		EvaluationErrors,
+		LockRejections,
+		ValidationFailures,
+		CacheHits,
+		CacheMisses,
+		ShadowEvaluations,
+		ShadowDivergences,
+		BuiltinCalls,
+		BuiltinErrors,
+		BuiltinDuration,
+	)
+}
+
+var policies = newPolicyGuard(DefaultMaxPolicies)
+
+// SetMaxPolicies limits the number of distinct policy versions used as metric
+// labels. Non-positive values disable the limit. It should be called before
+// any metric is recorded, as the already seen policy versions are reset.
+func SetMaxPolicies(n int) {
+	policies.reset(n)
+}
+
+// PolicyLabels returns the metric labels of a policy version. When the limit of
+// distinct policy versions is reached, the labels of new policy versions are
+// set to OtherLabel, so that the number of time series stays bounded.
+func PolicyLabels(repository, group, policy, version string) prometheus.Labels {
+	if !policies.allow(repository, group, policy, version) {
+		repository, group, policy, version = OtherLabel, OtherLabel, OtherLabel, OtherLabel
+	}
+
+	return prometheus.Labels{
+		"repository": repository,
+		"group":      group,
+		"policy":     policy,
+		"version":    version,
+	}
+}
+
+// OtherPolicyLabels returns the metric labels set to OtherLabel. They are used
+// for requests of unknown policies, which must not count towards the limit of
+// distinct policy versions.
+func OtherPolicyLabels() prometheus.Labels {
+	return prometheus.Labels{
+		"repository": OtherLabel,
+		"group":      OtherLabel,
+		"policy":     OtherLabel,
+		"version":    OtherLabel,
+	}
+}
+
+// ErrorKind returns the error kind label of an error, e.g. 'not_found'.
+func ErrorKind(err error) string {
+	kind := errors.Unknown
+	if e, ok := err.(*errors.Error); ok {
+		kind = e.Kind
+	}
+	return strings.ReplaceAll(kind.String(), " ", "_")
+}
+
+// With returns a copy of the labels with additional label.
+func With(labels prometheus.Labels, name, value string) prometheus.Labels {
+	l := make(prometheus.Labels, len(labels)+1)
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[name] = value
+	return l
+}
+
+// policyGuard keeps the set of policy versions which are allowed as metric labels.
+type policyGuard struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]struct{}
+}
+
+func newPolicyGuard(max int) *policyGuard {
+	return &policyGuard{max: max, seen: make(map[string]struct{})}
+}
+
+func (g *policyGuard) reset(max int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.max = max
+	g.seen = make(map[string]struct{})
+}
+
+func (g *policyGuard) allow(repository, group, policy, version string) bool {
+	key := strings.Join([]string{repository, group, policy, version}, "\x00")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.max <= 0 {
+		return true
+	}
+	if _, ok := g.seen[key]; ok {
+		return true
+	}
+	if len(g.seen) >= g.max {
+		return false
+	}
+	g.seen[key] = struct{}{}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+func TestPolicyGuard(t *testing.T) {
+	g := newPolicyGuard(2)
+
+	assert.True(t, g.allow("policies", "example", "allow", "1.0"))
+	assert.True(t, g.allow("policies", "example", "allow", "2.0"))
+	assert.False(t, g.allow("policies", "example", "allow", "3.0"))
+	// already seen policy versions are still allowed
+	assert.True(t, g.allow("policies", "example", "allow", "1.0"))
+
+	g.reset(0)
+	for i := 0; i < 10; i++ {
+		assert.True(t, g.allow("policies", "example", "allow", fmt.Sprintf("%d.0", i)))
+	}
+}
+
+func TestPolicyLabels(t *testing.T) {
+	SetMaxPolicies(1)
+	defer SetMaxPolicies(DefaultMaxPolicies)
+
+	labels := PolicyLabels("policies", "example", "allow", "1.0")
+	assert.Equal(t, "policies", labels["repository"])
+	assert.Equal(t, "example", labels["group"])
+	assert.Equal(t, "allow", labels["policy"])
+	assert.Equal(t, "1.0", labels["version"])
+
+	labels = PolicyLabels("policies", "example", "allow", "2.0")
+	assert.Equal(t, OtherLabel, labels["repository"])
+	assert.Equal(t, OtherLabel, labels["group"])
+	assert.Equal(t, OtherLabel, labels["policy"])
+	assert.Equal(t, OtherLabel, labels["version"])
+
+	kindLabels := With(labels, "kind", "timeout")
+	assert.Equal(t, "timeout", kindLabels["kind"])
+	assert.NotContains(t, labels, "kind")
+}
+
+func TestOtherPolicyLabels(t *testing.T) {
+	SetMaxPolicies(1)
+	defer SetMaxPolicies(DefaultMaxPolicies)
+
+	labels := OtherPolicyLabels()
+	assert.Equal(t, OtherLabel, labels["repository"])
+	assert.Equal(t, OtherLabel, labels["group"])
+	assert.Equal(t, OtherLabel, labels["policy"])
+	assert.Equal(t, OtherLabel, labels["version"])
+
+	// the labels don't use up the limit of distinct policy versions
+	labels = PolicyLabels("policies", "example", "allow", "1.0")
+	assert.Equal(t, "1.0", labels["version"])
+}
+
+func TestErrorKind(t *testing.T) {
+	assert.Equal(t, "not_found", ErrorKind(errors.New(errors.NotFound, "policy not found")))
+	assert.Equal(t, "bad_request", ErrorKind(errors.New("error evaluating policy", errors.New(errors.BadRequest, "invalid input"))))
+	assert.Equal(t, "unknown_error", ErrorKind(fmt.Errorf("some error")))
+}
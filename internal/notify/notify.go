@@ -48,7 +48,7 @@ func New(events Events, storage Storage, client *http.Client, logger *zap.Logger
 
 // PolicyDataChange is called when the policies source code or data are updated
 // in storage. The function will notify subscribers of the given changes.
-func (n *Notifier) PolicyDataChange(ctx context.Context, policyRepository, policyName, policyGroup, policyVersion string) error {
+func (n *Notifier) PolicyDataChange(ctx context.Context, policyRepository, policyGroup, policyName, policyVersion string) error {
 	logger := n.logger.With(zap.String("operation", "PolicyDataChange"))
 
 	event := &EventPolicyChange{
@@ -85,8 +85,8 @@ func TestNotify_PolicyDataChange(t *testing.T) {
 			notifier := notify.New(test.events, test.storage, http.DefaultClient, logger)
 			err := notifier.PolicyDataChange(context.Background(),
 				test.eventPolicyChange.Repository,
-				test.eventPolicyChange.Name,
 				test.eventPolicyChange.Group,
+				test.eventPolicyChange.Name,
 				test.eventPolicyChange.Version)
 
 			// we need to sleep a little, as notifier.PolicyDataChange(...)
@@ -0,0 +1,295 @@
+// Package policytest runs the unit tests of policies. Tests are written as
+// '_test.rego' modules, which are executed with the OPA tester, and as golden
+// test cases in 'tests.json', which compare the evaluation result for a given
+// input with the expected output.
+package policytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/cover"
+	"github.com/open-policy-agent/opa/rego"
+	opastorage "github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
+	"github.com/open-policy-agent/opa/tester"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+// libraryFolder is the folder of the shared library modules
+// in the compiled module filenames, like in the policy service.
+const libraryFolder = "library"
+
+// TestCase is a golden test case of a policy. The policy package, or the
+// rule of the package if it's set, is evaluated with the input and the
+// result must be equal to the expected output.
+type TestCase struct {
+	Name     string `json:"name"`
+	Rule     string `json:"rule"`
+	Input    any    `json:"input"`
+	Expected any    `json:"expected"`
+}
+
+// Tester runs the tests of policies.
+type Tester struct {
+	libraries []storage.Module
+	builtins  map[string]*ast.Builtin
+	functions []*tester.Builtin
+}
+
+// New creates a Tester. Policies are compiled together with the library
+// modules and may call the given extension functions. The functions are
+// not executed, because the services called by them aren't available
+// during tests, so they must be replaced with 'with' in the tests.
+func New(libraries []storage.Module, functions []*rego.Function) *Tester {
+	builtins := make(map[string]*ast.Builtin, len(functions))
+	stubs := make([]*tester.Builtin, 0, len(functions))
+	for _, f := range functions {
+		decl := &ast.Builtin{Name: f.Name, Decl: f.Decl}
+		builtins[f.Name] = decl
+		stubs = append(stubs, &tester.Builtin{Decl: decl, Func: rego.FunctionDyn(f, stub(f.Name))})
+	}
+
+	return &Tester{
+		libraries: libraries,
+		builtins:  builtins,
+		functions: stubs,
+	}
+}
+
+// HasTests reports whether the policy contains test modules or golden test cases.
+func HasTests(p *storage.Policy) bool {
+	return len(p.Tests) > 0 || strings.TrimSpace(p.TestCases) != ""
+}
+
+// Run executes the test modules and the golden test cases of a policy.
+// The tests pass if all of them succeed, failures and errors which
+// prevent the tests from running are described in the returned report.
+func (t *Tester) Run(ctx context.Context, p *storage.Policy) *storage.TestReport {
+	report := &storage.TestReport{RunAt: time.Now()}
+	if err := t.run(ctx, p, report); err != nil {
+		report.Error = err.Error()
+	}
+
+	report.Total = len(report.Results)
+	for _, r := range report.Results {
+		if !r.Passed {
+			report.Failed++
+		}
+	}
+	report.Passed = report.Error == "" && report.Failed == 0
+
+	return report
+}
+
+func (t *Tester) run(ctx context.Context, p *storage.Policy, report *storage.TestReport) error {
+	// module filenames are the same as in the policy service,
+	// so that the results show where a failing test is located
+	policyFolder := path.Dir(p.Filename)
+	if p.Filename == "" {
+		policyFolder = path.Join(p.Group, p.Name, p.Version)
+	}
+
+	modules, err := parseModules(policyFolder, p.Modules)
+	if err != nil {
+		return err
+	}
+	tests, err := parseModules(policyFolder, p.Tests)
+	if err != nil {
+		return err
+	}
+	libraries, err := parseModules(libraryFolder, t.libraries)
+	if err != nil {
+		return fmt.Errorf("error parsing library module: %w", err)
+	}
+
+	var data map[string]any
+	if p.Data != "" {
+		if err := json.Unmarshal([]byte(p.Data), &data); err != nil {
+			return fmt.Errorf("data.json: invalid JSON: %v", err)
+		}
+	}
+
+	var cases []TestCase
+	if strings.TrimSpace(p.TestCases) != "" {
+		if err := json.Unmarshal([]byte(p.TestCases), &cases); err != nil {
+			return fmt.Errorf("%s: invalid test cases: %v", storage.TestCasesFilename, err)
+		}
+	}
+
+	coverage := cover.New()
+
+	if len(tests) > 0 {
+		ch, err := tester.NewRunner().
+			SetCompiler(t.compiler()).
+			SetStore(inmem.NewFromObject(data)).
+			AddCustomBuiltins(t.functions).
+			SetCoverageQueryTracer(coverage).
+			SetModules(merge(modules, tests, libraries)).
+			RunTests(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for r := range ch {
+			if r.Skip {
+				continue
+			}
+
+			result := storage.TestResult{
+				Name:     strings.TrimPrefix(r.Package, "data.") + "." + r.Name,
+				Passed:   r.Pass(),
+				Duration: r.Duration,
+			}
+			if r.Location != nil {
+				result.Location = fmt.Sprintf("%s:%d", r.Location.File, r.Location.Row)
+			}
+			if r.Error != nil {
+				result.Error = r.Error.Error()
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	if len(cases) > 0 {
+		// test modules aren't compiled for the golden test cases, because
+		// their rules would be part of the evaluated package document
+		compiler := t.compiler()
+		if compiler.Compile(merge(modules, libraries)); compiler.Failed() {
+			return compiler.Errors
+		}
+
+		store := inmem.NewFromObject(data)
+		for i, c := range cases {
+			report.Results = append(report.Results, t.runCase(ctx, compiler, store, p, i, c, coverage))
+		}
+	}
+
+	report.Coverage = coveragePercentage(coverage.Report(modules), modules)
+
+	return nil
+}
+
+// runCase evaluates a golden test case and compares the result with the expected output.
+func (t *Tester) runCase(ctx context.Context, compiler *ast.Compiler, store opastorage.Store, p *storage.Policy, i int, c TestCase, coverage *cover.Cover) storage.TestResult {
+	result := storage.TestResult{
+		Name:     c.Name,
+		Location: fmt.Sprintf("%s:%d", storage.TestCasesFilename, i),
+	}
+	if result.Name == "" {
+		result.Name = fmt.Sprintf("case %d", i)
+	}
+
+	query := ast.DefaultRootRef.Append(ast.StringTerm(p.Group)).Append(ast.StringTerm(p.Name))
+	for _, segment := range strings.Split(strings.Trim(c.Rule, "/"), "/") {
+		if segment != "" {
+			query = query.Append(ast.StringTerm(segment))
+		}
+	}
+
+	args := []func(*rego.Rego){
+		rego.Compiler(compiler),
+		rego.Store(store),
+		rego.Query(query.String()),
+		rego.Input(c.Input),
+		rego.QueryTracer(coverage),
+		rego.StrictBuiltinErrors(true),
+	}
+	for _, f := range t.functions {
+		args = append(args, f.Func)
+	}
+
+	start := time.Now()
+	rs, err := rego.New(args...).Eval(ctx)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+		result.Error = "result is undefined"
+		return result
+	}
+
+	expected, err := ast.InterfaceToValue(c.Expected)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid expected output: %v", err)
+		return result
+	}
+	actual, err := ast.InterfaceToValue(rs[0].Expressions[0].Value)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid result: %v", err)
+		return result
+	}
+
+	if expected.Compare(actual) != 0 {
+		result.Error = fmt.Sprintf("expected %v, got %v", expected, actual)
+		return result
+	}
+
+	result.Passed = true
+	return result
+}
+
+func (t *Tester) compiler() *ast.Compiler {
+	return ast.NewCompiler().
+		WithBuiltins(t.builtins).
+		WithEnablePrintStatements(true)
+}
+
+// parseModules parses the modules with filenames in the given folder.
+func parseModules(folder string, modules []storage.Module) (map[string]*ast.Module, error) {
+	parsed := make(map[string]*ast.Module, len(modules))
+	for _, m := range modules {
+		filename := path.Join(folder, m.Filename)
+		module, err := ast.ParseModule(filename, m.Rego)
+		if err != nil {
+			return nil, err
+		}
+		parsed[filename] = module
+	}
+	return parsed, nil
+}
+
+func merge(modules ...map[string]*ast.Module) map[string]*ast.Module {
+	merged := make(map[string]*ast.Module)
+	for _, m := range modules {
+		for filename, module := range m {
+			merged[filename] = module
+		}
+	}
+	return merged
+}
+
+// coveragePercentage returns the percentage of the lines of the policy modules,
+// which are covered by the tests. Test and library modules aren't counted.
+func coveragePercentage(report cover.Report, modules map[string]*ast.Module) float64 {
+	var covered, notCovered int
+	for filename := range modules {
+		if f, ok := report.Files[filename]; ok {
+			covered += f.CoveredLines
+			notCovered += f.NotCoveredLines
+		}
+	}
+
+	if covered+notCovered == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(covered+notCovered)
+}
+
+// stub returns an implementation of an extension function, which halts
+// the evaluation, because the function isn't replaced in the test.
+// Other errors would only make the result of the test undefined.
+func stub(name string) rego.BuiltinDyn {
+	return func(rego.BuiltinContext, []*ast.Term) (*ast.Term, error) {
+		return nil, rego.NewHaltError(fmt.Errorf("extension function %s is not available in policy tests, replace it with 'with'", name))
+	}
+}
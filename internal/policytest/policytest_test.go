@@ -0,0 +1,147 @@
+package policytest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/policytest"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+const examplePolicy = `package example.example
+
+import data.lib.strings
+
+default allow = false
+
+allow {
+	url := did_to_url(input.did)
+	url == data.allowed[_]
+}
+
+greeting := strings.upper_msg(input.name)
+
+other := 1 {
+	input.other
+}
+`
+
+func TestTester_Run(t *testing.T) {
+	policy := func(data, testCases string, tests ...storage.Module) *storage.Policy {
+		return &storage.Policy{
+			Filename:  "example/example/1.0/policy.rego",
+			Group:     "example",
+			Name:      "example",
+			Version:   "1.0",
+			Modules:   []storage.Module{{Filename: "policy.rego", Rego: examplePolicy}},
+			Data:      data,
+			Tests:     tests,
+			TestCases: testCases,
+		}
+	}
+	module := func(filename, rego string) storage.Module {
+		return storage.Module{Filename: filename, Rego: rego}
+	}
+
+	libraries := []storage.Module{
+		module("lib/strings.rego", "package lib.strings\n\nupper_msg(msg) := upper(msg)\n"),
+	}
+
+	data := `{"allowed": ["https://example.com/.well-known/did.json"]}`
+
+	tests := []struct {
+		name   string
+		policy *storage.Policy
+
+		passed  bool
+		results map[string]string
+		err     string
+	}{
+		{
+			name: "passing test modules and test cases",
+			policy: policy(data,
+				`[{"name": "greeting", "rule": "greeting", "input": {"name": "alice"}, "expected": "ALICE"}]`,
+				module("policy_test.rego", "package example.example\n\ntest_allow {\n\tallow with input.did as \"did:web:example.com\" with did_to_url as \"https://example.com/.well-known/did.json\"\n}\n\ntest_deny {\n\tnot allow with input.did as \"did:web:other.com\" with did_to_url as \"https://other.com/.well-known/did.json\"\n}\n"),
+			),
+			passed: true,
+			results: map[string]string{
+				"example.example.test_allow": "",
+				"example.example.test_deny":  "",
+				"greeting":                   "",
+			},
+		},
+		{
+			name: "failing test module",
+			policy: policy(data, "",
+				module("policy_test.rego", "package example.example\n\ntest_allow {\n\tallow with input.did as \"did:web:other.com\" with did_to_url as \"https://other.com/.well-known/did.json\"\n}\n"),
+			),
+			results: map[string]string{"example.example.test_allow": ""},
+		},
+		{
+			name: "extension function is not replaced",
+			policy: policy(data, "",
+				module("policy_test.rego", "package example.example\n\ntest_allow {\n\tallow with input.did as \"did:web:example.com\"\n}\n"),
+			),
+			results: map[string]string{"example.example.test_allow": "extension function did_to_url is not available in policy tests"},
+		},
+		{
+			name: "test case with unexpected result",
+			policy: policy(data,
+				`[{"name": "package", "input": {"name": "bob"}, "expected": {"allow": false, "greeting": "BOB", "other": 1}}]`,
+			),
+			results: map[string]string{"package": "expected"},
+		},
+		{
+			name:   "test case with undefined result",
+			policy: policy(data, `[{"name": "other", "rule": "other", "input": {}, "expected": 1}]`),
+			results: map[string]string{
+				"other": "result is undefined",
+			},
+		},
+		{
+			name:   "invalid test cases",
+			policy: policy(data, `{"name": "x"}`),
+			err:    "tests.json: invalid test cases",
+		},
+		{
+			name:   "test module doesn't compile",
+			policy: policy(data, "", module("policy_test.rego", "package example.example\n\ntest_x {\n\tunknown(1)\n}\n")),
+			err:    "undefined function unknown",
+		},
+	}
+
+	tester := policytest.New(libraries, regofunc.Declarations())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := tester.Run(context.Background(), test.policy)
+
+			assert.Equal(t, test.passed, report.Passed)
+			if test.err != "" {
+				assert.Contains(t, report.Error, test.err)
+				return
+			}
+			assert.Empty(t, report.Error)
+
+			assert.Equal(t, len(test.results), report.Total)
+			for _, r := range report.Results {
+				errMsg, ok := test.results[r.Name]
+				if !assert.True(t, ok, "unexpected test result %q", r.Name) {
+					continue
+				}
+				assert.Contains(t, r.Error, errMsg)
+			}
+			assert.Greater(t, report.Coverage, 0.0)
+			assert.Less(t, report.Coverage, 100.0)
+		})
+	}
+}
+
+func TestHasTests(t *testing.T) {
+	assert.False(t, policytest.HasTests(&storage.Policy{}))
+	assert.True(t, policytest.HasTests(&storage.Policy{TestCases: "[]"}))
+	assert.True(t, policytest.HasTests(&storage.Policy{Tests: []storage.Module{{Filename: "policy_test.rego"}}}))
+}
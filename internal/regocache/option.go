@@ -0,0 +1,11 @@
+package regocache
+
+type Option func(*Cache)
+
+// WithMaxSize limits the number of policies and prepared queries kept in the cache.
+// A size of zero or less means that the cache is unbounded.
+func WithMaxSize(size int) Option {
+	return func(c *Cache) {
+		c.maxSize = size
+	}
+}
@@ -1,48 +1,243 @@
 // Package regocache implements in-memory caching of
-// policy data structure. It also implements a function
-// to purge the cache when external data changes have happened.
+// policy data structures and their compiled (prepared) queries.
+// It also implements a function to invalidate cache entries
+// when external data changes have happened.
 package regocache
 
 import (
+	"container/list"
 	"context"
+	"fmt"
 	"sync"
 
+	"github.com/open-policy-agent/opa/rego"
+
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
 )
 
+// maxQueriesPerPolicy limits the number of prepared queries of
+// different rule paths kept for a policy, because the rule paths
+// are given by the callers and may not even exist in the policy.
+const maxQueriesPerPolicy = 32
+
 type Cache struct {
-	mu    sync.RWMutex
-	cache map[string]*storage.Policy
+	mu sync.Mutex
+	// maxSize limits the number of cached policies and prepared queries.
+	// When the limit is reached, the least recently used entries are
+	// evicted. Zero value means that the cache is unbounded.
+	maxSize int
+	cache   map[string]*list.Element
+	lru     *list.List
+	// queries is the number of prepared queries of all entries.
+	queries int
 }
 
-func New() *Cache {
-	return &Cache{
-		cache: map[string]*storage.Policy{},
+type entry struct {
+	key    string
+	policy *storage.Policy
+	// queries are the prepared queries of the policy package
+	// and its rules, keyed by rule path. The whole package
+	// is queried with an empty rule path.
+	queries map[string]*rego.PreparedEvalQuery
+	// values are derived from the policy when it's evaluated, e.g.
+	// compiled schemas, and are keyed by their name. They aren't
+	// counted in the cache size, as every policy has only a few.
+	values map[string]any
+}
+
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		cache: map[string]*list.Element{},
+		lru:   list.New(),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Key constructs the cache key for a policy.
+func Key(repository, group, name, version string) string {
+	return fmt.Sprintf("%s,%s,%s,%s", repository, group, name, version)
 }
 
+// ReplayRule returns the rule of the prepared query of a rule path, whose extension
+// functions return recorded responses. The query is cached with the policy separately
+// from the query of the rule path. Rule paths can't contain the ':' of the marker.
+func ReplayRule(rule string) string {
+	return "replay:" + rule
+}
+
+// Set adds a policy to the cache. Any prepared query or derived value
+// which was previously cached for the key is discarded, as it may have
+// been compiled from a different version of the policy.
 func (c *Cache) Set(key string, policy *storage.Policy) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cache[key] = policy
+
+	if el, ok := c.cache[key]; ok {
+		c.queries -= len(el.Value.(*entry).queries)
+		el.Value = &entry{key: key, policy: policy}
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.cache[key] = c.lru.PushFront(&entry{key: key, policy: policy})
+	c.evict()
 }
 
 func (c *Cache) Get(key string) (policy *storage.Policy, found bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	v, ok := c.cache[key]
-	return v, ok
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+
+	return el.Value.(*entry).policy, true
+}
+
+// SetQuery attaches a prepared query for a rule path to a cached policy.
+// The policy is the one the query was compiled from. If it's no longer
+// in the cache (e.g. it was invalidated or updated while the query was
+// being prepared), or the policy already has maxQueriesPerPolicy queries,
+// the query is not stored.
+func (c *Cache) SetQuery(key, rule string, policy *storage.Policy, query *rego.PreparedEvalQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.cache[key]
+	if !ok {
+		return
+	}
+
+	e := el.Value.(*entry)
+	if e.policy != policy {
+		return
+	}
+	if e.queries == nil {
+		e.queries = map[string]*rego.PreparedEvalQuery{}
+	}
+	if _, ok := e.queries[rule]; !ok {
+		if len(e.queries) >= maxQueriesPerPolicy {
+			return
+		}
+		c.queries++
+	}
+	e.queries[rule] = query
+
+	c.lru.MoveToFront(el)
+	c.evict()
+}
+
+func (c *Cache) GetQuery(key, rule string) (query *rego.PreparedEvalQuery, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	query, ok = el.Value.(*entry).queries[rule]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+
+	return query, true
+}
+
+// SetValue attaches a value derived from a cached policy, e.g. its compiled
+// schema. The policy is the one the value was derived from. If it's no longer
+// in the cache, the value is not stored, the same as with SetQuery.
+func (c *Cache) SetValue(key, name string, policy *storage.Policy, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.cache[key]
+	if !ok {
+		return
+	}
+
+	e := el.Value.(*entry)
+	if e.policy != policy {
+		return
+	}
+	if e.values == nil {
+		e.values = map[string]any{}
+	}
+	e.values[name] = value
+}
+
+// GetValue returns a value derived from the given cached policy. A value
+// derived from another version of the policy stored under the key isn't returned.
+func (c *Cache) GetValue(key, name string, policy *storage.Policy) (value any, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if e.policy != policy {
+		return nil, false
+	}
+	value, ok = e.values[name]
+
+	return value, ok
+}
+
+// Delete removes a single policy, its prepared queries
+// and derived values from the cache.
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.cache[key]; ok {
+		c.remove(el)
+	}
 }
 
 // Purge deletes all cache values.
 func (c *Cache) Purge() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cache = map[string]*storage.Policy{}
+	c.cache = map[string]*list.Element{}
+	c.lru.Init()
+	c.queries = 0
 }
 
-// PolicyDataChange triggers purge on the cache.
-func (c *Cache) PolicyDataChange(_ context.Context, _, _, _, _ string) error {
-	c.Purge()
+// PolicyDataChange invalidates the cache entry of the changed
+// policy together with its prepared queries and derived values.
+func (c *Cache) PolicyDataChange(_ context.Context, repository, group, name, version string) error {
+	c.Delete(Key(repository, group, name, version))
 	return nil
 }
+
+// evict removes the least recently used entries until the number
+// of policies and prepared queries is within the configured limit.
+// The most recently used entry is kept, even if its queries alone
+// exceed the limit.
+func (c *Cache) evict() {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	for c.lru.Len()+c.queries > c.maxSize && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	e := el.Value.(*entry)
+	c.lru.Remove(el)
+	delete(c.cache, e.key)
+	c.queries -= len(e.queries)
+}
@@ -2,9 +2,11 @@ package regocache_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/open-policy-agent/opa/rego"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
@@ -23,7 +25,7 @@ func TestCache_SetAndGet(t *testing.T) {
 		Name:       "example",
 		Group:      "example",
 		Version:    "1.0",
-		Rego:       `package example.example _ = external.http.header("Authorization")`,
+		Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package example.example _ = external.http.header("Authorization")`}},
 		Data:       `{"hello":"world"}`,
 		Locked:     false,
 		LastUpdate: time.Now(),
@@ -43,7 +45,7 @@ func TestCache_Purge(t *testing.T) {
 		Name:       "example",
 		Group:      "example",
 		Version:    "1.0",
-		Rego:       `package example.example _ = external.http.header("Authorization")`,
+		Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package example.example _ = external.http.header("Authorization")`}},
 		Data:       `{"hello":"world"}`,
 		Locked:     false,
 		LastUpdate: time.Now(),
@@ -61,21 +63,203 @@ func TestCache_Purge(t *testing.T) {
 func TestCache_PolicyDataChange(t *testing.T) {
 	p1 := storage.Policy{
 		Filename:   "policy.rego",
+		Repository: "policies",
 		Name:       "example",
 		Group:      "example",
 		Version:    "1.0",
-		Rego:       `package example.example _ = external.http.header("Authorization")`,
+		Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package example.example _ = external.http.header("Authorization")`}},
 		Data:       `{"hello":"world"}`,
 		Locked:     false,
 		LastUpdate: time.Now(),
 	}
+	p2 := p1
+	p2.Version = "2.0"
+
+	key1 := regocache.Key(p1.Repository, p1.Group, p1.Name, p1.Version)
+	key2 := regocache.Key(p2.Repository, p2.Group, p2.Name, p2.Version)
 
 	cache := regocache.New()
-	cache.Set("key1", &p1)
+	cache.Set(key1, &p1)
+	cache.Set(key2, &p2)
 
-	err := cache.PolicyDataChange(context.Background(), "", "", "", "")
+	err := cache.PolicyDataChange(context.Background(), p1.Repository, p1.Group, p1.Name, p1.Version)
 	assert.Nil(t, err)
-	q2, ok := cache.Get("key1")
+	q1, ok := cache.Get(key1)
 	assert.False(t, ok)
-	assert.Nil(t, q2)
+	assert.Nil(t, q1)
+
+	// other policies are not affected by the change
+	q2, ok := cache.Get(key2)
+	assert.True(t, ok)
+	assert.Equal(t, p2, *q2)
+}
+
+func TestCache_SetQueryAndGetQuery(t *testing.T) {
+	p1 := storage.Policy{
+		Filename: "policy.rego",
+		Name:     "example",
+		Group:    "example",
+		Version:  "1.0",
+		Modules:  []storage.Module{{Filename: "policy.rego", Rego: `package example.example allow = true`}},
+	}
+
+	query, err := rego.New(
+		rego.Module(p1.Filename, p1.Modules[0].Rego),
+		rego.Query("data.example.example"),
+	).PrepareForEval(context.Background())
+	assert.NoError(t, err)
+
+	cache := regocache.New()
+
+	// query is not stored when the policy is not cached
+	cache.SetQuery("key1", "", &p1, &query)
+	q, ok := cache.GetQuery("key1", "")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+
+	cache.Set("key1", &p1)
+	q, ok = cache.GetQuery("key1", "")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+
+	cache.SetQuery("key1", "", &p1, &query)
+	q, ok = cache.GetQuery("key1", "")
+	assert.True(t, ok)
+	assert.Equal(t, &query, q)
+
+	// queries are cached per rule path
+	q, ok = cache.GetQuery("key1", "allow")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+
+	cache.SetQuery("key1", "allow", &p1, &query)
+	q, ok = cache.GetQuery("key1", "allow")
+	assert.True(t, ok)
+	assert.Equal(t, &query, q)
+
+	// setting the policy again discards the prepared queries
+	cache.Set("key1", &p1)
+	q, ok = cache.GetQuery("key1", "")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+	q, ok = cache.GetQuery("key1", "allow")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+
+	// query compiled from a policy which was updated in the meantime is not stored
+	p2 := p1
+	p2.Modules = []storage.Module{{Filename: "policy.rego", Rego: `package example.example allow = false`}}
+	cache.Set("key1", &p2)
+	cache.SetQuery("key1", "", &p1, &query)
+	q, ok = cache.GetQuery("key1", "")
+	assert.False(t, ok)
+	assert.Nil(t, q)
+}
+
+func TestCache_SetValueAndGetValue(t *testing.T) {
+	p1 := storage.Policy{Repository: "policies", Name: "example", Group: "example", Version: "1.0"}
+	key := regocache.Key(p1.Repository, p1.Group, p1.Name, p1.Version)
+
+	cache := regocache.New()
+
+	// value is not stored when the policy is not cached
+	cache.SetValue(key, "schema", &p1, "value1")
+	v, ok := cache.GetValue(key, "schema", &p1)
+	assert.False(t, ok)
+	assert.Nil(t, v)
+
+	cache.Set(key, &p1)
+	cache.SetValue(key, "schema", &p1, "value1")
+	v, ok = cache.GetValue(key, "schema", &p1)
+	assert.True(t, ok)
+	assert.Equal(t, "value1", v)
+
+	// values are kept per name
+	v, ok = cache.GetValue(key, "config", &p1)
+	assert.False(t, ok)
+	assert.Nil(t, v)
+
+	// value of another version of the policy is not returned
+	p2 := p1
+	v, ok = cache.GetValue(key, "schema", &p2)
+	assert.False(t, ok)
+	assert.Nil(t, v)
+
+	// values are invalidated together with the policy
+	err := cache.PolicyDataChange(context.Background(), p1.Repository, p1.Group, p1.Name, p1.Version)
+	assert.NoError(t, err)
+	cache.Set(key, &p1)
+	v, ok = cache.GetValue(key, "schema", &p1)
+	assert.False(t, ok)
+	assert.Nil(t, v)
+}
+
+func TestCache_WithMaxSize(t *testing.T) {
+	p1 := storage.Policy{Name: "example1", Group: "example", Version: "1.0"}
+	p2 := storage.Policy{Name: "example2", Group: "example", Version: "1.0"}
+	p3 := storage.Policy{Name: "example3", Group: "example", Version: "1.0"}
+
+	cache := regocache.New(regocache.WithMaxSize(2))
+	cache.Set("key1", &p1)
+	cache.Set("key2", &p2)
+
+	// access key1, so that key2 becomes the least recently used entry
+	_, ok := cache.Get("key1")
+	assert.True(t, ok)
+
+	cache.Set("key3", &p3)
+
+	_, ok = cache.Get("key2")
+	assert.False(t, ok)
+
+	q1, ok := cache.Get("key1")
+	assert.True(t, ok)
+	assert.Equal(t, p1, *q1)
+
+	q3, ok := cache.Get("key3")
+	assert.True(t, ok)
+	assert.Equal(t, p3, *q3)
+}
+
+func TestCache_WithMaxSizeCountsQueries(t *testing.T) {
+	p1 := storage.Policy{Name: "example1", Group: "example", Version: "1.0"}
+	p2 := storage.Policy{Name: "example2", Group: "example", Version: "1.0"}
+	query := rego.PreparedEvalQuery{}
+
+	cache := regocache.New(regocache.WithMaxSize(3))
+	cache.Set("key1", &p1)
+	cache.SetQuery("key1", "", &p1, &query)
+	cache.Set("key2", &p2)
+
+	// the queries of key1 count towards the cache size
+	cache.SetQuery("key2", "allow", &p2, &query)
+	_, ok := cache.Get("key1")
+	assert.False(t, ok)
+
+	_, ok = cache.GetQuery("key2", "allow")
+	assert.True(t, ok)
+}
+
+func TestCache_MaxQueriesPerPolicy(t *testing.T) {
+	p1 := storage.Policy{Name: "example1", Group: "example", Version: "1.0"}
+	query := rego.PreparedEvalQuery{}
+
+	cache := regocache.New()
+	cache.Set("key1", &p1)
+	for i := 0; i < 100; i++ {
+		cache.SetQuery("key1", fmt.Sprintf("rule%d", i), &p1, &query)
+	}
+
+	_, ok := cache.GetQuery("key1", "rule0")
+	assert.True(t, ok)
+	_, ok = cache.GetQuery("key1", "rule31")
+	assert.True(t, ok)
+	_, ok = cache.GetQuery("key1", "rule32")
+	assert.False(t, ok)
+
+	// the limit applies to the queries of the policy revision
+	cache.Set("key1", &p1)
+	cache.SetQuery("key1", "rule99", &p1, &query)
+	_, ok = cache.GetQuery("key1", "rule99")
+	assert.True(t, ok)
 }
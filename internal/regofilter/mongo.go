@@ -0,0 +1,197 @@
+// Package regofilter translates the residual queries of OPA partial
+// evaluation into database filters, so that policy based filtering
+// can be executed by the database instead of evaluating every record.
+package regofilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// mongoOperators maps rego comparison operators to MongoDB query operators.
+var mongoOperators = map[string]string{
+	ast.Equality.Name:      "$eq",
+	ast.Equal.Name:         "$eq",
+	ast.NotEqual.Name:      "$ne",
+	ast.LessThan.Name:      "$lt",
+	ast.LessThanEq.Name:    "$lte",
+	ast.GreaterThan.Name:   "$gt",
+	ast.GreaterThanEq.Name: "$gte",
+}
+
+// swappedOperators contains the operators which must be used
+// when the operands of a comparison are swapped.
+var swappedOperators = map[string]string{
+	"$eq":  "$eq",
+	"$ne":  "$ne",
+	"$lt":  "$gt",
+	"$lte": "$gte",
+	"$gt":  "$lt",
+	"$gte": "$lte",
+}
+
+// Mongo translates residual queries to a MongoDB filter document. Document
+// fields are the paths of references relative to the given unknowns, e.g.
+// with unknown 'input.resource' the reference 'input.resource.owner.name'
+// becomes the 'owner.name' field.
+//
+// Only comparisons of document fields with constant values and field
+// existence checks are supported, as well as their negations.
+func Mongo(pq *rego.PartialQueries, unknowns []string) (map[string]interface{}, error) {
+	if len(pq.Support) > 0 {
+		return nil, fmt.Errorf("residual queries with support modules cannot be translated")
+	}
+
+	prefixes := make([]ast.Ref, 0, len(unknowns))
+	for _, u := range unknowns {
+		ref, err := ast.ParseRef(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unknown %q: %v", u, err)
+		}
+		prefixes = append(prefixes, ref)
+	}
+
+	// the rule is never true
+	if len(pq.Queries) == 0 {
+		return map[string]interface{}{"$expr": false}, nil
+	}
+
+	filters := make([]interface{}, 0, len(pq.Queries))
+	for _, q := range pq.Queries {
+		// the rule is always true
+		if len(q) == 0 {
+			return map[string]interface{}{}, nil
+		}
+
+		f, err := mongoBody(q, prefixes)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	if len(filters) == 1 {
+		return filters[0].(map[string]interface{}), nil
+	}
+
+	return map[string]interface{}{"$or": filters}, nil
+}
+
+func mongoBody(body ast.Body, prefixes []ast.Ref) (map[string]interface{}, error) {
+	filters := make([]interface{}, 0, len(body))
+	for _, expr := range body {
+		f, err := mongoExpr(expr, prefixes)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	if len(filters) == 1 {
+		return filters[0].(map[string]interface{}), nil
+	}
+
+	return map[string]interface{}{"$and": filters}, nil
+}
+
+func mongoExpr(expr *ast.Expr, prefixes []ast.Ref) (map[string]interface{}, error) {
+	var filter map[string]interface{}
+
+	switch terms := expr.Terms.(type) {
+	case *ast.Term:
+		// a single reference is true if it's defined and not false
+		field, ok := fieldName(terms, prefixes)
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression: %v", expr)
+		}
+		filter = map[string]interface{}{field: map[string]interface{}{"$exists": true, "$ne": false}}
+	case []*ast.Term:
+		op, ok := mongoOperators[expr.Operator().String()]
+		if !ok || len(terms) != 3 {
+			return nil, fmt.Errorf("unsupported expression: %v", expr)
+		}
+
+		field, ok := fieldName(terms[1], prefixes)
+		value := terms[2]
+		if !ok {
+			field, ok = fieldName(terms[2], prefixes)
+			value = terms[1]
+			op = swappedOperators[op]
+		}
+		if !ok || !value.IsGround() {
+			return nil, fmt.Errorf("unsupported expression: %v", expr)
+		}
+
+		v, err := jsonValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported value in expression %v: %v", expr, err)
+		}
+		filter = map[string]interface{}{field: map[string]interface{}{op: v}}
+	default:
+		return nil, fmt.Errorf("unsupported expression: %v", expr)
+	}
+
+	if expr.Negated {
+		return map[string]interface{}{"$nor": []interface{}{filter}}, nil
+	}
+
+	return filter, nil
+}
+
+// fieldName returns the document field referenced by the term.
+// The longest unknown matching the reference is used as a prefix.
+func fieldName(term *ast.Term, prefixes []ast.Ref) (string, bool) {
+	ref, ok := term.Value.(ast.Ref)
+	if !ok {
+		return "", false
+	}
+
+	var prefix ast.Ref
+	for _, p := range prefixes {
+		if ref.HasPrefix(p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == nil || len(ref) == len(prefix) {
+		return "", false
+	}
+
+	path := make([]string, 0, len(ref)-len(prefix))
+	for _, t := range ref[len(prefix):] {
+		switch v := t.Value.(type) {
+		case ast.String:
+			path = append(path, string(v))
+		case ast.Number:
+			path = append(path, v.String())
+		default:
+			return "", false
+		}
+	}
+
+	return strings.Join(path, "."), true
+}
+
+// jsonValue converts a ground term to a JSON compatible value
+// where numbers are represented as float64.
+func jsonValue(term *ast.Term) (interface{}, error) {
+	v, err := ast.JSON(term.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res interface{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,100 @@
+package regofilter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofilter"
+)
+
+func TestMongo(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		input    map[string]interface{}
+		unknowns []string
+		filter   map[string]interface{}
+		errtext  string
+	}{
+		{
+			name:     "rule is never true",
+			policy:   `package example allow { input.user == "admin"; input.resource.public }`,
+			input:    map[string]interface{}{"user": "guest"},
+			unknowns: []string{"input.resource"},
+			filter:   map[string]interface{}{"$expr": false},
+		},
+		{
+			name:     "rule is always true",
+			policy:   `package example allow { input.user == "admin" }`,
+			input:    map[string]interface{}{"user": "admin"},
+			unknowns: []string{"input.resource"},
+			filter:   map[string]interface{}{},
+		},
+		{
+			name:     "single comparison",
+			policy:   `package example allow { input.resource.owner == input.user }`,
+			input:    map[string]interface{}{"user": "alice"},
+			unknowns: []string{"input.resource"},
+			filter:   map[string]interface{}{"owner": map[string]interface{}{"$eq": "alice"}},
+		},
+		{
+			name:     "conjunction with swapped operands and nested fields",
+			policy:   `package example allow { 18 <= input.resource.owner.age; input.resource.public }`,
+			unknowns: []string{"input.resource"},
+			filter: map[string]interface{}{"$and": []interface{}{
+				map[string]interface{}{"owner.age": map[string]interface{}{"$gte": float64(18)}},
+				map[string]interface{}{"public": map[string]interface{}{"$exists": true, "$ne": false}},
+			}},
+		},
+		{
+			name:     "disjunction and negation",
+			policy:   `package example allow { input.resource.public } allow { not input.resource.deleted; input.resource.owner != "bob" }`,
+			unknowns: []string{"input.resource"},
+			filter: map[string]interface{}{"$or": []interface{}{
+				map[string]interface{}{"public": map[string]interface{}{"$exists": true, "$ne": false}},
+				map[string]interface{}{"$and": []interface{}{
+					map[string]interface{}{"$nor": []interface{}{
+						map[string]interface{}{"deleted": map[string]interface{}{"$exists": true, "$ne": false}},
+					}},
+					map[string]interface{}{"owner": map[string]interface{}{"$ne": "bob"}},
+				}},
+			}},
+		},
+		{
+			name:     "unsupported iteration",
+			policy:   `package example allow { input.resource.tags[_] == "public" }`,
+			unknowns: []string{"input.resource"},
+			errtext:  "unsupported expression",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := []func(*rego.Rego){
+				rego.Module("policy.rego", test.policy),
+				rego.Query("data.example.allow == true"),
+				rego.Unknowns(test.unknowns),
+			}
+			if test.input != nil {
+				opts = append(opts, rego.Input(test.input))
+			}
+
+			pq, err := rego.New(opts...).Partial(context.Background())
+			require.NoError(t, err)
+
+			filter, err := regofilter.Mongo(pq, test.unknowns)
+			if test.errtext != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.filter, filter)
+		})
+	}
+}
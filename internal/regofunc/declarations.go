@@ -0,0 +1,47 @@
+package regofunc
+
+import (
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// Declarations returns the declarations of all extension functions, which are
+// registered by the policy service, so that policies can be compiled without
+// the services called by the functions. The list must contain every function
+// registered in cmd/policy.
+func Declarations() []*rego.Function {
+	cacheFuncs := NewCacheFuncs("", nil)
+	didResolverFuncs := NewDIDResolverFuncs("", nil)
+	taskFuncs := NewTaskFuncs("", nil)
+	ocmFuncs := NewOcmFuncs("", nil)
+	signerFuncs := NewSignerFuncs("", nil)
+	didWebFuncs := NewDIDWebFuncs()
+	storageFuncs := NewStorageFuncs(nil, nil)
+
+	return []*rego.Function{
+		declaration(GetHeaderFunc()),
+		declaration(cacheFuncs.CacheGetFunc()),
+		declaration(cacheFuncs.CacheSetFunc()),
+		declaration(didResolverFuncs.ResolveFunc()),
+		declaration(taskFuncs.CreateTaskFunc()),
+		declaration(taskFuncs.CreateTaskListFunc()),
+		declaration(signerFuncs.VerificationMethodFunc()),
+		declaration(signerFuncs.VerificationMethodsFunc()),
+		declaration(signerFuncs.AddVCProofFunc()),
+		declaration(signerFuncs.AddVPProofFunc()),
+		declaration(signerFuncs.VerifyProofFunc()),
+		declaration(ocmFuncs.GetLoginProofInvitation()),
+		declaration(ocmFuncs.SendPresentationRequest()),
+		declaration(ocmFuncs.GetLoginProofResult()),
+		declaration(ocmFuncs.GetRawProofResult()),
+		declaration(didWebFuncs.DIDToURLFunc()),
+		declaration(didWebFuncs.URLToDIDFunc()),
+		declaration(storageFuncs.GetData()),
+		declaration(storageFuncs.SetData()),
+		declaration(storageFuncs.DeleteData()),
+	}
+}
+
+// declaration drops the implementation of an extension function.
+func declaration(decl *rego.Function, _ any) *rego.Function {
+	return decl
+}
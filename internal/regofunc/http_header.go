@@ -6,22 +6,27 @@ import (
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/types"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
 )
 
-func GetHeaderFunc(headers map[string]string) (*rego.Function, rego.Builtin1) {
+// GetHeaderFunc returns the value of an HTTP request header. The headers
+// are taken from the evaluation context, so the function can be compiled
+// once into a prepared query and reused between requests.
+func GetHeaderFunc() (*rego.Function, rego.Builtin1) {
 	return &rego.Function{
 			Name:    "external.http.header",
 			Decl:    types.NewFunction(types.Args(types.S), types.S),
 			Memoize: true,
 		},
 		func(bctx rego.BuiltinContext, paramHeader *ast.Term) (*ast.Term, error) {
-			var header string
-			if err := ast.As(paramHeader.Value, &header); err != nil {
+			var name string
+			if err := ast.As(paramHeader.Value, &name); err != nil {
 				return nil, fmt.Errorf("invalid header parameter: %s", err)
 			}
 
-			headerValue := headers[header]
-			v, err := ast.InterfaceToValue(headerValue)
+			headers, _ := header.FromContext(bctx.Context)
+			v, err := ast.InterfaceToValue(headers[name])
 			if err != nil {
 				return nil, err
 			}
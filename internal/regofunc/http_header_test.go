@@ -2,26 +2,28 @@ package regofunc_test
 
 import (
 	"context"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
 )
 
 func TestGetHeaderFunc(t *testing.T) {
-	headers := map[string]string{
-		"Authorization": "Bearer XXX",
-		"X-Location":    "https://example.com",
-	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer XXX")
+	req.Header.Set("X-Location", "https://example.com")
+	ctx := header.ToContext(context.Background(), req)
 
 	t.Run("get Authorization header", func(t *testing.T) {
 		r := rego.New(
 			rego.Query(`external.http.header("Authorization")`),
-			rego.Function1(regofunc.GetHeaderFunc(headers)),
+			rego.Function1(regofunc.GetHeaderFunc()),
 		)
-		resultSet, err := r.Eval(context.Background())
+		resultSet, err := r.Eval(ctx)
 		assert.NoError(t, err)
 
 		result := resultSet[0].Expressions[0].Value
@@ -33,9 +35,9 @@ func TestGetHeaderFunc(t *testing.T) {
 	t.Run("get X-Location header", func(t *testing.T) {
 		r := rego.New(
 			rego.Query(`external.http.header("X-Location")`),
-			rego.Function1(regofunc.GetHeaderFunc(headers)),
+			rego.Function1(regofunc.GetHeaderFunc()),
 		)
-		resultSet, err := r.Eval(context.Background())
+		resultSet, err := r.Eval(ctx)
 		assert.NoError(t, err)
 
 		result := resultSet[0].Expressions[0].Value
@@ -43,4 +45,22 @@ func TestGetHeaderFunc(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "https://example.com", result)
 	})
+
+	t.Run("headers are taken from each evaluation context", func(t *testing.T) {
+		query, err := rego.New(
+			rego.Query(`external.http.header("Authorization")`),
+			rego.Function1(regofunc.GetHeaderFunc()),
+		).PrepareForEval(context.Background())
+		assert.NoError(t, err)
+
+		resultSet, err := query.Eval(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer XXX", resultSet[0].Expressions[0].Value)
+
+		otherReq := httptest.NewRequest("GET", "/", nil)
+		otherReq.Header.Set("Authorization", "Bearer YYY")
+		resultSet, err = query.Eval(header.ToContext(context.Background(), otherReq))
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer YYY", resultSet[0].Expressions[0].Value)
+	})
 }
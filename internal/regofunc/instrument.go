@@ -0,0 +1,75 @@
+package regofunc
+
+import (
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/tracing"
+)
+
+// Function1 returns an extension function with one argument, whose calls
+// are traced and whose calls, errors and durations are recorded in metrics.
+func Function1(decl *rego.Function, impl rego.Builtin1) RegoFunc {
+	return rego.Function1(decl, func(bctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+		return instrument(bctx, decl.Name, []*ast.Term{a}, func(bctx rego.BuiltinContext) (*ast.Term, error) {
+			return impl(bctx, a)
+		})
+	})
+}
+
+// Function2 returns an extension function with two arguments, whose calls
+// are traced and whose calls, errors and durations are recorded in metrics.
+func Function2(decl *rego.Function, impl rego.Builtin2) RegoFunc {
+	return rego.Function2(decl, func(bctx rego.BuiltinContext, a, b *ast.Term) (*ast.Term, error) {
+		return instrument(bctx, decl.Name, []*ast.Term{a, b}, func(bctx rego.BuiltinContext) (*ast.Term, error) {
+			return impl(bctx, a, b)
+		})
+	})
+}
+
+// Function3 returns an extension function with three arguments, whose calls
+// are traced and whose calls, errors and durations are recorded in metrics.
+func Function3(decl *rego.Function, impl rego.Builtin3) RegoFunc {
+	return rego.Function3(decl, func(bctx rego.BuiltinContext, a, b, c *ast.Term) (*ast.Term, error) {
+		return instrument(bctx, decl.Name, []*ast.Term{a, b, c}, func(bctx rego.BuiltinContext) (*ast.Term, error) {
+			return impl(bctx, a, b, c)
+		})
+	})
+}
+
+// Function4 returns an extension function with four arguments, whose calls
+// are traced and whose calls, errors and durations are recorded in metrics.
+func Function4(decl *rego.Function, impl rego.Builtin4) RegoFunc {
+	return rego.Function4(decl, func(bctx rego.BuiltinContext, a, b, c, d *ast.Term) (*ast.Term, error) {
+		return instrument(bctx, decl.Name, []*ast.Term{a, b, c, d}, func(bctx rego.BuiltinContext) (*ast.Term, error) {
+			return impl(bctx, a, b, c, d)
+		})
+	})
+}
+
+// instrument executes the extension function call in its own span, so that
+// outgoing HTTP requests of the function are children of the call span.
+// The call is recorded if the evaluation context contains a Recorder.
+func instrument(bctx rego.BuiltinContext, name string, args []*ast.Term, call func(rego.BuiltinContext) (*ast.Term, error)) (*ast.Term, error) {
+	ctx, span := tracing.Start(bctx.Context, "builtin "+name)
+	bctx.Context = ctx
+
+	start := time.Now()
+	term, err := call(bctx)
+
+	metrics.BuiltinCalls.WithLabelValues(name).Inc()
+	metrics.BuiltinDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.BuiltinErrors.WithLabelValues(name).Inc()
+	}
+	tracing.End(span, err)
+
+	if r, ok := bctx.Context.Value(recorderKey{}).(*Recorder); ok {
+		r.record(name, args, term, err, time.Since(start))
+	}
+
+	return term, err
+}
@@ -0,0 +1,57 @@
+package regofunc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+)
+
+func TestFunction1_Instrument(t *testing.T) {
+	decl := &rego.Function{
+		Name: "test.metrics",
+		Decl: types.NewFunction(types.Args(types.S), types.S),
+	}
+	impl := func(bctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+		if a.Value.Compare(ast.String("fail")) == 0 {
+			return nil, fmt.Errorf("function failed")
+		}
+		return a, nil
+	}
+
+	calls := counterValue(t, metrics.BuiltinCalls.WithLabelValues("test.metrics"))
+	errs := counterValue(t, metrics.BuiltinErrors.WithLabelValues("test.metrics"))
+
+	r := rego.New(
+		rego.Query(`test.metrics("ok")`),
+		regofunc.Function1(decl, impl),
+	)
+	_, err := r.Eval(context.Background())
+	require.NoError(t, err)
+
+	r = rego.New(
+		rego.Query(`test.metrics("fail")`),
+		rego.StrictBuiltinErrors(true),
+		regofunc.Function1(decl, impl),
+	)
+	_, err = r.Eval(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, calls+2, counterValue(t, metrics.BuiltinCalls.WithLabelValues("test.metrics")))
+	assert.Equal(t, errs+1, counterValue(t, metrics.BuiltinErrors.WithLabelValues("test.metrics")))
+}
+
+func counterValue(t *testing.T, c interface{ Write(*dto.Metric) error }) float64 {
+	var m dto.Metric
+	require.NoError(t, c.Write(&m))
+	return m.GetCounter().GetValue()
+}
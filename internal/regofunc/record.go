@@ -0,0 +1,168 @@
+package regofunc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type recorderKey struct{}
+
+type replayKey struct{}
+
+// Recorder records the calls of extension functions during a policy evaluation.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []storage.FunctionCall
+}
+
+// WithRecorder returns a context, which records the calls of the
+// extension functions evaluated with it in the returned Recorder.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// Calls returns the recorded calls in the order of their execution.
+func (r *Recorder) Calls() []storage.FunctionCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]storage.FunctionCall(nil), r.calls...)
+}
+
+func (r *Recorder) record(name string, args []*ast.Term, result *ast.Term, err error, duration time.Duration) {
+	call := storage.FunctionCall{
+		Name:      name,
+		Args:      make([]any, 0, len(args)),
+		Undefined: result == nil,
+		Duration:  duration,
+	}
+	for _, arg := range args {
+		call.Args = append(call.Args, jsonValue(arg.Value))
+	}
+	if result != nil {
+		call.Result = jsonValue(result.Value)
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+}
+
+// jsonValue converts a rego value to a value decoded from JSON, so that
+// numbers are stored as float64 and not as strings of json.Number.
+func jsonValue(v ast.Value) any {
+	x, err := ast.JSON(v)
+	if err != nil {
+		return nil
+	}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return nil
+	}
+	var res any
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil
+	}
+	return res
+}
+
+// replay answers the calls of extension functions with recorded calls.
+// Every recorded call is used once, so that repeated calls with the
+// same arguments are answered in the recorded order.
+type replay struct {
+	mu    sync.Mutex
+	calls []storage.FunctionCall
+	used  []bool
+}
+
+// WithRecordedCalls returns a context, whose calls of the extension
+// functions returned by ReplayFunctions are answered with the responses
+// of the given recorded calls.
+func WithRecordedCalls(ctx context.Context, calls []storage.FunctionCall) context.Context {
+	return context.WithValue(ctx, replayKey{}, &replay{calls: calls, used: make([]bool, len(calls))})
+}
+
+// ReplayFunctions returns all extension functions, except external.http.header,
+// which read the request headers from the context. The functions don't call any
+// services, but return the recorded responses of a context created with
+// WithRecordedCalls, so that evaluations can be repeated without side effects.
+func ReplayFunctions() []RegoFunc {
+	header, _ := GetHeaderFunc()
+
+	var funcs []RegoFunc
+	for _, decl := range Declarations() {
+		if decl.Name == header.Name {
+			continue
+		}
+		funcs = append(funcs, rego.FunctionDyn(decl, replayed(decl.Name, len(decl.Decl.FuncArgs().Args))))
+	}
+
+	return funcs
+}
+
+// replayed returns the implementation of a replayed function with the given
+// number of arguments. The terms of dynamic functions include the output term.
+func replayed(name string, arity int) rego.BuiltinDyn {
+	return func(bctx rego.BuiltinContext, terms []*ast.Term) (*ast.Term, error) {
+		r, ok := bctx.Context.Value(replayKey{}).(*replay)
+		if !ok {
+			return nil, fmt.Errorf("call of extension function %s was not recorded", name)
+		}
+		return r.answer(name, terms[:arity])
+	}
+}
+
+func (r *replay) answer(name string, args []*ast.Term) (*ast.Term, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, call := range r.calls {
+		if r.used[i] || call.Name != name || !sameArgs(call.Args, args) {
+			continue
+		}
+		r.used[i] = true
+
+		if call.Error != "" {
+			return nil, errors.New(call.Error)
+		}
+		if call.Undefined {
+			return nil, nil
+		}
+
+		result, err := ast.InterfaceToValue(call.Result)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded result of extension function %s: %v", name, err)
+		}
+		return ast.NewTerm(result), nil
+	}
+
+	return nil, fmt.Errorf("call of extension function %s with arguments %v was not recorded", name, ast.NewArray(args...))
+}
+
+func sameArgs(recorded []any, args []*ast.Term) bool {
+	if len(recorded) != len(args) {
+		return false
+	}
+
+	for i := range args {
+		v, err := ast.InterfaceToValue(recorded[i])
+		if err != nil || v.Compare(args[i].Value) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package regofunc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func TestRecorder(t *testing.T) {
+	decl := &rego.Function{
+		Name: "test.record",
+		Decl: types.NewFunction(types.Args(types.S, types.A), types.A),
+	}
+	impl := func(bctx rego.BuiltinContext, a, b *ast.Term) (*ast.Term, error) {
+		switch a.Value.Compare(ast.String("fail")) {
+		case 0:
+			return nil, fmt.Errorf("function failed")
+		default:
+			return ast.ObjectTerm(ast.Item(ast.StringTerm("arg"), b)), nil
+		}
+	}
+
+	ctx, recorder := regofunc.WithRecorder(context.Background())
+
+	r := rego.New(
+		rego.Query(`x := test.record("ok", {"a": [1, 2]}); not test.record("fail", 1)`),
+		regofunc.Function2(decl, impl),
+	)
+	_, err := r.Eval(ctx)
+	require.NoError(t, err)
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 2)
+
+	assert.Equal(t, "test.record", calls[0].Name)
+	assert.Equal(t, []any{"ok", map[string]any{"a": []any{float64(1), float64(2)}}}, normalize(t, calls[0].Args))
+	assert.Equal(t, map[string]any{"arg": map[string]any{"a": []any{float64(1), float64(2)}}}, normalize(t, calls[0].Result))
+	assert.Empty(t, calls[0].Error)
+
+	assert.True(t, calls[1].Undefined)
+	assert.Contains(t, calls[1].Error, "function failed")
+}
+
+func TestReplayFunctions(t *testing.T) {
+	calls := []storage.FunctionCall{
+		{Name: "did_to_url", Args: []any{"did:web:example.com"}, Result: "https://example.com/.well-known/did.json"},
+		{Name: "did_to_url", Args: []any{"did:web:second.example.com"}, Result: "https://second.example.com/.well-known/did.json"},
+		{Name: "url_to_did", Args: []any{"https://fail.com"}, Error: "recorded failure"},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		result any
+		err    string
+	}{
+		{
+			name:   "recorded calls are answered",
+			query:  `x := [did_to_url("did:web:second.example.com"), did_to_url("did:web:example.com")]`,
+			result: []any{"https://second.example.com/.well-known/did.json", "https://example.com/.well-known/did.json"},
+		},
+		{
+			name:  "recorded error is returned",
+			query: `x := url_to_did("https://fail.com")`,
+			err:   "recorded failure",
+		},
+		{
+			name:  "call with other arguments is not recorded",
+			query: `x := did_to_url("did:web:other.com")`,
+			err:   "was not recorded",
+		},
+		{
+			name:  "call of other function is not recorded",
+			query: `x := cache.get("key", "namespace", "scope")`,
+			err:   "was not recorded",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := []func(*rego.Rego){rego.Query(test.query), rego.StrictBuiltinErrors(true)}
+			for _, f := range regofunc.ReplayFunctions() {
+				args = append(args, f)
+			}
+
+			rs, err := rego.New(args...).Eval(regofunc.WithRecordedCalls(context.Background(), calls))
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rs, 1)
+			assert.Equal(t, test.result, rs[0].Bindings["x"])
+		})
+	}
+}
+
+// normalize converts numbers to float64 like in values decoded from JSON.
+func normalize(t *testing.T, v any) any {
+	value, err := ast.InterfaceToValue(v)
+	require.NoError(t, err)
+	res, err := ast.JSON(value)
+	require.NoError(t, err)
+	return toFloat(res)
+}
+
+func toFloat(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k := range x {
+			x[k] = toFloat(x[k])
+		}
+	case []any:
+		for i := range x {
+			x[i] = toFloat(x[i])
+		}
+	case interface{ Float64() (float64, error) }:
+		f, _ := x.Float64()
+		return f
+	}
+	return v
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
 	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
@@ -12,6 +14,7 @@ import (
 )
 
 //go:generate counterfeiter . Storage
+//go:generate counterfeiter . AuditLog
 
 type Storage interface {
 	GetData(ctx context.Context, key string) (any, error)
@@ -19,12 +22,18 @@ type Storage interface {
 	DeleteData(ctx context.Context, key string) error
 }
 
+// AuditLog records the changes of the storage. It's optional.
+type AuditLog interface {
+	Record(ctx context.Context, record *storage.AuditRecord)
+}
+
 type StorageFuncs struct {
-	storage Storage
+	storage  Storage
+	auditLog AuditLog
 }
 
-func NewStorageFuncs(storage Storage) *StorageFuncs {
-	return &StorageFuncs{storage: storage}
+func NewStorageFuncs(storage Storage, auditLog AuditLog) *StorageFuncs {
+	return &StorageFuncs{storage: storage, auditLog: auditLog}
 }
 
 func (sf *StorageFuncs) GetData() (*rego.Function, rego.Builtin1) {
@@ -79,11 +88,20 @@ func (sf *StorageFuncs) SetData() (*rego.Function, rego.Builtin2) {
 				return nil, fmt.Errorf("invalid data: %s", err)
 			}
 
+			before := sf.dataHash(bctx.Context, key)
+
 			err := sf.storage.SetData(bctx.Context, key, data)
 			if err != nil {
 				return nil, err
 			}
 
+			sf.audit(bctx.Context, &storage.AuditRecord{
+				Operation:  storage.AuditStorageSet,
+				Target:     key,
+				BeforeHash: before,
+				AfterHash:  audit.Hash(data),
+			})
+
 			var val ast.Value
 			val, err = ast.InterfaceToValue(err)
 			if err != nil {
@@ -110,11 +128,19 @@ func (sf *StorageFuncs) DeleteData() (*rego.Function, rego.Builtin1) {
 				return nil, errors.New("key cannot be empty")
 			}
 
+			before := sf.dataHash(bctx.Context, key)
+
 			err := sf.storage.DeleteData(bctx.Context, key)
 			if err != nil {
 				return nil, err
 			}
 
+			sf.audit(bctx.Context, &storage.AuditRecord{
+				Operation:  storage.AuditStorageDelete,
+				Target:     key,
+				BeforeHash: before,
+			})
+
 			var val ast.Value
 			val, err = ast.InterfaceToValue(err)
 			if err != nil {
@@ -124,3 +150,24 @@ func (sf *StorageFuncs) DeleteData() (*rego.Function, rego.Builtin1) {
 			return ast.NewTerm(val), nil
 		}
 }
+
+// dataHash returns the hash of the data stored under the key for the audit log.
+// It's empty if the data doesn't exist or the audit log isn't configured.
+func (sf *StorageFuncs) dataHash(ctx context.Context, key string) string {
+	if sf.auditLog == nil {
+		return ""
+	}
+
+	data, err := sf.storage.GetData(ctx, key)
+	if err != nil {
+		return ""
+	}
+	return audit.Hash(data)
+}
+
+func (sf *StorageFuncs) audit(ctx context.Context, record *storage.AuditRecord) {
+	if sf.auditLog == nil {
+		return
+	}
+	sf.auditLog.Record(ctx, record)
+}
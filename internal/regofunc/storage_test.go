@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc/regofuncfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,7 +19,7 @@ func TestStorageGetData(t *testing.T) {
 
 	storageFunc := regofunc.NewStorageFuncs(&regofuncfakes.FakeStorage{GetDataStub: func(ctx context.Context, s string) (any, error) {
 		return map[string]interface{}{"example": "data"}, nil
-	}})
+	}}, nil)
 
 	r := rego.New(
 		rego.Query(`storage.get("exampleKey")`),
@@ -33,7 +36,7 @@ func TestStorageGetData(t *testing.T) {
 func TestStorageSetData(t *testing.T) {
 	storageFunc := regofunc.NewStorageFuncs(&regofuncfakes.FakeStorage{SetDataStub: func(ctx context.Context, s string, m map[string]interface{}) error {
 		return nil
-	}})
+	}}, nil)
 
 	r := rego.New(
 		rego.Query(`storage.set("example", {"example":"data"})`),
@@ -50,7 +53,7 @@ func TestStorageSetData(t *testing.T) {
 func TestStorageDeleteData(t *testing.T) {
 	storageFunc := regofunc.NewStorageFuncs(&regofuncfakes.FakeStorage{DeleteDataStub: func(ctx context.Context, s string) error {
 		return nil
-	}})
+	}}, nil)
 
 	r := rego.New(
 		rego.Query(`storage.delete("example")`),
@@ -63,3 +66,49 @@ func TestStorageDeleteData(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, "null", string(resultBytes))
 }
+
+func TestStorageSetDataAudit(t *testing.T) {
+	auditLog := &regofuncfakes.FakeAuditLog{}
+	storageFunc := regofunc.NewStorageFuncs(&regofuncfakes.FakeStorage{
+		GetDataStub: func(ctx context.Context, s string) (any, error) {
+			return nil, errors.New(errors.NotFound)
+		},
+	}, auditLog)
+
+	r := rego.New(
+		rego.Query(`storage.set("example", {"example":"data"})`),
+		rego.Function2(storageFunc.SetData()),
+	)
+	_, err := r.Eval(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, auditLog.RecordCallCount())
+	_, record := auditLog.RecordArgsForCall(0)
+	assert.Equal(t, storage.AuditStorageSet, record.Operation)
+	assert.Equal(t, "example", record.Target)
+	assert.Empty(t, record.BeforeHash)
+	assert.Equal(t, audit.Hash(map[string]interface{}{"example": "data"}), record.AfterHash)
+}
+
+func TestStorageDeleteDataAudit(t *testing.T) {
+	auditLog := &regofuncfakes.FakeAuditLog{}
+	storageFunc := regofunc.NewStorageFuncs(&regofuncfakes.FakeStorage{
+		GetDataStub: func(ctx context.Context, s string) (any, error) {
+			return map[string]interface{}{"example": "data"}, nil
+		},
+	}, auditLog)
+
+	r := rego.New(
+		rego.Query(`storage.delete("example")`),
+		rego.Function1(storageFunc.DeleteData()),
+	)
+	_, err := r.Eval(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, auditLog.RecordCallCount())
+	_, record := auditLog.RecordArgsForCall(0)
+	assert.Equal(t, storage.AuditStorageDelete, record.Operation)
+	assert.Equal(t, "example", record.Target)
+	assert.Equal(t, audit.Hash(map[string]interface{}{"example": "data"}), record.BeforeHash)
+	assert.Empty(t, record.AfterHash)
+}
@@ -0,0 +1,122 @@
+// Package data implements the Data API of Open Policy Agent on top of the
+// policy service, so that OPA clients (SDKs, proxy plugins) can evaluate the
+// stored policies without changes.
+package data
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/data"
+	goapolicy "github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+const defaultVersion = "latest"
+
+type Service struct {
+	policy goapolicy.Service
+
+	// repository and version select the evaluated policy, unless
+	// they are overwritten with the headers of the request.
+	repository string
+	version    string
+
+	logger *zap.Logger
+}
+
+// New creates the Data API service, which evaluates the policies of the
+// repository. If the version is empty, the latest policy version is evaluated.
+func New(policySvc goapolicy.Service, repository, version string, logger *zap.Logger) *Service {
+	if version == "" {
+		version = defaultVersion
+	}
+
+	return &Service{
+		policy:     policySvc,
+		repository: repository,
+		version:    version,
+		logger:     logger,
+	}
+}
+
+// GetDocument evaluates the policy referenced by the data path without input.
+func (s *Service) GetDocument(ctx context.Context, req *data.DataRequest) (*data.DataResult, error) {
+	return s.query(ctx, req, nil)
+}
+
+// GetDocumentWithInput evaluates the policy referenced by the data path with input.
+func (s *Service) GetDocumentWithInput(ctx context.Context, req *data.DataInputRequest) (*data.DataResult, error) {
+	return s.query(ctx, &data.DataRequest{
+		Path:         req.Path,
+		Repository:   req.Repository,
+		Version:      req.Version,
+		EvaluationID: req.EvaluationID,
+	}, req.Input)
+}
+
+// query evaluates the policy whose package is referenced by the first two
+// segments of the data path, because the package of a policy is always
+// 'group.name'. The remaining segments are the path of a rule inside the
+// package. Undefined documents are returned without result, like in OPA.
+func (s *Service) query(ctx context.Context, req *data.DataRequest, input any) (*data.DataResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "dataQuery"),
+		zap.String("path", req.Path),
+	)
+
+	segments := strings.Split(strings.Trim(req.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		logger.Error("data path doesn't reference a policy package")
+		return nil, errors.New(errors.BadRequest, "data path must start with the policy group and name")
+	}
+
+	repository := s.repository
+	if req.Repository != nil && *req.Repository != "" {
+		repository = *req.Repository
+	}
+	if repository == "" {
+		logger.Error("policy repository is not specified")
+		return nil, errors.New(errors.BadRequest, "policy repository is not specified")
+	}
+
+	version := s.version
+	if req.Version != nil && *req.Version != "" {
+		version = *req.Version
+	}
+
+	evaluationID := uuid.NewString()
+	if req.EvaluationID != nil && *req.EvaluationID != "" {
+		evaluationID = *req.EvaluationID
+	}
+
+	evalReq := &goapolicy.EvaluateRequest{
+		Repository:   repository,
+		Group:        segments[0],
+		PolicyName:   segments[1],
+		Version:      version,
+		Input:        input,
+		EvaluationID: ptr.String(evaluationID),
+	}
+	if len(segments) > 2 {
+		evalReq.Rule = ptr.String(strings.Join(segments[2:], "/"))
+	}
+
+	res, err := s.policy.Evaluate(ctx, evalReq)
+	if err != nil {
+		if policy.IsUndefined(err) {
+			return &data.DataResult{DecisionID: evaluationID}, nil
+		}
+		return nil, err
+	}
+
+	return &data.DataResult{
+		Result:     res.Result,
+		DecisionID: res.ETag,
+	}, nil
+}
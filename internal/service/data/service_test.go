@@ -0,0 +1,146 @@
+package data_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	goadata "github.com/eclipse-xfsc/custom-policy-agent/gen/data"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/data"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policyfakes"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+func TestService_GetDocumentWithInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository string
+		req        *goadata.DataInputRequest
+		locked     bool
+
+		result     any
+		decisionID string
+		policy     []string
+		errkind    errors.Kind
+		errtext    string
+	}{
+		{
+			name:       "data path without policy name",
+			repository: "policies",
+			req:        &goadata.DataInputRequest{Path: "example"},
+			errkind:    errors.BadRequest,
+			errtext:    "data path must start with the policy group and name",
+		},
+		{
+			name:    "policy repository is not specified",
+			req:     &goadata.DataInputRequest{Path: "example/example/allow"},
+			errkind: errors.BadRequest,
+			errtext: "policy repository is not specified",
+		},
+		{
+			name:       "policy is locked",
+			repository: "policies",
+			req:        &goadata.DataInputRequest{Path: "example/example/allow"},
+			locked:     true,
+			errkind:    errors.Forbidden,
+			errtext:    "policy is locked",
+		},
+		{
+			name:       "rule value is returned",
+			repository: "policies",
+			req: &goadata.DataInputRequest{
+				Path:         "example/example/allow",
+				Input:        map[string]any{"msg": "yes"},
+				EvaluationID: ptr.String("123"),
+			},
+			result:     true,
+			decisionID: "123",
+			policy:     []string{"policies", "example", "example", "latest"},
+		},
+		{
+			name:       "package document is returned",
+			repository: "policies",
+			req: &goadata.DataInputRequest{
+				Path:         "/example/example/",
+				Input:        map[string]any{"msg": "no"},
+				EvaluationID: ptr.String("123"),
+			},
+			result:     map[string]any{"allow": false},
+			decisionID: "123",
+			policy:     []string{"policies", "example", "example", "latest"},
+		},
+		{
+			name:       "undefined rule has no result",
+			repository: "policies",
+			req: &goadata.DataInputRequest{
+				Path:         "example/example/violations",
+				EvaluationID: ptr.String("123"),
+			},
+			decisionID: "123",
+			policy:     []string{"policies", "example", "example", "latest"},
+		},
+		{
+			name:       "repository and version are overwritten",
+			repository: "policies",
+			req: &goadata.DataInputRequest{
+				Path:         "example/example/allow",
+				Repository:   ptr.String("other"),
+				Version:      ptr.String("2.0"),
+				Input:        map[string]any{"msg": "yes"},
+				EvaluationID: ptr.String("123"),
+			},
+			result:     true,
+			decisionID: "123",
+			policy:     []string{"other", "example", "example", "2.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var evaluated []string
+			policyStorage := &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					evaluated = []string{repo, group, name, version}
+					return &storage.Policy{
+						Repository: repo,
+						Name:       name,
+						Group:      group,
+						Version:    version,
+						Locked:     test.locked,
+						Modules: []storage.Module{{Filename: "policy.rego", Rego: `package example.example
+default allow = false
+allow { input.msg == "yes" }
+`}},
+					}, nil
+				},
+			}
+			policySvc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+
+			svc := data.New(policySvc, test.repository, "", zap.NewNop())
+			res, err := svc.GetDocumentWithInput(context.Background(), test.req)
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.decisionID, res.DecisionID)
+			assert.Equal(t, test.policy, evaluated)
+			assert.Equal(t, test.result, res.Result)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 
 	goahttp "goa.design/goa/v3/http"
 	goa "goa.design/goa/v3/pkg"
@@ -9,6 +10,33 @@ import (
 	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 )
 
+// DetailedError is implemented by errors which carry structured
+// details about the failure, e.g. the violations of a schema validation.
+// The details are returned to the caller in the error response.
+type DetailedError interface {
+	Details() any
+}
+
+// detailedErrorResponse is an error response with structured details.
+type detailedErrorResponse struct {
+	*errors.Error
+	details any
+}
+
+func (e *detailedErrorResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID      string      `json:"id,omitempty"`
+		Kind    errors.Kind `json:"kind"`
+		Message string      `json:"message,omitempty"`
+		Details any         `json:"details,omitempty"`
+	}{
+		ID:      e.ID,
+		Kind:    e.Kind,
+		Message: e.Message,
+		Details: e.details,
+	})
+}
+
 func NewErrorResponse(ctx context.Context, err error) goahttp.Statuser {
 	if err == nil {
 		return nil
@@ -37,5 +65,27 @@ func NewErrorResponse(ctx context.Context, err error) goahttp.Statuser {
 		}
 	}
 
+	if details, ok := errorDetails(newerr); ok {
+		return &detailedErrorResponse{Error: newerr, details: details}
+	}
+
 	return newerr
 }
+
+// errorDetails returns the details of the first detailed
+// error in the chain of wrapped errors.
+func errorDetails(err error) (any, bool) {
+	for err != nil {
+		if d, ok := err.(DetailedError); ok {
+			return d.Details(), true
+		}
+
+		e, ok := err.(*errors.Error)
+		if !ok {
+			return nil, false
+		}
+		err = e.Err
+	}
+
+	return nil, false
+}
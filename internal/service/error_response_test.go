@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+type detailedError struct {
+	details any
+}
+
+func (e *detailedError) Error() string { return "detailed error" }
+
+func (e *detailedError) Details() any { return e.details }
+
+func TestNewErrorResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		json   string
+	}{
+		{
+			name:   "error without details",
+			err:    &errors.Error{ID: "1", Kind: errors.NotFound, Message: "policy not found"},
+			status: http.StatusNotFound,
+			json:   `{"id":"1","kind":5,"message":"policy not found"}`,
+		},
+		{
+			name:   "unknown error",
+			err:    &detailedError{details: "some details"},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "wrapped error with details",
+			err: &errors.Error{ID: "1", Kind: errors.BadRequest, Message: "validation failed", Err: &errors.Error{
+				Message: "invalid value",
+				Err:     &detailedError{details: []string{"value is too long"}},
+			}},
+			status: http.StatusBadRequest,
+			json:   `{"id":"1","kind":1,"message":"validation failed","details":["value is too long"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := service.NewErrorResponse(context.Background(), test.err)
+			require.NotNil(t, res)
+			assert.Equal(t, test.status, res.StatusCode())
+
+			if test.json != "" {
+				data, err := json.Marshal(res)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.json, string(data))
+			}
+		})
+	}
+}
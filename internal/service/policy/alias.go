@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+// SetPolicyAlias pins a named alias to a policy version, so that
+// the alias can be used instead of the version in policy URLs.
+func (s *Service) SetPolicyAlias(ctx context.Context, req *policy.SetPolicyAliasRequest) (*policy.PolicyAlias, error) {
+	logger := s.logger.With(
+		zap.String("operation", "setPolicyAlias"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("alias", req.Alias),
+		zap.String("version", req.Version),
+	)
+
+	// a named alias takes precedence over the built-in
+	// aliases, so it would silently change their resolution
+	if req.Alias == versionLatest || req.Alias == versionStable {
+		return nil, errors.New(errors.BadRequest, fmt.Sprintf("alias %q is reserved", req.Alias))
+	}
+
+	// the alias must reference an existing version
+	if _, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version); err != nil {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		return nil, errors.New("error getting policy from storage", err)
+	}
+
+	// the previous version of the alias is only needed for the audit log
+	before, err := s.storage.PolicyAlias(ctx, req.Repository, req.Group, req.PolicyName, req.Alias)
+	if err != nil && !errors.Is(errors.NotFound, err) {
+		logger.Warn("error getting policy alias for the audit log", zap.Error(err))
+	}
+
+	alias := &storage.PolicyAlias{
+		Repository: req.Repository,
+		Group:      req.Group,
+		Name:       req.PolicyName,
+		Alias:      req.Alias,
+		Version:    req.Version,
+	}
+	if err := s.storage.SavePolicyAlias(ctx, alias); err != nil {
+		logger.Error("error saving policy alias", zap.Error(err))
+		return nil, errors.New("error saving policy alias", err)
+	}
+	s.versions.invalidate(req.Repository, req.Group, req.PolicyName)
+
+	saved, err := s.storage.PolicyAlias(ctx, req.Repository, req.Group, req.PolicyName, req.Alias)
+	if err != nil {
+		logger.Error("error getting policy alias", zap.Error(err))
+		return nil, errors.New("error getting policy alias", err)
+	}
+
+	s.auditAlias(ctx, storage.AuditSetPolicyAlias, before, saved)
+
+	return toPolicyAlias(saved), nil
+}
+
+// PolicyAliases returns all named aliases of a policy.
+func (s *Service) PolicyAliases(ctx context.Context, req *policy.PolicyAliasesRequest) (*policy.PolicyAliasesResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "policyAliases"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+	)
+
+	aliases, err := s.storage.PolicyAliases(ctx, req.Repository, req.Group, req.PolicyName)
+	if err != nil {
+		logger.Error("error getting policy aliases", zap.Error(err))
+		return nil, errors.New("error getting policy aliases", err)
+	}
+
+	result := make([]*policy.PolicyAlias, 0, len(aliases))
+	for _, a := range aliases {
+		result = append(result, toPolicyAlias(a))
+	}
+
+	return &policy.PolicyAliasesResult{Aliases: result}, nil
+}
+
+// DeletePolicyAlias removes a named alias of a policy.
+func (s *Service) DeletePolicyAlias(ctx context.Context, req *policy.DeletePolicyAliasRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "deletePolicyAlias"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("alias", req.Alias),
+	)
+
+	alias, err := s.storage.PolicyAlias(ctx, req.Repository, req.Group, req.PolicyName, req.Alias)
+	if err != nil {
+		logger.Error("error getting policy alias", zap.Error(err))
+		return errors.New("error getting policy alias", err)
+	}
+
+	if err := s.storage.DeletePolicyAlias(ctx, req.Repository, req.Group, req.PolicyName, req.Alias); err != nil {
+		logger.Error("error deleting policy alias", zap.Error(err))
+		return errors.New("error deleting policy alias", err)
+	}
+	s.versions.invalidate(req.Repository, req.Group, req.PolicyName)
+
+	s.auditAlias(ctx, storage.AuditDeletePolicyAlias, alias, nil)
+
+	return nil
+}
+
+func toPolicyAlias(a *storage.PolicyAlias) *policy.PolicyAlias {
+	return &policy.PolicyAlias{
+		Repository: a.Repository,
+		Group:      a.Group,
+		PolicyName: a.Name,
+		Alias:      a.Alias,
+		Version:    a.Version,
+		LastUpdate: a.LastUpdate.Unix(),
+	}
+}
@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// AuditLog returns the audit records matching the request filters.
+func (s *Service) AuditLog(ctx context.Context, req *policy.AuditLogRequest) (*policy.AuditLogResult, error) {
+	logger := s.logger.With(zap.String("operation", "auditLog"))
+
+	if s.auditLog == nil {
+		logger.Error("audit log is not configured")
+		return nil, errors.New(errors.NotFound, "audit log is not configured")
+	}
+
+	filter := &storage.AuditFilter{
+		Operation:  stringValue(req.Operation),
+		Actor:      stringValue(req.Actor),
+		Repository: stringValue(req.Repository),
+		Group:      stringValue(req.Group),
+		Name:       stringValue(req.PolicyName),
+		Version:    stringValue(req.Version),
+		Limit:      req.Limit,
+	}
+	if req.From != nil {
+		filter.From = time.Unix(*req.From, 0)
+	}
+	if req.To != nil {
+		filter.To = time.Unix(*req.To, 0)
+	}
+	if req.Offset != nil {
+		filter.Offset = *req.Offset
+	}
+
+	records, err := s.auditLog.Records(ctx, filter)
+	if err != nil {
+		logger.Error("error retrieving audit records", zap.Error(err))
+		return nil, errors.New("error retrieving audit records", err)
+	}
+
+	result := make([]*policy.AuditRecord, 0, len(records))
+	for _, r := range records {
+		record := &policy.AuditRecord{
+			Operation: r.Operation,
+			Timestamp: r.Timestamp.Unix(),
+		}
+		if r.Actor != "" {
+			record.Actor = ptr.String(r.Actor)
+		}
+		if r.ClientIP != "" {
+			record.ClientIP = ptr.String(r.ClientIP)
+		}
+		if r.Repository != "" {
+			record.Repository = ptr.String(r.Repository)
+		}
+		if r.Group != "" {
+			record.Group = ptr.String(r.Group)
+		}
+		if r.Name != "" {
+			record.PolicyName = ptr.String(r.Name)
+		}
+		if r.Version != "" {
+			record.Version = ptr.String(r.Version)
+		}
+		if r.Target != "" {
+			record.Target = ptr.String(r.Target)
+		}
+		if r.BeforeHash != "" {
+			record.BeforeHash = ptr.String(r.BeforeHash)
+		}
+		if r.AfterHash != "" {
+			record.AfterHash = ptr.String(r.AfterHash)
+		}
+		if r.BeforeVersion != "" {
+			record.BeforeVersion = ptr.String(r.BeforeVersion)
+		}
+		if r.AfterVersion != "" {
+			record.AfterVersion = ptr.String(r.AfterVersion)
+		}
+		result = append(result, record)
+	}
+
+	return &policy.AuditLogResult{Records: result}, nil
+}
+
+// audit records a state-changing operation if the audit log is configured.
+func (s *Service) audit(ctx context.Context, record *storage.AuditRecord) {
+	if s.auditLog == nil {
+		return
+	}
+	s.auditLog.Record(ctx, record)
+}
+
+// auditPolicy records a state-changing operation of a policy
+// with the policy states before and after the operation.
+func (s *Service) auditPolicy(ctx context.Context, operation string, before, after *storage.Policy) {
+	p := after
+	if p == nil {
+		p = before
+	}
+
+	s.audit(ctx, &storage.AuditRecord{
+		Operation:  operation,
+		Repository: p.Repository,
+		Group:      p.Group,
+		Name:       p.Name,
+		Version:    p.Version,
+		BeforeHash: audit.PolicyHash(before),
+		AfterHash:  audit.PolicyHash(after),
+	})
+}
+
+// withLocked returns a copy of the policy with the given lock state.
+func withLocked(p *storage.Policy, locked bool) *storage.Policy {
+	cpy := *p
+	cpy.Locked = locked
+	return &cpy
+}
+
+// auditAlias records a change of a policy alias with
+// the aliased versions before and after the change.
+func (s *Service) auditAlias(ctx context.Context, operation string, before, after *storage.PolicyAlias) {
+	a := after
+	if a == nil {
+		a = before
+	}
+
+	record := &storage.AuditRecord{
+		Operation:  operation,
+		Repository: a.Repository,
+		Group:      a.Group,
+		Name:       a.Name,
+		Target:     a.Alias,
+		BeforeHash: aliasHash(before),
+		AfterHash:  aliasHash(after),
+	}
+	if before != nil {
+		record.BeforeVersion = before.Version
+	}
+	if after != nil {
+		record.AfterVersion = after.Version
+	}
+	s.audit(ctx, record)
+}
+
+// aliasHash returns the hash of a policy alias.
+func aliasHash(a *storage.PolicyAlias) string {
+	if a == nil {
+		return ""
+	}
+	return audit.Hash(map[string]string{
+		"alias":   a.Alias,
+		"version": a.Version,
+	})
+}
+
+// autoImportHash returns the hash of an automatic import configuration.
+func autoImportHash(c *storage.PolicyAutoImport) string {
+	if c == nil {
+		return ""
+	}
+	return audit.Hash(map[string]string{
+		"policyURL": c.PolicyURL,
+		"interval":  c.Interval.String(),
+	})
+}
@@ -0,0 +1,240 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+type itemAuthorizerKey struct{}
+
+// ItemAuthorizer authorizes the evaluation of a batch item with the
+// policy version of the item as path parameters.
+type ItemAuthorizer func(ctx context.Context, params map[string]string) error
+
+// WithItemAuthorizer returns a context, whose batch items are
+// authorized by the given function before they're evaluated.
+func WithItemAuthorizer(ctx context.Context, authorize ItemAuthorizer) context.Context {
+	return context.WithValue(ctx, itemAuthorizerKey{}, authorize)
+}
+
+// batchItem is a single line of a newline delimited JSON batch evaluation stream.
+type batchItem struct {
+	Repository   string  `json:"repository"`
+	Group        string  `json:"group"`
+	PolicyName   string  `json:"policyName"`
+	Version      string  `json:"version"`
+	Input        any     `json:"input"`
+	EvaluationID *string `json:"evaluationID,omitempty"`
+	TTL          *int    `json:"ttl,omitempty"`
+}
+
+// batchItemResult is a single line of a newline delimited JSON batch evaluation
+// response stream.
+type batchItemResult struct {
+	Repository string `json:"repository"`
+	Group      string `json:"group"`
+	PolicyName string `json:"policyName"`
+	Version    string `json:"version"`
+	Result     any    `json:"result,omitempty"`
+	ETag       string `json:"ETag,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// EvaluateBatch executes multiple policies with their inputs. The evaluations
+// are executed concurrently by a bounded number of workers and the results
+// are returned in the same order as the request items. A failed evaluation
+// doesn't fail the whole batch, but its error is reported in the item result.
+// Batches with more items than the configured maximum are rejected.
+func (s *Service) EvaluateBatch(ctx context.Context, req *policy.BatchEvaluateRequest) (*policy.BatchEvaluateResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "evaluateBatch"),
+		zap.Int("items", len(req.Items)),
+	)
+
+	if len(req.Items) > s.maxBatchSize {
+		logger.Error("batch exceeds the maximum number of items", zap.Int("maxItems", s.maxBatchSize))
+		return nil, errors.New(errors.BadRequest, fmt.Sprintf("batch exceeds the maximum of %d items", s.maxBatchSize))
+	}
+
+	results := make([]*policy.BatchEvaluateItemResult, len(req.Items))
+
+	var g errgroup.Group
+	g.SetLimit(s.batchConcurrency)
+	for i, item := range req.Items {
+		g.Go(func() error {
+			results[i] = s.evaluateBatchItem(ctx, item)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	logger.Debug("batch evaluation completed")
+
+	return &policy.BatchEvaluateResult{Results: results}, nil
+}
+
+// EvaluateBatchStream reads newline delimited JSON evaluation items from the
+// request body and streams back newline delimited JSON results in the same order.
+// It is meant for large datasets which shouldn't be buffered in memory.
+func (s *Service) EvaluateBatchStream(ctx context.Context, body io.ReadCloser) (*policy.BatchEvaluateStreamResult, io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go s.streamBatch(ctx, body, pw)
+
+	return &policy.BatchEvaluateStreamResult{ContentType: ndjsonContentType}, pr, nil
+}
+
+// streamBatch evaluates the items read from body and writes the results to w.
+// Each item is evaluated in its own goroutine, while the pending channel
+// bounds the number of in-flight evaluations and preserves the item order.
+// No more items are read when the request is cancelled, e.g. because the
+// client disconnected, or when a result can't be written.
+func (s *Service) streamBatch(ctx context.Context, body io.ReadCloser, w *io.PipeWriter) {
+	logger := s.logger.With(zap.String("operation", "evaluateBatchStream"))
+	defer body.Close() //nolint:errcheck
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	// closing the body stops a read which waits for the next item
+	stop := context.AfterFunc(ctx, func() { _ = body.Close() })
+	defer stop()
+
+	pending := make(chan chan *batchItemResult, s.batchConcurrency)
+	g.Go(func() error {
+		defer close(pending)
+
+		dec := json.NewDecoder(body)
+		for ctx.Err() == nil {
+			var item batchItem
+			if err := dec.Decode(&item); err != nil {
+				if !goerrors.Is(err, io.EOF) && ctx.Err() == nil {
+					ch := make(chan *batchItemResult, 1)
+					ch <- &batchItemResult{Error: fmt.Sprintf("invalid batch item: %v", err)}
+					select {
+					case pending <- ch:
+					case <-ctx.Done():
+					}
+				}
+				return nil
+			}
+
+			ch := make(chan *batchItemResult, 1)
+			select {
+			case pending <- ch:
+			case <-ctx.Done():
+				return nil
+			}
+			go func() {
+				res := s.evaluateBatchItem(ctx, &policy.BatchEvaluateItem{
+					Repository:   item.Repository,
+					Group:        item.Group,
+					PolicyName:   item.PolicyName,
+					Version:      item.Version,
+					Input:        item.Input,
+					EvaluationID: item.EvaluationID,
+					TTL:          item.TTL,
+				})
+				ch <- &batchItemResult{
+					Repository: res.Repository,
+					Group:      res.Group,
+					PolicyName: res.PolicyName,
+					Version:    res.Version,
+					Result:     res.Result,
+					ETag:       stringValue(res.ETag),
+					Error:      stringValue(res.Error),
+				}
+			}()
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		enc := json.NewEncoder(w)
+		for ch := range pending {
+			res := <-ch
+			// results of evaluations which were cancelled aren't written
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+
+	if err := g.Wait(); err != nil {
+		if goerrors.Is(err, context.Canceled) {
+			logger.Debug("batch evaluation stream is cancelled")
+		} else {
+			logger.Error("error writing batch evaluation result", zap.Error(err))
+		}
+		w.CloseWithError(err)
+		return
+	}
+
+	w.Close() //nolint:errcheck
+}
+
+// evaluateBatchItem executes a single batch item with the same semantics
+// as the Evaluate method, including the storage of the result in cache.
+// If the context has an ItemAuthorizer, an item which isn't authorized
+// isn't evaluated, but the error is reported in the item result.
+func (s *Service) evaluateBatchItem(ctx context.Context, item *policy.BatchEvaluateItem) *policy.BatchEvaluateItemResult {
+	res := &policy.BatchEvaluateItemResult{
+		Repository: item.Repository,
+		Group:      item.Group,
+		PolicyName: item.PolicyName,
+		Version:    item.Version,
+	}
+
+	if authorize, ok := ctx.Value(itemAuthorizerKey{}).(ItemAuthorizer); ok {
+		err := authorize(ctx, map[string]string{
+			"repository": item.Repository,
+			"group":      item.Group,
+			"policyName": item.PolicyName,
+			"version":    item.Version,
+		})
+		if err != nil {
+			res.Error = ptr.String(err.Error())
+			return res
+		}
+	}
+
+	evalRes, err := s.Evaluate(ctx, &policy.EvaluateRequest{
+		Repository:   item.Repository,
+		Group:        item.Group,
+		PolicyName:   item.PolicyName,
+		Version:      item.Version,
+		Input:        item.Input,
+		EvaluationID: item.EvaluationID,
+		TTL:          item.TTL,
+	})
+	if err != nil {
+		res.Error = ptr.String(err.Error())
+		return res
+	}
+
+	res.Result = evalRes.Result
+	res.ETag = &evalRes.ETag
+
+	return res
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -54,10 +54,12 @@ func (s *Service) createPolicyBundle(policy *storage.Policy) ([]byte, error) {
 	})
 
 	// prepare source code
-	files = append(files, ZipFile{
-		Name:    "policy.rego",
-		Content: []byte(policy.Rego),
-	})
+	for _, m := range policy.Modules {
+		files = append(files, ZipFile{
+			Name:    m.Filename,
+			Content: []byte(m.Rego),
+		})
+	}
 
 	// prepare static data file
 	if strings.TrimSpace(policy.Data) != "" {
@@ -83,6 +85,22 @@ func (s *Service) createPolicyBundle(policy *storage.Policy) ([]byte, error) {
 		})
 	}
 
+	// prepare input json schema file
+	if strings.TrimSpace(policy.InputSchema) != "" {
+		files = append(files, ZipFile{
+			Name:    "input-schema.json",
+			Content: []byte(policy.InputSchema),
+		})
+	}
+
+	// prepare policy runtime configuration file
+	if strings.TrimSpace(policy.RuntimeConfig) != "" {
+		files = append(files, ZipFile{
+			Name:    "policy-config.json",
+			Content: []byte(policy.RuntimeConfig),
+		})
+	}
+
 	// prepare json schema config file
 	if strings.TrimSpace(policy.ExportConfig) != "" {
 		files = append(files, ZipFile{
@@ -91,6 +109,22 @@ func (s *Service) createPolicyBundle(policy *storage.Policy) ([]byte, error) {
 		})
 	}
 
+	// prepare test modules and golden test cases, so
+	// that the tests are executed when importing the bundle
+	for _, m := range policy.Tests {
+		files = append(files, ZipFile{
+			Name:    m.Filename,
+			Content: []byte(m.Rego),
+		})
+	}
+
+	if strings.TrimSpace(policy.TestCases) != "" {
+		files = append(files, ZipFile{
+			Name:    storage.TestCasesFilename,
+			Content: []byte(policy.TestCases),
+		})
+	}
+
 	return s.createZipArchive(files)
 }
 
@@ -183,9 +217,7 @@ func (s *Service) policyFromBundle(bundle []byte) (*storage.Policy, error) {
 			policy.Version = metadata.Policy.Version
 			policy.Locked = metadata.Policy.Locked
 			policy.LastUpdate = metadata.Policy.LastUpdate
-			policy.Filename = policy.Group + "/" + policy.Name + "/" + policy.Version + "/" + "policy.rego"
-		case "policy.rego":
-			policy.Rego = string(f.Content)
+			policy.Filename = policy.Group + "/" + policy.Name + "/" + policy.Version + "/" + storage.PolicyFilename
 		case "data.json":
 			policy.Data = string(f.Content)
 		case "data-config.json":
@@ -194,6 +226,22 @@ func (s *Service) policyFromBundle(bundle []byte) (*storage.Policy, error) {
 			policy.OutputSchema = string(f.Content)
 		case "export-config.json":
 			policy.ExportConfig = string(f.Content)
+		case "input-schema.json":
+			policy.InputSchema = string(f.Content)
+		case "policy-config.json":
+			policy.RuntimeConfig = string(f.Content)
+		case storage.TestCasesFilename:
+			policy.TestCases = string(f.Content)
+		default:
+			module := storage.Module{
+				Filename: f.Name,
+				Rego:     string(f.Content),
+			}
+			if strings.HasSuffix(f.Name, "_test.rego") {
+				policy.Tests = append(policy.Tests, module)
+			} else if strings.HasSuffix(f.Name, ".rego") {
+				policy.Modules = append(policy.Modules, module)
+			}
 		}
 	}
 
@@ -19,16 +19,20 @@ import (
 
 // should not be modified, read only
 var testPolicy = &storage.Policy{
-	Filename:   "example/mypolicy/1.0/policy.rego",
-	Repository: "myrepo",
-	Name:       "mypolicy",
-	Group:      "example",
-	Version:    "1.0",
-	Rego:       "package test",
-	Data:       `{"hello":"static data"}`,
-	DataConfig: `{"cfg":"static data config"}`,
-	Locked:     true,
-	LastUpdate: time.Date(2023, 11, 7, 1, 0, 0, 0, time.UTC),
+	Filename:      "example/mypolicy/1.0/policy.rego",
+	Repository:    "myrepo",
+	Name:          "mypolicy",
+	Group:         "example",
+	Version:       "1.0",
+	Modules:       []storage.Module{{Filename: "policy.rego", Rego: "package test"}},
+	Data:          `{"hello":"static data"}`,
+	DataConfig:    `{"cfg":"static data config"}`,
+	InputSchema:   `{"type":"object"}`,
+	RuntimeConfig: `{"timeout":"5s"}`,
+	Tests:         []storage.Module{{Filename: "policy_test.rego", Rego: "package test"}},
+	TestCases:     `[{"name":"test"}]`,
+	Locked:        true,
+	LastUpdate:    time.Date(2023, 11, 7, 1, 0, 0, 0, time.UTC),
 }
 
 var testMetadata = Metadata{
@@ -0,0 +1,427 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/decisionlog"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+const (
+	// captureTimeout limits the duration of saving a captured evaluation.
+	captureTimeout = 10 * time.Second
+
+	// replayCandidateRego is reported as candidate of a replay
+	// with an uploaded policy source.
+	replayCandidateRego = "rego"
+
+	// mainModuleFilename is the policy module which is
+	// replaced by the uploaded source of a replay.
+	mainModuleFilename = "policy.rego"
+
+	// headerFunction is the extension function which returns a request header.
+	headerFunction = "external.http.header"
+
+	replayStatusChanged    = "changed"
+	replayStatusNewError   = "new_error"
+	replayStatusFixedError = "fixed_error"
+)
+
+// SetPolicyCapture enables the recording of the evaluations of a policy version.
+// The recorded evaluations can be replayed against another version with Replay.
+func (s *Service) SetPolicyCapture(ctx context.Context, req *policy.SetPolicyCaptureRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "setPolicyCapture"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	capture := &storage.CaptureConfig{
+		SampleRate:    req.SampleRate,
+		MaxRecords:    req.MaxRecords,
+		RedactFields:  req.RedactFields,
+		RedactHeaders: req.RedactHeaders,
+	}
+
+	if err := s.storage.SetPolicyCapture(ctx, req.Repository, req.Group, req.PolicyName, req.Version, capture); err != nil {
+		logger.Error("error setting policy capture", zap.Error(err))
+		return errors.New("error setting policy capture", err)
+	}
+
+	logger.Debug("policy capture is set")
+
+	return nil
+}
+
+// DeletePolicyCapture disables the recording of the evaluations of a
+// policy version. The already recorded evaluations are not removed.
+func (s *Service) DeletePolicyCapture(ctx context.Context, req *policy.DeletePolicyCaptureRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "deletePolicyCapture"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if err := s.storage.SetPolicyCapture(ctx, req.Repository, req.Group, req.PolicyName, req.Version, nil); err != nil {
+		logger.Error("error deleting policy capture", zap.Error(err))
+		return errors.New("error deleting policy capture", err)
+	}
+
+	logger.Debug("policy capture is deleted")
+
+	return nil
+}
+
+// DeleteCapturedEvaluations removes the recorded evaluations of a policy version.
+func (s *Service) DeleteCapturedEvaluations(ctx context.Context, req *policy.DeleteCapturedEvaluationsRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "deleteCapturedEvaluations"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if err := s.storage.DeleteCapturedEvaluations(ctx, req.Repository, req.Group, req.PolicyName, req.Version); err != nil {
+		logger.Error("error deleting captured evaluations", zap.Error(err))
+		return errors.New("error deleting captured evaluations", err)
+	}
+
+	logger.Debug("captured evaluations are deleted")
+
+	return nil
+}
+
+// captureSampled reports whether the current evaluation of the policy is recorded.
+func (s *Service) captureSampled(pol *storage.Policy) bool {
+	return pol.Capture != nil && pol.Capture.SampleRate > 0 && rand.Float64() < pol.Capture.SampleRate //nolint:gosec
+}
+
+// captureEvaluation saves the evaluation together with the recorded extension
+// function calls in the background. The configured input fields and headers are
+// redacted. The evaluation isn't saved if the version has enough recorded evaluations.
+func (s *Service) captureEvaluation(ctx context.Context, pol *storage.Policy, req *policy.EvaluateRequest, evaluationID string, recorder *regofunc.Recorder, resultSet rego.ResultSet, evalErr error, duration time.Duration) {
+	capture := pol.Capture
+
+	evaluation := &storage.CapturedEvaluation{
+		EvaluationID: evaluationID,
+		Repository:   pol.Repository,
+		Group:        pol.Group,
+		Name:         pol.Name,
+		Version:      pol.Version,
+		Rule:         stringValue(req.Rule),
+		Input:        decisionlog.Redact(req.Input, capture.RedactFields),
+		Headers:      captureHeaders(ctx, capture.RedactHeaders),
+		Calls:        redactHeaderCalls(recorder.Calls(), capture.RedactHeaders),
+		Duration:     duration,
+		Timestamp:    time.Now(),
+	}
+
+	if evalErr == nil {
+		var result any
+		result, evalErr = evaluationResult(resultSet)
+		if evalErr == nil {
+			data, err := json.Marshal(result)
+			if err != nil {
+				evalErr = err
+			} else {
+				evaluation.Result = decodeJSON(data)
+			}
+		}
+	}
+	if evalErr != nil {
+		evaluation.Error = evalErr.Error()
+	}
+
+	// the evaluation is saved after the response is returned
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), captureTimeout)
+
+	go func() {
+		defer cancel()
+
+		logger := s.logger.With(
+			zap.String("operation", "captureEvaluation"),
+			zap.String("repository", pol.Repository),
+			zap.String("group", pol.Group),
+			zap.String("name", pol.Name),
+			zap.String("version", pol.Version),
+			zap.String("evaluationID", evaluationID),
+		)
+
+		saved, err := s.storage.SaveCapturedEvaluation(ctx, evaluation, capture.MaxRecords)
+		if err != nil {
+			logger.Error("error saving captured evaluation", zap.Error(err))
+			return
+		}
+		if !saved {
+			logger.Debug("evaluation is not captured because the maximum number of records is reached")
+		}
+	}()
+}
+
+// captureHeaders returns the request headers of the context without the
+// credential headers and the given headers, so that they aren't stored.
+func captureHeaders(ctx context.Context, redacted []string) map[string]string {
+	headers, ok := header.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return header.Strip(headers, redacted...)
+}
+
+// redactHeaderCalls replaces the results of external.http.header calls, which
+// return a header removed by captureHeaders. The calls aren't used by replays,
+// because replayed evaluations read the captured headers.
+func redactHeaderCalls(calls []storage.FunctionCall, redacted []string) []storage.FunctionCall {
+	for i, call := range calls {
+		if call.Name != headerFunction || len(call.Args) != 1 {
+			continue
+		}
+		if name, ok := call.Args[0].(string); ok && header.Stripped(name, redacted...) {
+			calls[i].Result = decisionlog.RedactedValue
+		}
+	}
+	return calls
+}
+
+// Replay evaluates the recorded evaluations of a policy version with a candidate,
+// which is either another version of the policy or the policy version with an
+// uploaded main module. The extension functions aren't executed, but return the
+// recorded responses, so that the replay has no side effects. Evaluations whose
+// result or error differs from the recorded evaluation are reported.
+func (s *Service) Replay(ctx context.Context, req *policy.ReplayRequest) (*policy.ReplayReport, error) {
+	logger := s.logger.With(
+		zap.String("operation", "replay"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if (req.CandidateVersion == nil) == (req.Rego == nil) {
+		logger.Error("either candidate version or rego must be specified")
+		return nil, errors.New(errors.BadRequest, "either candidate version or rego must be specified")
+	}
+
+	candidate, name, err := s.replayCandidate(ctx, req)
+	if err != nil {
+		logger.Error("error getting replay candidate", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error getting replay candidate", err)
+	}
+
+	evaluations, err := s.storage.CapturedEvaluations(ctx, req.Repository, req.Group, req.PolicyName, req.Version, req.Limit)
+	if err != nil {
+		logger.Error("error getting captured evaluations", zap.Error(err))
+		return nil, errors.New("error getting captured evaluations", err)
+	}
+
+	cfg, err := policyRuntimeConfig(candidate)
+	if err != nil {
+		logger.Error("error getting policy runtime configuration", zap.Error(err))
+		return nil, errors.New("error getting policy runtime configuration", err)
+	}
+
+	report := &policy.ReplayReport{
+		Candidate: name,
+		Diffs:     []*policy.ReplayDiff{},
+	}
+
+	// queries are prepared once for every evaluated rule
+	queries := make(map[string]*rego.PreparedEvalQuery)
+
+	var durationDeltas float64
+	for _, evaluation := range evaluations {
+		query, ok := queries[evaluation.Rule]
+		if !ok {
+			query, err = s.prepareReplayQuery(ctx, candidate, evaluation.Rule)
+			if err != nil {
+				logger.Error("error preparing replay query", zap.Error(err))
+				return nil, errors.New(errors.BadRequest, "error preparing replay query", err)
+			}
+			queries[evaluation.Rule] = query
+		}
+
+		result, duration, evalErr := replayEvaluation(ctx, cfg, query, evaluation)
+
+		diff := &policy.ReplayDiff{
+			EvaluationID:  evaluation.EvaluationID,
+			DurationDelta: float64(duration-ownDuration(evaluation)) / float64(time.Millisecond),
+			Timestamp:     evaluation.Timestamp.Unix(),
+		}
+		if evaluation.Rule != "" {
+			diff.Rule = ptr.String(evaluation.Rule)
+		}
+
+		report.Total++
+		durationDeltas += diff.DurationDelta
+		if report.Total == 1 || diff.DurationDelta > report.MaxDurationDelta {
+			report.MaxDurationDelta = diff.DurationDelta
+		}
+
+		switch {
+		case evaluation.Error != "" && evalErr != nil:
+			report.Unchanged++
+			continue
+		case evaluation.Error == "" && evalErr == nil:
+			if sameResult(evaluation.Result, result) {
+				report.Unchanged++
+				continue
+			}
+			report.Changed++
+			diff.Status = replayStatusChanged
+		case evalErr != nil:
+			report.NewErrors++
+			diff.Status = replayStatusNewError
+		default:
+			report.FixedErrors++
+			diff.Status = replayStatusFixedError
+		}
+
+		diff.Result = evaluation.Result
+		diff.CandidateResult = result
+		if evaluation.Error != "" {
+			diff.Error = ptr.String(evaluation.Error)
+		}
+		if evalErr != nil {
+			diff.CandidateError = ptr.String(evalErr.Error())
+		}
+		report.Diffs = append(report.Diffs, diff)
+	}
+
+	if report.Total > 0 {
+		report.DurationDelta = durationDeltas / float64(report.Total)
+	}
+
+	logger.Debug("captured evaluations are replayed", zap.Int("total", report.Total), zap.Int("diffs", len(report.Diffs)))
+
+	return report, nil
+}
+
+// replayCandidate returns the policy which replays the recorded
+// evaluations together with its name in the replay report.
+func (s *Service) replayCandidate(ctx context.Context, req *policy.ReplayRequest) (*storage.Policy, string, error) {
+	if req.CandidateVersion != nil {
+		candidate, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, *req.CandidateVersion)
+		if err != nil {
+			return nil, "", err
+		}
+		return candidate, *req.CandidateVersion, nil
+	}
+
+	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the stored policy is shared with the policy cache,
+	// so the modules are replaced in a copy
+	candidate := *pol
+	candidate.Modules = []storage.Module{{Filename: mainModuleFilename, Rego: *req.Rego}}
+	for _, m := range pol.Modules {
+		if m.Filename != mainModuleFilename {
+			candidate.Modules = append(candidate.Modules, m)
+		}
+	}
+
+	return &candidate, replayCandidateRego, nil
+}
+
+// prepareReplayQuery prepares the query of a rule of the candidate policy, whose
+// extension functions return the responses of recorded function calls.
+func (s *Service) prepareReplayQuery(ctx context.Context, candidate *storage.Policy, rule string) (*rego.PreparedEvalQuery, error) {
+	regoQuery := fmt.Sprintf("data.%s.%s", candidate.Group, candidate.Name)
+	if rule != "" {
+		rulePath, err := rulePath(rule)
+		if err != nil {
+			return nil, err
+		}
+		regoQuery += rulePath
+	}
+
+	regoArgs, err := s.buildRegoArgs(candidate, regoQuery, regofunc.ReplayFunctions())
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := rego.New(regoArgs...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+// replayEvaluation evaluates the query with the recorded input, headers and
+// extension function calls and returns the result and the evaluation duration.
+func replayEvaluation(ctx context.Context, cfg *runtimeConfig, query *rego.PreparedEvalQuery, evaluation *storage.CapturedEvaluation) (any, time.Duration, error) {
+	ctx = regofunc.WithRecordedCalls(ctx, evaluation.Calls)
+	if evaluation.Headers != nil {
+		ctx = header.WithHeaders(ctx, evaluation.Headers)
+	}
+
+	evalCtx, cancel := cfg.evalContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	resultSet, err := query.Eval(evalCtx, rego.EvalInput(evaluation.Input))
+	duration := time.Since(start)
+	if err != nil {
+		return nil, duration, err
+	}
+
+	result, err := evaluationResult(resultSet)
+	if err != nil {
+		return nil, duration, err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, duration, err
+	}
+
+	return decodeJSON(data), duration, nil
+}
+
+// ownDuration returns the duration of a recorded evaluation
+// without the durations of the extension function calls.
+func ownDuration(evaluation *storage.CapturedEvaluation) time.Duration {
+	duration := evaluation.Duration
+	for _, call := range evaluation.Calls {
+		duration -= call.Duration
+	}
+	return duration
+}
+
+// sameResult reports whether two evaluation results are equal.
+func sameResult(a, b any) bool {
+	x, err := ast.InterfaceToValue(a)
+	if err != nil {
+		return false
+	}
+	y, err := ast.InterfaceToValue(b)
+	if err != nil {
+		return false
+	}
+	return x.Compare(y) == 0
+}
@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// requestIDHeader is the header with the ID of the evaluated request,
+// which is recorded in the decision log, e.g. the ID generated by Envoy.
+const requestIDHeader = "X-Request-Id"
+
+// DecisionLogs returns the decision log records matching the request filters.
+// It's only available when the configured decision log storage supports queries.
+func (s *Service) DecisionLogs(ctx context.Context, req *policy.DecisionLogsRequest) (*policy.DecisionLogsResult, error) {
+	logger := s.logger.With(zap.String("operation", "decisionLogs"))
+
+	if s.decisionStorage == nil {
+		logger.Error("decision log storage is not configured")
+		return nil, errors.New(errors.NotFound, "decision log storage is not configured")
+	}
+
+	filter := &storage.DecisionFilter{
+		Repository:   stringValue(req.Repository),
+		Group:        stringValue(req.Group),
+		Name:         stringValue(req.PolicyName),
+		Version:      stringValue(req.Version),
+		EvaluationID: stringValue(req.EvaluationID),
+		Caller:       stringValue(req.Caller),
+		Limit:        req.Limit,
+	}
+	if req.From != nil {
+		filter.From = time.Unix(*req.From, 0)
+	}
+	if req.To != nil {
+		filter.To = time.Unix(*req.To, 0)
+	}
+	if req.Offset != nil {
+		filter.Offset = *req.Offset
+	}
+
+	decisions, err := s.decisionStorage.Decisions(ctx, filter)
+	if err != nil {
+		logger.Error("error retrieving decisions", zap.Error(err))
+		return nil, errors.New("error retrieving decisions", err)
+	}
+
+	result := make([]*policy.Decision, 0, len(decisions))
+	for _, d := range decisions {
+		decision := &policy.Decision{
+			EvaluationID:     d.EvaluationID,
+			Repository:       d.Repository,
+			Group:            d.Group,
+			PolicyName:       d.Name,
+			Version:          d.Version,
+			PolicyLastUpdate: d.PolicyLastUpdate.Unix(),
+			InputHash:        d.InputHash,
+			Input:            d.Input,
+			Result:           d.Result,
+			Duration:         d.Duration.Milliseconds(),
+			Timestamp:        d.Timestamp.Unix(),
+		}
+
+		if d.Error != "" {
+			decision.Error = ptr.String(d.Error)
+		}
+		if d.Caller != "" {
+			decision.Caller = ptr.String(d.Caller)
+		}
+		if d.ClientIP != "" {
+			decision.ClientIP = ptr.String(d.ClientIP)
+		}
+		if d.Rule != "" {
+			decision.Rule = ptr.String(d.Rule)
+		}
+		if d.RequestID != "" {
+			decision.RequestID = ptr.String(d.RequestID)
+		}
+
+		result = append(result, decision)
+	}
+
+	return &policy.DecisionLogsResult{Decisions: result}, nil
+}
+
+// logDecision sends the outcome of a policy evaluation to the decision log.
+func (s *Service) logDecision(ctx context.Context, req *policy.EvaluateRequest, evaluationID string, pol *storage.Policy, res *policy.EvaluateResult, err error, duration time.Duration) {
+	if s.decisionLog == nil {
+		return
+	}
+
+	d := &storage.Decision{
+		EvaluationID: evaluationID,
+		Repository:   req.Repository,
+		Group:        req.Group,
+		Name:         req.PolicyName,
+		Version:      req.Version,
+		Rule:         stringValue(req.Rule),
+		Input:        req.Input,
+		Duration:     duration,
+		Timestamp:    time.Now(),
+	}
+
+	if pol != nil {
+		// the requested version may be an alias or a version range
+		d.Version = pol.Version
+		d.PolicyLastUpdate = pol.LastUpdate
+	}
+	if res != nil {
+		d.Result = res.Result
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	if c, ok := caller.FromContext(ctx); ok {
+		if c.Verified {
+			d.Caller = c.Subject
+		}
+		d.ClientIP = c.ClientIP
+	}
+	if headers, ok := header.FromContext(ctx); ok {
+		d.RequestID = headers[requestIDHeader]
+	}
+
+	s.decisionLog.Log(d)
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/open-policy-agent/opa/topdown/lineage"
+	"github.com/open-policy-agent/opa/topdown/print"
+
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const (
+	explainOff   = "off"
+	explainNotes = "notes"
+	explainFails = "fails"
+)
+
+// explanation collects the trace and the output of print() calls
+// during a single policy evaluation.
+type explanation struct {
+	mode   string
+	tracer *topdown.BufferTracer
+
+	mu     sync.Mutex
+	prints []string
+}
+
+// newExplanation returns an explanation for the requested explain mode
+// or nil if the evaluation should not be explained. Explaining is only
+// allowed for callers which pass the configured explain check.
+func (s *Service) newExplanation(ctx context.Context, mode *string) (*explanation, error) {
+	if mode == nil || *mode == "" || *mode == explainOff {
+		return nil, nil
+	}
+
+	if s.explainCheck == nil || !s.explainCheck(ctx) {
+		return nil, errors.New(errors.Forbidden, "explain mode is not allowed")
+	}
+
+	return &explanation{
+		mode:   *mode,
+		tracer: topdown.NewBufferTracer(),
+	}, nil
+}
+
+func (e *explanation) evalOptions() []rego.EvalOption {
+	return []rego.EvalOption{
+		rego.EvalQueryTracer(e.tracer),
+		rego.EvalPrintHook(e),
+	}
+}
+
+// Print implements the OPA print.Hook interface.
+func (e *explanation) Print(_ print.Context, msg string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prints = append(e.prints, msg)
+	return nil
+}
+
+// wrap returns the evaluation result together with the human readable
+// evaluation trace filtered by the explain mode and the printed messages.
+func (e *explanation) wrap(result any) map[string]interface{} {
+	var trace []*topdown.Event
+	switch e.mode {
+	case explainNotes:
+		trace = lineage.Notes(*e.tracer)
+	case explainFails:
+		trace = lineage.Fails(*e.tracer)
+	default: // full
+		trace = lineage.Full(*e.tracer)
+	}
+
+	var buf bytes.Buffer
+	topdown.PrettyTraceWithLocation(&buf, trace)
+
+	explanation := []string{}
+	if trace := strings.TrimRight(buf.String(), "\n"); trace != "" {
+		explanation = strings.Split(trace, "\n")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prints := append([]string{}, e.prints...)
+
+	return map[string]interface{}{
+		"result":      result,
+		"explanation": explanation,
+		"print":       prints,
+	}
+}
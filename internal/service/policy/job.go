@@ -0,0 +1,485 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/tracing"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+const (
+	defaultJobQueueSize = 1000
+	defaultJobRetention = 24 * time.Hour
+
+	// defaultJobTimeout limits the duration of a job, including the delivery
+	// of its result to the callback URL. Running jobs which were not
+	// updated for a longer time were interrupted and are resumed.
+	defaultJobTimeout = 5 * time.Minute
+
+	// jobCleanupInterval is the interval between two removals of
+	// finished jobs exceeding the retention period.
+	jobCleanupInterval = time.Hour
+
+	// callbackAttempts is the number of attempts to deliver
+	// the job result to the callback URL.
+	callbackAttempts = 3
+	callbackBackoff  = time.Second
+)
+
+// jobTask is a job waiting in the queue of the worker pool. The context
+// keeps the values of the request context, e.g. the request headers.
+// Resumed jobs don't have a request context, so it's restored from the job.
+type jobTask struct {
+	ctx     context.Context
+	id      string
+	resumed bool
+}
+
+// jobCallback is the body of the request which delivers
+// the job result to the callback URL.
+type jobCallback struct {
+	JobID  string `json:"jobID"`
+	ETag   string `json:"ETag"`
+	Status string `json:"status"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// startJobs starts the worker pool executing the asynchronous evaluation
+// jobs and resumes the jobs which were not finished before a restart.
+func (s *Service) startJobs(ctx context.Context) {
+	s.jobQueue = make(chan jobTask, s.jobQueueSize)
+	for i := 0; i < s.jobWorkers; i++ {
+		go s.runJobWorker(ctx)
+	}
+
+	go s.resumeJobs(ctx)
+	go s.cleanupJobs(ctx)
+}
+
+// submitJob stores an asynchronous evaluation job and adds it to the queue
+// of the worker pool. The policy version is resolved when the job is
+// submitted, so that the job evaluates the version which was current.
+func (s *Service) submitJob(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "submitJob"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if s.jobQueue == nil {
+		logger.Error("asynchronous evaluation is not enabled")
+		return nil, errors.New(errors.BadRequest, "asynchronous evaluation is not enabled")
+	}
+
+	if req.Explain != nil && *req.Explain != "" && *req.Explain != explainOff {
+		logger.Error("explain mode is not supported for asynchronous evaluation")
+		return nil, errors.New(errors.BadRequest, "explain mode is not supported for asynchronous evaluation")
+	}
+
+	callbackURL := stringValue(req.CallbackURL)
+	if callbackURL != "" {
+		if err := s.checkCallbackURL(ctx, callbackURL); err != nil {
+			logger.Error("invalid callback URL", zap.String("callbackURL", callbackURL), zap.Error(err))
+			return nil, errors.New(errors.BadRequest, "invalid callback URL", err)
+		}
+	}
+
+	version, err := s.resolveVersion(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error resolving policy version", zap.Error(err))
+		return nil, errors.New("error evaluating policy", err)
+	}
+
+	input, err := json.Marshal(req.Input)
+	if err != nil {
+		logger.Error("error encoding policy input", zap.Error(err))
+		return nil, errors.New(errors.BadRequest, "error encoding policy input", err)
+	}
+
+	evaluationID := stringValue(req.EvaluationID)
+	if evaluationID == "" {
+		evaluationID = uuid.NewString()
+	}
+
+	now := time.Now()
+	job := &storage.Job{
+		ID:           uuid.NewString(),
+		EvaluationID: evaluationID,
+		Repository:   req.Repository,
+		Group:        req.Group,
+		Name:         req.PolicyName,
+		Version:      version,
+		Rule:         stringValue(req.Rule),
+		Input:        input,
+		TTL:          req.TTL,
+		CallbackURL:  callbackURL,
+		Status:       storage.JobPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if headers, ok := header.FromContext(ctx); ok {
+		// the job is run with the headers of the request context, the stored
+		// headers are only used to resume the job and never contain credentials
+		job.Headers = header.Strip(headers)
+	}
+	if c, ok := caller.FromContext(ctx); ok {
+		if c.Verified {
+			job.Caller = c.Subject
+		}
+		job.ClientIP = c.ClientIP
+	}
+	if err := s.storage.CreateJob(ctx, job); err != nil {
+		logger.Error("error saving job", zap.Error(err))
+		return nil, errors.New("error saving job", err)
+	}
+
+	select {
+	case s.jobQueue <- jobTask{ctx: context.WithoutCancel(ctx), id: job.ID}:
+	default:
+		job.Status = storage.JobFailed
+		job.Error = "job queue is full"
+		job.UpdatedAt = time.Now()
+		if err := s.storage.UpdateJob(ctx, job); err != nil {
+			logger.Error("error updating job", zap.Error(err))
+		}
+		logger.Error("job queue is full", zap.String("jobID", job.ID))
+		return nil, errors.New(errors.ServiceUnavailable, "too many pending evaluation jobs")
+	}
+
+	logger.Debug("job is submitted", zap.String("jobID", job.ID))
+
+	return &policy.EvaluateResult{
+		ETag:      evaluationID,
+		Version:   version,
+		JobID:     ptr.String(job.ID),
+		JobStatus: ptr.String(storage.JobPending),
+	}, nil
+}
+
+// JobStatus returns the status of an asynchronous evaluation job.
+func (s *Service) JobStatus(ctx context.Context, req *policy.JobStatusRequest) (*policy.EvaluationJob, error) {
+	job, err := s.storage.Job(ctx, req.JobID)
+	if err != nil {
+		s.logger.Error("error getting job", zap.Error(err), zap.String("operation", "jobStatus"), zap.String("jobID", req.JobID))
+		return nil, errors.New("error getting job", err)
+	}
+
+	return toEvaluationJob(job), nil
+}
+
+func (s *Service) runJobWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-s.jobQueue:
+			s.runJob(ctx, task)
+		}
+	}
+}
+
+// runJob claims and executes a job. The job is cancelled
+// when the worker pool is stopped, so that it's resumed later.
+func (s *Service) runJob(workerCtx context.Context, task jobTask) {
+	logger := s.logger.With(zap.String("operation", "runJob"), zap.String("jobID", task.id))
+
+	ctx, cancel := context.WithTimeout(task.ctx, s.jobTimeout)
+	defer cancel()
+	stop := context.AfterFunc(workerCtx, cancel)
+	defer stop()
+
+	job, err := s.storage.ClaimJob(ctx, task.id, time.Now().Add(-s.jobTimeout))
+	if err != nil {
+		if errors.Is(errors.NotFound, err) {
+			logger.Debug("job is already claimed")
+			return
+		}
+		logger.Error("error claiming job", zap.Error(err))
+		return
+	}
+
+	if task.resumed {
+		ctx = jobContext(ctx, job)
+	}
+
+	var res *policy.EvaluateResult
+	var input any
+	if err = json.Unmarshal(job.Input, &input); err != nil {
+		err = errors.New(errors.Internal, "error decoding job input", err)
+	} else {
+		req := &policy.EvaluateRequest{
+			Repository:   job.Repository,
+			Group:        job.Group,
+			PolicyName:   job.Name,
+			Version:      job.Version,
+			Input:        input,
+			EvaluationID: ptr.String(job.EvaluationID),
+			TTL:          job.TTL,
+		}
+		if job.Rule != "" {
+			req.Rule = ptr.String(job.Rule)
+		}
+
+		res, err = s.Evaluate(ctx, req)
+		if workerCtx.Err() != nil {
+			// the job stays running and is resumed after the restart
+			logger.Warn("job is interrupted", zap.Error(workerCtx.Err()))
+			return
+		}
+	}
+
+	// the job status is stored even if the job timeout is reached
+	storeCtx := context.WithoutCancel(ctx)
+
+	// the headers may contain credentials, so they
+	// are kept only as long as the job may be resumed
+	job.Headers = nil
+	job.Status = storage.JobDone
+	if err != nil {
+		job.Status = storage.JobFailed
+		job.Error = err.Error()
+	}
+	job.UpdatedAt = time.Now()
+	if err := s.storage.UpdateJob(storeCtx, job); err != nil {
+		logger.Error("error updating job", zap.Error(err))
+		return
+	}
+
+	if job.CallbackURL == "" {
+		return
+	}
+
+	callback := &jobCallback{
+		JobID:  job.ID,
+		ETag:   job.EvaluationID,
+		Status: job.Status,
+		Error:  job.Error,
+	}
+	if res != nil {
+		callback.Result = res.Result
+	}
+
+	if err := s.deliverCallback(ctx, job.CallbackURL, callback); err != nil {
+		logger.Error("error delivering job result to callback URL", zap.Error(err))
+		job.CallbackError = err.Error()
+		job.UpdatedAt = time.Now()
+		if err := s.storage.UpdateJob(storeCtx, job); err != nil {
+			logger.Error("error updating job", zap.Error(err))
+		}
+	}
+}
+
+// deliverCallback sends the job result to the callback URL
+// and retries the request if it fails.
+func (s *Service) deliverCallback(ctx context.Context, callbackURL string, callback *jobCallback) error {
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = s.postCallback(ctx, callbackURL, body)
+		if err == nil || attempt == callbackAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * callbackBackoff):
+		}
+	}
+}
+
+func (s *Service) postCallback(ctx context.Context, callbackURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.callbackClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	return nil
+}
+
+// checkCallbackURL checks that the callback URL is an HTTP URL of an allowed host.
+// If no callback hosts are configured, hosts resolving to loopback, link-local or
+// private addresses are not allowed, so that the callbacks can't reach internal services.
+func (s *Service) checkCallbackURL(ctx context.Context, callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("callback URL must be an absolute HTTP URL")
+	}
+
+	host := u.Hostname()
+	if len(s.callbackHosts) > 0 {
+		if !slices.ContainsFunc(s.callbackHosts, func(h string) bool { return strings.EqualFold(h, host) }) {
+			return fmt.Errorf("callback host %s is not allowed", host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return fmt.Errorf("callback host %s resolves to internal address %s", host, addr.IP)
+		}
+	}
+
+	return nil
+}
+
+// publicAddress reports whether the IP address isn't a loopback,
+// link-local, private, multicast or unspecified address.
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// newCallbackClient returns the HTTP client delivering the job results. If no
+// callback hosts are configured, the client refuses to connect to internal
+// addresses, because the host may resolve to another address than when the
+// job was submitted. Redirects are not followed for the same reason.
+func newCallbackClient(hosts []string) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if len(hosts) == 0 {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("callback address %s is not allowed", host)
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Transport: tracing.Transport(&http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			IdleConnTimeout:     60 * time.Second,
+		}),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: 30 * time.Second,
+	}
+}
+
+// jobContext restores the request headers and the caller of a job.
+func jobContext(ctx context.Context, job *storage.Job) context.Context {
+	if job.Headers != nil {
+		ctx = header.WithHeaders(ctx, job.Headers)
+	}
+	if job.Caller != "" || job.ClientIP != "" {
+		// only the subject of a verified caller is stored with the job
+		ctx = caller.WithCaller(ctx, &caller.Caller{
+			Subject:  job.Caller,
+			Verified: job.Caller != "",
+			ClientIP: job.ClientIP,
+		})
+	}
+	return ctx
+}
+
+// resumeJobs adds the jobs which were not finished before
+// the restart of the service to the queue of the worker pool.
+func (s *Service) resumeJobs(ctx context.Context) {
+	jobs, err := s.storage.ResumableJobs(ctx, time.Now().Add(-s.jobTimeout))
+	if err != nil {
+		s.logger.Error("error getting resumable jobs", zap.Error(err), zap.String("operation", "resumeJobs"))
+		return
+	}
+
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			return
+		case s.jobQueue <- jobTask{ctx: context.Background(), id: job.ID, resumed: true}:
+		}
+	}
+
+	if len(jobs) > 0 {
+		s.logger.Info("jobs are resumed", zap.Int("count", len(jobs)))
+	}
+}
+
+// cleanupJobs periodically removes the finished jobs exceeding the retention period.
+func (s *Service) cleanupJobs(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jobCleanupInterval):
+			if err := s.storage.DeleteJobs(ctx, time.Now().Add(-s.jobRetention)); err != nil {
+				s.logger.Error("error deleting finished jobs", zap.Error(err), zap.String("operation", "cleanupJobs"))
+			}
+		}
+	}
+}
+
+func toEvaluationJob(job *storage.Job) *policy.EvaluationJob {
+	res := &policy.EvaluationJob{
+		JobID:      job.ID,
+		ETag:       job.EvaluationID,
+		Repository: job.Repository,
+		Group:      job.Group,
+		PolicyName: job.Name,
+		Version:    job.Version,
+		Status:     job.Status,
+		CreatedAt:  job.CreatedAt.Unix(),
+		UpdatedAt:  job.UpdatedAt.Unix(),
+	}
+	if job.Rule != "" {
+		res.Rule = ptr.String(job.Rule)
+	}
+	if job.Error != "" {
+		res.Error = ptr.String(job.Error)
+	}
+	if job.CallbackURL != "" {
+		res.CallbackURL = ptr.String(job.CallbackURL)
+	}
+	if job.CallbackError != "" {
+		res.CallbackError = ptr.String(job.CallbackError)
+	}
+
+	return res
+}
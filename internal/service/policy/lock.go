@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// LockHistory returns the current lock and the previous lock and unlock events of a policy.
+func (s *Service) LockHistory(ctx context.Context, req *policy.LockHistoryRequest) (*policy.LockHistoryResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "lockHistory"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error getting policy from storage", err)
+	}
+
+	res := &policy.LockHistoryResult{
+		Locked:  pol.Locked,
+		History: make([]*policy.PolicyLockEvent, 0, len(pol.LockHistory)),
+	}
+	if pol.Locked && pol.Lock != nil {
+		res.Lock = toPolicyLockEvent(pol.Lock)
+	}
+	for i := range pol.LockHistory {
+		res.History = append(res.History, toPolicyLockEvent(&pol.LockHistory[i]))
+	}
+
+	return res, nil
+}
+
+// newLockEvent returns a lock or unlock event of the caller in the context.
+// The actor is only set if the caller has a verified bearer token.
+func newLockEvent(ctx context.Context, locked bool, source, reason string) *storage.LockEvent {
+	event := &storage.LockEvent{
+		Locked:    locked,
+		Reason:    reason,
+		Source:    source,
+		Timestamp: time.Now(),
+	}
+	if c, ok := caller.FromContext(ctx); ok && c.Verified {
+		event.Actor = c.Subject
+	}
+	return event
+}
+
+// checkLock returns a Forbidden error with the lock reason if the policy is locked.
+// A lock whose unlock time has passed is removed, so that the policy can be evaluated.
+func (s *Service) checkLock(ctx context.Context, pol *storage.Policy) error {
+	if !pol.Locked {
+		return nil
+	}
+
+	if pol.Lock != nil && pol.Lock.UnlockAt != nil && !time.Now().Before(*pol.Lock.UnlockAt) {
+		event := &storage.LockEvent{
+			Reason:    "lock expired",
+			Source:    storage.LockSourceExpiry,
+			Timestamp: time.Now(),
+		}
+		unlocked, err := s.storage.SetPolicyLock(ctx, pol.Repository, pol.Group, pol.Name, pol.Version, event)
+		if err != nil {
+			// the lock is expired even if the unlock isn't stored
+			s.logger.Error("error unlocking policy with expired lock",
+				zap.String("repository", pol.Repository),
+				zap.String("group", pol.Group),
+				zap.String("name", pol.Name),
+				zap.String("version", pol.Version),
+				zap.Error(err),
+			)
+			return nil
+		}
+
+		// the cached policy is still locked, so it's removed to not
+		// unlock it again on every evaluation
+		s.policyCache.Delete(s.queryCacheKey(pol.Repository, pol.Group, pol.Name, pol.Version))
+
+		if unlocked {
+			s.audit(ctx, &storage.AuditRecord{
+				Operation:  storage.AuditUnlock,
+				Actor:      audit.SystemActor,
+				Repository: pol.Repository,
+				Group:      pol.Group,
+				Name:       pol.Name,
+				Version:    pol.Version,
+				BeforeHash: audit.PolicyHash(pol),
+				AfterHash:  audit.PolicyHash(withLocked(pol, false)),
+			})
+		}
+		return nil
+	}
+
+	msg := "policy is locked"
+	if pol.Lock != nil && pol.Lock.Reason != "" {
+		msg += ": " + pol.Lock.Reason
+	}
+	return errors.New(errors.Forbidden, msg)
+}
+
+func toPolicyLockEvent(e *storage.LockEvent) *policy.PolicyLockEvent {
+	event := &policy.PolicyLockEvent{
+		Locked:    e.Locked,
+		Source:    e.Source,
+		Timestamp: e.Timestamp.Unix(),
+	}
+	if e.Reason != "" {
+		event.Reason = ptr.String(e.Reason)
+	}
+	if e.Actor != "" {
+		event.Actor = ptr.String(e.Actor)
+	}
+	if e.UnlockAt != nil {
+		event.UnlockAt = ptr.Int64(e.UnlockAt.Unix())
+	}
+	return event
+}
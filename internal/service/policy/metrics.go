@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+// evaluationLabels returns the metric labels of an evaluated policy. If the
+// policy could not be retrieved, the labels are set to metrics.OtherLabel,
+// because the request path may reference any number of unknown policies.
+func evaluationLabels(pol *storage.Policy) prometheus.Labels {
+	if pol != nil {
+		return metrics.PolicyLabels(pol.Repository, pol.Group, pol.Name, pol.Version)
+	}
+	return metrics.OtherPolicyLabels()
+}
+
+// observeEvaluation records the outcome and the duration of a policy evaluation.
+func observeEvaluation(pol *storage.Policy, err error, duration time.Duration) {
+	labels := evaluationLabels(pol)
+	metrics.Evaluations.With(labels).Inc()
+	metrics.EvaluationDuration.With(labels).Observe(duration.Seconds())
+	if err != nil {
+		metrics.EvaluationErrors.With(metrics.With(labels, "kind", metrics.ErrorKind(err))).Inc()
+	}
+}
@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type Option func(*Service)
+
+// WithBatchConcurrency limits the number of policy evaluations
+// executed concurrently for a single batch evaluation request.
+func WithBatchConcurrency(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.batchConcurrency = n
+		}
+	}
+}
+
+// WithMaxBatchSize limits the number of items of a single batch
+// evaluation request. Larger batches are rejected.
+func WithMaxBatchSize(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.maxBatchSize = n
+		}
+	}
+}
+
+// WithShadowConcurrency limits the number of shadow evaluations of
+// candidate policy versions executed concurrently in the background.
+func WithShadowConcurrency(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.shadowConcurrency = n
+		}
+	}
+}
+
+// WithMaxResultWait limits the time a request waits for the
+// result of a policy evaluation to be stored in the cache.
+func WithMaxResultWait(d time.Duration) Option {
+	return func(s *Service) {
+		if d >= 0 {
+			s.maxResultWait = d
+		}
+	}
+}
+
+// WithJobs enables asynchronous evaluation jobs, which are executed by
+// the given number of workers. At most queueSize jobs wait for execution,
+// further jobs are rejected. Finished jobs are removed after the retention.
+// A job running longer than the timeout is cancelled.
+func WithJobs(workers, queueSize int, retention, timeout time.Duration) Option {
+	return func(s *Service) {
+		s.jobWorkers = workers
+		if queueSize > 0 {
+			s.jobQueueSize = queueSize
+		}
+		if retention > 0 {
+			s.jobRetention = retention
+		}
+		if timeout > 0 {
+			s.jobTimeout = timeout
+		}
+	}
+}
+
+// WithJobCallbackHosts limits the hosts of job callback URLs to the given
+// hosts. Without it, only hosts with public addresses are allowed.
+func WithJobCallbackHosts(hosts ...string) Option {
+	return func(s *Service) {
+		s.callbackHosts = hosts
+	}
+}
+
+// WithDecisionLog records the decision of every policy evaluation.
+func WithDecisionLog(log DecisionLog) Option {
+	return func(s *Service) {
+		s.decisionLog = log
+	}
+}
+
+// WithDecisionStorage enables querying of the decision log records.
+func WithDecisionStorage(storage DecisionStorage) Option {
+	return func(s *Service) {
+		s.decisionStorage = storage
+	}
+}
+
+// WithAuditLog records the state-changing operations in the audit log
+// and enables querying of the audit records.
+func WithAuditLog(log AuditLog) Option {
+	return func(s *Service) {
+		s.auditLog = log
+	}
+}
+
+// WithExplainCheck sets the check which allows callers to
+// request the evaluation trace of a policy.
+func WithExplainCheck(check func(ctx context.Context) bool) Option {
+	return func(s *Service) {
+		s.explainCheck = check
+	}
+}
+
+// WithLibraries sets shared rego modules which are compiled together
+// with every policy, so that policies can import them.
+func WithLibraries(modules []storage.Module) Option {
+	return func(s *Service) {
+		s.libraries = modules
+	}
+}
+
+// WithPolicySubscribers subscribes the given subscribers together with the
+// service for the policy changes of the storage. They are subscribed before
+// the jobs and the automatic import are started, so that no change is missed.
+func WithPolicySubscribers(subscribers ...storage.PolicySubscriber) Option {
+	return func(s *Service) {
+		s.subscribers = subscribers
+		s.subscribe = true
+	}
+}
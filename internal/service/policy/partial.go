@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofilter"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/tracing"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const partialTargetMongo = "mongo"
+
+// PartialEvaluate executes partial evaluation of a boolean policy rule,
+// where the given unknowns are not known at evaluation time. The returned
+// residual queries express the conditions for the unknowns under which the
+// rule is true, so that they can be used for filtering of data, e.g. by
+// translating them to a database query.
+func (s *Service) PartialEvaluate(ctx context.Context, req *policy.PartialEvaluateRequest) (res *policy.PartialEvaluateResult, err error) {
+	ctx, span := tracing.Start(ctx, "policy.PartialEvaluate", tracing.PolicyAttributes(req.Repository, req.Group, req.PolicyName, req.Version)...)
+	defer func() { tracing.End(span, err) }()
+
+	logger := s.logger.With(
+		zap.String("operation", "partialEvaluate"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.String("rule", req.Rule),
+	)
+
+	version, err := s.resolveVersion(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error resolving policy version", zap.Error(err))
+		return nil, errors.New("error retrieving policy", err)
+	}
+
+	pol, err := s.retrievePolicy(ctx, req.Repository, req.Group, req.PolicyName, version)
+	if err != nil {
+		logger.Error("error retrieving policy", zap.Error(err))
+		return nil, errors.New("error retrieving policy", err)
+	}
+
+	if err := s.checkLock(ctx, pol); err != nil {
+		logger.Error("policy is locked", zap.Error(err))
+		return nil, err
+	}
+
+	regoQuery := fmt.Sprintf("data.%s.%s.%s == true", req.Group, req.PolicyName, req.Rule)
+	regoArgs, err := s.buildRegoArgs(pol, regoQuery, regofunc.List())
+	if err != nil {
+		logger.Error("error building rego runtime functions", zap.Error(err))
+		return nil, errors.New("error building rego runtime functions", err)
+	}
+
+	regoArgs = append(regoArgs, rego.Unknowns(req.Unknowns))
+	if req.Input != nil {
+		regoArgs = append(regoArgs, rego.Input(req.Input))
+	}
+
+	pq, err := rego.New(regoArgs...).Partial(ctx)
+	if err != nil {
+		logger.Error("error executing partial evaluation", zap.Error(err))
+		return nil, errors.New("error executing partial evaluation", err)
+	}
+
+	res = &policy.PartialEvaluateResult{
+		Queries: make([]string, 0, len(pq.Queries)),
+		Version: version,
+	}
+	for _, q := range pq.Queries {
+		res.Queries = append(res.Queries, q.String())
+	}
+	for _, m := range pq.Support {
+		res.Support = append(res.Support, m.String())
+	}
+
+	if req.Target == partialTargetMongo {
+		filter, err := regofilter.Mongo(pq, req.Unknowns)
+		if err != nil {
+			logger.Error("error translating residual queries to mongo filter", zap.Error(err))
+			return nil, errors.New(errors.BadRequest, "error translating residual queries to mongo filter", err)
+		}
+		res.Filter = filter
+	}
+
+	return res, nil
+}
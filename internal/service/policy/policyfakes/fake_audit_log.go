@@ -0,0 +1,161 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package policyfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type FakeAuditLog struct {
+	RecordStub        func(context.Context, *storage.AuditRecord)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.AuditRecord
+	}
+	RecordsStub        func(context.Context, *storage.AuditFilter) ([]*storage.AuditRecord, error)
+	recordsMutex       sync.RWMutex
+	recordsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.AuditFilter
+	}
+	recordsReturns struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}
+	recordsReturnsOnCall map[int]struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditLog) Record(arg1 context.Context, arg2 *storage.AuditRecord) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.AuditRecord
+	}{arg1, arg2})
+	stub := fake.RecordStub
+	fake.recordInvocation("Record", []interface{}{arg1, arg2})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		fake.RecordStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeAuditLog) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeAuditLog) RecordCalls(stub func(context.Context, *storage.AuditRecord)) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *FakeAuditLog) RecordArgsForCall(i int) (context.Context, *storage.AuditRecord) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuditLog) Records(arg1 context.Context, arg2 *storage.AuditFilter) ([]*storage.AuditRecord, error) {
+	fake.recordsMutex.Lock()
+	ret, specificReturn := fake.recordsReturnsOnCall[len(fake.recordsArgsForCall)]
+	fake.recordsArgsForCall = append(fake.recordsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.AuditFilter
+	}{arg1, arg2})
+	stub := fake.RecordsStub
+	fakeReturns := fake.recordsReturns
+	fake.recordInvocation("Records", []interface{}{arg1, arg2})
+	fake.recordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditLog) RecordsCallCount() int {
+	fake.recordsMutex.RLock()
+	defer fake.recordsMutex.RUnlock()
+	return len(fake.recordsArgsForCall)
+}
+
+func (fake *FakeAuditLog) RecordsCalls(stub func(context.Context, *storage.AuditFilter) ([]*storage.AuditRecord, error)) {
+	fake.recordsMutex.Lock()
+	defer fake.recordsMutex.Unlock()
+	fake.RecordsStub = stub
+}
+
+func (fake *FakeAuditLog) RecordsArgsForCall(i int) (context.Context, *storage.AuditFilter) {
+	fake.recordsMutex.RLock()
+	defer fake.recordsMutex.RUnlock()
+	argsForCall := fake.recordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuditLog) RecordsReturns(result1 []*storage.AuditRecord, result2 error) {
+	fake.recordsMutex.Lock()
+	defer fake.recordsMutex.Unlock()
+	fake.RecordsStub = nil
+	fake.recordsReturns = struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditLog) RecordsReturnsOnCall(i int, result1 []*storage.AuditRecord, result2 error) {
+	fake.recordsMutex.Lock()
+	defer fake.recordsMutex.Unlock()
+	fake.RecordsStub = nil
+	if fake.recordsReturnsOnCall == nil {
+		fake.recordsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.AuditRecord
+			result2 error
+		})
+	}
+	fake.recordsReturnsOnCall[i] = struct {
+		result1 []*storage.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditLog) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	fake.recordsMutex.RLock()
+	defer fake.recordsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditLog) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ policy.AuditLog = new(FakeAuditLog)
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package policyfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+type FakeDecisionStorage struct {
+	DecisionsStub        func(context.Context, *storage.DecisionFilter) ([]*storage.Decision, error)
+	decisionsMutex       sync.RWMutex
+	decisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.DecisionFilter
+	}
+	decisionsReturns struct {
+		result1 []*storage.Decision
+		result2 error
+	}
+	decisionsReturnsOnCall map[int]struct {
+		result1 []*storage.Decision
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDecisionStorage) Decisions(arg1 context.Context, arg2 *storage.DecisionFilter) ([]*storage.Decision, error) {
+	fake.decisionsMutex.Lock()
+	ret, specificReturn := fake.decisionsReturnsOnCall[len(fake.decisionsArgsForCall)]
+	fake.decisionsArgsForCall = append(fake.decisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.DecisionFilter
+	}{arg1, arg2})
+	stub := fake.DecisionsStub
+	fakeReturns := fake.decisionsReturns
+	fake.recordInvocation("Decisions", []interface{}{arg1, arg2})
+	fake.decisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDecisionStorage) DecisionsCallCount() int {
+	fake.decisionsMutex.RLock()
+	defer fake.decisionsMutex.RUnlock()
+	return len(fake.decisionsArgsForCall)
+}
+
+func (fake *FakeDecisionStorage) DecisionsCalls(stub func(context.Context, *storage.DecisionFilter) ([]*storage.Decision, error)) {
+	fake.decisionsMutex.Lock()
+	defer fake.decisionsMutex.Unlock()
+	fake.DecisionsStub = stub
+}
+
+func (fake *FakeDecisionStorage) DecisionsArgsForCall(i int) (context.Context, *storage.DecisionFilter) {
+	fake.decisionsMutex.RLock()
+	defer fake.decisionsMutex.RUnlock()
+	argsForCall := fake.decisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDecisionStorage) DecisionsReturns(result1 []*storage.Decision, result2 error) {
+	fake.decisionsMutex.Lock()
+	defer fake.decisionsMutex.Unlock()
+	fake.DecisionsStub = nil
+	fake.decisionsReturns = struct {
+		result1 []*storage.Decision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDecisionStorage) DecisionsReturnsOnCall(i int, result1 []*storage.Decision, result2 error) {
+	fake.decisionsMutex.Lock()
+	defer fake.decisionsMutex.Unlock()
+	fake.DecisionsStub = nil
+	if fake.decisionsReturnsOnCall == nil {
+		fake.decisionsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.Decision
+			result2 error
+		})
+	}
+	fake.decisionsReturnsOnCall[i] = struct {
+		result1 []*storage.Decision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDecisionStorage) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.decisionsMutex.RLock()
+	defer fake.decisionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDecisionStorage) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ policy.DecisionStorage = new(FakeDecisionStorage)
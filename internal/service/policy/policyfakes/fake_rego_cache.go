@@ -6,9 +6,15 @@ import (
 
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	"github.com/open-policy-agent/opa/rego"
 )
 
 type FakeRegoCache struct {
+	DeleteStub        func(string)
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 string
+	}
 	GetStub        func(string) (*storage.Policy, bool)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -22,16 +28,93 @@ type FakeRegoCache struct {
 		result1 *storage.Policy
 		result2 bool
 	}
+	GetQueryStub        func(string, string) (*rego.PreparedEvalQuery, bool)
+	getQueryMutex       sync.RWMutex
+	getQueryArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getQueryReturns struct {
+		result1 *rego.PreparedEvalQuery
+		result2 bool
+	}
+	getQueryReturnsOnCall map[int]struct {
+		result1 *rego.PreparedEvalQuery
+		result2 bool
+	}
+	GetValueStub        func(string, string, *storage.Policy) (any, bool)
+	getValueMutex       sync.RWMutex
+	getValueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+	}
+	getValueReturns struct {
+		result1 any
+		result2 bool
+	}
+	getValueReturnsOnCall map[int]struct {
+		result1 any
+		result2 bool
+	}
 	SetStub        func(string, *storage.Policy)
 	setMutex       sync.RWMutex
 	setArgsForCall []struct {
 		arg1 string
 		arg2 *storage.Policy
 	}
+	SetQueryStub        func(string, string, *storage.Policy, *rego.PreparedEvalQuery)
+	setQueryMutex       sync.RWMutex
+	setQueryArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+		arg4 *rego.PreparedEvalQuery
+	}
+	SetValueStub        func(string, string, *storage.Policy, any)
+	setValueMutex       sync.RWMutex
+	setValueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+		arg4 any
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRegoCache) Delete(arg1 string) {
+	fake.deleteMutex.Lock()
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteStub
+	fake.recordInvocation("Delete", []interface{}{arg1})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		fake.DeleteStub(arg1)
+	}
+}
+
+func (fake *FakeRegoCache) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeRegoCache) DeleteCalls(stub func(string)) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeRegoCache) DeleteArgsForCall(i int) string {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeRegoCache) Get(arg1 string) (*storage.Policy, bool) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -96,6 +179,137 @@ func (fake *FakeRegoCache) GetReturnsOnCall(i int, result1 *storage.Policy, resu
 	}{result1, result2}
 }
 
+func (fake *FakeRegoCache) GetQuery(arg1 string, arg2 string) (*rego.PreparedEvalQuery, bool) {
+	fake.getQueryMutex.Lock()
+	ret, specificReturn := fake.getQueryReturnsOnCall[len(fake.getQueryArgsForCall)]
+	fake.getQueryArgsForCall = append(fake.getQueryArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetQueryStub
+	fakeReturns := fake.getQueryReturns
+	fake.recordInvocation("GetQuery", []interface{}{arg1, arg2})
+	fake.getQueryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRegoCache) GetQueryCallCount() int {
+	fake.getQueryMutex.RLock()
+	defer fake.getQueryMutex.RUnlock()
+	return len(fake.getQueryArgsForCall)
+}
+
+func (fake *FakeRegoCache) GetQueryCalls(stub func(string, string) (*rego.PreparedEvalQuery, bool)) {
+	fake.getQueryMutex.Lock()
+	defer fake.getQueryMutex.Unlock()
+	fake.GetQueryStub = stub
+}
+
+func (fake *FakeRegoCache) GetQueryArgsForCall(i int) (string, string) {
+	fake.getQueryMutex.RLock()
+	defer fake.getQueryMutex.RUnlock()
+	argsForCall := fake.getQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRegoCache) GetQueryReturns(result1 *rego.PreparedEvalQuery, result2 bool) {
+	fake.getQueryMutex.Lock()
+	defer fake.getQueryMutex.Unlock()
+	fake.GetQueryStub = nil
+	fake.getQueryReturns = struct {
+		result1 *rego.PreparedEvalQuery
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRegoCache) GetQueryReturnsOnCall(i int, result1 *rego.PreparedEvalQuery, result2 bool) {
+	fake.getQueryMutex.Lock()
+	defer fake.getQueryMutex.Unlock()
+	fake.GetQueryStub = nil
+	if fake.getQueryReturnsOnCall == nil {
+		fake.getQueryReturnsOnCall = make(map[int]struct {
+			result1 *rego.PreparedEvalQuery
+			result2 bool
+		})
+	}
+	fake.getQueryReturnsOnCall[i] = struct {
+		result1 *rego.PreparedEvalQuery
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRegoCache) GetValue(arg1 string, arg2 string, arg3 *storage.Policy) (any, bool) {
+	fake.getValueMutex.Lock()
+	ret, specificReturn := fake.getValueReturnsOnCall[len(fake.getValueArgsForCall)]
+	fake.getValueArgsForCall = append(fake.getValueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+	}{arg1, arg2, arg3})
+	stub := fake.GetValueStub
+	fakeReturns := fake.getValueReturns
+	fake.recordInvocation("GetValue", []interface{}{arg1, arg2, arg3})
+	fake.getValueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRegoCache) GetValueCallCount() int {
+	fake.getValueMutex.RLock()
+	defer fake.getValueMutex.RUnlock()
+	return len(fake.getValueArgsForCall)
+}
+
+func (fake *FakeRegoCache) GetValueCalls(stub func(string, string, *storage.Policy) (any, bool)) {
+	fake.getValueMutex.Lock()
+	defer fake.getValueMutex.Unlock()
+	fake.GetValueStub = stub
+}
+
+func (fake *FakeRegoCache) GetValueArgsForCall(i int) (string, string, *storage.Policy) {
+	fake.getValueMutex.RLock()
+	defer fake.getValueMutex.RUnlock()
+	argsForCall := fake.getValueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRegoCache) GetValueReturns(result1 any, result2 bool) {
+	fake.getValueMutex.Lock()
+	defer fake.getValueMutex.Unlock()
+	fake.GetValueStub = nil
+	fake.getValueReturns = struct {
+		result1 any
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRegoCache) GetValueReturnsOnCall(i int, result1 any, result2 bool) {
+	fake.getValueMutex.Lock()
+	defer fake.getValueMutex.Unlock()
+	fake.GetValueStub = nil
+	if fake.getValueReturnsOnCall == nil {
+		fake.getValueReturnsOnCall = make(map[int]struct {
+			result1 any
+			result2 bool
+		})
+	}
+	fake.getValueReturnsOnCall[i] = struct {
+		result1 any
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeRegoCache) Set(arg1 string, arg2 *storage.Policy) {
 	fake.setMutex.Lock()
 	fake.setArgsForCall = append(fake.setArgsForCall, struct {
@@ -129,13 +343,93 @@ func (fake *FakeRegoCache) SetArgsForCall(i int) (string, *storage.Policy) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRegoCache) SetQuery(arg1 string, arg2 string, arg3 *storage.Policy, arg4 *rego.PreparedEvalQuery) {
+	fake.setQueryMutex.Lock()
+	fake.setQueryArgsForCall = append(fake.setQueryArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+		arg4 *rego.PreparedEvalQuery
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetQueryStub
+	fake.recordInvocation("SetQuery", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setQueryMutex.Unlock()
+	if stub != nil {
+		fake.SetQueryStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeRegoCache) SetQueryCallCount() int {
+	fake.setQueryMutex.RLock()
+	defer fake.setQueryMutex.RUnlock()
+	return len(fake.setQueryArgsForCall)
+}
+
+func (fake *FakeRegoCache) SetQueryCalls(stub func(string, string, *storage.Policy, *rego.PreparedEvalQuery)) {
+	fake.setQueryMutex.Lock()
+	defer fake.setQueryMutex.Unlock()
+	fake.SetQueryStub = stub
+}
+
+func (fake *FakeRegoCache) SetQueryArgsForCall(i int) (string, string, *storage.Policy, *rego.PreparedEvalQuery) {
+	fake.setQueryMutex.RLock()
+	defer fake.setQueryMutex.RUnlock()
+	argsForCall := fake.setQueryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRegoCache) SetValue(arg1 string, arg2 string, arg3 *storage.Policy, arg4 any) {
+	fake.setValueMutex.Lock()
+	fake.setValueArgsForCall = append(fake.setValueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *storage.Policy
+		arg4 any
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetValueStub
+	fake.recordInvocation("SetValue", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setValueMutex.Unlock()
+	if stub != nil {
+		fake.SetValueStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeRegoCache) SetValueCallCount() int {
+	fake.setValueMutex.RLock()
+	defer fake.setValueMutex.RUnlock()
+	return len(fake.setValueArgsForCall)
+}
+
+func (fake *FakeRegoCache) SetValueCalls(stub func(string, string, *storage.Policy, any)) {
+	fake.setValueMutex.Lock()
+	defer fake.setValueMutex.Unlock()
+	fake.SetValueStub = stub
+}
+
+func (fake *FakeRegoCache) SetValueArgsForCall(i int) (string, string, *storage.Policy, any) {
+	fake.setValueMutex.RLock()
+	defer fake.setValueMutex.RUnlock()
+	argsForCall := fake.setValueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
 func (fake *FakeRegoCache) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.getQueryMutex.RLock()
+	defer fake.getQueryMutex.RUnlock()
+	fake.getValueMutex.RLock()
+	defer fake.getValueMutex.RUnlock()
 	fake.setMutex.RLock()
 	defer fake.setMutex.RUnlock()
+	fake.setQueryMutex.RLock()
+	defer fake.setQueryMutex.RUnlock()
+	fake.setValueMutex.RLock()
+	defer fake.setValueMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,6 +4,7 @@ package policyfakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
@@ -55,11 +56,56 @@ type FakeStorage struct {
 		result1 []*storage.PolicyAutoImport
 		result2 error
 	}
+	CapturedEvaluationsStub        func(context.Context, string, string, string, string, int) ([]*storage.CapturedEvaluation, error)
+	capturedEvaluationsMutex       sync.RWMutex
+	capturedEvaluationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int
+	}
+	capturedEvaluationsReturns struct {
+		result1 []*storage.CapturedEvaluation
+		result2 error
+	}
+	capturedEvaluationsReturnsOnCall map[int]struct {
+		result1 []*storage.CapturedEvaluation
+		result2 error
+	}
+	ClaimJobStub        func(context.Context, string, time.Time) (*storage.Job, error)
+	claimJobMutex       sync.RWMutex
+	claimJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}
+	claimJobReturns struct {
+		result1 *storage.Job
+		result2 error
+	}
+	claimJobReturnsOnCall map[int]struct {
+		result1 *storage.Job
+		result2 error
+	}
 	CloseStub        func(context.Context)
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
 		arg1 context.Context
 	}
+	CreateJobStub        func(context.Context, *storage.Job) error
+	createJobMutex       sync.RWMutex
+	createJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.Job
+	}
+	createJobReturns struct {
+		result1 error
+	}
+	createJobReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateSubscriberStub        func(context.Context, *storage.Subscriber) (*storage.Subscriber, error)
 	createSubscriberMutex       sync.RWMutex
 	createSubscriberArgsForCall []struct {
@@ -86,6 +132,21 @@ type FakeStorage struct {
 	deleteAutoImportConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteCapturedEvaluationsStub        func(context.Context, string, string, string, string) error
+	deleteCapturedEvaluationsMutex       sync.RWMutex
+	deleteCapturedEvaluationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	deleteCapturedEvaluationsReturns struct {
+		result1 error
+	}
+	deleteCapturedEvaluationsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteDataStub        func(context.Context, string) error
 	deleteDataMutex       sync.RWMutex
 	deleteDataArgsForCall []struct {
@@ -98,6 +159,33 @@ type FakeStorage struct {
 	deleteDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteJobsStub        func(context.Context, time.Time) error
+	deleteJobsMutex       sync.RWMutex
+	deleteJobsArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	deleteJobsReturns struct {
+		result1 error
+	}
+	deleteJobsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeletePolicyAliasStub        func(context.Context, string, string, string, string) error
+	deletePolicyAliasMutex       sync.RWMutex
+	deletePolicyAliasArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	deletePolicyAliasReturns struct {
+		result1 error
+	}
+	deletePolicyAliasReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetDataStub        func(context.Context, string) (any, error)
 	getDataMutex       sync.RWMutex
 	getDataArgsForCall []struct {
@@ -127,6 +215,20 @@ type FakeStorage struct {
 		result1 []*storage.Policy
 		result2 error
 	}
+	JobStub        func(context.Context, string) (*storage.Job, error)
+	jobMutex       sync.RWMutex
+	jobArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	jobReturns struct {
+		result1 *storage.Job
+		result2 error
+	}
+	jobReturnsOnCall map[int]struct {
+		result1 *storage.Job
+		result2 error
+	}
 	ListenPolicyDataChangesStub        func(context.Context) error
 	listenPolicyDataChangesMutex       sync.RWMutex
 	listenPolicyDataChangesArgsForCall []struct {
@@ -155,6 +257,69 @@ type FakeStorage struct {
 		result1 *storage.Policy
 		result2 error
 	}
+	PolicyAliasStub        func(context.Context, string, string, string, string) (*storage.PolicyAlias, error)
+	policyAliasMutex       sync.RWMutex
+	policyAliasArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	policyAliasReturns struct {
+		result1 *storage.PolicyAlias
+		result2 error
+	}
+	policyAliasReturnsOnCall map[int]struct {
+		result1 *storage.PolicyAlias
+		result2 error
+	}
+	PolicyAliasesStub        func(context.Context, string, string, string) ([]*storage.PolicyAlias, error)
+	policyAliasesMutex       sync.RWMutex
+	policyAliasesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	policyAliasesReturns struct {
+		result1 []*storage.PolicyAlias
+		result2 error
+	}
+	policyAliasesReturnsOnCall map[int]struct {
+		result1 []*storage.PolicyAlias
+		result2 error
+	}
+	PolicyVersionsStub        func(context.Context, string, string, string) ([]string, error)
+	policyVersionsMutex       sync.RWMutex
+	policyVersionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	policyVersionsReturns struct {
+		result1 []string
+		result2 error
+	}
+	policyVersionsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ResumableJobsStub        func(context.Context, time.Time) ([]*storage.Job, error)
+	resumableJobsMutex       sync.RWMutex
+	resumableJobsArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	resumableJobsReturns struct {
+		result1 []*storage.Job
+		result2 error
+	}
+	resumableJobsReturnsOnCall map[int]struct {
+		result1 []*storage.Job
+		result2 error
+	}
 	SaveAutoImportConfigStub        func(context.Context, *storage.PolicyAutoImport) error
 	saveAutoImportConfigMutex       sync.RWMutex
 	saveAutoImportConfigArgsForCall []struct {
@@ -167,6 +332,21 @@ type FakeStorage struct {
 	saveAutoImportConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveCapturedEvaluationStub        func(context.Context, *storage.CapturedEvaluation, int) (bool, error)
+	saveCapturedEvaluationMutex       sync.RWMutex
+	saveCapturedEvaluationArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.CapturedEvaluation
+		arg3 int
+	}
+	saveCapturedEvaluationReturns struct {
+		result1 bool
+		result2 error
+	}
+	saveCapturedEvaluationReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SavePolicyStub        func(context.Context, *storage.Policy) error
 	savePolicyMutex       sync.RWMutex
 	savePolicyArgsForCall []struct {
@@ -179,6 +359,30 @@ type FakeStorage struct {
 	savePolicyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SavePolicyAliasStub        func(context.Context, *storage.PolicyAlias) error
+	savePolicyAliasMutex       sync.RWMutex
+	savePolicyAliasArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.PolicyAlias
+	}
+	savePolicyAliasReturns struct {
+		result1 error
+	}
+	savePolicyAliasReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveShadowDivergenceStub        func(context.Context, *storage.ShadowDivergence) error
+	saveShadowDivergenceMutex       sync.RWMutex
+	saveShadowDivergenceArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.ShadowDivergence
+	}
+	saveShadowDivergenceReturns struct {
+		result1 error
+	}
+	saveShadowDivergenceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetDataStub        func(context.Context, string, map[string]interface{}) error
 	setDataMutex       sync.RWMutex
 	setDataArgsForCall []struct {
@@ -192,7 +396,23 @@ type FakeStorage struct {
 	setDataReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetPolicyLockStub        func(context.Context, string, string, string, string, bool) error
+	SetPolicyCaptureStub        func(context.Context, string, string, string, string, *storage.CaptureConfig) error
+	setPolicyCaptureMutex       sync.RWMutex
+	setPolicyCaptureArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 *storage.CaptureConfig
+	}
+	setPolicyCaptureReturns struct {
+		result1 error
+	}
+	setPolicyCaptureReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetPolicyLockStub        func(context.Context, string, string, string, string, *storage.LockEvent) (bool, error)
 	setPolicyLockMutex       sync.RWMutex
 	setPolicyLockArgsForCall []struct {
 		arg1 context.Context
@@ -200,12 +420,30 @@ type FakeStorage struct {
 		arg3 string
 		arg4 string
 		arg5 string
-		arg6 bool
+		arg6 *storage.LockEvent
 	}
 	setPolicyLockReturns struct {
-		result1 error
+		result1 bool
+		result2 error
 	}
 	setPolicyLockReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SetPolicyShadowStub        func(context.Context, string, string, string, string, string) error
+	setPolicyShadowMutex       sync.RWMutex
+	setPolicyShadowArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}
+	setPolicyShadowReturns struct {
+		result1 error
+	}
+	setPolicyShadowReturnsOnCall map[int]struct {
 		result1 error
 	}
 	SubscriberStub        func(context.Context, string, string, string, string, string, string) (*storage.Subscriber, error)
@@ -227,6 +465,18 @@ type FakeStorage struct {
 		result1 *storage.Subscriber
 		result2 error
 	}
+	UpdateJobStub        func(context.Context, *storage.Job) error
+	updateJobMutex       sync.RWMutex
+	updateJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 *storage.Job
+	}
+	updateJobReturns struct {
+		result1 error
+	}
+	updateJobReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -456,6 +706,141 @@ func (fake *FakeStorage) AutoImportConfigsReturnsOnCall(i int, result1 []*storag
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) CapturedEvaluations(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 int) ([]*storage.CapturedEvaluation, error) {
+	fake.capturedEvaluationsMutex.Lock()
+	ret, specificReturn := fake.capturedEvaluationsReturnsOnCall[len(fake.capturedEvaluationsArgsForCall)]
+	fake.capturedEvaluationsArgsForCall = append(fake.capturedEvaluationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 int
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CapturedEvaluationsStub
+	fakeReturns := fake.capturedEvaluationsReturns
+	fake.recordInvocation("CapturedEvaluations", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.capturedEvaluationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) CapturedEvaluationsCallCount() int {
+	fake.capturedEvaluationsMutex.RLock()
+	defer fake.capturedEvaluationsMutex.RUnlock()
+	return len(fake.capturedEvaluationsArgsForCall)
+}
+
+func (fake *FakeStorage) CapturedEvaluationsCalls(stub func(context.Context, string, string, string, string, int) ([]*storage.CapturedEvaluation, error)) {
+	fake.capturedEvaluationsMutex.Lock()
+	defer fake.capturedEvaluationsMutex.Unlock()
+	fake.CapturedEvaluationsStub = stub
+}
+
+func (fake *FakeStorage) CapturedEvaluationsArgsForCall(i int) (context.Context, string, string, string, string, int) {
+	fake.capturedEvaluationsMutex.RLock()
+	defer fake.capturedEvaluationsMutex.RUnlock()
+	argsForCall := fake.capturedEvaluationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) CapturedEvaluationsReturns(result1 []*storage.CapturedEvaluation, result2 error) {
+	fake.capturedEvaluationsMutex.Lock()
+	defer fake.capturedEvaluationsMutex.Unlock()
+	fake.CapturedEvaluationsStub = nil
+	fake.capturedEvaluationsReturns = struct {
+		result1 []*storage.CapturedEvaluation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) CapturedEvaluationsReturnsOnCall(i int, result1 []*storage.CapturedEvaluation, result2 error) {
+	fake.capturedEvaluationsMutex.Lock()
+	defer fake.capturedEvaluationsMutex.Unlock()
+	fake.CapturedEvaluationsStub = nil
+	if fake.capturedEvaluationsReturnsOnCall == nil {
+		fake.capturedEvaluationsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.CapturedEvaluation
+			result2 error
+		})
+	}
+	fake.capturedEvaluationsReturnsOnCall[i] = struct {
+		result1 []*storage.CapturedEvaluation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) ClaimJob(arg1 context.Context, arg2 string, arg3 time.Time) (*storage.Job, error) {
+	fake.claimJobMutex.Lock()
+	ret, specificReturn := fake.claimJobReturnsOnCall[len(fake.claimJobArgsForCall)]
+	fake.claimJobArgsForCall = append(fake.claimJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.ClaimJobStub
+	fakeReturns := fake.claimJobReturns
+	fake.recordInvocation("ClaimJob", []interface{}{arg1, arg2, arg3})
+	fake.claimJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) ClaimJobCallCount() int {
+	fake.claimJobMutex.RLock()
+	defer fake.claimJobMutex.RUnlock()
+	return len(fake.claimJobArgsForCall)
+}
+
+func (fake *FakeStorage) ClaimJobCalls(stub func(context.Context, string, time.Time) (*storage.Job, error)) {
+	fake.claimJobMutex.Lock()
+	defer fake.claimJobMutex.Unlock()
+	fake.ClaimJobStub = stub
+}
+
+func (fake *FakeStorage) ClaimJobArgsForCall(i int) (context.Context, string, time.Time) {
+	fake.claimJobMutex.RLock()
+	defer fake.claimJobMutex.RUnlock()
+	argsForCall := fake.claimJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStorage) ClaimJobReturns(result1 *storage.Job, result2 error) {
+	fake.claimJobMutex.Lock()
+	defer fake.claimJobMutex.Unlock()
+	fake.ClaimJobStub = nil
+	fake.claimJobReturns = struct {
+		result1 *storage.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) ClaimJobReturnsOnCall(i int, result1 *storage.Job, result2 error) {
+	fake.claimJobMutex.Lock()
+	defer fake.claimJobMutex.Unlock()
+	fake.ClaimJobStub = nil
+	if fake.claimJobReturnsOnCall == nil {
+		fake.claimJobReturnsOnCall = make(map[int]struct {
+			result1 *storage.Job
+			result2 error
+		})
+	}
+	fake.claimJobReturnsOnCall[i] = struct {
+		result1 *storage.Job
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) Close(arg1 context.Context) {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
@@ -488,6 +873,68 @@ func (fake *FakeStorage) CloseArgsForCall(i int) context.Context {
 	return argsForCall.arg1
 }
 
+func (fake *FakeStorage) CreateJob(arg1 context.Context, arg2 *storage.Job) error {
+	fake.createJobMutex.Lock()
+	ret, specificReturn := fake.createJobReturnsOnCall[len(fake.createJobArgsForCall)]
+	fake.createJobArgsForCall = append(fake.createJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.Job
+	}{arg1, arg2})
+	stub := fake.CreateJobStub
+	fakeReturns := fake.createJobReturns
+	fake.recordInvocation("CreateJob", []interface{}{arg1, arg2})
+	fake.createJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) CreateJobCallCount() int {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	return len(fake.createJobArgsForCall)
+}
+
+func (fake *FakeStorage) CreateJobCalls(stub func(context.Context, *storage.Job) error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = stub
+}
+
+func (fake *FakeStorage) CreateJobArgsForCall(i int) (context.Context, *storage.Job) {
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
+	argsForCall := fake.createJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) CreateJobReturns(result1 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	fake.createJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) CreateJobReturnsOnCall(i int, result1 error) {
+	fake.createJobMutex.Lock()
+	defer fake.createJobMutex.Unlock()
+	fake.CreateJobStub = nil
+	if fake.createJobReturnsOnCall == nil {
+		fake.createJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorage) CreateSubscriber(arg1 context.Context, arg2 *storage.Subscriber) (*storage.Subscriber, error) {
 	fake.createSubscriberMutex.Lock()
 	ret, specificReturn := fake.createSubscriberReturnsOnCall[len(fake.createSubscriberArgsForCall)]
@@ -615,19 +1062,22 @@ func (fake *FakeStorage) DeleteAutoImportConfigReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeStorage) DeleteData(arg1 context.Context, arg2 string) error {
-	fake.deleteDataMutex.Lock()
-	ret, specificReturn := fake.deleteDataReturnsOnCall[len(fake.deleteDataArgsForCall)]
-	fake.deleteDataArgsForCall = append(fake.deleteDataArgsForCall, struct {
+func (fake *FakeStorage) DeleteCapturedEvaluations(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.deleteCapturedEvaluationsMutex.Lock()
+	ret, specificReturn := fake.deleteCapturedEvaluationsReturnsOnCall[len(fake.deleteCapturedEvaluationsArgsForCall)]
+	fake.deleteCapturedEvaluationsArgsForCall = append(fake.deleteCapturedEvaluationsArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	stub := fake.DeleteDataStub
-	fakeReturns := fake.deleteDataReturns
-	fake.recordInvocation("DeleteData", []interface{}{arg1, arg2})
-	fake.deleteDataMutex.Unlock()
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DeleteCapturedEvaluationsStub
+	fakeReturns := fake.deleteCapturedEvaluationsReturns
+	fake.recordInvocation("DeleteCapturedEvaluations", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.deleteCapturedEvaluationsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -635,22 +1085,84 @@ func (fake *FakeStorage) DeleteData(arg1 context.Context, arg2 string) error {
 	return fakeReturns.result1
 }
 
-func (fake *FakeStorage) DeleteDataCallCount() int {
-	fake.deleteDataMutex.RLock()
-	defer fake.deleteDataMutex.RUnlock()
-	return len(fake.deleteDataArgsForCall)
+func (fake *FakeStorage) DeleteCapturedEvaluationsCallCount() int {
+	fake.deleteCapturedEvaluationsMutex.RLock()
+	defer fake.deleteCapturedEvaluationsMutex.RUnlock()
+	return len(fake.deleteCapturedEvaluationsArgsForCall)
 }
 
-func (fake *FakeStorage) DeleteDataCalls(stub func(context.Context, string) error) {
-	fake.deleteDataMutex.Lock()
-	defer fake.deleteDataMutex.Unlock()
-	fake.DeleteDataStub = stub
+func (fake *FakeStorage) DeleteCapturedEvaluationsCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.deleteCapturedEvaluationsMutex.Lock()
+	defer fake.deleteCapturedEvaluationsMutex.Unlock()
+	fake.DeleteCapturedEvaluationsStub = stub
 }
 
-func (fake *FakeStorage) DeleteDataArgsForCall(i int) (context.Context, string) {
-	fake.deleteDataMutex.RLock()
-	defer fake.deleteDataMutex.RUnlock()
-	argsForCall := fake.deleteDataArgsForCall[i]
+func (fake *FakeStorage) DeleteCapturedEvaluationsArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.deleteCapturedEvaluationsMutex.RLock()
+	defer fake.deleteCapturedEvaluationsMutex.RUnlock()
+	argsForCall := fake.deleteCapturedEvaluationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) DeleteCapturedEvaluationsReturns(result1 error) {
+	fake.deleteCapturedEvaluationsMutex.Lock()
+	defer fake.deleteCapturedEvaluationsMutex.Unlock()
+	fake.DeleteCapturedEvaluationsStub = nil
+	fake.deleteCapturedEvaluationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeleteCapturedEvaluationsReturnsOnCall(i int, result1 error) {
+	fake.deleteCapturedEvaluationsMutex.Lock()
+	defer fake.deleteCapturedEvaluationsMutex.Unlock()
+	fake.DeleteCapturedEvaluationsStub = nil
+	if fake.deleteCapturedEvaluationsReturnsOnCall == nil {
+		fake.deleteCapturedEvaluationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteCapturedEvaluationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeleteData(arg1 context.Context, arg2 string) error {
+	fake.deleteDataMutex.Lock()
+	ret, specificReturn := fake.deleteDataReturnsOnCall[len(fake.deleteDataArgsForCall)]
+	fake.deleteDataArgsForCall = append(fake.deleteDataArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteDataStub
+	fakeReturns := fake.deleteDataReturns
+	fake.recordInvocation("DeleteData", []interface{}{arg1, arg2})
+	fake.deleteDataMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeleteDataCallCount() int {
+	fake.deleteDataMutex.RLock()
+	defer fake.deleteDataMutex.RUnlock()
+	return len(fake.deleteDataArgsForCall)
+}
+
+func (fake *FakeStorage) DeleteDataCalls(stub func(context.Context, string) error) {
+	fake.deleteDataMutex.Lock()
+	defer fake.deleteDataMutex.Unlock()
+	fake.DeleteDataStub = stub
+}
+
+func (fake *FakeStorage) DeleteDataArgsForCall(i int) (context.Context, string) {
+	fake.deleteDataMutex.RLock()
+	defer fake.deleteDataMutex.RUnlock()
+	argsForCall := fake.deleteDataArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
@@ -677,6 +1189,133 @@ func (fake *FakeStorage) DeleteDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStorage) DeleteJobs(arg1 context.Context, arg2 time.Time) error {
+	fake.deleteJobsMutex.Lock()
+	ret, specificReturn := fake.deleteJobsReturnsOnCall[len(fake.deleteJobsArgsForCall)]
+	fake.deleteJobsArgsForCall = append(fake.deleteJobsArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.DeleteJobsStub
+	fakeReturns := fake.deleteJobsReturns
+	fake.recordInvocation("DeleteJobs", []interface{}{arg1, arg2})
+	fake.deleteJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeleteJobsCallCount() int {
+	fake.deleteJobsMutex.RLock()
+	defer fake.deleteJobsMutex.RUnlock()
+	return len(fake.deleteJobsArgsForCall)
+}
+
+func (fake *FakeStorage) DeleteJobsCalls(stub func(context.Context, time.Time) error) {
+	fake.deleteJobsMutex.Lock()
+	defer fake.deleteJobsMutex.Unlock()
+	fake.DeleteJobsStub = stub
+}
+
+func (fake *FakeStorage) DeleteJobsArgsForCall(i int) (context.Context, time.Time) {
+	fake.deleteJobsMutex.RLock()
+	defer fake.deleteJobsMutex.RUnlock()
+	argsForCall := fake.deleteJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) DeleteJobsReturns(result1 error) {
+	fake.deleteJobsMutex.Lock()
+	defer fake.deleteJobsMutex.Unlock()
+	fake.DeleteJobsStub = nil
+	fake.deleteJobsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeleteJobsReturnsOnCall(i int, result1 error) {
+	fake.deleteJobsMutex.Lock()
+	defer fake.deleteJobsMutex.Unlock()
+	fake.DeleteJobsStub = nil
+	if fake.deleteJobsReturnsOnCall == nil {
+		fake.deleteJobsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteJobsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeletePolicyAlias(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.deletePolicyAliasMutex.Lock()
+	ret, specificReturn := fake.deletePolicyAliasReturnsOnCall[len(fake.deletePolicyAliasArgsForCall)]
+	fake.deletePolicyAliasArgsForCall = append(fake.deletePolicyAliasArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DeletePolicyAliasStub
+	fakeReturns := fake.deletePolicyAliasReturns
+	fake.recordInvocation("DeletePolicyAlias", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.deletePolicyAliasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) DeletePolicyAliasCallCount() int {
+	fake.deletePolicyAliasMutex.RLock()
+	defer fake.deletePolicyAliasMutex.RUnlock()
+	return len(fake.deletePolicyAliasArgsForCall)
+}
+
+func (fake *FakeStorage) DeletePolicyAliasCalls(stub func(context.Context, string, string, string, string) error) {
+	fake.deletePolicyAliasMutex.Lock()
+	defer fake.deletePolicyAliasMutex.Unlock()
+	fake.DeletePolicyAliasStub = stub
+}
+
+func (fake *FakeStorage) DeletePolicyAliasArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.deletePolicyAliasMutex.RLock()
+	defer fake.deletePolicyAliasMutex.RUnlock()
+	argsForCall := fake.deletePolicyAliasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) DeletePolicyAliasReturns(result1 error) {
+	fake.deletePolicyAliasMutex.Lock()
+	defer fake.deletePolicyAliasMutex.Unlock()
+	fake.DeletePolicyAliasStub = nil
+	fake.deletePolicyAliasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) DeletePolicyAliasReturnsOnCall(i int, result1 error) {
+	fake.deletePolicyAliasMutex.Lock()
+	defer fake.deletePolicyAliasMutex.Unlock()
+	fake.DeletePolicyAliasStub = nil
+	if fake.deletePolicyAliasReturnsOnCall == nil {
+		fake.deletePolicyAliasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePolicyAliasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorage) GetData(arg1 context.Context, arg2 string) (any, error) {
 	fake.getDataMutex.Lock()
 	ret, specificReturn := fake.getDataReturnsOnCall[len(fake.getDataArgsForCall)]
@@ -808,6 +1447,71 @@ func (fake *FakeStorage) GetPoliciesReturnsOnCall(i int, result1 []*storage.Poli
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) Job(arg1 context.Context, arg2 string) (*storage.Job, error) {
+	fake.jobMutex.Lock()
+	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
+	fake.jobArgsForCall = append(fake.jobArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.JobStub
+	fakeReturns := fake.jobReturns
+	fake.recordInvocation("Job", []interface{}{arg1, arg2})
+	fake.jobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) JobCallCount() int {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	return len(fake.jobArgsForCall)
+}
+
+func (fake *FakeStorage) JobCalls(stub func(context.Context, string) (*storage.Job, error)) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = stub
+}
+
+func (fake *FakeStorage) JobArgsForCall(i int) (context.Context, string) {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	argsForCall := fake.jobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) JobReturns(result1 *storage.Job, result2 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	fake.jobReturns = struct {
+		result1 *storage.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) JobReturnsOnCall(i int, result1 *storage.Job, result2 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	if fake.jobReturnsOnCall == nil {
+		fake.jobReturnsOnCall = make(map[int]struct {
+			result1 *storage.Job
+			result2 error
+		})
+	}
+	fake.jobReturnsOnCall[i] = struct {
+		result1 *storage.Job
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) ListenPolicyDataChanges(arg1 context.Context) error {
 	fake.listenPolicyDataChangesMutex.Lock()
 	ret, specificReturn := fake.listenPolicyDataChangesReturnsOnCall[len(fake.listenPolicyDataChangesArgsForCall)]
@@ -937,6 +1641,273 @@ func (fake *FakeStorage) PolicyReturnsOnCall(i int, result1 *storage.Policy, res
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) PolicyAlias(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) (*storage.PolicyAlias, error) {
+	fake.policyAliasMutex.Lock()
+	ret, specificReturn := fake.policyAliasReturnsOnCall[len(fake.policyAliasArgsForCall)]
+	fake.policyAliasArgsForCall = append(fake.policyAliasArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PolicyAliasStub
+	fakeReturns := fake.policyAliasReturns
+	fake.recordInvocation("PolicyAlias", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.policyAliasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyAliasCallCount() int {
+	fake.policyAliasMutex.RLock()
+	defer fake.policyAliasMutex.RUnlock()
+	return len(fake.policyAliasArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyAliasCalls(stub func(context.Context, string, string, string, string) (*storage.PolicyAlias, error)) {
+	fake.policyAliasMutex.Lock()
+	defer fake.policyAliasMutex.Unlock()
+	fake.PolicyAliasStub = stub
+}
+
+func (fake *FakeStorage) PolicyAliasArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.policyAliasMutex.RLock()
+	defer fake.policyAliasMutex.RUnlock()
+	argsForCall := fake.policyAliasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeStorage) PolicyAliasReturns(result1 *storage.PolicyAlias, result2 error) {
+	fake.policyAliasMutex.Lock()
+	defer fake.policyAliasMutex.Unlock()
+	fake.PolicyAliasStub = nil
+	fake.policyAliasReturns = struct {
+		result1 *storage.PolicyAlias
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyAliasReturnsOnCall(i int, result1 *storage.PolicyAlias, result2 error) {
+	fake.policyAliasMutex.Lock()
+	defer fake.policyAliasMutex.Unlock()
+	fake.PolicyAliasStub = nil
+	if fake.policyAliasReturnsOnCall == nil {
+		fake.policyAliasReturnsOnCall = make(map[int]struct {
+			result1 *storage.PolicyAlias
+			result2 error
+		})
+	}
+	fake.policyAliasReturnsOnCall[i] = struct {
+		result1 *storage.PolicyAlias
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyAliases(arg1 context.Context, arg2 string, arg3 string, arg4 string) ([]*storage.PolicyAlias, error) {
+	fake.policyAliasesMutex.Lock()
+	ret, specificReturn := fake.policyAliasesReturnsOnCall[len(fake.policyAliasesArgsForCall)]
+	fake.policyAliasesArgsForCall = append(fake.policyAliasesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PolicyAliasesStub
+	fakeReturns := fake.policyAliasesReturns
+	fake.recordInvocation("PolicyAliases", []interface{}{arg1, arg2, arg3, arg4})
+	fake.policyAliasesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyAliasesCallCount() int {
+	fake.policyAliasesMutex.RLock()
+	defer fake.policyAliasesMutex.RUnlock()
+	return len(fake.policyAliasesArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyAliasesCalls(stub func(context.Context, string, string, string) ([]*storage.PolicyAlias, error)) {
+	fake.policyAliasesMutex.Lock()
+	defer fake.policyAliasesMutex.Unlock()
+	fake.PolicyAliasesStub = stub
+}
+
+func (fake *FakeStorage) PolicyAliasesArgsForCall(i int) (context.Context, string, string, string) {
+	fake.policyAliasesMutex.RLock()
+	defer fake.policyAliasesMutex.RUnlock()
+	argsForCall := fake.policyAliasesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStorage) PolicyAliasesReturns(result1 []*storage.PolicyAlias, result2 error) {
+	fake.policyAliasesMutex.Lock()
+	defer fake.policyAliasesMutex.Unlock()
+	fake.PolicyAliasesStub = nil
+	fake.policyAliasesReturns = struct {
+		result1 []*storage.PolicyAlias
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyAliasesReturnsOnCall(i int, result1 []*storage.PolicyAlias, result2 error) {
+	fake.policyAliasesMutex.Lock()
+	defer fake.policyAliasesMutex.Unlock()
+	fake.PolicyAliasesStub = nil
+	if fake.policyAliasesReturnsOnCall == nil {
+		fake.policyAliasesReturnsOnCall = make(map[int]struct {
+			result1 []*storage.PolicyAlias
+			result2 error
+		})
+	}
+	fake.policyAliasesReturnsOnCall[i] = struct {
+		result1 []*storage.PolicyAlias
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyVersions(arg1 context.Context, arg2 string, arg3 string, arg4 string) ([]string, error) {
+	fake.policyVersionsMutex.Lock()
+	ret, specificReturn := fake.policyVersionsReturnsOnCall[len(fake.policyVersionsArgsForCall)]
+	fake.policyVersionsArgsForCall = append(fake.policyVersionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PolicyVersionsStub
+	fakeReturns := fake.policyVersionsReturns
+	fake.recordInvocation("PolicyVersions", []interface{}{arg1, arg2, arg3, arg4})
+	fake.policyVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) PolicyVersionsCallCount() int {
+	fake.policyVersionsMutex.RLock()
+	defer fake.policyVersionsMutex.RUnlock()
+	return len(fake.policyVersionsArgsForCall)
+}
+
+func (fake *FakeStorage) PolicyVersionsCalls(stub func(context.Context, string, string, string) ([]string, error)) {
+	fake.policyVersionsMutex.Lock()
+	defer fake.policyVersionsMutex.Unlock()
+	fake.PolicyVersionsStub = stub
+}
+
+func (fake *FakeStorage) PolicyVersionsArgsForCall(i int) (context.Context, string, string, string) {
+	fake.policyVersionsMutex.RLock()
+	defer fake.policyVersionsMutex.RUnlock()
+	argsForCall := fake.policyVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStorage) PolicyVersionsReturns(result1 []string, result2 error) {
+	fake.policyVersionsMutex.Lock()
+	defer fake.policyVersionsMutex.Unlock()
+	fake.PolicyVersionsStub = nil
+	fake.policyVersionsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) PolicyVersionsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.policyVersionsMutex.Lock()
+	defer fake.policyVersionsMutex.Unlock()
+	fake.PolicyVersionsStub = nil
+	if fake.policyVersionsReturnsOnCall == nil {
+		fake.policyVersionsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.policyVersionsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) ResumableJobs(arg1 context.Context, arg2 time.Time) ([]*storage.Job, error) {
+	fake.resumableJobsMutex.Lock()
+	ret, specificReturn := fake.resumableJobsReturnsOnCall[len(fake.resumableJobsArgsForCall)]
+	fake.resumableJobsArgsForCall = append(fake.resumableJobsArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.ResumableJobsStub
+	fakeReturns := fake.resumableJobsReturns
+	fake.recordInvocation("ResumableJobs", []interface{}{arg1, arg2})
+	fake.resumableJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) ResumableJobsCallCount() int {
+	fake.resumableJobsMutex.RLock()
+	defer fake.resumableJobsMutex.RUnlock()
+	return len(fake.resumableJobsArgsForCall)
+}
+
+func (fake *FakeStorage) ResumableJobsCalls(stub func(context.Context, time.Time) ([]*storage.Job, error)) {
+	fake.resumableJobsMutex.Lock()
+	defer fake.resumableJobsMutex.Unlock()
+	fake.ResumableJobsStub = stub
+}
+
+func (fake *FakeStorage) ResumableJobsArgsForCall(i int) (context.Context, time.Time) {
+	fake.resumableJobsMutex.RLock()
+	defer fake.resumableJobsMutex.RUnlock()
+	argsForCall := fake.resumableJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) ResumableJobsReturns(result1 []*storage.Job, result2 error) {
+	fake.resumableJobsMutex.Lock()
+	defer fake.resumableJobsMutex.Unlock()
+	fake.ResumableJobsStub = nil
+	fake.resumableJobsReturns = struct {
+		result1 []*storage.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) ResumableJobsReturnsOnCall(i int, result1 []*storage.Job, result2 error) {
+	fake.resumableJobsMutex.Lock()
+	defer fake.resumableJobsMutex.Unlock()
+	fake.ResumableJobsStub = nil
+	if fake.resumableJobsReturnsOnCall == nil {
+		fake.resumableJobsReturnsOnCall = make(map[int]struct {
+			result1 []*storage.Job
+			result2 error
+		})
+	}
+	fake.resumableJobsReturnsOnCall[i] = struct {
+		result1 []*storage.Job
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) SaveAutoImportConfig(arg1 context.Context, arg2 *storage.PolicyAutoImport) error {
 	fake.saveAutoImportConfigMutex.Lock()
 	ret, specificReturn := fake.saveAutoImportConfigReturnsOnCall[len(fake.saveAutoImportConfigArgsForCall)]
@@ -999,6 +1970,72 @@ func (fake *FakeStorage) SaveAutoImportConfigReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeStorage) SaveCapturedEvaluation(arg1 context.Context, arg2 *storage.CapturedEvaluation, arg3 int) (bool, error) {
+	fake.saveCapturedEvaluationMutex.Lock()
+	ret, specificReturn := fake.saveCapturedEvaluationReturnsOnCall[len(fake.saveCapturedEvaluationArgsForCall)]
+	fake.saveCapturedEvaluationArgsForCall = append(fake.saveCapturedEvaluationArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.CapturedEvaluation
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.SaveCapturedEvaluationStub
+	fakeReturns := fake.saveCapturedEvaluationReturns
+	fake.recordInvocation("SaveCapturedEvaluation", []interface{}{arg1, arg2, arg3})
+	fake.saveCapturedEvaluationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStorage) SaveCapturedEvaluationCallCount() int {
+	fake.saveCapturedEvaluationMutex.RLock()
+	defer fake.saveCapturedEvaluationMutex.RUnlock()
+	return len(fake.saveCapturedEvaluationArgsForCall)
+}
+
+func (fake *FakeStorage) SaveCapturedEvaluationCalls(stub func(context.Context, *storage.CapturedEvaluation, int) (bool, error)) {
+	fake.saveCapturedEvaluationMutex.Lock()
+	defer fake.saveCapturedEvaluationMutex.Unlock()
+	fake.SaveCapturedEvaluationStub = stub
+}
+
+func (fake *FakeStorage) SaveCapturedEvaluationArgsForCall(i int) (context.Context, *storage.CapturedEvaluation, int) {
+	fake.saveCapturedEvaluationMutex.RLock()
+	defer fake.saveCapturedEvaluationMutex.RUnlock()
+	argsForCall := fake.saveCapturedEvaluationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStorage) SaveCapturedEvaluationReturns(result1 bool, result2 error) {
+	fake.saveCapturedEvaluationMutex.Lock()
+	defer fake.saveCapturedEvaluationMutex.Unlock()
+	fake.SaveCapturedEvaluationStub = nil
+	fake.saveCapturedEvaluationReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SaveCapturedEvaluationReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.saveCapturedEvaluationMutex.Lock()
+	defer fake.saveCapturedEvaluationMutex.Unlock()
+	fake.SaveCapturedEvaluationStub = nil
+	if fake.saveCapturedEvaluationReturnsOnCall == nil {
+		fake.saveCapturedEvaluationReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.saveCapturedEvaluationReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStorage) SavePolicy(arg1 context.Context, arg2 *storage.Policy) error {
 	fake.savePolicyMutex.Lock()
 	ret, specificReturn := fake.savePolicyReturnsOnCall[len(fake.savePolicyArgsForCall)]
@@ -1061,6 +2098,130 @@ func (fake *FakeStorage) SavePolicyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStorage) SavePolicyAlias(arg1 context.Context, arg2 *storage.PolicyAlias) error {
+	fake.savePolicyAliasMutex.Lock()
+	ret, specificReturn := fake.savePolicyAliasReturnsOnCall[len(fake.savePolicyAliasArgsForCall)]
+	fake.savePolicyAliasArgsForCall = append(fake.savePolicyAliasArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.PolicyAlias
+	}{arg1, arg2})
+	stub := fake.SavePolicyAliasStub
+	fakeReturns := fake.savePolicyAliasReturns
+	fake.recordInvocation("SavePolicyAlias", []interface{}{arg1, arg2})
+	fake.savePolicyAliasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SavePolicyAliasCallCount() int {
+	fake.savePolicyAliasMutex.RLock()
+	defer fake.savePolicyAliasMutex.RUnlock()
+	return len(fake.savePolicyAliasArgsForCall)
+}
+
+func (fake *FakeStorage) SavePolicyAliasCalls(stub func(context.Context, *storage.PolicyAlias) error) {
+	fake.savePolicyAliasMutex.Lock()
+	defer fake.savePolicyAliasMutex.Unlock()
+	fake.SavePolicyAliasStub = stub
+}
+
+func (fake *FakeStorage) SavePolicyAliasArgsForCall(i int) (context.Context, *storage.PolicyAlias) {
+	fake.savePolicyAliasMutex.RLock()
+	defer fake.savePolicyAliasMutex.RUnlock()
+	argsForCall := fake.savePolicyAliasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SavePolicyAliasReturns(result1 error) {
+	fake.savePolicyAliasMutex.Lock()
+	defer fake.savePolicyAliasMutex.Unlock()
+	fake.SavePolicyAliasStub = nil
+	fake.savePolicyAliasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SavePolicyAliasReturnsOnCall(i int, result1 error) {
+	fake.savePolicyAliasMutex.Lock()
+	defer fake.savePolicyAliasMutex.Unlock()
+	fake.SavePolicyAliasStub = nil
+	if fake.savePolicyAliasReturnsOnCall == nil {
+		fake.savePolicyAliasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.savePolicyAliasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SaveShadowDivergence(arg1 context.Context, arg2 *storage.ShadowDivergence) error {
+	fake.saveShadowDivergenceMutex.Lock()
+	ret, specificReturn := fake.saveShadowDivergenceReturnsOnCall[len(fake.saveShadowDivergenceArgsForCall)]
+	fake.saveShadowDivergenceArgsForCall = append(fake.saveShadowDivergenceArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.ShadowDivergence
+	}{arg1, arg2})
+	stub := fake.SaveShadowDivergenceStub
+	fakeReturns := fake.saveShadowDivergenceReturns
+	fake.recordInvocation("SaveShadowDivergence", []interface{}{arg1, arg2})
+	fake.saveShadowDivergenceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SaveShadowDivergenceCallCount() int {
+	fake.saveShadowDivergenceMutex.RLock()
+	defer fake.saveShadowDivergenceMutex.RUnlock()
+	return len(fake.saveShadowDivergenceArgsForCall)
+}
+
+func (fake *FakeStorage) SaveShadowDivergenceCalls(stub func(context.Context, *storage.ShadowDivergence) error) {
+	fake.saveShadowDivergenceMutex.Lock()
+	defer fake.saveShadowDivergenceMutex.Unlock()
+	fake.SaveShadowDivergenceStub = stub
+}
+
+func (fake *FakeStorage) SaveShadowDivergenceArgsForCall(i int) (context.Context, *storage.ShadowDivergence) {
+	fake.saveShadowDivergenceMutex.RLock()
+	defer fake.saveShadowDivergenceMutex.RUnlock()
+	argsForCall := fake.saveShadowDivergenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) SaveShadowDivergenceReturns(result1 error) {
+	fake.saveShadowDivergenceMutex.Lock()
+	defer fake.saveShadowDivergenceMutex.Unlock()
+	fake.SaveShadowDivergenceStub = nil
+	fake.saveShadowDivergenceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SaveShadowDivergenceReturnsOnCall(i int, result1 error) {
+	fake.saveShadowDivergenceMutex.Lock()
+	defer fake.saveShadowDivergenceMutex.Unlock()
+	fake.SaveShadowDivergenceStub = nil
+	if fake.saveShadowDivergenceReturnsOnCall == nil {
+		fake.saveShadowDivergenceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveShadowDivergenceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorage) SetData(arg1 context.Context, arg2 string, arg3 map[string]interface{}) error {
 	fake.setDataMutex.Lock()
 	ret, specificReturn := fake.setDataReturnsOnCall[len(fake.setDataArgsForCall)]
@@ -1124,7 +2285,73 @@ func (fake *FakeStorage) SetDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 bool) error {
+func (fake *FakeStorage) SetPolicyCapture(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 *storage.CaptureConfig) error {
+	fake.setPolicyCaptureMutex.Lock()
+	ret, specificReturn := fake.setPolicyCaptureReturnsOnCall[len(fake.setPolicyCaptureArgsForCall)]
+	fake.setPolicyCaptureArgsForCall = append(fake.setPolicyCaptureArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 *storage.CaptureConfig
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.SetPolicyCaptureStub
+	fakeReturns := fake.setPolicyCaptureReturns
+	fake.recordInvocation("SetPolicyCapture", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.setPolicyCaptureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SetPolicyCaptureCallCount() int {
+	fake.setPolicyCaptureMutex.RLock()
+	defer fake.setPolicyCaptureMutex.RUnlock()
+	return len(fake.setPolicyCaptureArgsForCall)
+}
+
+func (fake *FakeStorage) SetPolicyCaptureCalls(stub func(context.Context, string, string, string, string, *storage.CaptureConfig) error) {
+	fake.setPolicyCaptureMutex.Lock()
+	defer fake.setPolicyCaptureMutex.Unlock()
+	fake.SetPolicyCaptureStub = stub
+}
+
+func (fake *FakeStorage) SetPolicyCaptureArgsForCall(i int) (context.Context, string, string, string, string, *storage.CaptureConfig) {
+	fake.setPolicyCaptureMutex.RLock()
+	defer fake.setPolicyCaptureMutex.RUnlock()
+	argsForCall := fake.setPolicyCaptureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) SetPolicyCaptureReturns(result1 error) {
+	fake.setPolicyCaptureMutex.Lock()
+	defer fake.setPolicyCaptureMutex.Unlock()
+	fake.SetPolicyCaptureStub = nil
+	fake.setPolicyCaptureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyCaptureReturnsOnCall(i int, result1 error) {
+	fake.setPolicyCaptureMutex.Lock()
+	defer fake.setPolicyCaptureMutex.Unlock()
+	fake.SetPolicyCaptureStub = nil
+	if fake.setPolicyCaptureReturnsOnCall == nil {
+		fake.setPolicyCaptureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPolicyCaptureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 *storage.LockEvent) (bool, error) {
 	fake.setPolicyLockMutex.Lock()
 	ret, specificReturn := fake.setPolicyLockReturnsOnCall[len(fake.setPolicyLockArgsForCall)]
 	fake.setPolicyLockArgsForCall = append(fake.setPolicyLockArgsForCall, struct {
@@ -1133,7 +2360,7 @@ func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 s
 		arg3 string
 		arg4 string
 		arg5 string
-		arg6 bool
+		arg6 *storage.LockEvent
 	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.SetPolicyLockStub
 	fakeReturns := fake.setPolicyLockReturns
@@ -1143,9 +2370,9 @@ func (fake *FakeStorage) SetPolicyLock(arg1 context.Context, arg2 string, arg3 s
 		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeStorage) SetPolicyLockCallCount() int {
@@ -1154,38 +2381,107 @@ func (fake *FakeStorage) SetPolicyLockCallCount() int {
 	return len(fake.setPolicyLockArgsForCall)
 }
 
-func (fake *FakeStorage) SetPolicyLockCalls(stub func(context.Context, string, string, string, string, bool) error) {
+func (fake *FakeStorage) SetPolicyLockCalls(stub func(context.Context, string, string, string, string, *storage.LockEvent) (bool, error)) {
 	fake.setPolicyLockMutex.Lock()
 	defer fake.setPolicyLockMutex.Unlock()
 	fake.SetPolicyLockStub = stub
 }
 
-func (fake *FakeStorage) SetPolicyLockArgsForCall(i int) (context.Context, string, string, string, string, bool) {
+func (fake *FakeStorage) SetPolicyLockArgsForCall(i int) (context.Context, string, string, string, string, *storage.LockEvent) {
 	fake.setPolicyLockMutex.RLock()
 	defer fake.setPolicyLockMutex.RUnlock()
 	argsForCall := fake.setPolicyLockArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
-func (fake *FakeStorage) SetPolicyLockReturns(result1 error) {
+func (fake *FakeStorage) SetPolicyLockReturns(result1 bool, result2 error) {
 	fake.setPolicyLockMutex.Lock()
 	defer fake.setPolicyLockMutex.Unlock()
 	fake.SetPolicyLockStub = nil
 	fake.setPolicyLockReturns = struct {
-		result1 error
-	}{result1}
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeStorage) SetPolicyLockReturnsOnCall(i int, result1 error) {
+func (fake *FakeStorage) SetPolicyLockReturnsOnCall(i int, result1 bool, result2 error) {
 	fake.setPolicyLockMutex.Lock()
 	defer fake.setPolicyLockMutex.Unlock()
 	fake.SetPolicyLockStub = nil
 	if fake.setPolicyLockReturnsOnCall == nil {
 		fake.setPolicyLockReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 bool
+			result2 error
 		})
 	}
 	fake.setPolicyLockReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStorage) SetPolicyShadow(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
+	fake.setPolicyShadowMutex.Lock()
+	ret, specificReturn := fake.setPolicyShadowReturnsOnCall[len(fake.setPolicyShadowArgsForCall)]
+	fake.setPolicyShadowArgsForCall = append(fake.setPolicyShadowArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.SetPolicyShadowStub
+	fakeReturns := fake.setPolicyShadowReturns
+	fake.recordInvocation("SetPolicyShadow", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.setPolicyShadowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) SetPolicyShadowCallCount() int {
+	fake.setPolicyShadowMutex.RLock()
+	defer fake.setPolicyShadowMutex.RUnlock()
+	return len(fake.setPolicyShadowArgsForCall)
+}
+
+func (fake *FakeStorage) SetPolicyShadowCalls(stub func(context.Context, string, string, string, string, string) error) {
+	fake.setPolicyShadowMutex.Lock()
+	defer fake.setPolicyShadowMutex.Unlock()
+	fake.SetPolicyShadowStub = stub
+}
+
+func (fake *FakeStorage) SetPolicyShadowArgsForCall(i int) (context.Context, string, string, string, string, string) {
+	fake.setPolicyShadowMutex.RLock()
+	defer fake.setPolicyShadowMutex.RUnlock()
+	argsForCall := fake.setPolicyShadowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeStorage) SetPolicyShadowReturns(result1 error) {
+	fake.setPolicyShadowMutex.Lock()
+	defer fake.setPolicyShadowMutex.Unlock()
+	fake.SetPolicyShadowStub = nil
+	fake.setPolicyShadowReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) SetPolicyShadowReturnsOnCall(i int, result1 error) {
+	fake.setPolicyShadowMutex.Lock()
+	defer fake.setPolicyShadowMutex.Unlock()
+	fake.SetPolicyShadowStub = nil
+	if fake.setPolicyShadowReturnsOnCall == nil {
+		fake.setPolicyShadowReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPolicyShadowReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -1260,6 +2556,68 @@ func (fake *FakeStorage) SubscriberReturnsOnCall(i int, result1 *storage.Subscri
 	}{result1, result2}
 }
 
+func (fake *FakeStorage) UpdateJob(arg1 context.Context, arg2 *storage.Job) error {
+	fake.updateJobMutex.Lock()
+	ret, specificReturn := fake.updateJobReturnsOnCall[len(fake.updateJobArgsForCall)]
+	fake.updateJobArgsForCall = append(fake.updateJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 *storage.Job
+	}{arg1, arg2})
+	stub := fake.UpdateJobStub
+	fakeReturns := fake.updateJobReturns
+	fake.recordInvocation("UpdateJob", []interface{}{arg1, arg2})
+	fake.updateJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStorage) UpdateJobCallCount() int {
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
+	return len(fake.updateJobArgsForCall)
+}
+
+func (fake *FakeStorage) UpdateJobCalls(stub func(context.Context, *storage.Job) error) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = stub
+}
+
+func (fake *FakeStorage) UpdateJobArgsForCall(i int) (context.Context, *storage.Job) {
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
+	argsForCall := fake.updateJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStorage) UpdateJobReturns(result1 error) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = nil
+	fake.updateJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStorage) UpdateJobReturnsOnCall(i int, result1 error) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = nil
+	if fake.updateJobReturnsOnCall == nil {
+		fake.updateJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStorage) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1271,32 +2629,66 @@ func (fake *FakeStorage) Invocations() map[string][][]interface{} {
 	defer fake.autoImportConfigMutex.RUnlock()
 	fake.autoImportConfigsMutex.RLock()
 	defer fake.autoImportConfigsMutex.RUnlock()
+	fake.capturedEvaluationsMutex.RLock()
+	defer fake.capturedEvaluationsMutex.RUnlock()
+	fake.claimJobMutex.RLock()
+	defer fake.claimJobMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
+	fake.createJobMutex.RLock()
+	defer fake.createJobMutex.RUnlock()
 	fake.createSubscriberMutex.RLock()
 	defer fake.createSubscriberMutex.RUnlock()
 	fake.deleteAutoImportConfigMutex.RLock()
 	defer fake.deleteAutoImportConfigMutex.RUnlock()
+	fake.deleteCapturedEvaluationsMutex.RLock()
+	defer fake.deleteCapturedEvaluationsMutex.RUnlock()
 	fake.deleteDataMutex.RLock()
 	defer fake.deleteDataMutex.RUnlock()
+	fake.deleteJobsMutex.RLock()
+	defer fake.deleteJobsMutex.RUnlock()
+	fake.deletePolicyAliasMutex.RLock()
+	defer fake.deletePolicyAliasMutex.RUnlock()
 	fake.getDataMutex.RLock()
 	defer fake.getDataMutex.RUnlock()
 	fake.getPoliciesMutex.RLock()
 	defer fake.getPoliciesMutex.RUnlock()
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
 	fake.listenPolicyDataChangesMutex.RLock()
 	defer fake.listenPolicyDataChangesMutex.RUnlock()
 	fake.policyMutex.RLock()
 	defer fake.policyMutex.RUnlock()
+	fake.policyAliasMutex.RLock()
+	defer fake.policyAliasMutex.RUnlock()
+	fake.policyAliasesMutex.RLock()
+	defer fake.policyAliasesMutex.RUnlock()
+	fake.policyVersionsMutex.RLock()
+	defer fake.policyVersionsMutex.RUnlock()
+	fake.resumableJobsMutex.RLock()
+	defer fake.resumableJobsMutex.RUnlock()
 	fake.saveAutoImportConfigMutex.RLock()
 	defer fake.saveAutoImportConfigMutex.RUnlock()
+	fake.saveCapturedEvaluationMutex.RLock()
+	defer fake.saveCapturedEvaluationMutex.RUnlock()
 	fake.savePolicyMutex.RLock()
 	defer fake.savePolicyMutex.RUnlock()
+	fake.savePolicyAliasMutex.RLock()
+	defer fake.savePolicyAliasMutex.RUnlock()
+	fake.saveShadowDivergenceMutex.RLock()
+	defer fake.saveShadowDivergenceMutex.RUnlock()
 	fake.setDataMutex.RLock()
 	defer fake.setDataMutex.RUnlock()
+	fake.setPolicyCaptureMutex.RLock()
+	defer fake.setPolicyCaptureMutex.RUnlock()
 	fake.setPolicyLockMutex.RLock()
 	defer fake.setPolicyLockMutex.RUnlock()
+	fake.setPolicyShadowMutex.RLock()
+	defer fake.setPolicyShadowMutex.RUnlock()
 	fake.subscriberMutex.RLock()
 	defer fake.subscriberMutex.RUnlock()
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
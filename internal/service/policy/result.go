@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const (
+	// defaultMaxResultWait limits the long-polling of evaluation results.
+	// It must be lower than the write timeout of the HTTP server.
+	defaultMaxResultWait = 8 * time.Second
+
+	// resultPollInterval is the interval between two cache lookups
+	// while waiting for the result of a policy evaluation.
+	resultPollInterval = 500 * time.Millisecond
+)
+
+// EvaluationResult returns the result of a policy evaluation, which is stored
+// in the cache under the evaluationID. If the result is not yet stored and the
+// request sets 'wait', the cache is polled until the result is stored or the
+// wait time is over. This allows a client to collect the result of an evaluation
+// started by another system, which sets the evaluationID with x-evaluation-id.
+func (s *Service) EvaluationResult(ctx context.Context, req *policy.EvaluationResultRequest) (*policy.StoredEvaluationResult, error) {
+	logger := s.logger.With(
+		zap.String("operation", "evaluationResult"),
+		zap.String("evaluationID", req.EvaluationID),
+	)
+
+	var wait time.Duration
+	if req.Wait != nil {
+		wait = min(time.Duration(*req.Wait)*time.Second, s.maxResultWait)
+	}
+	deadline := time.Now().Add(wait)
+
+	var value []byte
+	for {
+		var err error
+		value, err = s.cache.Get(ctx, req.EvaluationID, "", "")
+		if err == nil {
+			break
+		}
+		if !errors.Is(errors.NotFound, err) {
+			logger.Error("error getting evaluation result from cache", zap.Error(err))
+			return nil, errors.New("error getting evaluation result from cache", err)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, errors.New(errors.NotFound, "evaluation result not found")
+		}
+
+		timer := time.NewTimer(min(resultPollInterval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.New("waiting for evaluation result is cancelled", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	var result any
+	if err := json.Unmarshal(value, &result); err != nil {
+		logger.Error("error decoding evaluation result", zap.Error(err))
+		return nil, errors.New(errors.Internal, "error decoding evaluation result", err)
+	}
+
+	return &policy.StoredEvaluationResult{
+		Result: result,
+		ETag:   req.EvaluationID,
+	}, nil
+}
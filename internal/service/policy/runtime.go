@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policydata"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+// runtimeConfig contains the evaluation settings of a single policy,
+// which are defined in the 'policy-config.json' file of the policy.
+type runtimeConfig struct {
+	// Timeout limits the duration of a policy evaluation, e.g. '5s'.
+	Timeout policydata.Duration `json:"timeout"`
+	// MaxInputSize limits the size of the JSON encoded input in bytes.
+	MaxInputSize int `json:"maxInputSize"`
+	// DefaultTTL is the cache TTL in seconds of the evaluation
+	// result when the request doesn't specify it.
+	DefaultTTL *int `json:"defaultTTL"`
+	// RequiredHeaders must be present in every evaluation request.
+	RequiredHeaders []string `json:"requiredHeaders"`
+	// Cache specifies whether the evaluation result is stored in cache.
+	// Results are stored in cache if it's not set.
+	Cache *bool `json:"cache"`
+}
+
+// runtimeConfigValue is the name of the parsed runtime
+// configuration of a policy in the policy cache.
+const runtimeConfigValue = "runtimeConfig"
+
+// inputLimitKey marks the contexts of requests, whose body size
+// was limited to the maximum input size of the evaluated policy.
+type inputLimitKey struct{}
+
+// runtimeConfig returns the runtime configuration of a policy, which is
+// parsed only once for every cached policy.
+func (s *Service) runtimeConfig(p *storage.Policy) (*runtimeConfig, error) {
+	key := s.queryCacheKey(p.Repository, p.Group, p.Name, p.Version)
+	if cfg, ok := s.policyCache.GetValue(key, runtimeConfigValue, p); ok {
+		return cfg.(*runtimeConfig), nil
+	}
+
+	cfg, err := policyRuntimeConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	s.policyCache.SetValue(key, runtimeConfigValue, p, cfg)
+
+	return cfg, nil
+}
+
+// InputLimit is an HTTP server middleware of the evaluation endpoints, which
+// limits the size of the request body to the maximum input size of the policy
+// in the request path, so that larger inputs are rejected while they're decoded.
+// The path parameters are taken from the request with the vars function.
+func (s *Service) InputLimit(vars func(*http.Request) map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := vars(r)
+			if limit := s.maxInputSize(r.Context(), v["repository"], v["group"], v["policyName"], v["version"]); limit > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, int64(limit))
+				r = r.WithContext(context.WithValue(r.Context(), inputLimitKey{}, true))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxInputSize returns the maximum input size of a policy. Zero is returned
+// if the policy can't be retrieved, so that the evaluation reports the error.
+func (s *Service) maxInputSize(ctx context.Context, repository, group, name, version string) int {
+	version, err := s.resolveVersion(ctx, repository, group, name, version)
+	if err != nil {
+		return 0
+	}
+
+	pol, err := s.retrievePolicy(ctx, repository, group, name, version)
+	if err != nil {
+		return 0
+	}
+
+	cfg, err := s.runtimeConfig(pol)
+	if err != nil {
+		return 0
+	}
+
+	return cfg.MaxInputSize
+}
+
+// policyRuntimeConfig returns the runtime configuration of a policy. Policies
+// without configuration file get an empty configuration with default behavior.
+func policyRuntimeConfig(p *storage.Policy) (*runtimeConfig, error) {
+	var cfg runtimeConfig
+	if strings.TrimSpace(p.RuntimeConfig) == "" {
+		return &cfg, nil
+	}
+
+	if err := json.Unmarshal([]byte(p.RuntimeConfig), &cfg); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal policy runtime configuration: %v", err)
+	}
+
+	return &cfg, nil
+}
+
+// checkRequest verifies that the evaluation request input and headers
+// satisfy the limits and requirements of the runtime configuration.
+// The input size is only checked if it wasn't limited by the transport,
+// e.g. for gRPC requests and batch items.
+func (c *runtimeConfig) checkRequest(ctx context.Context, input any) error {
+	if limited, _ := ctx.Value(inputLimitKey{}).(bool); c.MaxInputSize > 0 && !limited {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return errors.New(errors.BadRequest, "error encoding policy input", err)
+		}
+		if len(data) > c.MaxInputSize {
+			return errors.New(errors.BadRequest, fmt.Sprintf("policy input exceeds the maximum size of %d bytes", c.MaxInputSize))
+		}
+	}
+
+	if len(c.RequiredHeaders) > 0 {
+		headers, _ := header.FromContext(ctx)
+		for _, name := range c.RequiredHeaders {
+			if headers[http.CanonicalHeaderKey(name)] == "" {
+				return errors.New(errors.BadRequest, fmt.Sprintf("required request header is missing: %s", name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// evalContext returns the context for evaluating the policy query,
+// which is cancelled when the evaluation timeout is reached.
+func (c *runtimeConfig) evalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(c.Timeout))
+}
+
+// ttl returns the cache TTL of the evaluation result.
+func (c *runtimeConfig) ttl(requestTTL *int) int {
+	if requestTTL != nil {
+		return *requestTTL
+	}
+	if c.DefaultTTL != nil {
+		return *c.DefaultTTL
+	}
+	return 0
+}
+
+// cacheResult reports whether the evaluation result is stored in cache.
+func (c *runtimeConfig) cacheResult() bool {
+	return c.Cache == nil || *c.Cache
+}
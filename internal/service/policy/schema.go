@@ -0,0 +1,163 @@
+package policy
+
+import (
+	goerrors "errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+const (
+	// schemaResource is the URL of the compiled schema, which
+	// is only used for resolving references inside the schema.
+	schemaResource = "schema.json"
+
+	// inputSchemaValue and outputSchemaValue are the names
+	// of the compiled schemas of a policy in the policy cache.
+	inputSchemaValue  = "inputSchema"
+	outputSchemaValue = "outputSchema"
+)
+
+// schemaViolation is a single failed constraint of a JSON schema validation.
+type schemaViolation struct {
+	// InstanceLocation is the JSON pointer of the invalid value.
+	InstanceLocation string `json:"instanceLocation"`
+	// Keyword is the name of the failed schema keyword, e.g. 'minimum'.
+	Keyword string `json:"keyword"`
+	// KeywordLocation is the JSON pointer of the failed schema keyword.
+	KeywordLocation string `json:"keywordLocation"`
+	Message         string `json:"message"`
+}
+
+func (v schemaViolation) String() string {
+	return fmt.Sprintf("'%s': %s", v.InstanceLocation, v.Message)
+}
+
+// schemaValidationError is returned when a value doesn't conform to
+// a JSON schema. The violations are returned to the caller as error details.
+type schemaValidationError struct {
+	violations []schemaViolation
+}
+
+func (e *schemaValidationError) Error() string {
+	return formatViolations(e.violations)
+}
+
+// Details returns the violations of the schema validation.
+func (e *schemaValidationError) Details() any {
+	return e.violations
+}
+
+// compileSchema compiles a JSON schema together with its annotations,
+// so that default values of the schema can be applied.
+func compileSchema(schema string) (*jsonschema.Schema, error) {
+	c := jsonschema.NewCompiler()
+	c.ExtractAnnotations = true
+	if err := c.AddResource(schemaResource, strings.NewReader(schema)); err != nil {
+		return nil, err
+	}
+	return c.Compile(schemaResource)
+}
+
+// policySchema returns a compiled schema of a policy, which is compiled only
+// once for every cached policy and is kept in the cache under the given name.
+func (s *Service) policySchema(p *storage.Policy, name, schema string) (*jsonschema.Schema, error) {
+	key := s.queryCacheKey(p.Repository, p.Group, p.Name, p.Version)
+	if sch, ok := s.policyCache.GetValue(key, name, p); ok {
+		return sch.(*jsonschema.Schema), nil
+	}
+
+	sch, err := compileSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	s.policyCache.SetValue(key, name, p, sch)
+
+	return sch, nil
+}
+
+// validateValue validates a JSON value against a compiled JSON schema
+// and returns all violated constraints.
+func validateValue(sch *jsonschema.Schema, value any) ([]schemaViolation, error) {
+	err := sch.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !goerrors.As(err, &verr) {
+		return nil, err
+	}
+
+	return collectViolations(verr, nil), nil
+}
+
+// collectViolations returns the leaf errors of the validation error tree,
+// as they describe the actual failed constraints.
+func collectViolations(verr *jsonschema.ValidationError, violations []schemaViolation) []schemaViolation {
+	if len(verr.Causes) == 0 {
+		return append(violations, schemaViolation{
+			InstanceLocation: verr.InstanceLocation,
+			Keyword:          path.Base(verr.KeywordLocation),
+			KeywordLocation:  verr.KeywordLocation,
+			Message:          verr.Message,
+		})
+	}
+
+	for _, cause := range verr.Causes {
+		violations = collectViolations(cause, violations)
+	}
+
+	return violations
+}
+
+// formatViolations returns a human readable list of the violations.
+func formatViolations(violations []schemaViolation) string {
+	s := make([]string, len(violations))
+	for i, v := range violations {
+		s[i] = v.String()
+	}
+	return strings.Join(s, "; ")
+}
+
+// applyDefaults sets the default values of the schema properties which
+// are missing in the value. Objects of the value are modified in place.
+func applyDefaults(sch *jsonschema.Schema, value any) any {
+	if sch == nil {
+		return value
+	}
+
+	if value == nil && sch.Default != nil {
+		value = sch.Default
+	}
+
+	if sch.Ref != nil {
+		value = applyDefaults(sch.Ref, value)
+	}
+	for _, s := range sch.AllOf {
+		value = applyDefaults(s, value)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, prop := range sch.Properties {
+			if pv := applyDefaults(prop, v[name]); pv != nil {
+				v[name] = pv
+			}
+		}
+	case []interface{}:
+		items := sch.Items2020
+		if s, ok := sch.Items.(*jsonschema.Schema); ok {
+			items = s
+		}
+		for i := range v {
+			v[i] = applyDefaults(items, v[i])
+		}
+	}
+
+	return value
+}
@@ -5,22 +5,29 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage/inmem"
-	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
-	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/policytest"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/tracing"
 	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
 )
@@ -29,19 +36,54 @@ import (
 //go:generate counterfeiter . Storage
 //go:generate counterfeiter . RegoCache
 //go:generate counterfeiter . Signer
+//go:generate counterfeiter . DecisionLog
+//go:generate counterfeiter . DecisionStorage
+//go:generate counterfeiter . AuditLog
 
 const (
 	BundleFilename          = "policy_bundle.zip"
 	BundleSignatureFilename = "signature.raw"
+
+	defaultBatchConcurrency = 10
+	defaultMaxBatchSize     = 1000
+
+	// libraryFolder is the folder of the shared library
+	// modules in the compiled policy module filenames.
+	libraryFolder = "library"
 )
 
+var ruleSegmentRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// errUndefinedResult is the cause of the errors returned without wrapping
+// when the evaluated policy package or rule is undefined for the given input.
+var errUndefinedResult = goerrors.New("policy evaluation results are empty")
+
+// newUndefinedError returns an error of an undefined evaluation result
+// with its own ID, so that every failed evaluation can be traced.
+func newUndefinedError() error {
+	return errors.New(errUndefinedResult)
+}
+
+// IsUndefined reports whether an evaluation failed because
+// the result of the evaluated policy package or rule is undefined.
+func IsUndefined(err error) bool {
+	var e *errors.Error
+	return goerrors.As(err, &e) && goerrors.Is(e.Err, errUndefinedResult)
+}
+
 type Cache interface {
 	Set(ctx context.Context, key, namespace, scope string, value []byte, ttl int) error
+	Get(ctx context.Context, key, namespace, scope string) ([]byte, error)
 }
 
 type RegoCache interface {
 	Set(key string, policy *storage.Policy)
 	Get(key string) (policy *storage.Policy, found bool)
+	Delete(key string)
+	SetQuery(key, rule string, policy *storage.Policy, query *rego.PreparedEvalQuery)
+	GetQuery(key, rule string) (query *rego.PreparedEvalQuery, found bool)
+	SetValue(key, name string, policy *storage.Policy, value any)
+	GetValue(key, name string, policy *storage.Policy) (value any, found bool)
 }
 
 type Signer interface {
@@ -49,6 +91,17 @@ type Signer interface {
 	Sign(ctx context.Context, namespace string, key string, data []byte) ([]byte, error)
 }
 
+// DecisionLog records the decisions of policy evaluations.
+type DecisionLog interface {
+	Log(decision *storage.Decision)
+}
+
+// AuditLog records the state-changing operations of the service.
+type AuditLog interface {
+	Record(ctx context.Context, record *storage.AuditRecord)
+	Records(ctx context.Context, filter *storage.AuditFilter) ([]*storage.AuditRecord, error)
+}
+
 type Service struct {
 	storage        Storage
 	policyCache    RegoCache
@@ -58,6 +111,63 @@ type Service struct {
 	validationLock bool
 	logger         *zap.Logger
 
+	// libraries are rego modules which are compiled
+	// together with every policy.
+	libraries []storage.Module
+
+	// versions caches the resolved version aliases and ranges.
+	versions *versionCache
+
+	// tester runs the tests of imported policies.
+	tester *policytest.Tester
+
+	// explainCheck reports whether the caller is allowed to request
+	// explained evaluations. If it's nil, explaining is not allowed.
+	explainCheck func(ctx context.Context) bool
+
+	// decisionLog is optional and records every policy evaluation.
+	decisionLog DecisionLog
+	// decisionStorage is optional and is used to query
+	// the records of the decision log.
+	decisionStorage DecisionStorage
+
+	// auditLog is optional and records the state-changing operations.
+	auditLog AuditLog
+
+	// subscribers are subscribed for the policy changes of the storage
+	// together with the service if subscribe is set.
+	subscribers []storage.PolicySubscriber
+	subscribe   bool
+
+	// batchConcurrency limits the number of concurrently
+	// executed evaluations of a batch request.
+	batchConcurrency int
+	// maxBatchSize limits the number of items of a batch request.
+	maxBatchSize int
+
+	// shadowConcurrency limits the number of concurrently executed
+	// shadow evaluations. The evaluations are counted in shadowLimit.
+	shadowConcurrency int
+	shadowLimit       chan struct{}
+
+	// maxResultWait limits the time a request waits for
+	// the result of a policy evaluation to be stored.
+	maxResultWait time.Duration
+
+	// jobWorkers is the number of workers executing asynchronous
+	// evaluation jobs. If it's zero, asynchronous evaluation is disabled.
+	jobWorkers   int
+	jobQueueSize int
+	jobQueue     chan jobTask
+	// jobRetention is the time finished jobs are kept in storage.
+	jobRetention time.Duration
+	// jobTimeout limits the duration of a job.
+	jobTimeout time.Duration
+	// callbackHosts are the hosts allowed in job callback URLs. If it's
+	// empty, all hosts with public addresses are allowed.
+	callbackHosts  []string
+	callbackClient *http.Client
+
 	// externalHostname specifies the hostname where the policy service can be
 	// reached from the public internet. This setting is very important for
 	// export/import of policy bundles, as the policy service must include the
@@ -77,16 +187,43 @@ func New(
 	importPollInterval time.Duration,
 	httpClient *http.Client,
 	logger *zap.Logger,
+	opts ...Option,
 ) *Service {
 	svc := &Service{
-		storage:          storage,
-		policyCache:      policyCache,
-		cache:            cache,
-		signer:           signer,
-		httpClient:       httpClient,
-		validationLock:   validationLock,
-		logger:           logger,
-		externalHostname: hostname,
+		storage:           storage,
+		policyCache:       policyCache,
+		cache:             cache,
+		signer:            signer,
+		httpClient:        httpClient,
+		validationLock:    validationLock,
+		logger:            logger,
+		externalHostname:  hostname,
+		batchConcurrency:  defaultBatchConcurrency,
+		maxBatchSize:      defaultMaxBatchSize,
+		shadowConcurrency: defaultShadowConcurrency,
+		maxResultWait:     defaultMaxResultWait,
+		jobQueueSize:      defaultJobQueueSize,
+		jobRetention:      defaultJobRetention,
+		jobTimeout:        defaultJobTimeout,
+		versions:          newVersionCache(),
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	svc.shadowLimit = make(chan struct{}, svc.shadowConcurrency)
+	svc.tester = policytest.New(svc.libraries, regofunc.Declarations())
+
+	// the caches must be invalidated by the changes of the
+	// background processes, so they are subscribed first
+	if svc.subscribe {
+		storage.AddPolicySubscribers(append(svc.subscribers, svc)...)
+	}
+
+	if svc.jobWorkers > 0 {
+		svc.callbackClient = newCallbackClient(svc.callbackHosts)
+		svc.startJobs(ctx)
 	}
 
 	// start process to auto import policy bundles
@@ -103,7 +240,82 @@ func New(
 // Evaluating the URL: `.../policies/mygroup/example/1.0/evaluation` will
 // return results correctly, only if the package declaration inside the policy is:
 // `package mygroup.example`.
-func (s *Service) Evaluate(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error) {
+func (s *Service) Evaluate(ctx context.Context, req *policy.EvaluateRequest) (res *policy.EvaluateResult, err error) {
+	ctx, span := tracing.Start(ctx, "policy.Evaluate", tracing.PolicyAttributes(req.Repository, req.Group, req.PolicyName, req.Version)...)
+	defer func() { tracing.End(span, err) }()
+
+	if req.Async != nil && *req.Async {
+		return s.submitJob(ctx, req)
+	}
+
+	exp, err := s.newExplanation(ctx, req.Explain)
+	if err != nil {
+		s.logger.Error("error evaluating policy", zap.Error(err), zap.String("operation", "evaluate"))
+		return nil, err
+	}
+
+	res, err = s.evaluate(ctx, req, exp, nil, evalDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	// the explanation is added after the result is stored in cache,
+	// so that traces are only returned to the caller
+	if exp != nil {
+		res.Result = exp.wrap(res.Result)
+	}
+
+	return res, nil
+}
+
+// Authorize evaluates a policy which authorizes a request to the service itself.
+// Unlike Evaluate, the result isn't stored in cache and the evaluation is neither
+// captured nor evaluated with the shadow version, so that authorizing a request
+// has no side effects besides the decision log and the metrics.
+func (s *Service) Authorize(ctx context.Context, req *policy.EvaluateRequest) (res *policy.EvaluateResult, err error) {
+	ctx, span := tracing.Start(ctx, "policy.Authorize", tracing.PolicyAttributes(req.Repository, req.Group, req.PolicyName, req.Version)...)
+	defer func() { tracing.End(span, err) }()
+
+	return s.evaluate(ctx, req, nil, nil, evalInternal)
+}
+
+// EvaluateUncached evaluates a policy like Evaluate, but without storing the
+// result in cache. It's used for evaluations whose result is never retrieved
+// by the evaluation ID, like the authorization checks of a proxy.
+func (s *Service) EvaluateUncached(ctx context.Context, req *policy.EvaluateRequest) (res *policy.EvaluateResult, err error) {
+	ctx, span := tracing.Start(ctx, "policy.EvaluateUncached", tracing.PolicyAttributes(req.Repository, req.Group, req.PolicyName, req.Version)...)
+	defer func() { tracing.End(span, err) }()
+
+	return s.evaluate(ctx, req, nil, nil, evalUncached)
+}
+
+// resultHook transforms the result of a policy evaluation before it's
+// recorded in the decision log, stored in cache and returned.
+type resultHook func(pol *storage.Policy, result any) (any, error)
+
+// evalMode selects the side effects of a policy evaluation besides
+// the decision log and the metrics.
+type evalMode int
+
+const (
+	// evalDefault stores the result in cache and captures and
+	// evaluates the evaluation in shadow if configured.
+	evalDefault evalMode = iota
+	// evalUncached doesn't store the result in cache.
+	evalUncached
+	// evalInternal doesn't store the result in cache and the
+	// evaluation is neither captured nor evaluated in shadow.
+	evalInternal
+)
+
+// evaluate executes a policy and stores the result in cache. If exp is not nil,
+// the evaluation trace and printed messages are collected in it. If hook is not
+// nil, the result is replaced with the value returned by the hook. The mode
+// selects whether the result is stored in cache and the evaluation is captured
+// and evaluated in shadow.
+func (s *Service) evaluate(ctx context.Context, req *policy.EvaluateRequest, exp *explanation, hook resultHook, mode evalMode) (res *policy.EvaluateResult, err error) {
+	start := time.Now()
+
 	var evaluationID string
 	if req.EvaluationID != nil && *req.EvaluationID != "" {
 		evaluationID = *req.EvaluationID
@@ -120,32 +332,152 @@ func (s *Service) Evaluate(ctx context.Context, req *policy.EvaluateRequest) (*p
 		zap.String("evaluationID", evaluationID),
 	)
 
-	headers, _ := header.FromContext(ctx)
-	query, err := s.prepareQuery(ctx, req.Repository, req.Group, req.PolicyName, req.Version, headers)
+	var pol *storage.Policy
+	defer func() {
+		duration := time.Since(start)
+		observeEvaluation(pol, err, duration)
+		s.logDecision(ctx, req, evaluationID, pol, res, err, duration)
+	}()
+
+	var rule string
+	if req.Rule != nil {
+		rule = *req.Rule
+	}
+
+	version, err := s.resolveVersion(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error resolving policy version", zap.Error(err))
+		return nil, errors.New("error evaluating policy", err)
+	}
+
+	query, pol, err := s.prepareQuery(ctx, req.Repository, req.Group, req.PolicyName, version, rule)
 	if err != nil {
 		logger.Error("error getting prepared query", zap.Error(err))
 		return nil, errors.New("error evaluating policy", err)
 	}
 
-	resultSet, err := query.Eval(ctx, rego.EvalInput(req.Input))
+	cfg, err := s.runtimeConfig(pol)
+	if err != nil {
+		logger.Error("error getting policy runtime configuration", zap.Error(err))
+		return nil, errors.New("error getting policy runtime configuration", err)
+	}
+
+	if err = cfg.checkRequest(ctx, req.Input); err != nil {
+		logger.Error("evaluation request is not allowed by policy runtime configuration", zap.Error(err))
+		return nil, err
+	}
+
+	if pol.InputSchema != "" {
+		sch, err := s.policySchema(pol, inputSchemaValue, pol.InputSchema)
+		if err != nil {
+			logger.Error("error compiling input validation schema", zap.Error(err))
+			return nil, errors.New("error compiling input validation schema", err)
+		}
+		violations, err := validateValue(sch, req.Input)
+		if err != nil {
+			logger.Error("error validating policy input", zap.Error(err))
+			return nil, errors.New("error validating policy input", err)
+		}
+		if len(violations) > 0 {
+			metrics.ValidationFailures.With(metrics.With(evaluationLabels(pol), "schema", "input")).Inc()
+			logger.Error("policy input schema validation failed", zap.String("violations", formatViolations(violations)))
+			return nil, errors.New(errors.BadRequest, "policy input schema validation failed", &schemaValidationError{violations: violations})
+		}
+	}
+
+	evalOpts := []rego.EvalOption{rego.EvalInput(req.Input)}
+	if exp != nil {
+		evalOpts = append(evalOpts, exp.evalOptions()...)
+	}
+
+	evalCtx, cancel := cfg.evalContext(ctx)
+	defer cancel()
+
+	// the extension function calls of captured evaluations are recorded, so
+	// that the evaluations can be replayed later, and the calls of evaluations
+	// with a shadow version are recorded to answer the calls of the shadow
+	capture := mode != evalInternal && s.captureSampled(pol)
+	shadow := mode != evalInternal && pol.ShadowVersion != ""
+	var recorder *regofunc.Recorder
+	if capture || shadow {
+		evalCtx, recorder = regofunc.WithRecorder(evalCtx)
+	}
+
+	evalCtx, evalSpan := tracing.Start(evalCtx, "rego.Eval")
+	evalStart := time.Now()
+	resultSet, err := query.Eval(evalCtx, evalOpts...)
+	evalDuration := time.Since(evalStart)
+	tracing.End(evalSpan, err)
+	if capture {
+		s.captureEvaluation(ctx, pol, req, evaluationID, recorder, resultSet, err, evalDuration)
+	}
 	if err != nil {
+		if evalCtx.Err() == context.DeadlineExceeded {
+			logger.Error("policy evaluation timed out", zap.Error(err))
+			return nil, errors.New(errors.Timeout, "policy evaluation timed out", err)
+		}
 		logger.Error("error evaluating rego query", zap.Error(err))
 		return nil, errors.New("error evaluating rego query", err)
 	}
 
+	result, err := evaluationResult(resultSet)
+	if err != nil {
+		logger.Error("error getting policy evaluation result", zap.Error(err))
+		return nil, err
+	}
+
+	jsonValue, err := json.Marshal(result)
+	if err != nil {
+		logger.Error("error encoding result to json", zap.Error(err))
+		return nil, errors.New("error encoding result to json")
+	}
+
+	// the shadow version is compared with the result of the policy itself
+	policyResult := jsonValue
+	if hook != nil {
+		if result, err = hook(pol, result); err != nil {
+			return nil, err
+		}
+		if jsonValue, err = json.Marshal(result); err != nil {
+			logger.Error("error encoding result to json", zap.Error(err))
+			return nil, errors.New("error encoding result to json")
+		}
+	}
+
+	if mode == evalDefault && cfg.cacheResult() {
+		err = s.cache.Set(ctx, evaluationID, "", "", jsonValue, cfg.ttl(req.TTL))
+		if err != nil {
+			// if the cache service is not available, don't stop but continue with returning the result
+			if !errors.Is(errors.ServiceUnavailable, err) {
+				logger.Error("error storing policy result in cache", zap.Error(err))
+				return nil, errors.New("error storing policy result in cache")
+			}
+		}
+	}
+
+	if shadow {
+		s.evaluateShadow(ctx, pol, req, evaluationID, policyResult, recorder.Calls())
+	}
+
+	return &policy.EvaluateResult{
+		Result:  result,
+		ETag:    evaluationID,
+		Version: version,
+	}, nil
+}
+
+// evaluationResult returns the value of the first expression of the query results.
+// If there is only a single result from the policy evaluation and it was assigned to an empty
+// variable, then only the value of the empty variable is returned without any mapping.
+func evaluationResult(resultSet rego.ResultSet) (any, error) {
 	if len(resultSet) == 0 {
-		logger.Error("policy evaluation results are empty")
-		return nil, errors.New("policy evaluation results are empty")
+		return nil, newUndefinedError()
 	}
 
 	if len(resultSet[0].Expressions) == 0 {
-		logger.Error("policy evaluation result expressions are empty")
 		return nil, errors.New("policy evaluation result expressions are empty")
 	}
 
-	// If there is only a single result from the policy evaluation and it was assigned to an empty
-	// variable, then we'll return a custom response containing only the value of the empty variable
-	// without any mapping.
 	result := resultSet[0].Expressions[0].Value
 	if resultMap, ok := result.(map[string]interface{}); ok {
 		if len(resultMap) == 1 {
@@ -157,35 +489,27 @@ func (s *Service) Evaluate(ctx context.Context, req *policy.EvaluateRequest) (*p
 		}
 	}
 
-	jsonValue, err := json.Marshal(result)
-	if err != nil {
-		logger.Error("error encoding result to json", zap.Error(err))
-		return nil, errors.New("error encoding result to json")
-	}
-
-	var ttl int
-	if req.TTL != nil {
-		ttl = *req.TTL
-	}
+	return result, nil
+}
 
-	err = s.cache.Set(ctx, evaluationID, "", "", jsonValue, ttl)
-	if err != nil {
-		// if the cache service is not available, don't stop but continue with returning the result
-		if !errors.Is(errors.ServiceUnavailable, err) {
-			logger.Error("error storing policy result in cache", zap.Error(err))
-			return nil, errors.New("error storing policy result in cache")
-		}
+// EvaluateRule executes a policy with the given input and returns
+// only the value of the requested rule path inside the policy package.
+func (s *Service) EvaluateRule(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error) {
+	if req.Rule == nil || strings.Trim(*req.Rule, "/") == "" {
+		s.logger.Error("rule path is not specified", zap.String("operation", "evaluateRule"))
+		return nil, errors.New(errors.BadRequest, "rule path is not specified")
 	}
 
-	return &policy.EvaluateResult{
-		Result: result,
-		ETag:   evaluationID,
-	}, nil
+	return s.Evaluate(ctx, req)
 }
 
 // Validate executes a policy with given input and then validates the output against
-// a predefined JSON schema.
-func (s *Service) Validate(ctx context.Context, req *policy.EvaluateRequest) (*policy.EvaluateResult, error) {
+// a predefined JSON schema. The validated output, with the applied default values
+// or wrapped in the validation report, is the result which is stored in cache.
+func (s *Service) Validate(ctx context.Context, req *policy.EvaluateRequest) (res *policy.EvaluateResult, err error) {
+	ctx, span := tracing.Start(ctx, "policy.Validate", tracing.PolicyAttributes(req.Repository, req.Group, req.PolicyName, req.Version)...)
+	defer func() { tracing.End(span, err) }()
+
 	logger := s.logger.With(
 		zap.String("operation", "validate"),
 		zap.String("repository", req.Repository),
@@ -194,8 +518,14 @@ func (s *Service) Validate(ctx context.Context, req *policy.EvaluateRequest) (*p
 		zap.String("version", req.Version),
 	)
 
+	version, err := s.resolveVersion(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error resolving policy version", zap.Error(err))
+		return nil, errors.New("error retrieving policy", err)
+	}
+
 	// retrieve policy
-	pol, err := s.retrievePolicy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	pol, err := s.retrievePolicy(ctx, req.Repository, req.Group, req.PolicyName, version)
 	if err != nil {
 		logger.Error("error retrieving policy", zap.Error(err))
 		return nil, errors.New("error retrieving policy", err)
@@ -206,33 +536,88 @@ func (s *Service) Validate(ctx context.Context, req *policy.EvaluateRequest) (*p
 		return nil, errors.New(errors.BadRequest, "validation schema for policy output is not found")
 	}
 
-	// evaluate the policy and get the result
-	res, err := s.Evaluate(ctx, req)
+	exp, err := s.newExplanation(ctx, req.Explain)
 	if err != nil {
+		logger.Error("error validating policy", zap.Error(err))
 		return nil, err
 	}
 
+	// evaluate the policy and validate the result before it's stored
+	res, err = s.evaluate(ctx, req, exp, func(pol *storage.Policy, result any) (any, error) {
+		return s.validateOutput(ctx, req, pol, result, logger)
+	}, evalDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	if exp != nil {
+		res.Result = exp.wrap(res.Result)
+	}
+
+	return res, nil
+}
+
+// validateOutput validates the result of a policy evaluation against the output
+// schema of the policy and returns the validated result. The default values of
+// the schema are applied to the result if requested. When a validation report
+// is requested, the verdict is returned together with the result.
+func (s *Service) validateOutput(ctx context.Context, req *policy.EvaluateRequest, pol *storage.Policy, result any, logger *zap.Logger) (any, error) {
+	if pol.OutputSchema == "" {
+		logger.Error("validation schema for policy output is not found")
+		return nil, errors.New(errors.BadRequest, "validation schema for policy output is not found")
+	}
+
 	// compile the validation schema
-	sch, err := jsonschema.CompileString("", pol.OutputSchema)
+	sch, err := s.policySchema(pol, outputSchemaValue, pol.OutputSchema)
 	if err != nil {
 		logger.Error("error compiling output validation schema", zap.Error(err))
 		return nil, errors.New("error compiling output validation schema")
 	}
 
+	// apply the schema default values to the policy output if requested
+	if req.Coerce != nil && *req.Coerce {
+		result = applyDefaults(sch, result)
+	}
+
 	// validate the policy output
-	if err := sch.Validate(res.Result); err != nil {
+	violations, err := validateValue(sch, result)
+	if err != nil {
+		logger.Error("error validating policy output", zap.Error(err))
+		return nil, errors.New("error validating policy output", err)
+	}
+
+	report := req.Report != nil && *req.Report
+	if len(violations) > 0 {
+		metrics.ValidationFailures.With(metrics.With(metrics.PolicyLabels(pol.Repository, pol.Group, pol.Name, pol.Version), "schema", "output")).Inc()
+
 		// lock the policy for execution if configured
 		if s.validationLock {
-			if err := s.lock(ctx, pol); err != nil {
+			event := newLockEvent(ctx, true, storage.LockSourceValidation, "policy output schema validation failed: "+formatViolations(violations))
+			if err := s.lock(ctx, pol, event); err != nil {
 				logger.Error("error locking policy after validation failure", zap.Error(err))
 			}
 		}
 
-		logger.Error("policy output schema validation failed", zap.Error(err))
-		return nil, errors.New(errors.Unknown, "policy output schema validation failed", err)
+		logger.Error("policy output schema validation failed", zap.String("violations", formatViolations(violations)))
+		if !report {
+			return nil, errors.New(errors.Unknown, "policy output schema validation failed", &schemaValidationError{violations: violations})
+		}
 	}
 
-	return res, nil
+	// the validation verdict is returned together with the result
+	// instead of an error when a validation report is requested
+	if report {
+		if violations == nil {
+			violations = []schemaViolation{}
+		}
+		result = map[string]interface{}{
+			"result": result,
+			"valid":  len(violations) == 0,
+			"errors": violations,
+		}
+	}
+
+	return result, nil
 }
 
 // Lock a policy so that it cannot be evaluated.
@@ -254,7 +639,17 @@ func (s *Service) Lock(ctx context.Context, req *policy.LockRequest) error {
 		return errors.New("error locking policy", err)
 	}
 
-	if err := s.lock(ctx, pol); err != nil {
+	event := newLockEvent(ctx, true, storage.LockSourceManual, stringValue(req.Reason))
+	if req.UnlockAt != nil {
+		unlockAt := time.Unix(*req.UnlockAt, 0)
+		if !unlockAt.After(time.Now()) {
+			logger.Error("unlock time is not in the future")
+			return errors.New(errors.BadRequest, "unlock time must be in the future")
+		}
+		event.UnlockAt = &unlockAt
+	}
+
+	if err := s.lock(ctx, pol, event); err != nil {
 		logger.Error("error locking policy", zap.Error(err))
 		return err
 	}
@@ -264,14 +659,21 @@ func (s *Service) Lock(ctx context.Context, req *policy.LockRequest) error {
 	return nil
 }
 
-func (s *Service) lock(ctx context.Context, p *storage.Policy) error {
+func (s *Service) lock(ctx context.Context, p *storage.Policy, event *storage.LockEvent) error {
 	if p.Locked {
 		return errors.New(errors.Forbidden, "policy is already locked")
 	}
 
-	if err := s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, true); err != nil {
+	locked, err := s.storage.SetPolicyLock(ctx, p.Repository, p.Group, p.Name, p.Version, event)
+	if err != nil {
 		return errors.New("error locking policy", err)
 	}
+	if !locked {
+		// the policy was locked concurrently
+		return errors.New(errors.Forbidden, "policy is already locked")
+	}
+
+	s.auditPolicy(ctx, storage.AuditLock, p, withLocked(p, true))
 
 	return nil
 }
@@ -299,10 +701,18 @@ func (s *Service) Unlock(ctx context.Context, req *policy.UnlockRequest) error {
 		return errors.New(errors.Forbidden, "policy is unlocked")
 	}
 
-	if err := s.storage.SetPolicyLock(ctx, req.Repository, req.Group, req.PolicyName, req.Version, false); err != nil {
+	event := newLockEvent(ctx, false, storage.LockSourceManual, stringValue(req.Reason))
+	unlocked, err := s.storage.SetPolicyLock(ctx, req.Repository, req.Group, req.PolicyName, req.Version, event)
+	if err != nil {
 		logger.Error("error unlocking policy", zap.Error(err))
 		return errors.New("error unlocking policy", err)
 	}
+	if !unlocked {
+		// the policy was unlocked concurrently
+		return errors.New(errors.Forbidden, "policy is unlocked")
+	}
+
+	s.auditPolicy(ctx, storage.AuditUnlock, pol, withLocked(pol, false))
 
 	logger.Debug("policy is unlocked")
 
@@ -416,7 +826,10 @@ func (s *Service) PolicyPublicKey(ctx context.Context, req *policy.PolicyPublicK
 }
 
 // ImportBundle imports a signed policy bundle.
-func (s *Service) ImportBundle(ctx context.Context, _ *policy.ImportBundlePayload, payload io.ReadCloser) (any, error) {
+func (s *Service) ImportBundle(ctx context.Context, _ *policy.ImportBundlePayload, payload io.ReadCloser) (res any, err error) {
+	ctx, span := tracing.Start(ctx, "policy.ImportBundle")
+	defer func() { tracing.End(span, err) }()
+
 	logger := s.logger.With(zap.String("operation", "importBundle"))
 	defer payload.Close() //nolint:errcheck
 
@@ -450,11 +863,36 @@ func (s *Service) ImportBundle(ctx context.Context, _ *policy.ImportBundlePayloa
 		return nil, errors.New("cannot make policy from bundle", err)
 	}
 
+	// policies whose tests fail are stored locked, so that
+	// they can't be evaluated until the tests are fixed
+	if policytest.HasTests(policy) {
+		policy.TestReport = s.tester.Run(ctx, policy)
+		if !policy.TestReport.Passed {
+			logger.Warn("policy tests failed, the imported policy is locked",
+				zap.Int("failed", policy.TestReport.Failed),
+				zap.String("error", policy.TestReport.Error),
+			)
+			policy.Locked = true
+			policy.Lock = newLockEvent(ctx, true, storage.LockSourceImport, "policy tests failed")
+		}
+	}
+	if policy.Locked && policy.Lock == nil {
+		policy.Lock = newLockEvent(ctx, true, storage.LockSourceImport, "policy is locked in the imported bundle")
+	}
+
+	// the stored revision is only needed for the audit log
+	before, err := s.storage.Policy(ctx, policy.Repository, policy.Group, policy.Name, policy.Version)
+	if err != nil && !errors.Is(errors.NotFound, err) {
+		logger.Warn("error getting stored policy for the audit log", zap.Error(err))
+	}
+
 	if err := s.storage.SavePolicy(ctx, policy); err != nil {
 		logger.Error("error saving imported policy bundle", zap.Error(err))
 		return nil, errors.New("error saving imported policy bundle", err)
 	}
 
+	s.auditPolicy(ctx, storage.AuditImportBundle, before, policy)
+
 	return map[string]interface{}{
 		"repository": policy.Repository,
 		"group":      policy.Group,
@@ -480,16 +918,29 @@ func (s *Service) SetPolicyAutoImport(ctx context.Context, req *policy.SetPolicy
 		return nil, errors.New(errors.BadRequest, fmt.Sprintf("invalid interval definition: %v", err))
 	}
 
-	err = s.storage.SaveAutoImportConfig(ctx, &storage.PolicyAutoImport{
+	// the stored configuration is only needed for the audit log
+	before, err := s.storage.AutoImportConfig(ctx, req.PolicyURL)
+	if err != nil && !errors.Is(errors.NotFound, err) {
+		logger.Warn("error getting auto import configuration for the audit log", zap.Error(err))
+	}
+
+	config := &storage.PolicyAutoImport{
 		PolicyURL:  req.PolicyURL,
 		Interval:   interval,
 		NextImport: time.Now().Add(interval),
-	})
-	if err != nil {
+	}
+	if err := s.storage.SaveAutoImportConfig(ctx, config); err != nil {
 		logger.Error("error saving auto import configuration", zap.Error(err))
 		return nil, errors.New("error saving auto import configuration", err)
 	}
 
+	s.audit(ctx, &storage.AuditRecord{
+		Operation:  storage.AuditSetPolicyAutoImport,
+		Target:     req.PolicyURL,
+		BeforeHash: autoImportHash(before),
+		AfterHash:  autoImportHash(config),
+	})
+
 	return map[string]string{
 		"policyURL": req.PolicyURL,
 		"interval":  req.Interval,
@@ -534,6 +985,12 @@ func (s *Service) DeletePolicyAutoImport(ctx context.Context, req *policy.Delete
 		return nil, errors.New("failed to delete auto import configuration", err)
 	}
 
+	s.audit(ctx, &storage.AuditRecord{
+		Operation:  storage.AuditDeletePolicyAutoImport,
+		Target:     req.PolicyURL,
+		BeforeHash: autoImportHash(config),
+	})
+
 	return map[string]string{
 		"policyURL": config.PolicyURL,
 		"interval":  config.Interval.String(),
@@ -561,8 +1018,22 @@ func (s *Service) ListPolicies(ctx context.Context, req *policy.PoliciesRequest)
 			LastUpdate: p.LastUpdate.Unix(),
 		}
 
+		if p.ShadowVersion != "" {
+			policy.ShadowVersion = ptr.String(p.ShadowVersion)
+		}
+
+		if p.Locked && p.Lock != nil {
+			policy.Lock = toPolicyLockEvent(p.Lock)
+		}
+
 		if req.Rego != nil && *req.Rego {
-			policy.Rego = ptr.String(p.Rego)
+			if rego, ok := p.Module(storage.PolicyFilename); ok {
+				policy.Rego = ptr.String(rego)
+			}
+			policy.Modules = make(map[string]string, len(p.Modules))
+			for _, m := range p.Modules {
+				policy.Modules[m.Filename] = m.Rego
+			}
 		}
 
 		if req.Data != nil && *req.Data {
@@ -609,62 +1080,117 @@ func (s *Service) SubscribeForPolicyChange(ctx context.Context, req *policy.Subs
 		return nil, err
 	}
 
+	s.audit(ctx, &storage.AuditRecord{
+		Operation:  storage.AuditSubscribe,
+		Repository: req.Repository,
+		Group:      req.Group,
+		Name:       req.PolicyName,
+		Version:    req.Version,
+		Target:     req.WebhookURL,
+		AfterHash:  audit.Hash(map[string]string{"name": req.Subscriber, "webhookURL": req.WebhookURL}),
+	})
+
 	return subscriber, nil
 }
 
 // prepareQuery tries to get a prepared query from the regocache.
 // If the policyCache entry is not found, it will try to prepare a new
 // query and will set it into the policyCache for future use.
-func (s *Service) prepareQuery(ctx context.Context, repository, group, policyName, version string, headers map[string]string) (*rego.PreparedEvalQuery, error) {
+// The policy of the query is also returned. If rule is not empty,
+// the query returns only the value of the rule inside the policy package.
+func (s *Service) prepareQuery(ctx context.Context, repository, group, policyName, version, rule string) (_ *rego.PreparedEvalQuery, _ *storage.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "policy.prepareQuery", tracing.PolicyAttributes(repository, group, policyName, version)...)
+	defer func() { tracing.End(span, err) }()
+
 	// retrieve policy
 	pol, err := s.retrievePolicy(ctx, repository, group, policyName, version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	labels := metrics.PolicyLabels(repository, group, policyName, version)
+
 	// if policy is locked, return an error
-	if pol.Locked {
-		return nil, errors.New(errors.Forbidden, "policy is locked")
+	if err := s.checkLock(ctx, pol); err != nil {
+		metrics.LockRejections.With(labels).Inc()
+		return nil, pol, err
 	}
 
+	key := s.queryCacheKey(repository, group, policyName, version)
+	if query, ok := s.policyCache.GetQuery(key, rule); ok {
+		metrics.CacheHits.With(metrics.With(labels, "entry", "query")).Inc()
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return query, pol, nil
+	}
+	metrics.CacheMisses.With(metrics.With(labels, "entry", "query")).Inc()
+
+	start := time.Now()
+
 	// regoQuery must match both the package declaration inside the policy
 	// and the group and policy name.
 	regoQuery := fmt.Sprintf("data.%s.%s", group, policyName)
+	if rule != "" {
+		rulePath, err := rulePath(rule)
+		if err != nil {
+			return nil, pol, err
+		}
+		regoQuery += rulePath
+	}
 
 	// regoArgs contains all rego functions passed to evaluation runtime
-	regoArgs, err := s.buildRegoArgs(pol.Filename, pol.Rego, regoQuery, pol.Data)
+	regoArgs, err := s.buildRegoArgs(pol, regoQuery, regofunc.List())
 	if err != nil {
-		return nil, errors.New("error building rego runtime functions", err)
+		return nil, pol, errors.New("error building rego runtime functions", err)
 	}
 
-	// Append dynamically the external.http.header function on every request,
-	// because it is populated with different headers each time.
-	regoArgs = append(regoArgs, rego.Function1(regofunc.GetHeaderFunc(headers)))
-
 	newQuery, err := rego.New(
 		regoArgs...,
 	).PrepareForEval(ctx)
 	if err != nil {
-		return nil, errors.New("error preparing rego query", err)
+		return nil, pol, errors.New("error preparing rego query", err)
 	}
 
-	return &newQuery, nil
+	metrics.PrepareDuration.With(labels).Observe(time.Since(start).Seconds())
+
+	s.policyCache.SetQuery(key, rule, pol, &newQuery)
+
+	return &newQuery, pol, nil
 }
 
-func (s *Service) buildRegoArgs(filename, regoPolicy, regoQuery, regoData string) (availableFuncs []func(*rego.Rego), err error) {
-	availableFuncs = make([]func(*rego.Rego), 3)
-	availableFuncs[0] = rego.Module(filename, regoPolicy)
-	availableFuncs[1] = rego.Query(regoQuery)
-	availableFuncs[2] = rego.StrictBuiltinErrors(true)
-	extensionFuncs := regofunc.List()
+// buildRegoArgs returns the arguments for compiling the policy modules together
+// with the shared library modules and for executing the given query with the
+// given implementations of the extension functions.
+func (s *Service) buildRegoArgs(pol *storage.Policy, regoQuery string, extensionFuncs []regofunc.RegoFunc) (availableFuncs []func(*rego.Rego), err error) {
+	availableFuncs = make([]func(*rego.Rego), 4)
+	availableFuncs[0] = rego.Query(regoQuery)
+	availableFuncs[1] = rego.StrictBuiltinErrors(true)
+	// external.http.header reads the request headers from the evaluation
+	// context, so that the prepared query can be reused between requests.
+	availableFuncs[2] = regofunc.Function1(regofunc.GetHeaderFunc())
+	// print() output is discarded unless an evaluation is explained
+	availableFuncs[3] = rego.EnablePrintStatements(true)
+
+	// module filenames are unique for every policy, so that the
+	// compiler errors show where the failing module is located
+	policyFolder := path.Dir(pol.Filename)
+	if pol.Filename == "" {
+		policyFolder = path.Join(pol.Group, pol.Name, pol.Version)
+	}
+	for _, m := range pol.Modules {
+		availableFuncs = append(availableFuncs, rego.Module(path.Join(policyFolder, m.Filename), m.Rego))
+	}
+	for _, m := range s.libraries {
+		availableFuncs = append(availableFuncs, rego.Module(path.Join(libraryFolder, m.Filename), m.Rego))
+	}
+
 	for i := range extensionFuncs {
 		availableFuncs = append(availableFuncs, extensionFuncs[i])
 	}
 
 	// add static data to evaluation runtime
-	if regoData != "" {
+	if pol.Data != "" {
 		var data map[string]interface{}
-		err := json.Unmarshal([]byte(regoData), &data)
+		err := json.Unmarshal([]byte(pol.Data), &data)
 		if err != nil {
 			return nil, err
 		}
@@ -680,16 +1206,23 @@ func (s *Service) retrievePolicy(ctx context.Context, repository, group, policyN
 	// retrieve policy from cache
 	key := s.queryCacheKey(repository, group, policyName, version)
 	p, ok := s.policyCache.Get(key)
-	if !ok {
+	if ok {
+		metrics.CacheHits.With(metrics.With(metrics.PolicyLabels(repository, group, policyName, version), "entry", "policy")).Inc()
+	} else {
 		// retrieve policy from storage
 		var err error
-		p, err = s.storage.Policy(ctx, repository, group, policyName, version)
+		storageCtx, span := tracing.Start(ctx, "storage.Policy", tracing.PolicyAttributes(repository, group, policyName, version)...)
+		p, err = s.storage.Policy(storageCtx, repository, group, policyName, version)
+		tracing.End(span, err)
 		if err != nil {
 			if errors.Is(errors.NotFound, err) {
 				return nil, err
 			}
 			return nil, errors.New("error getting policy from storage", err)
 		}
+		// only existing policies are counted as cache misses, as
+		// unknown versions may be aliases which are never cached
+		metrics.CacheMisses.With(metrics.With(metrics.PolicyLabels(repository, group, policyName, version), "entry", "policy")).Inc()
 		s.policyCache.Set(key, p)
 	}
 
@@ -697,5 +1230,21 @@ func (s *Service) retrievePolicy(ctx context.Context, repository, group, policyN
 }
 
 func (s *Service) queryCacheKey(repository, group, policyName, version string) string {
-	return fmt.Sprintf("%s,%s,%s,%s", repository, group, policyName, version)
+	return regocache.Key(repository, group, policyName, version)
+}
+
+// rulePath converts a slash separated rule path (e.g. 'violations/critical')
+// to a rego reference suffix (e.g. '.violations.critical'). Only identifiers
+// are allowed as path segments, so that the path cannot change the query.
+func rulePath(rule string) (string, error) {
+	var path strings.Builder
+	for _, segment := range strings.Split(strings.Trim(rule, "/"), "/") {
+		if !ruleSegmentRegexp.MatchString(segment) {
+			return "", errors.New(errors.BadRequest, fmt.Sprintf("invalid rule path: %s", rule))
+		}
+		path.WriteString(".")
+		path.WriteString(segment)
+	}
+
+	return path.String(), nil
 }
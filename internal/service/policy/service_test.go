@@ -4,22 +4,33 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/open-policy-agent/opa/rego"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	goapolicy "github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/audit"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/caller"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/header"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/service/policy/policyfakes"
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage/memory"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage/memory/memoryfakes"
 	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
 )
@@ -29,6 +40,33 @@ func TestNew(t *testing.T) {
 	assert.Implements(t, (*goapolicy.Service)(nil), svc)
 }
 
+func TestNew_PolicySubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resumed := make(chan int, 1)
+	policyStorage := &policyfakes.FakeStorage{}
+	policyStorage.ResumableJobsStub = func(ctx context.Context, staleBefore time.Time) ([]*storage.Job, error) {
+		resumed <- policyStorage.AddPolicySubscribersCallCount()
+		return nil, nil
+	}
+	policyCache := regocache.New()
+
+	svc := policy.New(ctx, policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, time.Hour, http.DefaultClient, zap.NewNop(),
+		policy.WithJobs(1, 10, time.Hour, time.Minute),
+		policy.WithPolicySubscribers(policyCache),
+	)
+
+	// the caches are subscribed before the jobs are resumed
+	select {
+	case subscribed := <-resumed:
+		assert.Equal(t, 1, subscribed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("jobs are not resumed")
+	}
+	assert.Equal(t, []storage.PolicySubscriber{policyCache, svc}, policyStorage.AddPolicySubscribersArgsForCall(0))
+}
+
 // testReq prepares test request to be used in tests
 func testReq() *goapolicy.EvaluateRequest {
 	input := map[string]interface{}{"msg": "yes"}
@@ -51,7 +89,7 @@ func TestService_Evaluate(t *testing.T) {
 		Name:       "example",
 		Group:      "testgroup",
 		Version:    "1.0",
-		Rego:       `package testgroup.example default allow = false allow { input.msg == "yes" }`,
+		Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example default allow = false allow { input.msg == "yes" }`}},
 		Locked:     false,
 		LastUpdate: time.Now(),
 	}
@@ -124,6 +162,34 @@ func TestService_Evaluate(t *testing.T) {
 				Result: map[string]interface{}{"allow": true},
 			},
 		},
+		{
+			name: "prepared query is found in policyCache",
+			ctx:  ctxWithHeaders(),
+			req:  testReq(),
+			regocache: &policyfakes.FakeRegoCache{
+				GetStub: func(key string) (*storage.Policy, bool) {
+					return testPolicy, true
+				},
+				GetQueryStub: func(key, rule string) (*rego.PreparedEvalQuery, bool) {
+					query, err := rego.New(
+						rego.Module("policy.rego", `package testgroup.example allow = "cached"`),
+						rego.Query("data.testgroup.example"),
+					).PrepareForEval(context.Background())
+					if err != nil {
+						return nil, false
+					}
+					return &query, true
+				},
+			},
+			cache: &policyfakes.FakeCache{
+				SetStub: func(ctx context.Context, s string, s2 string, s3 string, bytes []byte, i int) error {
+					return nil
+				},
+			},
+			res: &goapolicy.EvaluateResult{
+				Result: map[string]interface{}{"allow": "cached"},
+			},
+		},
 		{
 			name: "policy is not found",
 			req:  testReq(),
@@ -136,6 +202,9 @@ func TestService_Evaluate(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return nil, errors.New(errors.NotFound)
 				},
+				PolicyAliasStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.PolicyAlias, error) {
+					return nil, errors.New(errors.NotFound)
+				},
 			},
 			res:     nil,
 			errkind: errors.NotFound,
@@ -236,7 +305,7 @@ func TestService_Evaluate(t *testing.T) {
 						Name:       "example",
 						Group:      "testgroup",
 						Version:    "1.0",
-						Rego:       testPolicyBlankAssignment,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: testPolicyBlankAssignment}},
 						Locked:     false,
 						LastUpdate: time.Now(),
 					}, nil
@@ -267,7 +336,7 @@ func TestService_Evaluate(t *testing.T) {
 						Name:       "example",
 						Group:      "testgroup",
 						Version:    "1.0",
-						Rego:       testPolicyBlankAssignment,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: testPolicyBlankAssignment}},
 						Locked:     false,
 						LastUpdate: time.Now(),
 					}, nil
@@ -298,7 +367,7 @@ func TestService_Evaluate(t *testing.T) {
 						Name:       "example",
 						Group:      "testgroup",
 						Version:    "1.0",
-						Rego:       testPolicyWithStaticData,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: testPolicyWithStaticData}},
 						Data:       `{"msg": "hello world"}`,
 						Locked:     false,
 						LastUpdate: time.Now(),
@@ -330,7 +399,7 @@ func TestService_Evaluate(t *testing.T) {
 						Name:       "example",
 						Group:      "testgroup",
 						Version:    "1.0",
-						Rego:       testPolicyAccessingHeaders,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: testPolicyAccessingHeaders}},
 						Locked:     false,
 						LastUpdate: time.Now(),
 					}, nil
@@ -466,7 +535,7 @@ func TestService_Validate(t *testing.T) {
 						Name:         "example",
 						Group:        "testgroup",
 						Version:      "1.0",
-						Rego:         `package testgroup.example _ = {"hello":"world"}`,
+						Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = {"hello":"world"}`}},
 						Locked:       false,
 						OutputSchema: "",
 						LastUpdate:   time.Now(),
@@ -496,7 +565,7 @@ func TestService_Validate(t *testing.T) {
 						Name:         "example",
 						Group:        "testgroup",
 						Version:      "1.0",
-						Rego:         `package testgroup.example _ = {"hello":"world"}`,
+						Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = {"hello":"world"}`}},
 						Locked:       false,
 						OutputSchema: "invalid JSON schema",
 						LastUpdate:   time.Now(),
@@ -526,7 +595,7 @@ func TestService_Validate(t *testing.T) {
 						Name:         "example",
 						Group:        "testgroup",
 						Version:      "1.0",
-						Rego:         `package testgroup.example _ = {"foo":"bar"}`,
+						Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = {"foo":"bar"}`}},
 						Locked:       false,
 						OutputSchema: jsonSchema,
 						LastUpdate:   time.Now(),
@@ -556,7 +625,7 @@ func TestService_Validate(t *testing.T) {
 						Name:         "example",
 						Group:        "testgroup",
 						Version:      "1.0",
-						Rego:         `package testgroup.example _ = {"foo":"barbaz"}`,
+						Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = {"foo":"barbaz"}`}},
 						Locked:       false,
 						OutputSchema: jsonSchema,
 						LastUpdate:   time.Now(),
@@ -587,7 +656,7 @@ func TestService_Validate(t *testing.T) {
 						Name:         "example",
 						Group:        "testgroup",
 						Version:      "1.0",
-						Rego:         `package testgroup.example _ = {"foo":"barbaz"}`,
+						Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = {"foo":"barbaz"}`}},
 						Locked:       false,
 						OutputSchema: jsonSchemaWithSchemaProperty,
 						LastUpdate:   time.Now(),
@@ -690,6 +759,21 @@ func TestService_Lock(t *testing.T) {
 			errkind: errors.Forbidden,
 			errtext: "policy is already locked",
 		},
+		{
+			name: "unlock time is not in the future",
+			req: func() *goapolicy.LockRequest {
+				req := testReq()
+				req.UnlockAt = ptr.Int64(time.Now().Add(-time.Minute).Unix())
+				return req
+			}(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: false}, nil
+				},
+			},
+			errkind: errors.BadRequest,
+			errtext: "unlock time must be in the future",
+		},
 		{
 			name: "fail to lock policy",
 			req:  testReq(),
@@ -697,13 +781,27 @@ func TestService_Lock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: false}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
-					return errors.New(errors.Internal, "error locking policy")
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return false, errors.New(errors.Internal, "error locking policy")
 				},
 			},
 			errkind: errors.Internal,
 			errtext: "error locking policy",
 		},
+		{
+			name: "policy is locked concurrently",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: false}, nil
+				},
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return false, nil
+				},
+			},
+			errkind: errors.Forbidden,
+			errtext: "policy is already locked",
+		},
 		{
 			name: "policy is locked successfully",
 			req:  testReq(),
@@ -711,8 +809,8 @@ func TestService_Lock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: false}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
-					return nil
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return true, nil
 				},
 			},
 			errtext: "",
@@ -806,13 +904,27 @@ func TestService_Unlock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: true}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
-					return errors.New(errors.Internal, "error unlocking policy")
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return false, errors.New(errors.Internal, "error unlocking policy")
 				},
 			},
 			errkind: errors.Internal,
 			errtext: "error unlocking policy",
 		},
+		{
+			name: "policy is unlocked concurrently",
+			req:  testReq(),
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{Locked: true}, nil
+				},
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return false, nil
+				},
+			},
+			errkind: errors.Forbidden,
+			errtext: "policy is unlocked",
+		},
 		{
 			name: "policy is unlocked successfully",
 			req:  testReq(),
@@ -820,8 +932,8 @@ func TestService_Unlock(t *testing.T) {
 				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
 					return &storage.Policy{Locked: true}, nil
 				},
-				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, lock bool) error {
-					return nil
+				SetPolicyLockStub: func(ctx context.Context, repository, name, group, version string, event *storage.LockEvent) (bool, error) {
+					return true, nil
 				},
 			},
 			errtext: "",
@@ -856,6 +968,207 @@ func TestService_Unlock(t *testing.T) {
 	}
 }
 
+func TestService_LockHistory(t *testing.T) {
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+		return strings.Join([]string{repo, group, name, version}, ",")
+	}}
+	policyStorage := memory.New(keyConstructor, map[string]*storage.Policy{
+		"policies,testgroup,example,1.0": {
+			Repository: "policies",
+			Group:      "testgroup",
+			Name:       "example",
+			Version:    "1.0",
+			Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := true`}},
+		},
+	}, zap.NewNop())
+
+	// policies aren't cached, so that lock changes are visible immediately
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+
+	token, err := jwt.NewBuilder().Subject("admin").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+string(signed))
+	ctx := caller.ToContext(context.Background(), r, caller.WithVerifiedTokens())
+
+	svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+
+	evaluate := func() error {
+		_, err := svc.Evaluate(ctx, &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+		})
+		return err
+	}
+	history := func() *goapolicy.LockHistoryResult {
+		res, err := svc.LockHistory(ctx, &goapolicy.LockHistoryRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	unlockAt := time.Now().Add(time.Hour).Unix()
+	err = svc.Lock(ctx, &goapolicy.LockRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Reason:     ptr.String("maintenance"),
+		UnlockAt:   ptr.Int64(unlockAt),
+	})
+	require.NoError(t, err)
+
+	err = evaluate()
+	require.Error(t, err)
+	assert.Equal(t, errors.Forbidden, err.(*errors.Error).Kind)
+	assert.Contains(t, err.Error(), "policy is locked: maintenance")
+
+	res := history()
+	assert.True(t, res.Locked)
+	require.NotNil(t, res.Lock)
+	assert.Equal(t, "admin", *res.Lock.Actor)
+	assert.Equal(t, "maintenance", *res.Lock.Reason)
+	assert.Equal(t, storage.LockSourceManual, res.Lock.Source)
+	assert.Equal(t, unlockAt, *res.Lock.UnlockAt)
+	assert.Len(t, res.History, 1)
+
+	err = svc.Unlock(ctx, &goapolicy.UnlockRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Reason:     ptr.String("done"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, evaluate())
+
+	res = history()
+	assert.False(t, res.Locked)
+	assert.Nil(t, res.Lock)
+	require.Len(t, res.History, 2)
+	assert.False(t, res.History[1].Locked)
+	assert.Equal(t, "done", *res.History[1].Reason)
+
+	// a lock whose unlock time has passed is removed on evaluation
+	expired := time.Now().Add(-time.Minute)
+	_, err = policyStorage.SetPolicyLock(ctx, "policies", "testgroup", "example", "1.0", &storage.LockEvent{Locked: true, Source: storage.LockSourceManual, UnlockAt: &expired})
+	require.NoError(t, err)
+	require.NoError(t, evaluate())
+
+	res = history()
+	assert.False(t, res.Locked)
+	require.Len(t, res.History, 4)
+	assert.Equal(t, storage.LockSourceExpiry, res.History[3].Source)
+}
+
+func TestService_PolicyTests(t *testing.T) {
+	req := &goapolicy.PolicyTestsRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+	}
+
+	runAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		storage policy.Storage
+
+		res     *goapolicy.PolicyTestReport
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name: "policy is not found",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return nil, errors.New(errors.NotFound)
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "not found",
+		},
+		{
+			name: "error getting policy from storage",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			errkind: errors.Unknown,
+			errtext: "some error",
+		},
+		{
+			name: "policy tests were not executed",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "policy tests were not executed",
+		},
+		{
+			name: "test report is returned",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, s string, s2 string, s3 string, s4 string) (*storage.Policy, error) {
+					return &storage.Policy{TestReport: &storage.TestReport{
+						Total:    2,
+						Failed:   1,
+						Coverage: 75,
+						Results: []storage.TestResult{
+							{Name: "testgroup.example.test_allow", Location: "policy_test.rego:3", Passed: true, Duration: 2 * time.Millisecond},
+							{Name: "deny", Location: "tests.json:0", Error: "expected false, got true"},
+						},
+						RunAt: runAt,
+					}}, nil
+				},
+			},
+			res: &goapolicy.PolicyTestReport{
+				Total:    2,
+				Failed:   1,
+				Coverage: 75,
+				Results: []*goapolicy.PolicyTestResult{
+					{Name: "testgroup.example.test_allow", Location: "policy_test.rego:3", Passed: true, Duration: 2},
+					{Name: "deny", Location: "tests.json:0", Error: ptr.String("expected false, got true")},
+				},
+				RunAt: runAt.Unix(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), test.storage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.PolicyTests(context.Background(), req)
+			if test.errtext != "" {
+				require.Error(t, err)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Contains(t, e.Error(), test.errtext)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
 func TestService_ListPolicies(t *testing.T) {
 	boolTrue := true
 	boolFalse := false
@@ -889,7 +1202,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some Data",
 					DataConfig: "data config",
 					Locked:     false,
@@ -899,7 +1212,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     true,
@@ -937,7 +1250,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     true,
@@ -969,7 +1282,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     false,
@@ -1001,7 +1314,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     true,
@@ -1024,6 +1337,7 @@ func TestService_ListPolicies(t *testing.T) {
 						Group:      "example",
 						Version:    "example",
 						Rego:       ptr.String("some rego"),
+						Modules:    map[string]string{"policy.rego": "some rego"},
 						Data:       ptr.String("some data"),
 						DataConfig: ptr.String("data config"),
 						Locked:     true,
@@ -1040,7 +1354,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     false,
@@ -1076,7 +1390,7 @@ func TestService_ListPolicies(t *testing.T) {
 					Name:       "example",
 					Group:      "example",
 					Version:    "example",
-					Rego:       "some rego",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "some rego"}},
 					Data:       "some data",
 					DataConfig: "data config",
 					Locked:     false,
@@ -1317,7 +1631,7 @@ func TestService_ExportBundleError(t *testing.T) {
 					Name:       "myname",
 					Group:      "mygroup",
 					Version:    "1.52",
-					Rego:       "package test",
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: "package test"}},
 					Data:       `{"key":"value"}`,
 					DataConfig: `{"new":"value"}`,
 					Locked:     false,
@@ -1353,7 +1667,7 @@ func TestService_ExportBundleError(t *testing.T) {
 					Name:         "myname",
 					Group:        "mygroup",
 					Version:      "1.52",
-					Rego:         "package test",
+					Modules:      []storage.Module{{Filename: "policy.rego", Rego: "package test"}},
 					Data:         `{"key":"value"}`,
 					DataConfig:   `{"new":"value"}`,
 					ExportConfig: `{"namespace":"transit","key":"key1"}`,
@@ -1397,7 +1711,7 @@ func TestService_ExportBundleSuccess(t *testing.T) {
 				Name:         "myname",
 				Group:        "mygroup",
 				Version:      "1.52",
-				Rego:         "package test",
+				Modules:      []storage.Module{{Filename: "policy.rego", Rego: "package test"}},
 				Data:         `{"key":"value"}`,
 				DataConfig:   `{"new":"value"}`,
 				ExportConfig: `{"namespace":"transit","key":"key1"}`,
@@ -1671,3 +1985,2554 @@ func TestService_SetPolicyAutoImport(t *testing.T) {
 		}
 	}
 }
+
+func TestService_EvaluateBatch(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			if name == "missing" {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: fmt.Sprintf(`package %s.%s default allow = false allow { input.msg == "yes" }`, group, name)}},
+			}, nil
+		},
+		PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+			return nil, errors.New(errors.NotFound, "policy alias not found")
+		},
+	}
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+	cache := &policyfakes.FakeCache{
+		SetStub: func(ctx context.Context, s string, s2 string, s3 string, bytes []byte, i int) error {
+			return nil
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, policyCache, cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithBatchConcurrency(2))
+
+	var items []*goapolicy.BatchEvaluateItem
+	for i := 0; i < 5; i++ {
+		items = append(items, &goapolicy.BatchEvaluateItem{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: fmt.Sprintf("example%d", i),
+			Version:    "1.0",
+			Input:      map[string]interface{}{"msg": "yes"},
+			TTL:        ptr.Int(30),
+		})
+	}
+	items = append(items, &goapolicy.BatchEvaluateItem{
+		Repository:   "policies",
+		Group:        "testgroup",
+		PolicyName:   "missing",
+		Version:      "1.0",
+		EvaluationID: ptr.String("my-evaluation"),
+	})
+
+	res, err := svc.EvaluateBatch(context.Background(), &goapolicy.BatchEvaluateRequest{Items: items})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 6)
+
+	for i, r := range res.Results[:5] {
+		assert.Equal(t, fmt.Sprintf("example%d", i), r.PolicyName)
+		assert.Equal(t, map[string]interface{}{"allow": true}, r.Result)
+		assert.NotEmpty(t, r.ETag)
+		assert.Nil(t, r.Error)
+	}
+
+	assert.Equal(t, "missing", res.Results[5].PolicyName)
+	assert.Nil(t, res.Results[5].Result)
+	assert.Nil(t, res.Results[5].ETag)
+	require.NotNil(t, res.Results[5].Error)
+	assert.Contains(t, *res.Results[5].Error, "policy not found")
+
+	// every successful evaluation result is stored in cache with its TTL
+	assert.Equal(t, 5, cache.SetCallCount())
+	_, _, _, _, _, ttl := cache.SetArgsForCall(0)
+	assert.Equal(t, 30, ttl)
+}
+
+func TestService_EvaluateBatchMaxSize(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{}
+	svc := policy.New(context.Background(), policyStorage, &policyfakes.FakeRegoCache{}, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithMaxBatchSize(2))
+
+	items := make([]*goapolicy.BatchEvaluateItem, 3)
+	for i := range items {
+		items[i] = &goapolicy.BatchEvaluateItem{Repository: "policies", Group: "testgroup", PolicyName: "example", Version: "1.0"}
+	}
+
+	res, err := svc.EvaluateBatch(context.Background(), &goapolicy.BatchEvaluateRequest{Items: items})
+	assert.Nil(t, res)
+	require.Error(t, err)
+	e, ok := err.(*errors.Error)
+	require.True(t, ok)
+	assert.Equal(t, errors.BadRequest, e.Kind)
+	assert.Contains(t, e.Error(), "maximum of 2 items")
+
+	// no item is evaluated
+	assert.Equal(t, 0, policyStorage.PolicyCallCount())
+}
+
+func TestService_EvaluateBatchStream(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example default allow = false allow { input.msg == "yes" }`}},
+			}, nil
+		},
+	}
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+	cache := &policyfakes.FakeCache{
+		SetStub: func(ctx context.Context, s string, s2 string, s3 string, bytes []byte, i int) error {
+			return nil
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, policyCache, cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithBatchConcurrency(2))
+
+	body := `{"repository":"policies","group":"testgroup","policyName":"example","version":"1.0","input":{"msg":"yes"}}
+{"repository":"policies","group":"testgroup","policyName":"example","version":"1.0","input":{"msg":"no"}}
+{"repository":"policies","group":"testgroup","policyName":"example","version":"1.0","evaluationID":"my-evaluation"}
+not json
+`
+	res, stream, err := svc.EvaluateBatchStream(context.Background(), io.NopCloser(bytes.NewBufferString(body)))
+	require.NoError(t, err)
+	assert.Equal(t, "application/x-ndjson", res.ContentType)
+
+	var results []map[string]interface{}
+	dec := json.NewDecoder(stream)
+	for {
+		var r map[string]interface{}
+		if err := dec.Decode(&r); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		results = append(results, r)
+	}
+
+	require.Len(t, results, 4)
+	assert.Equal(t, map[string]interface{}{"allow": true}, results[0]["result"])
+	assert.NotEmpty(t, results[0]["ETag"])
+	assert.Equal(t, map[string]interface{}{"allow": false}, results[1]["result"])
+	assert.Equal(t, "my-evaluation", results[2]["ETag"])
+	assert.Contains(t, results[3]["error"], "invalid batch item")
+}
+
+func TestService_EvaluateBatchStreamCancel(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example default allow = false allow { input.msg == "yes" }`}},
+			}, nil
+		},
+	}
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithBatchConcurrency(2))
+
+	item := `{"repository":"policies","group":"testgroup","policyName":"example","version":"1.0","input":{"msg":"yes"}}` + "\n"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	body, bodyWriter := io.Pipe()
+	_, stream, err := svc.EvaluateBatchStream(ctx, body)
+	require.NoError(t, err)
+
+	go bodyWriter.Write([]byte(item)) //nolint:errcheck
+
+	dec := json.NewDecoder(stream)
+	var r map[string]interface{}
+	require.NoError(t, dec.Decode(&r))
+	assert.Equal(t, map[string]interface{}{"allow": true}, r["result"])
+	calls := policyStorage.PolicyCallCount()
+
+	// the stream ends when the request is cancelled, although the body is still open
+	cancel()
+	err = dec.Decode(&r)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// items sent after the cancellation aren't read and evaluated
+	_, err = bodyWriter.Write([]byte(item))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	assert.Equal(t, calls, policyStorage.PolicyCallCount())
+}
+
+func TestService_EvaluateDecisionLog(t *testing.T) {
+	lastUpdate := time.Now().Add(-time.Hour)
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			if name == "missing" {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example default allow = false allow { input.msg == "yes" }`}},
+				LastUpdate: lastUpdate,
+			}, nil
+		},
+		PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+			return nil, errors.New(errors.NotFound, "policy alias not found")
+		},
+	}
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+	cache := &policyfakes.FakeCache{}
+	decisionLog := &policyfakes.FakeDecisionLog{}
+
+	svc := policy.New(context.Background(), policyStorage, policyCache, cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionLog(decisionLog))
+
+	token, err := jwt.NewBuilder().Subject("alice").Build()
+	require.NoError(t, err)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/policy/policies/testgroup/example/1.0/evaluation", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("Authorization", "Bearer "+string(signed))
+	ctx := caller.ToContext(context.Background(), r)
+
+	input := map[string]interface{}{"msg": "yes"}
+	res, err := svc.Evaluate(ctx, &goapolicy.EvaluateRequest{
+		Repository:   "policies",
+		Group:        "testgroup",
+		PolicyName:   "example",
+		Version:      "1.0",
+		Input:        input,
+		EvaluationID: ptr.String("my-evaluation"),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, decisionLog.LogCallCount())
+	d := decisionLog.LogArgsForCall(0)
+	assert.Equal(t, "my-evaluation", d.EvaluationID)
+	assert.Equal(t, "testgroup", d.Group)
+	assert.Equal(t, "example", d.Name)
+	assert.Equal(t, lastUpdate, d.PolicyLastUpdate)
+	assert.Equal(t, input, d.Input)
+	assert.Equal(t, res.Result, d.Result)
+	assert.Empty(t, d.Error)
+	assert.Equal(t, "10.0.0.1", d.ClientIP)
+	assert.Empty(t, d.Caller, "the subject of an unverified token must not be logged")
+	assert.False(t, d.Timestamp.IsZero())
+
+	_, err = svc.Evaluate(ctx, &goapolicy.EvaluateRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "missing",
+		Version:    "1.0",
+	})
+	require.Error(t, err)
+
+	require.Equal(t, 2, decisionLog.LogCallCount())
+	d = decisionLog.LogArgsForCall(1)
+	assert.Equal(t, "missing", d.Name)
+	assert.Nil(t, d.Result)
+	assert.Contains(t, d.Error, "policy not found")
+	assert.True(t, d.PolicyLastUpdate.IsZero())
+
+	verified := caller.ToContext(context.Background(), r, caller.WithVerifiedTokens())
+	_, err = svc.Evaluate(verified, &goapolicy.EvaluateRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Input:      input,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 3, decisionLog.LogCallCount())
+	assert.Equal(t, "alice", decisionLog.LogArgsForCall(2).Caller)
+}
+
+func TestService_DecisionLogs(t *testing.T) {
+	t.Run("decision storage is not configured", func(t *testing.T) {
+		svc := policy.New(context.Background(), &policyfakes.FakeStorage{}, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.DecisionLogs(context.Background(), &goapolicy.DecisionLogsRequest{Limit: 100})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.NotFound, err))
+	})
+
+	t.Run("error querying decision storage", func(t *testing.T) {
+		decisionStorage := &policyfakes.FakeDecisionStorage{
+			DecisionsStub: func(ctx context.Context, filter *storage.DecisionFilter) ([]*storage.Decision, error) {
+				return nil, errors.New("some error")
+			},
+		}
+		svc := policy.New(context.Background(), &policyfakes.FakeStorage{}, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionStorage(decisionStorage))
+		res, err := svc.DecisionLogs(context.Background(), &goapolicy.DecisionLogsRequest{Limit: 100})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error retrieving decisions")
+	})
+
+	t.Run("decisions are returned", func(t *testing.T) {
+		timestamp := time.Unix(1700000000, 0)
+		decisionStorage := &policyfakes.FakeDecisionStorage{
+			DecisionsStub: func(ctx context.Context, filter *storage.DecisionFilter) ([]*storage.Decision, error) {
+				return []*storage.Decision{
+					{
+						EvaluationID: "my-evaluation",
+						Repository:   "policies",
+						Group:        "testgroup",
+						Name:         "example",
+						Version:      "1.0",
+						InputHash:    "hash",
+						Result:       map[string]interface{}{"allow": true},
+						Duration:     1500 * time.Millisecond,
+						Caller:       "user",
+						Timestamp:    timestamp,
+					},
+				}, nil
+			},
+		}
+		svc := policy.New(context.Background(), &policyfakes.FakeStorage{}, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionStorage(decisionStorage))
+		res, err := svc.DecisionLogs(context.Background(), &goapolicy.DecisionLogsRequest{
+			Group:  ptr.String("testgroup"),
+			From:   ptr.Int64(1600000000),
+			Limit:  10,
+			Offset: ptr.Int(20),
+		})
+		require.NoError(t, err)
+
+		_, filter := decisionStorage.DecisionsArgsForCall(0)
+		assert.Equal(t, &storage.DecisionFilter{
+			Group:  "testgroup",
+			From:   time.Unix(1600000000, 0),
+			Limit:  10,
+			Offset: 20,
+		}, filter)
+
+		require.Len(t, res.Decisions, 1)
+		assert.Equal(t, "my-evaluation", res.Decisions[0].EvaluationID)
+		assert.Equal(t, "example", res.Decisions[0].PolicyName)
+		assert.Equal(t, int64(1500), res.Decisions[0].Duration)
+		assert.Equal(t, timestamp.Unix(), res.Decisions[0].Timestamp)
+		assert.Equal(t, ptr.String("user"), res.Decisions[0].Caller)
+		assert.Nil(t, res.Decisions[0].Error)
+	})
+}
+
+func TestService_EvaluateExplain(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository:   repo,
+				Name:         name,
+				Group:        group,
+				Version:      version,
+				Modules:      []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example default allow = false allow { print("msg is", input.msg); trace("checking msg"); input.msg == "yes" }`}},
+				OutputSchema: `{"type":"object","properties":{"allow":{"type":"boolean"}},"required":["allow"]}`,
+			}, nil
+		},
+	}
+	policyCache := &policyfakes.FakeRegoCache{
+		GetStub: func(key string) (*storage.Policy, bool) {
+			return nil, false
+		},
+	}
+
+	req := func(explain string) *goapolicy.EvaluateRequest {
+		return &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Input:      map[string]interface{}{"msg": "yes"},
+			Explain:    ptr.String(explain),
+		}
+	}
+
+	allowAll := func(ctx context.Context) bool { return true }
+
+	t.Run("explain is not allowed without explain check", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.Evaluate(context.Background(), req("full"))
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.Forbidden, err))
+
+		res, err = svc.Validate(context.Background(), req("full"))
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.Forbidden, err))
+	})
+
+	t.Run("explain is not allowed when explain check fails", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+			policy.WithExplainCheck(func(ctx context.Context) bool { return false }))
+		_, err := svc.Evaluate(context.Background(), req("notes"))
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.Forbidden, err))
+	})
+
+	t.Run("explain off returns plain result", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.Evaluate(context.Background(), req("off"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"allow": true}, res.Result)
+	})
+
+	t.Run("full explanation is returned but not cached", func(t *testing.T) {
+		cache := &policyfakes.FakeCache{}
+		svc := policy.New(context.Background(), policyStorage, policyCache, cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithExplainCheck(allowAll))
+		res, err := svc.Evaluate(context.Background(), req("full"))
+		require.NoError(t, err)
+
+		result, ok := res.Result.(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"allow": true}, result["result"])
+		assert.NotEmpty(t, result["explanation"])
+		assert.Equal(t, []string{"msg is yes"}, result["print"])
+
+		require.Equal(t, 1, cache.SetCallCount())
+		_, _, _, _, value, _ := cache.SetArgsForCall(0)
+		assert.JSONEq(t, `{"allow":true}`, string(value))
+	})
+
+	t.Run("notes explanation contains only trace notes", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithExplainCheck(allowAll))
+		res, err := svc.Evaluate(context.Background(), req("notes"))
+		require.NoError(t, err)
+
+		result := res.Result.(map[string]interface{})
+		explanation := result["explanation"].([]string)
+		require.NotEmpty(t, explanation)
+		var found bool
+		for _, line := range explanation {
+			if strings.Contains(line, "checking msg") {
+				found = true
+			}
+			assert.NotContains(t, line, "Fail")
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("validate returns explanation of valid result", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithExplainCheck(allowAll))
+		res, err := svc.Validate(context.Background(), req("fails"))
+		require.NoError(t, err)
+
+		result := res.Result.(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"allow": true}, result["result"])
+		assert.Contains(t, result, "explanation")
+	})
+}
+
+func TestService_PartialEvaluate(t *testing.T) {
+	testPolicy := `package testgroup.example
+allow { input.user == "admin" }
+allow { input.resource.owner == input.user }
+`
+	partialReq := func(target string) *goapolicy.PartialEvaluateRequest {
+		return &goapolicy.PartialEvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Rule:       "allow",
+			Unknowns:   []string{"input.resource"},
+			Input:      map[string]interface{}{"user": "alice"},
+			Target:     target,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		policy  *storage.Policy
+		req     *goapolicy.PartialEvaluateRequest
+		res     *goapolicy.PartialEvaluateResult
+		errkind errors.Kind
+		errtext string
+	}{
+		{
+			name:    "policy is locked",
+			policy:  &storage.Policy{Modules: []storage.Module{{Filename: "policy.rego", Rego: testPolicy}}, Locked: true},
+			req:     partialReq("rego"),
+			errkind: errors.Forbidden,
+			errtext: "policy is locked",
+		},
+		{
+			name:   "residual queries are returned",
+			policy: &storage.Policy{Modules: []storage.Module{{Filename: "policy.rego", Rego: testPolicy}}},
+			req:    partialReq("rego"),
+			res: &goapolicy.PartialEvaluateResult{
+				Queries: []string{`"alice" = input.resource.owner`},
+				Version: "1.0",
+			},
+		},
+		{
+			name:   "residual queries are translated to mongo filter",
+			policy: &storage.Policy{Modules: []storage.Module{{Filename: "policy.rego", Rego: testPolicy}}},
+			req:    partialReq("mongo"),
+			res: &goapolicy.PartialEvaluateResult{
+				Queries: []string{`"alice" = input.resource.owner`},
+				Filter:  map[string]interface{}{"owner": map[string]interface{}{"$eq": "alice"}},
+				Version: "1.0",
+			},
+		},
+		{
+			name:    "residual queries cannot be translated to mongo filter",
+			policy:  &storage.Policy{Modules: []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow { input.resource.tags[_] == input.user }`}}},
+			req:     partialReq("mongo"),
+			errkind: errors.BadRequest,
+			errtext: "error translating residual queries to mongo filter",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyStorage := &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					return test.policy, nil
+				},
+			}
+			policyCache := &policyfakes.FakeRegoCache{
+				GetStub: func(key string) (*storage.Policy, bool) {
+					return nil, false
+				},
+			}
+
+			svc := policy.New(context.Background(), policyStorage, policyCache, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.PartialEvaluate(context.Background(), test.req)
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestService_EvaluateRule(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules: []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example
+default allow = false
+allow { input.msg == "yes" }
+violations.critical := ["missing msg"] { not input.msg }
+violations.warnings := []
+`}},
+			}, nil
+		},
+	}
+
+	req := func(rule *string, input map[string]interface{}) *goapolicy.EvaluateRequest {
+		return &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Input:      input,
+			Rule:       rule,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		req       *goapolicy.EvaluateRequest
+		result    any
+		errkind   errors.Kind
+		errtext   string
+		undefined bool
+	}{
+		{
+			name:    "rule path is missing",
+			req:     req(nil, nil),
+			errkind: errors.BadRequest,
+			errtext: "rule path is not specified",
+		},
+		{
+			name:    "rule path is empty",
+			req:     req(ptr.String("/"), nil),
+			errkind: errors.BadRequest,
+			errtext: "rule path is not specified",
+		},
+		{
+			name:    "rule path is invalid",
+			req:     req(ptr.String("allow/x == x"), nil),
+			errkind: errors.BadRequest,
+			errtext: "invalid rule path",
+		},
+		{
+			name:      "rule value is undefined",
+			req:       req(ptr.String("violations/missing"), map[string]interface{}{"msg": "yes"}),
+			errkind:   errors.Unknown,
+			errtext:   "policy evaluation results are empty",
+			undefined: true,
+		},
+		{
+			name:   "rule value is returned",
+			req:    req(ptr.String("allow"), map[string]interface{}{"msg": "yes"}),
+			result: true,
+		},
+		{
+			name:   "nested rule value is returned",
+			req:    req(ptr.String("violations/critical/"), map[string]interface{}{}),
+			result: []interface{}{"missing msg"},
+		},
+		{
+			name:   "rule object is returned",
+			req:    req(ptr.String("violations"), map[string]interface{}{"msg": "yes"}),
+			result: map[string]interface{}{"warnings": []interface{}{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.EvaluateRule(context.Background(), test.req)
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, test.errkind, e.Kind)
+				assert.Equal(t, test.undefined, policy.IsUndefined(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.result, res.Result)
+		})
+	}
+}
+
+func TestService_EvaluateUndefinedErrorIDs(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow { input.msg == "yes" }`}},
+			}, nil
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+	req := &goapolicy.EvaluateRequest{Repository: "policies", Group: "testgroup", PolicyName: "example", Version: "1.0", Rule: ptr.String("allow")}
+
+	_, err1 := svc.EvaluateRule(context.Background(), req)
+	_, err2 := svc.EvaluateRule(context.Background(), req)
+	require.True(t, policy.IsUndefined(err1))
+	require.True(t, policy.IsUndefined(err2))
+
+	// every undefined result has its own error ID
+	assert.NotEqual(t, err1.(*errors.Error).ID, err2.(*errors.Error).ID)
+	assert.False(t, policy.IsUndefined(errors.New("policy evaluation results are empty")))
+}
+
+func TestService_EvaluateWithModules(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Filename:   "testgroup/example/1.0/policy.rego",
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules: []storage.Module{
+					{Filename: "policy.rego", Rego: `package testgroup.example
+import data.library.strings
+default allow = false
+allow { valid_msg; strings.has_prefix_ci(input.user, "admin") }`},
+					{Filename: "helpers.rego", Rego: `package testgroup.example
+valid_msg { input.msg == "yes" }`},
+				},
+			}, nil
+		},
+	}
+
+	libraries := []storage.Module{
+		{Filename: "strings.rego", Rego: `package library.strings
+has_prefix_ci(s, prefix) { startswith(lower(s), lower(prefix)) }`},
+	}
+
+	tests := []struct {
+		name      string
+		libraries []storage.Module
+		input     map[string]interface{}
+		result    any
+		errtext   string
+	}{
+		{
+			name:      "helper module and library are used",
+			libraries: libraries,
+			input:     map[string]interface{}{"msg": "yes", "user": "Admin-1"},
+			result:    map[string]interface{}{"allow": true, "valid_msg": true},
+		},
+		{
+			name:      "helper module rule is not satisfied",
+			libraries: libraries,
+			input:     map[string]interface{}{"msg": "no", "user": "Admin-1"},
+			result:    map[string]interface{}{"allow": false},
+		},
+		{
+			name:    "library is not configured",
+			input:   map[string]interface{}{"msg": "yes", "user": "Admin-1"},
+			errtext: "error preparing rego query",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithLibraries(test.libraries))
+			res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				Input:      test.input,
+			})
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.result, res.Result)
+		})
+	}
+}
+
+func TestService_EvaluateWithRuntimeConfig(t *testing.T) {
+	newStorage := func(rego, runtimeConfig string) *policyfakes.FakeStorage {
+		return &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+				return &storage.Policy{
+					Repository:    repo,
+					Name:          name,
+					Group:         group,
+					Version:       version,
+					Modules:       []storage.Module{{Filename: "policy.rego", Rego: rego}},
+					RuntimeConfig: runtimeConfig,
+				}, nil
+			},
+		}
+	}
+
+	req := func(ttl *int) *goapolicy.EvaluateRequest {
+		return &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Input:      map[string]interface{}{"msg": "hello"},
+			TTL:        ttl,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		rego          string
+		runtimeConfig string
+		req           *goapolicy.EvaluateRequest
+		headers       map[string]string
+
+		cacheCalls int
+		ttl        int
+		errkind    errors.Kind
+		errtext    string
+	}{
+		{
+			name:       "policy without runtime configuration",
+			req:        req(ptr.Int(10)),
+			cacheCalls: 1,
+			ttl:        10,
+		},
+		{
+			name:          "invalid runtime configuration",
+			runtimeConfig: `{"timeout":"five seconds"}`,
+			req:           req(nil),
+			errkind:       errors.Unknown,
+			errtext:       "error getting policy runtime configuration",
+		},
+		{
+			name:          "evaluation timeout is reached",
+			rego:          `package testgroup.example _ = count([1 | numbers.range(1, 5000)[_]; numbers.range(1, 5000)[_]])`,
+			runtimeConfig: `{"timeout":"10ms"}`,
+			req:           req(nil),
+			errkind:       errors.Timeout,
+			errtext:       "policy evaluation timed out",
+		},
+		{
+			name:          "default cache ttl is used",
+			runtimeConfig: `{"defaultTTL":60}`,
+			req:           req(nil),
+			cacheCalls:    1,
+			ttl:           60,
+		},
+		{
+			name:          "request cache ttl overrides the default",
+			runtimeConfig: `{"defaultTTL":60}`,
+			req:           req(ptr.Int(5)),
+			cacheCalls:    1,
+			ttl:           5,
+		},
+		{
+			name:          "result is not stored in cache",
+			runtimeConfig: `{"cache":false}`,
+			req:           req(nil),
+		},
+		{
+			name:          "input exceeds the maximum size",
+			runtimeConfig: `{"maxInputSize":10}`,
+			req:           req(nil),
+			errkind:       errors.BadRequest,
+			errtext:       "policy input exceeds the maximum size of 10 bytes",
+		},
+		{
+			name:          "input is within the maximum size",
+			runtimeConfig: `{"maxInputSize":100}`,
+			req:           req(nil),
+			cacheCalls:    1,
+		},
+		{
+			name:          "required header is missing",
+			runtimeConfig: `{"requiredHeaders":["x-client-id"]}`,
+			req:           req(nil),
+			errkind:       errors.BadRequest,
+			errtext:       "required request header is missing: x-client-id",
+		},
+		{
+			name:          "required header is present",
+			runtimeConfig: `{"requiredHeaders":["x-client-id"]}`,
+			req:           req(nil),
+			headers:       map[string]string{"X-Client-Id": "client"},
+			cacheCalls:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.headers != nil {
+				r := httptest.NewRequest(http.MethodPost, "/", nil)
+				for k, v := range test.headers {
+					r.Header.Set(k, v)
+				}
+				ctx = header.ToContext(ctx, r)
+			}
+
+			rego := test.rego
+			if rego == "" {
+				rego = `package testgroup.example _ = input.msg`
+			}
+
+			cache := &policyfakes.FakeCache{}
+			svc := policy.New(context.Background(), newStorage(rego, test.runtimeConfig), regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.Evaluate(ctx, test.req)
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, test.errkind, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "hello", res.Result)
+			require.Equal(t, test.cacheCalls, cache.SetCallCount())
+			if test.cacheCalls > 0 {
+				_, _, _, _, _, ttl := cache.SetArgsForCall(0)
+				assert.Equal(t, test.ttl, ttl)
+			}
+		})
+	}
+}
+
+func TestService_InputLimit(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			if name == "missing" {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			p := &storage.Policy{Repository: repo, Name: name, Group: group, Version: version}
+			if name == "limited" {
+				p.RuntimeConfig = `{"maxInputSize":10}`
+			}
+			return p, nil
+		},
+		PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+			return nil, errors.New(errors.NotFound, "policy alias not found")
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+
+	tests := []struct {
+		name    string
+		policy  string
+		body    string
+		errtext string
+	}{
+		{
+			name:    "body exceeds the maximum input size",
+			policy:  "limited",
+			body:    `{"msg":"hello world"}`,
+			errtext: "request body too large",
+		},
+		{
+			name:   "body is within the maximum input size",
+			policy: "limited",
+			body:   `{"a":1}`,
+		},
+		{
+			name:   "policy without maximum input size",
+			policy: "unlimited",
+			body:   `{"msg":"hello world"}`,
+		},
+		{
+			name:   "policy is not found",
+			policy: "missing",
+			body:   `{"msg":"hello world"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vars := func(*http.Request) map[string]string {
+				return map[string]string{"repository": "policies", "group": "testgroup", "policyName": test.policy, "version": "1.0"}
+			}
+
+			var readErr error
+			var body []byte
+			h := svc.InputLimit(vars)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, readErr = io.ReadAll(r.Body)
+			}))
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body)))
+
+			if test.errtext != "" {
+				require.Error(t, readErr)
+				assert.Contains(t, readErr.Error(), test.errtext)
+				return
+			}
+			require.NoError(t, readErr)
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+}
+
+func TestService_EvaluateCachesInputSchema(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository:  repo,
+				Name:        name,
+				Group:       group,
+				Version:     version,
+				Modules:     []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = input.name`}},
+				InputSchema: `{"type": "object", "required": ["name"]}`,
+			}, nil
+		},
+	}
+
+	cache := regocache.New()
+	svc := policy.New(context.Background(), policyStorage, cache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+	req := &goapolicy.EvaluateRequest{Repository: "policies", Group: "testgroup", PolicyName: "example", Version: "1.0", Input: map[string]interface{}{"name": "john"}}
+
+	_, err := svc.Evaluate(context.Background(), req)
+	require.NoError(t, err)
+
+	key := regocache.Key("policies", "testgroup", "example", "1.0")
+	pol, ok := cache.Get(key)
+	require.True(t, ok)
+	sch, ok := cache.GetValue(key, "inputSchema", pol)
+	require.True(t, ok)
+
+	// the compiled schema is reused by the next evaluation
+	_, err = svc.Evaluate(context.Background(), req)
+	require.NoError(t, err)
+	cached, ok := cache.GetValue(key, "inputSchema", pol)
+	require.True(t, ok)
+	assert.Same(t, sch, cached)
+
+	// the compiled schema is invalidated together with the policy
+	require.NoError(t, cache.PolicyDataChange(context.Background(), "policies", "testgroup", "example", "1.0"))
+	_, ok = cache.GetValue(key, "inputSchema", pol)
+	assert.False(t, ok)
+}
+
+func TestService_EvaluateWithInputSchema(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example _ = input.user.name`}},
+				InputSchema: `{
+					"type": "object",
+					"required": ["user"],
+					"properties": {
+						"user": {
+							"type": "object",
+							"required": ["name"],
+							"properties": {
+								"name": {"type": "string"},
+								"age": {"type": "integer", "minimum": 0}
+							}
+						}
+					}
+				}`,
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		input   any
+		result  any
+		errtext []string
+	}{
+		{
+			name:   "input is valid",
+			input:  map[string]interface{}{"user": map[string]interface{}{"name": "john", "age": 30}},
+			result: "john",
+		},
+		{
+			name:    "input is missing",
+			errtext: []string{"policy input schema validation failed", "'': expected object, but got null"},
+		},
+		{
+			name:  "input has multiple violations",
+			input: map[string]interface{}{"user": map[string]interface{}{"name": 1, "age": -1}},
+			errtext: []string{
+				"policy input schema validation failed",
+				"'/user/name': expected string, but got number",
+				"'/user/age': must be >= 0 but found -1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+				Input:      test.input,
+			})
+			if len(test.errtext) > 0 {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				for _, text := range test.errtext {
+					assert.Contains(t, err.Error(), text)
+				}
+				e, ok := err.(*errors.Error)
+				require.True(t, ok)
+				assert.Equal(t, errors.BadRequest, e.Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.result, res.Result)
+		})
+	}
+}
+
+func TestService_ValidateReport(t *testing.T) {
+	newStorage := func(rego string) *policyfakes.FakeStorage {
+		return &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+				return &storage.Policy{
+					Repository: repo,
+					Name:       name,
+					Group:      group,
+					Version:    version,
+					Modules:    []storage.Module{{Filename: "policy.rego", Rego: rego}},
+					OutputSchema: `{
+						"type": "object",
+						"required": ["allow", "reason"],
+						"properties": {
+							"allow": {"type": "boolean"},
+							"reason": {"type": "string", "default": "none"},
+							"score": {"type": "integer", "maximum": 10}
+						}
+					}`,
+				}, nil
+			},
+		}
+	}
+
+	req := func(report, coerce bool) *goapolicy.EvaluateRequest {
+		return &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Report:     ptr.Bool(report),
+			Coerce:     ptr.Bool(coerce),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		rego       string
+		req        *goapolicy.EvaluateRequest
+		result     any
+		violations []string
+	}{
+		{
+			name:       "validation fails with structured errors",
+			rego:       `package testgroup.example _ = {"allow": "yes", "score": 11}`,
+			req:        req(false, false),
+			violations: []string{"/allow type", "/score maximum", " required"},
+		},
+		{
+			name: "validation report is returned for invalid result",
+			rego: `package testgroup.example _ = {"allow": true, "reason": "ok", "score": 11}`,
+			req:  req(true, false),
+			result: map[string]interface{}{
+				"result": map[string]interface{}{"allow": true, "reason": "ok", "score": json.Number("11")},
+				"valid":  false,
+				"errors": []map[string]interface{}{{
+					"instanceLocation": "/score",
+					"keyword":          "maximum",
+					"keywordLocation":  "/properties/score/maximum",
+					"message":          "must be <= 10 but found 11",
+				}},
+			},
+		},
+		{
+			name: "validation report is returned for valid result",
+			rego: `package testgroup.example _ = {"allow": true, "reason": "ok"}`,
+			req:  req(true, false),
+			result: map[string]interface{}{
+				"result": map[string]interface{}{"allow": true, "reason": "ok"},
+				"valid":  true,
+				"errors": []map[string]interface{}{},
+			},
+		},
+		{
+			name:   "schema defaults are applied in coerce mode",
+			rego:   `package testgroup.example _ = {"allow": true}`,
+			req:    req(false, true),
+			result: map[string]interface{}{"allow": true, "reason": "none"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := &policyfakes.FakeCache{}
+			decisionLog := &policyfakes.FakeDecisionLog{}
+			svc := policy.New(context.Background(), newStorage(test.rego), regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionLog(decisionLog))
+			res, err := svc.Validate(context.Background(), test.req)
+			if len(test.violations) > 0 {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "policy output schema validation failed")
+
+				details, ok := err.(*errors.Error).Err.(interface{ Details() any })
+				require.True(t, ok)
+				data, err := json.Marshal(details.Details())
+				require.NoError(t, err)
+
+				var violations []map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &violations))
+				var got []string
+				for _, v := range violations {
+					got = append(got, fmt.Sprintf("%s %s", v["instanceLocation"], v["keyword"]))
+				}
+				assert.ElementsMatch(t, test.violations, got)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// compare JSON representations, as the violations are unexported
+			expected, err := json.Marshal(test.result)
+			require.NoError(t, err)
+			actual, err := json.Marshal(res.Result)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+
+			// the validated result is stored in cache and in the decision log
+			require.Equal(t, 1, cache.SetCallCount())
+			_, _, _, _, cached, _ := cache.SetArgsForCall(0)
+			assert.JSONEq(t, string(expected), string(cached))
+			require.Equal(t, 1, decisionLog.LogCallCount())
+			logged, err := json.Marshal(decisionLog.LogArgsForCall(0).Result)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(logged))
+		})
+	}
+}
+
+func TestService_EvaluateVersionResolution(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			if !slices.Contains([]string{"1.0", "1.2.0", "2.0.0-beta"}, version) {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: fmt.Sprintf(`package testgroup.example _ = %q`, version)}},
+			}, nil
+		},
+		PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+			if alias != "production" {
+				return nil, errors.New(errors.NotFound, "policy alias not found")
+			}
+			return &storage.PolicyAlias{Repository: repo, Group: group, Name: name, Alias: alias, Version: "1.0"}, nil
+		},
+		PolicyVersionsStub: func(ctx context.Context, repo, group, name string) ([]string, error) {
+			return []string{"1.0", "1.2.0", "2.0.0-beta"}, nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		result  string
+		errtext string
+	}{
+		{name: "exact version", version: "1.0", result: "1.0"},
+		{name: "named alias", version: "production", result: "1.0"},
+		{name: "latest version", version: "latest", result: "2.0.0-beta"},
+		{name: "stable version", version: "stable", result: "1.2.0"},
+		{name: "version range", version: "~1.0", result: "1.0"},
+		{name: "caret version range", version: "^1", result: "1.2.0"},
+		{name: "version range is not matched", version: "^3", errtext: `no policy version matches "^3"`},
+		{name: "version is not found", version: "staging", errtext: "policy not found"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    test.version,
+			})
+			if test.errtext != "" {
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				assert.True(t, errors.Is(errors.NotFound, err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.result, res.Result)
+			assert.Equal(t, test.result, res.Version)
+		})
+	}
+}
+
+func TestService_EvaluateCachesVersionResolution(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			if version != "1.0" && version != "1.2.0" {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			}
+			return &storage.Policy{
+				Repository: repo,
+				Name:       name,
+				Group:      group,
+				Version:    version,
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: fmt.Sprintf(`package testgroup.example _ = %q`, version)}},
+			}, nil
+		},
+		PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+			if alias != "production" {
+				return nil, errors.New(errors.NotFound, "policy alias not found")
+			}
+			return &storage.PolicyAlias{Repository: repo, Group: group, Name: name, Alias: alias, Version: "1.0"}, nil
+		},
+		PolicyVersionsStub: func(ctx context.Context, repo, group, name string) ([]string, error) {
+			return []string{"1.0", "1.2.0"}, nil
+		},
+		DeletePolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) error {
+			return nil
+		},
+	}
+
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+	evaluate := func(version string) {
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    version,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.Version)
+	}
+
+	evaluate("production")
+	evaluate("production")
+	evaluate("latest")
+	evaluate("latest")
+	assert.Equal(t, 2, policyStorage.PolicyAliasCallCount())
+	assert.Equal(t, 1, policyStorage.PolicyVersionsCallCount())
+
+	// a new version of the policy invalidates the resolution
+	err := svc.PolicyDataChange(context.Background(), "policies", "testgroup", "example", "1.3.0")
+	require.NoError(t, err)
+	evaluate("latest")
+	assert.Equal(t, 2, policyStorage.PolicyVersionsCallCount())
+
+	// an alias change invalidates the resolution
+	err = svc.DeletePolicyAlias(context.Background(), &goapolicy.DeletePolicyAliasRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Alias:      "production",
+	})
+	require.NoError(t, err)
+	// the deleted alias is retrieved for the audit log
+	assert.Equal(t, 4, policyStorage.PolicyAliasCallCount())
+	evaluate("production")
+	assert.Equal(t, 5, policyStorage.PolicyAliasCallCount())
+}
+
+func TestService_SetPolicyAlias(t *testing.T) {
+	t.Run("alias is reserved", func(t *testing.T) {
+		policyStorage := &policyfakes.FakeStorage{}
+
+		svc := policy.New(context.Background(), policyStorage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		for _, alias := range []string{"latest", "stable"} {
+			res, err := svc.SetPolicyAlias(context.Background(), &goapolicy.SetPolicyAliasRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Alias:      alias,
+				Version:    "1.0",
+			})
+			assert.Nil(t, res)
+			require.Error(t, err)
+			assert.True(t, errors.Is(errors.BadRequest, err))
+		}
+		assert.Equal(t, 0, policyStorage.SavePolicyAliasCallCount())
+	})
+
+	t.Run("policy version doesn't exist", func(t *testing.T) {
+		policyStorage := &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+				return nil, errors.New(errors.NotFound, "policy not found")
+			},
+		}
+
+		svc := policy.New(context.Background(), policyStorage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.SetPolicyAlias(context.Background(), &goapolicy.SetPolicyAliasRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+			Version:    "3.0",
+		})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.NotFound, err))
+		assert.Equal(t, 0, policyStorage.SavePolicyAliasCallCount())
+	})
+
+	t.Run("alias is saved", func(t *testing.T) {
+		lastUpdate := time.Now()
+		policyStorage := &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+				return &storage.Policy{Repository: repo, Group: group, Name: name, Version: version}, nil
+			},
+			PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+				return &storage.PolicyAlias{Repository: repo, Group: group, Name: name, Alias: alias, Version: "1.0", LastUpdate: lastUpdate}, nil
+			},
+		}
+
+		svc := policy.New(context.Background(), policyStorage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.SetPolicyAlias(context.Background(), &goapolicy.SetPolicyAliasRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+			Version:    "1.0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &goapolicy.PolicyAlias{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+			Version:    "1.0",
+			LastUpdate: lastUpdate.Unix(),
+		}, res)
+
+		require.Equal(t, 1, policyStorage.SavePolicyAliasCallCount())
+		_, alias := policyStorage.SavePolicyAliasArgsForCall(0)
+		assert.Equal(t, "production", alias.Alias)
+		assert.Equal(t, "1.0", alias.Version)
+	})
+
+	t.Run("alias changes are recorded in the audit log", func(t *testing.T) {
+		current := &storage.PolicyAlias{Repository: "policies", Group: "testgroup", Name: "example", Alias: "production", Version: "1.0"}
+		policyStorage := &policyfakes.FakeStorage{
+			PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+				return &storage.Policy{Repository: repo, Group: group, Name: name, Version: version}, nil
+			},
+			PolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) (*storage.PolicyAlias, error) {
+				if current == nil {
+					return nil, errors.New(errors.NotFound, "policy alias not found")
+				}
+				a := *current
+				return &a, nil
+			},
+			SavePolicyAliasStub: func(ctx context.Context, alias *storage.PolicyAlias) error {
+				current = alias
+				return nil
+			},
+			DeletePolicyAliasStub: func(ctx context.Context, repo, group, name, alias string) error {
+				current = nil
+				return nil
+			},
+		}
+		auditLog := &policyfakes.FakeAuditLog{}
+
+		svc := policy.New(context.Background(), policyStorage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithAuditLog(auditLog))
+		_, err := svc.SetPolicyAlias(context.Background(), &goapolicy.SetPolicyAliasRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+			Version:    "2.0",
+		})
+		require.NoError(t, err)
+		err = svc.DeletePolicyAlias(context.Background(), &goapolicy.DeletePolicyAliasRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, auditLog.RecordCallCount())
+		_, set := auditLog.RecordArgsForCall(0)
+		assert.Equal(t, storage.AuditSetPolicyAlias, set.Operation)
+		assert.Equal(t, "example", set.Name)
+		assert.Equal(t, "production", set.Target)
+		assert.Equal(t, "1.0", set.BeforeVersion)
+		assert.Equal(t, "2.0", set.AfterVersion)
+		assert.NotEqual(t, set.BeforeHash, set.AfterHash)
+
+		_, del := auditLog.RecordArgsForCall(1)
+		assert.Equal(t, storage.AuditDeletePolicyAlias, del.Operation)
+		assert.Equal(t, "production", del.Target)
+		assert.Equal(t, "2.0", del.BeforeVersion)
+		assert.Empty(t, del.AfterVersion)
+		assert.Equal(t, set.AfterHash, del.BeforeHash)
+		assert.Empty(t, del.AfterHash)
+
+		// deleting a missing alias isn't recorded
+		err = svc.DeletePolicyAlias(context.Background(), &goapolicy.DeletePolicyAliasRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Alias:      "production",
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(errors.NotFound, err))
+		assert.Equal(t, 1, policyStorage.DeletePolicyAliasCallCount())
+		assert.Equal(t, 2, auditLog.RecordCallCount())
+	})
+}
+
+func TestService_EvaluateShadow(t *testing.T) {
+	tests := []struct {
+		name         string
+		shadowRego   string
+		divergence   bool
+		shadowResult any
+		shadowError  string
+	}{
+		{
+			name:       "shadow result is equal",
+			shadowRego: `package testgroup.example allow := input.msg == "yes"`,
+		},
+		{
+			name:         "shadow result diverges",
+			shadowRego:   `package testgroup.example allow := input.msg == "no"`,
+			divergence:   true,
+			shadowResult: map[string]interface{}{"allow": false},
+		},
+		{
+			name:        "shadow evaluation fails",
+			shadowRego:  `package testgroup.example allow := 1 / 0`,
+			divergence:  true,
+			shadowError: "div: divide by zero",
+		},
+		{
+			name:        "shadow extension function call is not executed",
+			shadowRego:  `package testgroup.example allow := cache.get("key", "namespace", "scope") == "yes"`,
+			divergence:  true,
+			shadowError: "call of extension function cache.get with arguments",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyStorage := &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					pol := &storage.Policy{
+						Repository: repo,
+						Name:       name,
+						Group:      group,
+						Version:    version,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := input.msg == "yes"`}},
+					}
+					if version == "1.0" {
+						pol.ShadowVersion = "2.0"
+					} else {
+						pol.Modules[0].Rego = test.shadowRego
+					}
+					return pol, nil
+				},
+			}
+
+			policyCache := regocache.New()
+			svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+				Repository:   "policies",
+				Group:        "testgroup",
+				PolicyName:   "example",
+				Version:      "1.0",
+				Input:        map[string]interface{}{"msg": "yes"},
+				EvaluationID: ptr.String("123"),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"allow": true}, res.Result)
+
+			// the shadow version is prepared in the background
+			require.Eventually(t, func() bool {
+				for i := 0; i < policyStorage.PolicyCallCount(); i++ {
+					if _, _, _, _, version := policyStorage.PolicyArgsForCall(i); version == "2.0" {
+						return true
+					}
+				}
+				return false
+			}, 5*time.Second, 10*time.Millisecond)
+
+			if !test.divergence {
+				time.Sleep(100 * time.Millisecond)
+				assert.Equal(t, 0, policyStorage.SaveShadowDivergenceCallCount())
+
+				// the replay query of the shadow version is cached
+				// separately from the query of the version itself
+				key := regocache.Key("policies", "testgroup", "example", "2.0")
+				_, ok := policyCache.GetQuery(key, regocache.ReplayRule(""))
+				assert.True(t, ok)
+				_, ok = policyCache.GetQuery(key, "")
+				assert.False(t, ok)
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return policyStorage.SaveShadowDivergenceCallCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			_, divergence := policyStorage.SaveShadowDivergenceArgsForCall(0)
+			assert.Equal(t, "123", divergence.EvaluationID)
+			assert.Equal(t, "1.0", divergence.Version)
+			assert.Equal(t, "2.0", divergence.ShadowVersion)
+			assert.NotEmpty(t, divergence.InputHash)
+			assert.Equal(t, map[string]interface{}{"allow": true}, divergence.Result)
+			assert.Equal(t, test.shadowResult, divergence.ShadowResult)
+			assert.Contains(t, divergence.ShadowError, test.shadowError)
+		})
+	}
+}
+
+func TestService_SetPolicyShadow(t *testing.T) {
+	tests := []struct {
+		name          string
+		shadowVersion string
+		storage       *policyfakes.FakeStorage
+		errkind       errors.Kind
+		errtext       string
+	}{
+		{
+			name:          "shadow version is the policy version",
+			shadowVersion: "1.0",
+			storage:       &policyfakes.FakeStorage{},
+			errkind:       errors.BadRequest,
+			errtext:       "policy version cannot be evaluated in its own shadow",
+		},
+		{
+			name:          "shadow version is not found",
+			shadowVersion: "2.0",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					return nil, errors.New(errors.NotFound, "policy not found")
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "policy not found",
+		},
+		{
+			name:          "policy is not found",
+			shadowVersion: "2.0",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+				SetPolicyShadowStub: func(ctx context.Context, repo, group, name, version, shadowVersion string) error {
+					return errors.New(errors.NotFound, "policy not found")
+				},
+			},
+			errkind: errors.NotFound,
+			errtext: "policy not found",
+		},
+		{
+			name:          "shadow version is set",
+			shadowVersion: "2.0",
+			storage: &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					return &storage.Policy{}, nil
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), test.storage, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			err := svc.SetPolicyShadow(context.Background(), &goapolicy.SetPolicyShadowRequest{
+				Repository:    "policies",
+				Group:         "testgroup",
+				PolicyName:    "example",
+				Version:       "1.0",
+				ShadowVersion: test.shadowVersion,
+			})
+			if test.errtext != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				assert.Equal(t, test.errkind, err.(*errors.Error).Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, test.storage.SetPolicyShadowCallCount())
+			_, _, _, _, version, shadowVersion := test.storage.SetPolicyShadowArgsForCall(0)
+			assert.Equal(t, "1.0", version)
+			assert.Equal(t, "2.0", shadowVersion)
+		})
+	}
+}
+
+func TestService_CaptureEvaluation(t *testing.T) {
+	capture := &storage.CaptureConfig{
+		SampleRate:    1,
+		MaxRecords:    10,
+		RedactFields:  []string{"user.email"},
+		RedactHeaders: []string{"x-client-secret"},
+	}
+
+	tests := []struct {
+		name     string
+		capture  *storage.CaptureConfig
+		full     bool
+		captured bool
+	}{
+		{
+			name: "capture is not enabled",
+		},
+		{
+			name:    "maximum number of records is reached",
+			capture: capture,
+			full:    true,
+		},
+		{
+			name:     "evaluation is captured",
+			capture:  capture,
+			captured: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			saved := make(chan *storage.CapturedEvaluation, 1)
+			policyStorage := &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					return &storage.Policy{
+						Repository: repo,
+						Name:       name,
+						Group:      group,
+						Version:    version,
+						Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := external.http.header("Authorization") == "secret"`}},
+						Capture:    test.capture,
+					}, nil
+				},
+				SaveCapturedEvaluationStub: func(ctx context.Context, evaluation *storage.CapturedEvaluation, maxRecords int) (bool, error) {
+					assert.Equal(t, 10, maxRecords)
+					if test.full {
+						return false, nil
+					}
+					saved <- evaluation
+					return true, nil
+				},
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Authorization", "secret")
+			r.Header.Set("X-Client-Id", "client")
+			r.Header.Set("X-Client-Secret", "client-secret")
+			ctx := header.ToContext(context.Background(), r)
+
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.Evaluate(ctx, &goapolicy.EvaluateRequest{
+				Repository:   "policies",
+				Group:        "testgroup",
+				PolicyName:   "example",
+				Version:      "1.0",
+				Input:        map[string]interface{}{"user": map[string]interface{}{"name": "alice", "email": "alice@example.com"}},
+				EvaluationID: ptr.String("123"),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"allow": true}, res.Result)
+
+			if !test.captured {
+				time.Sleep(100 * time.Millisecond)
+				assert.Empty(t, saved)
+				return
+			}
+
+			var evaluation *storage.CapturedEvaluation
+			select {
+			case evaluation = <-saved:
+			case <-time.After(5 * time.Second):
+				t.Fatal("evaluation is not captured")
+			}
+
+			assert.Equal(t, "123", evaluation.EvaluationID)
+			assert.Equal(t, "1.0", evaluation.Version)
+			assert.Equal(t, map[string]interface{}{"user": map[string]interface{}{"name": "alice", "email": "[REDACTED]"}}, evaluation.Input)
+			// credential headers and redacted headers aren't stored
+			assert.NotContains(t, evaluation.Headers, "Authorization")
+			assert.NotContains(t, evaluation.Headers, "X-Client-Secret")
+			assert.Equal(t, "client", evaluation.Headers["X-Client-Id"])
+			assert.Equal(t, map[string]interface{}{"allow": true}, evaluation.Result)
+			assert.Empty(t, evaluation.Error)
+			require.Len(t, evaluation.Calls, 1)
+			assert.Equal(t, "external.http.header", evaluation.Calls[0].Name)
+			assert.Equal(t, []any{"Authorization"}, evaluation.Calls[0].Args)
+			assert.Equal(t, "[REDACTED]", evaluation.Calls[0].Result)
+		})
+	}
+}
+
+func TestService_Authorize(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository:    repo,
+				Name:          name,
+				Group:         group,
+				Version:       version,
+				Modules:       []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := input.user == "alice"`}},
+				ShadowVersion: "2.0",
+				Capture:       &storage.CaptureConfig{SampleRate: 1},
+			}, nil
+		},
+	}
+	cache := &policyfakes.FakeCache{}
+	decisionLog := &policyfakes.FakeDecisionLog{}
+
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionLog(decisionLog))
+	res, err := svc.Authorize(context.Background(), &goapolicy.EvaluateRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Rule:       ptr.String("allow"),
+		Input:      map[string]interface{}{"user": "alice"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, res.Result)
+
+	// the result isn't stored in cache and the evaluation
+	// is neither captured nor evaluated with the shadow version
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, cache.SetCallCount())
+	assert.Equal(t, 0, policyStorage.SaveCapturedEvaluationCallCount())
+	assert.Equal(t, 1, policyStorage.PolicyCallCount())
+	assert.Equal(t, 1, decisionLog.LogCallCount())
+}
+
+func TestService_EvaluateUncached(t *testing.T) {
+	policyStorage := &policyfakes.FakeStorage{
+		PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+			return &storage.Policy{
+				Repository:    repo,
+				Name:          name,
+				Group:         group,
+				Version:       version,
+				Modules:       []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := input.user == "alice"`}},
+				ShadowVersion: "2.0",
+				Capture:       &storage.CaptureConfig{SampleRate: 1},
+			}, nil
+		},
+	}
+	cache := &policyfakes.FakeCache{}
+	decisionLog := &policyfakes.FakeDecisionLog{}
+
+	svc := policy.New(context.Background(), policyStorage, regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithDecisionLog(decisionLog))
+	res, err := svc.EvaluateUncached(context.Background(), &goapolicy.EvaluateRequest{
+		Repository: "policies",
+		Group:      "testgroup",
+		PolicyName: "example",
+		Version:    "1.0",
+		Rule:       ptr.String("allow"),
+		Input:      map[string]interface{}{"user": "alice"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, res.Result)
+
+	// the result isn't stored in cache, but the evaluation
+	// is captured and evaluated with the shadow version
+	require.Eventually(t, func() bool {
+		return policyStorage.SaveCapturedEvaluationCallCount() == 1 && policyStorage.PolicyCallCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, cache.SetCallCount())
+	assert.Equal(t, 1, decisionLog.LogCallCount())
+}
+
+func TestService_Replay(t *testing.T) {
+	const recordedRego = `package testgroup.example
+
+default allow = false
+
+allow {
+	did_to_url(input.did) == "https://example.com/.well-known/did.json"
+}
+`
+	call := func(did, url string) []storage.FunctionCall {
+		return []storage.FunctionCall{{Name: "did_to_url", Args: []any{did}, Result: url}}
+	}
+
+	evaluations := []*storage.CapturedEvaluation{
+		{
+			EvaluationID: "1",
+			Input:        map[string]any{"did": "did:web:example.com"},
+			Calls:        call("did:web:example.com", "https://example.com/.well-known/did.json"),
+			Result:       map[string]any{"allow": true},
+		},
+		{
+			EvaluationID: "2",
+			Input:        map[string]any{"did": "did:web:other.com"},
+			Calls:        call("did:web:other.com", "https://other.com/.well-known/did.json"),
+			Result:       map[string]any{"allow": false},
+		},
+		{
+			EvaluationID: "3",
+			Input:        map[string]any{"did": "did:web:fail.com"},
+			Calls:        []storage.FunctionCall{{Name: "did_to_url", Args: []any{"did:web:fail.com"}, Undefined: true, Error: "did resolution failed"}},
+			Error:        "did resolution failed",
+		},
+		{
+			EvaluationID: "4",
+			Rule:         "allow",
+			Input:        map[string]any{"did": "did:web:new.com"},
+			Result:       false,
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  *goapolicy.ReplayRequest
+
+		candidate string
+		unchanged int
+		diffs     map[string]string
+		errkind   errors.Kind
+		errtext   string
+	}{
+		{
+			name:    "candidate is not specified",
+			req:     &goapolicy.ReplayRequest{},
+			errkind: errors.BadRequest,
+			errtext: "either candidate version or rego must be specified",
+		},
+		{
+			name:    "candidate version and rego are specified",
+			req:     &goapolicy.ReplayRequest{CandidateVersion: ptr.String("2.0"), Rego: ptr.String("package testgroup.example")},
+			errkind: errors.BadRequest,
+			errtext: "either candidate version or rego must be specified",
+		},
+		{
+			name:    "candidate version is not found",
+			req:     &goapolicy.ReplayRequest{CandidateVersion: ptr.String("3.0")},
+			errkind: errors.NotFound,
+			errtext: "policy not found",
+		},
+		{
+			name:    "candidate rego doesn't compile",
+			req:     &goapolicy.ReplayRequest{Rego: ptr.String("package testgroup.example\n\nallow {")},
+			errkind: errors.BadRequest,
+			errtext: "error preparing replay query",
+		},
+		{
+			name:      "candidate version",
+			req:       &goapolicy.ReplayRequest{CandidateVersion: ptr.String("2.0")},
+			candidate: "2.0",
+			unchanged: 2,
+			diffs:     map[string]string{"2": "changed", "4": "new_error"},
+		},
+		{
+			name:      "candidate rego",
+			req:       &goapolicy.ReplayRequest{Rego: ptr.String("package testgroup.example\n\ndefault allow = false\n")},
+			candidate: "rego",
+			unchanged: 2,
+			diffs:     map[string]string{"1": "changed", "3": "fixed_error"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyStorage := &policyfakes.FakeStorage{
+				PolicyStub: func(ctx context.Context, repo, group, name, version string) (*storage.Policy, error) {
+					pol := &storage.Policy{Repository: repo, Group: group, Name: name, Version: version}
+					switch version {
+					case "1.0":
+						pol.Modules = []storage.Module{{Filename: "policy.rego", Rego: recordedRego}}
+					case "2.0":
+						pol.Modules = []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example
+
+default allow = false
+
+allow {
+	startswith(did_to_url(input.did), "https://")
+}
+`}}
+					default:
+						return nil, errors.New(errors.NotFound, "policy not found")
+					}
+					return pol, nil
+				},
+			}
+			policyStorage.CapturedEvaluationsReturns(evaluations, nil)
+
+			req := test.req
+			req.Repository = "policies"
+			req.Group = "testgroup"
+			req.PolicyName = "example"
+			req.Version = "1.0"
+			req.Limit = 100
+
+			svc := policy.New(context.Background(), policyStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			report, err := svc.Replay(context.Background(), req)
+			if test.errtext != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				assert.Equal(t, test.errkind, err.(*errors.Error).Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.candidate, report.Candidate)
+			assert.Equal(t, len(evaluations), report.Total)
+			assert.Equal(t, test.unchanged, report.Unchanged)
+			assert.Equal(t, len(test.diffs), report.Changed+report.NewErrors+report.FixedErrors)
+
+			diffs := make(map[string]string, len(report.Diffs))
+			for _, diff := range report.Diffs {
+				diffs[diff.EvaluationID] = diff.Status
+			}
+			assert.Equal(t, test.diffs, diffs)
+
+			_, _, _, _, version, limit := policyStorage.CapturedEvaluationsArgsForCall(0)
+			assert.Equal(t, "1.0", version)
+			assert.Equal(t, 100, limit)
+		})
+	}
+}
+
+func TestService_EvaluationResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		wait        *int
+		cache       *policyfakes.FakeCache
+		minGetCalls int
+		res         *goapolicy.StoredEvaluationResult
+		errkind     errors.Kind
+		errtext     string
+	}{
+		{
+			name: "result is not found",
+			cache: &policyfakes.FakeCache{
+				GetStub: func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+					return nil, errors.New(errors.NotFound)
+				},
+			},
+			minGetCalls: 1,
+			errkind:     errors.NotFound,
+			errtext:     "evaluation result not found",
+		},
+		{
+			name: "cache service is not available",
+			cache: &policyfakes.FakeCache{
+				GetStub: func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+					return nil, errors.New(errors.ServiceUnavailable, "cache service is not available")
+				},
+			},
+			minGetCalls: 1,
+			errkind:     errors.ServiceUnavailable,
+			errtext:     "cache service is not available",
+		},
+		{
+			name: "result is found",
+			cache: &policyfakes.FakeCache{
+				GetStub: func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+					return []byte(`{"allow":true}`), nil
+				},
+			},
+			minGetCalls: 1,
+			res: &goapolicy.StoredEvaluationResult{
+				Result: map[string]any{"allow": true},
+				ETag:   "123",
+			},
+		},
+		{
+			name: "result is found while waiting",
+			wait: ptr.Int(5),
+			cache: &policyfakes.FakeCache{
+				GetStub: func() func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+					calls := 0
+					return func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+						calls++
+						if calls < 2 {
+							return nil, errors.New(errors.NotFound)
+						}
+						return []byte(`{"allow":false}`), nil
+					}
+				}(),
+			},
+			minGetCalls: 2,
+			res: &goapolicy.StoredEvaluationResult{
+				Result: map[string]any{"allow": false},
+				ETag:   "123",
+			},
+		},
+		{
+			name: "result is not found after waiting",
+			wait: ptr.Int(1),
+			cache: &policyfakes.FakeCache{
+				GetStub: func(ctx context.Context, key, namespace, scope string) ([]byte, error) {
+					return nil, errors.New(errors.NotFound)
+				},
+			},
+			minGetCalls: 3,
+			errkind:     errors.NotFound,
+			errtext:     "evaluation result not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := policy.New(context.Background(), nil, nil, test.cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+			res, err := svc.EvaluationResult(context.Background(), &goapolicy.EvaluationResultRequest{
+				EvaluationID: "123",
+				Wait:         test.wait,
+			})
+			assert.GreaterOrEqual(t, test.cache.GetCallCount(), test.minGetCalls)
+			if test.errtext != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errtext)
+				assert.Equal(t, test.errkind, err.(*errors.Error).Kind)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.res, res)
+
+			_, key, _, _ := test.cache.GetArgsForCall(0)
+			assert.Equal(t, "123", key)
+		})
+	}
+}
+
+func TestService_EvaluateAsync(t *testing.T) {
+	newStorage := func() *memory.Storage {
+		keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+			return strings.Join([]string{repo, group, name, version}, ",")
+		}}
+		return memory.New(keyConstructor, map[string]*storage.Policy{
+			"policies,testgroup,example,1.0": {
+				Repository: "policies",
+				Group:      "testgroup",
+				Name:       "example",
+				Version:    "1.0",
+				Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := input.msg == "yes"`}},
+			},
+		}, zap.NewNop())
+	}
+
+	t.Run("asynchronous evaluation is not enabled", func(t *testing.T) {
+		svc := policy.New(context.Background(), newStorage(), regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Async:      ptr.Bool(true),
+		})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Equal(t, errors.BadRequest, err.(*errors.Error).Kind)
+		assert.Contains(t, err.Error(), "asynchronous evaluation is not enabled")
+	})
+
+	t.Run("invalid callback URL", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		svc := policy.New(ctx, newStorage(), regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithJobs(1, 10, time.Hour, time.Minute))
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository:  "policies",
+			Group:       "testgroup",
+			PolicyName:  "example",
+			Version:     "1.0",
+			Async:       ptr.Bool(true),
+			CallbackURL: ptr.String("ftp://example.com"),
+		})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Equal(t, errors.BadRequest, err.(*errors.Error).Kind)
+		assert.Contains(t, err.Error(), "invalid callback URL")
+	})
+
+	t.Run("callback host is not allowed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tests := []struct {
+			name        string
+			hosts       []string
+			callbackURL string
+		}{
+			{name: "loopback address", callbackURL: "http://127.0.0.1:8080/callback"},
+			{name: "private address", callbackURL: "http://10.0.0.1/callback"},
+			{name: "link-local address", callbackURL: "http://169.254.169.254/latest/meta-data"},
+			{name: "host is not in allowed hosts", hosts: []string{"callback.example.com"}, callbackURL: "https://example.com/callback"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				svc := policy.New(ctx, newStorage(), regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+					policy.WithJobs(1, 10, time.Hour, time.Minute),
+					policy.WithJobCallbackHosts(test.hosts...),
+				)
+				res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+					Repository:  "policies",
+					Group:       "testgroup",
+					PolicyName:  "example",
+					Version:     "1.0",
+					Async:       ptr.Bool(true),
+					CallbackURL: ptr.String(test.callbackURL),
+				})
+				assert.Nil(t, res)
+				require.Error(t, err)
+				assert.Equal(t, errors.BadRequest, err.(*errors.Error).Kind)
+				assert.Contains(t, err.Error(), "invalid callback URL")
+			})
+		}
+	})
+
+	t.Run("resumed job is evaluated with stored caller", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		jobStorage := newStorage()
+		err := jobStorage.CreateJob(context.Background(), &storage.Job{
+			ID:           "job1",
+			EvaluationID: "123",
+			Repository:   "policies",
+			Group:        "testgroup",
+			Name:         "example",
+			Version:      "1.0",
+			Input:        []byte(`{"msg":"yes"}`),
+			Headers:      map[string]string{"Authorization": "Bearer token"},
+			Caller:       "alice",
+			ClientIP:     "10.0.0.1",
+			Status:       storage.JobPending,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		})
+		require.NoError(t, err)
+
+		decisionLog := &policyfakes.FakeDecisionLog{}
+		svc := policy.New(ctx, jobStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+			policy.WithJobs(1, 10, time.Hour, time.Minute),
+			policy.WithDecisionLog(decisionLog),
+		)
+
+		require.Eventually(t, func() bool {
+			job, err := svc.JobStatus(context.Background(), &goapolicy.JobStatusRequest{JobID: "job1"})
+			return err == nil && job.Status == "done"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			return decisionLog.LogCallCount() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		decision := decisionLog.LogArgsForCall(0)
+		assert.Equal(t, "alice", decision.Caller)
+		assert.Equal(t, "10.0.0.1", decision.ClientIP)
+
+		// the headers are removed when the job is finished
+		job, err := jobStorage.Job(context.Background(), "job1")
+		require.NoError(t, err)
+		assert.Nil(t, job.Headers)
+	})
+
+	t.Run("policy is not found", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		svc := policy.New(ctx, newStorage(), regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithJobs(1, 10, time.Hour, time.Minute))
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "2.0",
+			Async:      ptr.Bool(true),
+		})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).Kind)
+	})
+
+	t.Run("result is stored and delivered to callback URL", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		callbacks := make(chan map[string]any, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			callbacks <- body
+		}))
+		defer srv.Close()
+
+		cache := &policyfakes.FakeCache{}
+		svc := policy.New(ctx, newStorage(), regocache.New(), cache, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+			policy.WithJobs(1, 10, time.Hour, time.Minute),
+			policy.WithJobCallbackHosts("127.0.0.1"),
+		)
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository:   "policies",
+			Group:        "testgroup",
+			PolicyName:   "example",
+			Version:      "1.0",
+			Input:        map[string]any{"msg": "yes"},
+			EvaluationID: ptr.String("123"),
+			TTL:          ptr.Int(60),
+			Async:        ptr.Bool(true),
+			CallbackURL:  ptr.String(srv.URL),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "123", res.ETag)
+		assert.Equal(t, "1.0", res.Version)
+		assert.Equal(t, "pending", *res.JobStatus)
+		require.NotNil(t, res.JobID)
+
+		select {
+		case body := <-callbacks:
+			assert.Equal(t, *res.JobID, body["jobID"])
+			assert.Equal(t, "123", body["ETag"])
+			assert.Equal(t, "done", body["status"])
+			assert.Equal(t, map[string]any{"allow": true}, body["result"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("job result is not delivered to callback URL")
+		}
+
+		require.Equal(t, 1, cache.SetCallCount())
+		_, key, _, _, value, ttl := cache.SetArgsForCall(0)
+		assert.Equal(t, "123", key)
+		assert.JSONEq(t, `{"allow":true}`, string(value))
+		assert.Equal(t, 60, ttl)
+
+		require.Eventually(t, func() bool {
+			job, err := svc.JobStatus(context.Background(), &goapolicy.JobStatusRequest{JobID: *res.JobID})
+			return err == nil && job.Status == "done"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("credential headers are not stored with the job", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		jobs := make(chan *storage.Job, 1)
+		jobStorage := newStorage()
+		svc := policy.New(ctx, &policyfakes.FakeStorage{
+			PolicyStub: jobStorage.Policy,
+			CreateJobStub: func(ctx context.Context, job *storage.Job) error {
+				jobs <- job
+				return nil
+			},
+			ClaimJobStub: func(ctx context.Context, id string, staleBefore time.Time) (*storage.Job, error) {
+				return nil, errors.New(errors.NotFound, "job not found")
+			},
+		}, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithJobs(1, 10, time.Hour, time.Minute))
+
+		reqCtx := header.WithHeaders(context.Background(), map[string]string{
+			"Authorization": "Bearer token",
+			"Cookie":        "session=1",
+			"X-Client-Id":   "client",
+		})
+		_, err := svc.Evaluate(reqCtx, &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Async:      ptr.Bool(true),
+		})
+		require.NoError(t, err)
+
+		job := <-jobs
+		assert.Equal(t, map[string]string{"X-Client-Id": "client"}, job.Headers)
+	})
+
+	t.Run("failed evaluation is recorded", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		jobStorage := newStorage()
+		svc := policy.New(ctx, jobStorage, regocache.New(), &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(), policy.WithJobs(1, 10, time.Hour, time.Minute))
+		res, err := svc.Evaluate(context.Background(), &goapolicy.EvaluateRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+			Rule:       ptr.String("deny"),
+			Async:      ptr.Bool(true),
+		})
+		require.NoError(t, err)
+
+		var job *goapolicy.EvaluationJob
+		require.Eventually(t, func() bool {
+			job, err = svc.JobStatus(context.Background(), &goapolicy.JobStatusRequest{JobID: *res.JobID})
+			return err == nil && job.Status == "failed"
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, "deny", *job.Rule)
+		require.NotNil(t, job.Error)
+	})
+}
+
+func TestService_JobStatus(t *testing.T) {
+	t.Run("job is not found", func(t *testing.T) {
+		svc := policy.New(context.Background(), &policyfakes.FakeStorage{
+			JobStub: func(ctx context.Context, id string) (*storage.Job, error) {
+				return nil, errors.New(errors.NotFound, "job not found")
+			},
+		}, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.JobStatus(context.Background(), &goapolicy.JobStatusRequest{JobID: "1"})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).Kind)
+	})
+
+	t.Run("job is found", func(t *testing.T) {
+		created := time.Now().Add(-time.Minute)
+		svc := policy.New(context.Background(), &policyfakes.FakeStorage{
+			JobStub: func(ctx context.Context, id string) (*storage.Job, error) {
+				return &storage.Job{
+					ID:            id,
+					EvaluationID:  "123",
+					Repository:    "policies",
+					Group:         "testgroup",
+					Name:          "example",
+					Version:       "1.0",
+					CallbackURL:   "https://example.com/callback",
+					CallbackError: "unexpected response: 500",
+					Status:        storage.JobDone,
+					CreatedAt:     created,
+					UpdatedAt:     created,
+				}, nil
+			},
+		}, nil, nil, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.JobStatus(context.Background(), &goapolicy.JobStatusRequest{JobID: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, &goapolicy.EvaluationJob{
+			JobID:         "1",
+			ETag:          "123",
+			Repository:    "policies",
+			Group:         "testgroup",
+			PolicyName:    "example",
+			Version:       "1.0",
+			Status:        "done",
+			CallbackURL:   ptr.String("https://example.com/callback"),
+			CallbackError: ptr.String("unexpected response: 500"),
+			CreatedAt:     created.Unix(),
+			UpdatedAt:     created.Unix(),
+		}, res)
+	})
+}
+
+func TestService_AuditLog(t *testing.T) {
+	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(repo, group, name, version string) string {
+		return strings.Join([]string{repo, group, name, version}, ",")
+	}}
+	policyStorage := memory.New(keyConstructor, map[string]*storage.Policy{
+		"policies,testgroup,example,1.0": {
+			Repository: "policies",
+			Group:      "testgroup",
+			Name:       "example",
+			Version:    "1.0",
+			Modules:    []storage.Module{{Filename: "policy.rego", Rego: `package testgroup.example allow := true`}},
+		},
+	}, zap.NewNop())
+
+	t.Run("audit log is not configured", func(t *testing.T) {
+		svc := policy.New(context.Background(), policyStorage, &policyfakes.FakeRegoCache{}, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop())
+		res, err := svc.AuditLog(context.Background(), &goapolicy.AuditLogRequest{Limit: 100})
+		assert.Nil(t, res)
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).Kind)
+	})
+
+	t.Run("lock and unlock are recorded", func(t *testing.T) {
+		token, err := jwt.NewBuilder().Subject("admin").Build()
+		require.NoError(t, err)
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.RemoteAddr = "8.8.8.8:1234"
+		r.Header.Set("Authorization", "Bearer "+string(signed))
+		ctx := caller.ToContext(context.Background(), r, caller.WithVerifiedTokens())
+
+		svc := policy.New(context.Background(), policyStorage, &policyfakes.FakeRegoCache{}, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+			policy.WithAuditLog(audit.New(policyStorage, zap.NewNop())),
+		)
+
+		err = svc.Lock(ctx, &goapolicy.LockRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+		})
+		require.NoError(t, err)
+		err = svc.Unlock(ctx, &goapolicy.UnlockRequest{
+			Repository: "policies",
+			Group:      "testgroup",
+			PolicyName: "example",
+			Version:    "1.0",
+		})
+		require.NoError(t, err)
+
+		res, err := svc.AuditLog(ctx, &goapolicy.AuditLogRequest{Actor: ptr.String("admin"), Limit: 100})
+		require.NoError(t, err)
+		require.Len(t, res.Records, 2)
+
+		unlock, lock := res.Records[0], res.Records[1]
+		assert.Equal(t, storage.AuditUnlock, unlock.Operation)
+		assert.Equal(t, storage.AuditLock, lock.Operation)
+		assert.Equal(t, "admin", *lock.Actor)
+		assert.Equal(t, "8.8.8.8", *lock.ClientIP)
+		assert.Equal(t, "testgroup", *lock.Group)
+		assert.Equal(t, "example", *lock.PolicyName)
+		assert.NotEqual(t, *lock.BeforeHash, *lock.AfterHash)
+		// unlocking restores the policy state before the lock
+		assert.Equal(t, *lock.AfterHash, *unlock.BeforeHash)
+		assert.Equal(t, *lock.BeforeHash, *unlock.AfterHash)
+
+		res, err = svc.AuditLog(ctx, &goapolicy.AuditLogRequest{Operation: ptr.String(storage.AuditLock), Limit: 100})
+		require.NoError(t, err)
+		assert.Len(t, res.Records, 1)
+
+		res, err = svc.AuditLog(ctx, &goapolicy.AuditLogRequest{Actor: ptr.String("other"), Limit: 100})
+		require.NoError(t, err)
+		assert.Empty(t, res.Records)
+	})
+
+	t.Run("expired lock is removed and recorded once", func(t *testing.T) {
+		ctx := context.Background()
+		expired := time.Now().Add(-time.Minute)
+		_, err := policyStorage.SetPolicyLock(ctx, "policies", "testgroup", "example", "1.0", &storage.LockEvent{Locked: true, Source: storage.LockSourceManual, UnlockAt: &expired})
+		require.NoError(t, err)
+		locked, err := policyStorage.Policy(ctx, "policies", "testgroup", "example", "1.0")
+		require.NoError(t, err)
+
+		key := regocache.Key("policies", "testgroup", "example", "1.0")
+		policyCache := regocache.New()
+		svc := policy.New(context.Background(), policyStorage, policyCache, &policyfakes.FakeCache{}, nil, "hostname.com", false, 10*time.Second, http.DefaultClient, zap.NewNop(),
+			policy.WithAuditLog(audit.New(policyStorage, zap.NewNop())),
+		)
+		evaluate := func() {
+			_, err := svc.Evaluate(ctx, &goapolicy.EvaluateRequest{
+				Repository: "policies",
+				Group:      "testgroup",
+				PolicyName: "example",
+				Version:    "1.0",
+			})
+			require.NoError(t, err)
+		}
+
+		policyCache.Set(key, locked)
+		evaluate()
+		_, ok := policyCache.Get(key)
+		assert.False(t, ok, "the locked policy must be removed from the cache")
+
+		// the policy is already unlocked in the storage
+		policyCache.Set(key, locked)
+		evaluate()
+		_, ok = policyCache.Get(key)
+		assert.False(t, ok)
+
+		res, err := svc.AuditLog(ctx, &goapolicy.AuditLogRequest{Actor: ptr.String(audit.SystemActor), Limit: 100})
+		require.NoError(t, err)
+		require.Len(t, res.Records, 1)
+		assert.Equal(t, storage.AuditUnlock, res.Records[0].Operation)
+	})
+}
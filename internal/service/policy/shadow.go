@@ -0,0 +1,230 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/open-policy-agent/opa/rego"
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/metrics"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regocache"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/regofunc"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const (
+	defaultShadowConcurrency = 10
+
+	// shadowTimeout limits the duration of a shadow evaluation
+	// whose candidate version doesn't configure a timeout.
+	shadowTimeout = 30 * time.Second
+)
+
+// SetPolicyShadow configures a candidate version, which is evaluated in shadow
+// with the same input whenever the policy version is evaluated.
+func (s *Service) SetPolicyShadow(ctx context.Context, req *policy.SetPolicyShadowRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "setPolicyShadow"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.String("shadowVersion", req.ShadowVersion),
+	)
+
+	if req.ShadowVersion == req.Version {
+		logger.Error("policy version cannot be evaluated in its own shadow")
+		return errors.New(errors.BadRequest, "policy version cannot be evaluated in its own shadow")
+	}
+
+	// the candidate must be an existing version of the same policy
+	if _, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.ShadowVersion); err != nil {
+		logger.Error("error getting shadow policy from storage", zap.Error(err))
+		return errors.New("error getting shadow policy from storage", err)
+	}
+
+	if err := s.storage.SetPolicyShadow(ctx, req.Repository, req.Group, req.PolicyName, req.Version, req.ShadowVersion); err != nil {
+		logger.Error("error setting policy shadow", zap.Error(err))
+		return errors.New("error setting policy shadow", err)
+	}
+
+	logger.Debug("policy shadow is set")
+
+	return nil
+}
+
+// DeletePolicyShadow disables the shadow evaluation of a policy version.
+func (s *Service) DeletePolicyShadow(ctx context.Context, req *policy.DeletePolicyShadowRequest) error {
+	logger := s.logger.With(
+		zap.String("operation", "deletePolicyShadow"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	if err := s.storage.SetPolicyShadow(ctx, req.Repository, req.Group, req.PolicyName, req.Version, ""); err != nil {
+		logger.Error("error deleting policy shadow", zap.Error(err))
+		return errors.New("error deleting policy shadow", err)
+	}
+
+	logger.Debug("policy shadow is deleted")
+
+	return nil
+}
+
+// evaluateShadow evaluates the shadow version of the policy in the background
+// with the same input and records the evaluation if its result diverges from
+// the result of the policy. The shadow result is never returned to the caller.
+// If too many shadow evaluations are running, the evaluation is skipped.
+// The extension function calls of the shadow are answered with the recorded
+// calls of the policy, so that their side effects aren't executed twice.
+func (s *Service) evaluateShadow(ctx context.Context, pol *storage.Policy, req *policy.EvaluateRequest, evaluationID string, result []byte, calls []storage.FunctionCall) {
+	logger := s.logger.With(
+		zap.String("operation", "evaluateShadow"),
+		zap.String("repository", pol.Repository),
+		zap.String("group", pol.Group),
+		zap.String("name", pol.Name),
+		zap.String("version", pol.Version),
+		zap.String("shadowVersion", pol.ShadowVersion),
+		zap.String("evaluationID", evaluationID),
+	)
+
+	select {
+	case s.shadowLimit <- struct{}{}:
+	default:
+		logger.Warn("shadow evaluation is skipped because of too many concurrent shadow evaluations")
+		return
+	}
+
+	// the shadow evaluation continues after the response is returned,
+	// but it keeps the values of the request context, e.g. the headers
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shadowTimeout)
+
+	go func() {
+		defer func() { <-s.shadowLimit }()
+		defer cancel()
+
+		labels := metrics.With(metrics.PolicyLabels(pol.Repository, pol.Group, pol.Name, pol.Version), "shadow_version", pol.ShadowVersion)
+		metrics.ShadowEvaluations.With(labels).Inc()
+
+		rule := stringValue(req.Rule)
+		shadowResult, err := s.evaluateCandidate(ctx, pol, rule, req.Input, calls)
+		if err == nil && bytes.Equal(result, shadowResult) {
+			return
+		}
+
+		metrics.ShadowDivergences.With(labels).Inc()
+		logger.Info("shadow evaluation result diverges from policy result", zap.Error(err))
+
+		divergence := &storage.ShadowDivergence{
+			EvaluationID:  evaluationID,
+			Repository:    pol.Repository,
+			Group:         pol.Group,
+			Name:          pol.Name,
+			Version:       pol.Version,
+			ShadowVersion: pol.ShadowVersion,
+			Rule:          rule,
+			InputHash:     inputHash(req.Input),
+			Result:        decodeJSON(result),
+			Timestamp:     time.Now(),
+		}
+		if err != nil {
+			divergence.ShadowError = err.Error()
+		} else {
+			divergence.ShadowResult = decodeJSON(shadowResult)
+		}
+
+		if err := s.storage.SaveShadowDivergence(ctx, divergence); err != nil {
+			logger.Error("error saving shadow divergence", zap.Error(err))
+		}
+	}()
+}
+
+// evaluateCandidate evaluates the shadow version of the policy and returns the
+// JSON encoded result. The query is prepared with the replayed extension functions,
+// so a call which the policy itself didn't make fails the shadow evaluation.
+func (s *Service) evaluateCandidate(ctx context.Context, pol *storage.Policy, rule string, input any, calls []storage.FunctionCall) ([]byte, error) {
+	candidate, err := s.retrievePolicy(ctx, pol.Repository, pol.Group, pol.Name, pol.ShadowVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkLock(ctx, candidate); err != nil {
+		return nil, err
+	}
+
+	query, err := s.shadowQuery(ctx, candidate, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.runtimeConfig(candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	evalCtx, cancel := cfg.evalContext(ctx)
+	defer cancel()
+
+	resultSet, err := query.Eval(regofunc.WithRecordedCalls(evalCtx, calls), rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := evaluationResult(resultSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+// shadowQuery returns the replay query of a rule of the shadow version. The query
+// is cached with the shadow version, so that it's only prepared again after
+// the shadow version is changed.
+func (s *Service) shadowQuery(ctx context.Context, candidate *storage.Policy, rule string) (*rego.PreparedEvalQuery, error) {
+	key := s.queryCacheKey(candidate.Repository, candidate.Group, candidate.Name, candidate.Version)
+	labels := metrics.PolicyLabels(candidate.Repository, candidate.Group, candidate.Name, candidate.Version)
+
+	if query, ok := s.policyCache.GetQuery(key, regocache.ReplayRule(rule)); ok {
+		metrics.CacheHits.With(metrics.With(labels, "entry", "query")).Inc()
+		return query, nil
+	}
+	metrics.CacheMisses.With(metrics.With(labels, "entry", "query")).Inc()
+
+	query, err := s.prepareReplayQuery(ctx, candidate, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.policyCache.SetQuery(key, regocache.ReplayRule(rule), candidate, query)
+
+	return query, nil
+}
+
+// inputHash returns the SHA256 hash of the JSON encoded input.
+func inputHash(input any) string {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// decodeJSON decodes a JSON encoded evaluation result.
+func decodeJSON(data []byte) any {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	return v
+}
@@ -2,14 +2,36 @@ package policy
 
 import (
 	"context"
+	"time"
 
 	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
 )
 
 type Storage interface {
 	Policy(ctx context.Context, repository, group, name, version string) (*storage.Policy, error)
+	// SavePolicy stores the policy. The lock of a locked policy
+	// is appended to the lock history of the policy.
 	SavePolicy(ctx context.Context, policy *storage.Policy) error
-	SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error
+	// SetPolicyLock locks or unlocks a policy and appends the event to its lock
+	// history. Nothing is changed if the policy is already in the requested state.
+	// It reports whether the policy was changed.
+	SetPolicyLock(ctx context.Context, repository, group, name, version string, event *storage.LockEvent) (bool, error)
+	// SetPolicyShadow sets the candidate version which is evaluated in shadow
+	// of a policy version. Empty shadow version disables the shadow evaluation.
+	SetPolicyShadow(ctx context.Context, repository, group, name, version, shadowVersion string) error
+	// SaveShadowDivergence records a shadow evaluation with divergent result.
+	SaveShadowDivergence(ctx context.Context, divergence *storage.ShadowDivergence) error
+	// SetPolicyCapture sets the configuration for recording the evaluations
+	// of a policy version. Nil configuration disables the recording.
+	SetPolicyCapture(ctx context.Context, repository, group, name, version string, capture *storage.CaptureConfig) error
+	// SaveCapturedEvaluation records a captured policy evaluation unless the policy
+	// version already has maxRecords captured evaluations. A maxRecords of 0 doesn't
+	// limit the number of records. It reports whether the evaluation is recorded.
+	SaveCapturedEvaluation(ctx context.Context, evaluation *storage.CapturedEvaluation, maxRecords int) (bool, error)
+	// CapturedEvaluations returns the captured evaluations of a policy version, newest first.
+	CapturedEvaluations(ctx context.Context, repository, group, name, version string, limit int) ([]*storage.CapturedEvaluation, error)
+	// DeleteCapturedEvaluations removes the captured evaluations of a policy version.
+	DeleteCapturedEvaluations(ctx context.Context, repository, group, name, version string) error
 	GetPolicies(ctx context.Context, locked *bool, policyName *string) ([]*storage.Policy, error)
 	AddPolicySubscribers(subscribers ...storage.PolicySubscriber)
 	ListenPolicyDataChanges(ctx context.Context) error
@@ -30,4 +52,36 @@ type Storage interface {
 	// ActiveImportConfigs returns all import configurations which specify
 	// that the time to automatically import a policy bundle has been reached.
 	ActiveImportConfigs(ctx context.Context) ([]*storage.PolicyAutoImport, error)
+	// PolicyVersions returns all stored versions of a policy.
+	PolicyVersions(ctx context.Context, repository, group, name string) ([]string, error)
+	// PolicyAlias returns a named alias of a policy version.
+	PolicyAlias(ctx context.Context, repository, group, name, alias string) (*storage.PolicyAlias, error)
+	// PolicyAliases returns all named aliases of a policy.
+	PolicyAliases(ctx context.Context, repository, group, name string) ([]*storage.PolicyAlias, error)
+	// SavePolicyAlias creates or updates a named alias of a policy version.
+	SavePolicyAlias(ctx context.Context, alias *storage.PolicyAlias) error
+	// DeletePolicyAlias removes a named alias of a policy.
+	DeletePolicyAlias(ctx context.Context, repository, group, name, alias string) error
+	// CreateJob stores a new asynchronous evaluation job.
+	CreateJob(ctx context.Context, job *storage.Job) error
+	// Job returns an asynchronous evaluation job.
+	Job(ctx context.Context, id string) (*storage.Job, error)
+	// ClaimJob sets the status of a pending job to running and returns it.
+	// Running jobs which were not updated since staleBefore are claimed
+	// again, as their evaluation was interrupted. If the job can't be
+	// claimed, an error of kind NotFound is returned.
+	ClaimJob(ctx context.Context, id string, staleBefore time.Time) (*storage.Job, error)
+	// UpdateJob stores the status of a claimed job.
+	UpdateJob(ctx context.Context, job *storage.Job) error
+	// ResumableJobs returns the pending jobs and the running jobs
+	// which were not updated since staleBefore.
+	ResumableJobs(ctx context.Context, staleBefore time.Time) ([]*storage.Job, error)
+	// DeleteJobs removes the finished jobs which were not updated since before.
+	DeleteJobs(ctx context.Context, before time.Time) error
+}
+
+// DecisionStorage is implemented by storages which can be
+// queried for the records of the decision log.
+type DecisionStorage interface {
+	Decisions(ctx context.Context, filter *storage.DecisionFilter) ([]*storage.Decision, error)
 }
@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/gen/policy"
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+	ptr "github.com/eclipse-xfsc/microservice-core-go/pkg/ptr"
+)
+
+// PolicyTests returns the report of the last test run of a policy. Tests are
+// executed when policies are synchronized or imported.
+func (s *Service) PolicyTests(ctx context.Context, req *policy.PolicyTestsRequest) (*policy.PolicyTestReport, error) {
+	logger := s.logger.With(
+		zap.String("operation", "policyTests"),
+		zap.String("repository", req.Repository),
+		zap.String("group", req.Group),
+		zap.String("name", req.PolicyName),
+		zap.String("version", req.Version),
+	)
+
+	pol, err := s.storage.Policy(ctx, req.Repository, req.Group, req.PolicyName, req.Version)
+	if err != nil {
+		logger.Error("error getting policy from storage", zap.Error(err))
+		if errors.Is(errors.NotFound, err) {
+			return nil, err
+		}
+		return nil, errors.New("error getting policy from storage", err)
+	}
+
+	if pol.TestReport == nil {
+		return nil, errors.New(errors.NotFound, "policy tests were not executed")
+	}
+
+	return toPolicyTestReport(pol.TestReport), nil
+}
+
+func toPolicyTestReport(r *storage.TestReport) *policy.PolicyTestReport {
+	report := &policy.PolicyTestReport{
+		Passed:   r.Passed,
+		Total:    r.Total,
+		Failed:   r.Failed,
+		Coverage: r.Coverage,
+		Results:  make([]*policy.PolicyTestResult, 0, len(r.Results)),
+		RunAt:    r.RunAt.Unix(),
+	}
+	if r.Error != "" {
+		report.Error = ptr.String(r.Error)
+	}
+
+	for _, res := range r.Results {
+		result := &policy.PolicyTestResult{
+			Name:     res.Name,
+			Location: res.Location,
+			Passed:   res.Passed,
+			Duration: res.Duration.Milliseconds(),
+		}
+		if res.Error != "" {
+			result.Error = ptr.String(res.Error)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
@@ -0,0 +1,254 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
+)
+
+const (
+	// versionLatest resolves to the highest stored version, including pre-releases.
+	versionLatest = "latest"
+	// versionStable resolves to the highest stored version without pre-release.
+	versionStable = "stable"
+
+	// versionResolutionTTL limits the time a resolved alias or range is cached,
+	// so that alias changes made by other instances of the service are applied.
+	versionResolutionTTL = time.Minute
+	// maxResolutionsPerPolicy limits the number of cached
+	// aliases and ranges of a single policy.
+	maxResolutionsPerPolicy = 32
+)
+
+// versionCache keeps the versions resolved from aliases and semver
+// ranges, so that they aren't looked up in storage on every evaluation.
+type versionCache struct {
+	mu          sync.Mutex
+	resolutions map[string]map[string]resolution
+}
+
+type resolution struct {
+	version string
+	expires time.Time
+}
+
+func newVersionCache() *versionCache {
+	return &versionCache{resolutions: make(map[string]map[string]resolution)}
+}
+
+func versionCacheKey(repository, group, name string) string {
+	return repository + "/" + group + "/" + name
+}
+
+func (c *versionCache) get(repository, group, name, version string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.resolutions[versionCacheKey(repository, group, name)][version]
+	if !ok || time.Now().After(r.expires) {
+		return "", false
+	}
+
+	return r.version, true
+}
+
+func (c *versionCache) set(repository, group, name, version, resolved string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := versionCacheKey(repository, group, name)
+	resolutions, ok := c.resolutions[key]
+	if !ok {
+		resolutions = make(map[string]resolution)
+		c.resolutions[key] = resolutions
+	}
+
+	if _, ok := resolutions[version]; !ok && len(resolutions) >= maxResolutionsPerPolicy {
+		// drop the expired resolutions to make room for the new one
+		now := time.Now()
+		for v, r := range resolutions {
+			if now.After(r.expires) {
+				delete(resolutions, v)
+			}
+		}
+		if len(resolutions) >= maxResolutionsPerPolicy {
+			return
+		}
+	}
+
+	resolutions[version] = resolution{version: resolved, expires: time.Now().Add(versionResolutionTTL)}
+}
+
+// invalidate removes the resolved versions of all aliases and ranges of a policy.
+func (c *versionCache) invalidate(repository, group, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.resolutions, versionCacheKey(repository, group, name))
+}
+
+// PolicyDataChange invalidates the resolved aliases and ranges of the changed
+// policy, because a new or updated version may change their resolution.
+func (s *Service) PolicyDataChange(_ context.Context, repository, group, name, _ string) error {
+	s.versions.invalidate(repository, group, name)
+	return nil
+}
+
+// resolveVersion returns the stored policy version which is referenced by the
+// version of a policy URL. Exact versions take precedence over named aliases,
+// which take precedence over 'latest', 'stable' and semver ranges like '~1.2' or '^2'.
+// Resolved aliases and ranges are cached until the policy or its aliases change.
+func (s *Service) resolveVersion(ctx context.Context, repository, group, name, version string) (string, error) {
+	if resolved, ok := s.versions.get(repository, group, name, version); ok {
+		return resolved, nil
+	}
+
+	_, err := s.retrievePolicy(ctx, repository, group, name, version)
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(errors.NotFound, err) {
+		return "", err
+	}
+	notFound := err
+
+	alias, err := s.storage.PolicyAlias(ctx, repository, group, name, version)
+	if err == nil {
+		s.versions.set(repository, group, name, version, alias.Version)
+		return alias.Version, nil
+	}
+	if !errors.Is(errors.NotFound, err) {
+		return "", errors.New("error getting policy alias", err)
+	}
+
+	if version != versionLatest && version != versionStable && !isVersionRange(version) {
+		return "", notFound
+	}
+
+	versions, err := s.storage.PolicyVersions(ctx, repository, group, name)
+	if err != nil {
+		return "", errors.New("error getting policy versions", err)
+	}
+
+	resolved, ok := matchVersion(version, versions)
+	if !ok {
+		return "", errors.New(errors.NotFound, fmt.Sprintf("no policy version matches %q", version))
+	}
+	s.versions.set(repository, group, name, version, resolved)
+
+	return resolved, nil
+}
+
+// isVersionRange reports whether the version is a semver range
+// with '~' or '^' prefix or with 'x' or '*' wildcards, e.g. '1.x'.
+func isVersionRange(version string) bool {
+	if strings.HasPrefix(version, "~") || strings.HasPrefix(version, "^") {
+		return true
+	}
+	for _, part := range strings.Split(version, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchVersion returns the highest version matching the version
+// expression. Versions which are not valid semver are ignored.
+func matchVersion(expr string, versions []string) (string, bool) {
+	var match func(v string) bool
+	switch expr {
+	case versionLatest:
+		match = func(string) bool { return true }
+	case versionStable:
+		match = func(v string) bool { return semver.Prerelease(v) == "" }
+	default:
+		lower, upper, ok := versionBounds(expr)
+		if !ok {
+			return "", false
+		}
+		match = func(v string) bool {
+			return semver.Prerelease(v) == "" &&
+				semver.Compare(v, lower) >= 0 &&
+				semver.Compare(v, upper) < 0
+		}
+	}
+
+	var best, bestSemver string
+	for _, version := range versions {
+		v := "v" + version
+		if !semver.IsValid(v) || !match(v) {
+			continue
+		}
+		if best == "" || semver.Compare(v, bestSemver) > 0 {
+			best, bestSemver = version, v
+		}
+	}
+
+	return best, best != ""
+}
+
+// versionBounds returns the inclusive lower and the exclusive upper semver
+// bounds of a version range. Caret ranges allow changes which don't modify
+// the left-most non-zero number, tilde and wildcard ranges allow changes of
+// the numbers after the last specified one (or of the patch number for tilde).
+func versionBounds(expr string) (lower, upper string, ok bool) {
+	var op string
+	if strings.HasPrefix(expr, "~") || strings.HasPrefix(expr, "^") {
+		op, expr = expr[:1], expr[1:]
+	}
+
+	var nums []int
+	for _, part := range strings.Split(expr, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", "", false
+		}
+		nums = append(nums, n)
+	}
+	if len(nums) == 0 || len(nums) > 3 {
+		return "", "", false
+	}
+
+	var l, u [3]int
+	copy(l[:], nums)
+
+	switch {
+	case op == "^":
+		// the first non-zero number must not change
+		i := 0
+		for i < len(nums)-1 && nums[i] == 0 {
+			i++
+		}
+		u = bump(l, i)
+	case op == "~" && len(nums) == 3:
+		u = bump(l, 1)
+	default:
+		u = bump(l, len(nums)-1)
+	}
+
+	return semverString(l), semverString(u), true
+}
+
+// bump increments the number at index i and resets the following numbers.
+func bump(v [3]int, i int) [3]int {
+	v[i]++
+	for j := i + 1; j < len(v); j++ {
+		v[j] = 0
+	}
+	return v
+}
+
+func semverString(v [3]int) string {
+	return fmt.Sprintf("v%d.%d.%d", v[0], v[1], v[2])
+}
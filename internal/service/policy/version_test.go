@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchVersion(t *testing.T) {
+	versions := []string{"1.0", "1.2.0", "1.2.5", "1.3.0", "2.0.0", "2.1.0", "3.0.0-rc.1", "0.2.1", "0.2.9", "0.3.0", "example"}
+
+	tests := []struct {
+		expr    string
+		version string
+		ok      bool
+	}{
+		{expr: "latest", version: "3.0.0-rc.1", ok: true},
+		{expr: "stable", version: "2.1.0", ok: true},
+		{expr: "~1.2", version: "1.2.5", ok: true},
+		{expr: "~1.2.1", version: "1.2.5", ok: true},
+		{expr: "~1", version: "1.3.0", ok: true},
+		{expr: "^1", version: "1.3.0", ok: true},
+		{expr: "^2", version: "2.1.0", ok: true},
+		{expr: "^1.2.6", version: "1.3.0", ok: true},
+		{expr: "^0.2", version: "0.2.9", ok: true},
+		{expr: "1.x", version: "1.3.0", ok: true},
+		{expr: "1.2.*", version: "1.2.5", ok: true},
+		{expr: "^3", ok: false},
+		{expr: "~4.0", ok: false},
+		{expr: "^a.b", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			version, ok := matchVersion(test.expr, versions)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.version, version)
+		})
+	}
+}
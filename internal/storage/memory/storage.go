@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +14,17 @@ import (
 	errors "github.com/eclipse-xfsc/microservice-core-go/pkg/err"
 )
 
+// maxShadowDivergences limits the number of shadow
+// divergence records kept in memory.
+const maxShadowDivergences = 1000
+
+// maxCapturedEvaluations limits the number of captured
+// evaluations of a policy version kept in memory.
+const maxCapturedEvaluations = 1000
+
+// maxAuditRecords limits the number of audit records kept in memory.
+const maxAuditRecords = 10000
+
 //go:generate counterfeiter . KeyConstructor
 
 type KeyConstructor interface {
@@ -36,6 +48,21 @@ type Storage struct {
 	muAutoImport sync.RWMutex
 	autoImport   map[string]*storage.PolicyAutoImport
 
+	muAliases sync.RWMutex
+	aliases   map[string]*storage.PolicyAlias
+
+	muDivergences sync.Mutex
+	divergences   []*storage.ShadowDivergence
+
+	muJobs sync.Mutex
+	jobs   map[string]*storage.Job
+
+	muCaptures sync.Mutex
+	captures   map[string][]*storage.CapturedEvaluation
+
+	muAudit sync.Mutex
+	audit   []*storage.AuditRecord
+
 	logger *zap.Logger
 }
 
@@ -48,6 +75,9 @@ func New(c KeyConstructor, p map[string]*storage.Policy, l *zap.Logger) *Storage
 		policies:          p,
 		policySubscribers: map[string]*storage.Subscriber{},
 		commonStorage:     map[string]interface{}{},
+		aliases:           map[string]*storage.PolicyAlias{},
+		jobs:              map[string]*storage.Job{},
+		captures:          map[string][]*storage.CapturedEvaluation{},
 		logger:            l,
 	}
 }
@@ -73,6 +103,12 @@ func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error
 	)
 
 	s.mu.Lock()
+	if existing, ok := s.policies[key]; ok {
+		policy.LockHistory = existing.LockHistory
+	}
+	if policy.Locked && policy.Lock != nil {
+		policy.LockHistory = appendLockEvent(policy.LockHistory, *policy.Lock)
+	}
 	s.policies[key] = policy
 	s.mu.Unlock()
 
@@ -88,7 +124,50 @@ func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error
 	return nil
 }
 
-func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error {
+func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, event *storage.LockEvent) (bool, error) {
+	key := s.keyConstructor.ConstructKey(repository, group, name, version)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.policies[key]
+	if !ok {
+		return false, errors.New(errors.NotFound, "policy not found in memory storage")
+	}
+
+	if p.Locked == event.Locked {
+		return false, nil
+	}
+
+	p.Locked = event.Locked
+	p.Lock = nil
+	if event.Locked {
+		p.Lock = event
+	}
+	p.LockHistory = appendLockEvent(p.LockHistory, *event)
+
+	// send the changed policy to subscribers
+	go func(policy *storage.Policy) {
+		select {
+		case s.changes <- *policy:
+		case <-time.After(10 * time.Second):
+		case <-ctx.Done():
+		}
+	}(p)
+
+	return true, nil
+}
+
+// appendLockEvent returns a new lock history with the event
+// appended, which keeps only the newest events.
+func appendLockEvent(history []storage.LockEvent, event storage.LockEvent) []storage.LockEvent {
+	res := append(slices.Clone(history), event)
+	if len(res) > storage.MaxLockHistory {
+		res = res[len(res)-storage.MaxLockHistory:]
+	}
+	return res
+}
+
+func (s *Storage) SetPolicyShadow(ctx context.Context, repository, group, name, version, shadowVersion string) error {
 	key := s.keyConstructor.ConstructKey(repository, group, name, version)
 
 	s.mu.Lock()
@@ -98,7 +177,7 @@ func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, ve
 		return errors.New(errors.NotFound, "policy not found in memory storage")
 	}
 
-	p.Locked = lock
+	p.ShadowVersion = shadowVersion
 
 	// send the changed policy to subscribers
 	go func(policy *storage.Policy) {
@@ -186,9 +265,9 @@ func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 				err := subscriber.PolicyDataChange(
 					ctx,
 					p.Repository,
+					p.Group,
 					p.Name,
 					p.Version,
-					p.Group,
 				)
 				if err != nil {
 					return err
@@ -316,3 +395,308 @@ func (s *Storage) DeleteAutoImportConfig(_ context.Context, policyURL string) er
 
 	return nil
 }
+
+func (s *Storage) PolicyVersions(_ context.Context, repository, group, name string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var versions []string
+	for _, p := range s.policies {
+		if p.Repository == repository && p.Group == group && p.Name == name {
+			versions = append(versions, p.Version)
+		}
+	}
+
+	return versions, nil
+}
+
+func (s *Storage) PolicyAlias(_ context.Context, repository, group, name, alias string) (*storage.PolicyAlias, error) {
+	s.muAliases.RLock()
+	defer s.muAliases.RUnlock()
+
+	a, ok := s.aliases[s.keyConstructor.ConstructKey(repository, group, name, alias)]
+	if !ok {
+		return nil, errors.New(errors.NotFound, "policy alias not found in memory storage")
+	}
+
+	res := *a // don't return the PolicyAlias by reference
+	return &res, nil
+}
+
+func (s *Storage) PolicyAliases(_ context.Context, repository, group, name string) ([]*storage.PolicyAlias, error) {
+	s.muAliases.RLock()
+	defer s.muAliases.RUnlock()
+
+	var aliases []*storage.PolicyAlias
+	for _, a := range s.aliases {
+		if a.Repository == repository && a.Group == group && a.Name == name {
+			res := *a
+			aliases = append(aliases, &res)
+		}
+	}
+
+	return aliases, nil
+}
+
+func (s *Storage) SavePolicyAlias(_ context.Context, alias *storage.PolicyAlias) error {
+	a := *alias
+	a.LastUpdate = time.Now()
+
+	s.muAliases.Lock()
+	s.aliases[s.keyConstructor.ConstructKey(alias.Repository, alias.Group, alias.Name, alias.Alias)] = &a
+	s.muAliases.Unlock()
+
+	return nil
+}
+
+func (s *Storage) DeletePolicyAlias(_ context.Context, repository, group, name, alias string) error {
+	s.muAliases.Lock()
+	defer s.muAliases.Unlock()
+
+	key := s.keyConstructor.ConstructKey(repository, group, name, alias)
+	if _, ok := s.aliases[key]; !ok {
+		return errors.New(errors.NotFound, "policy alias not found in memory storage")
+	}
+	delete(s.aliases, key)
+
+	return nil
+}
+
+// SaveShadowDivergence keeps the last maxShadowDivergences records in memory.
+func (s *Storage) SaveShadowDivergence(_ context.Context, divergence *storage.ShadowDivergence) error {
+	s.muDivergences.Lock()
+	defer s.muDivergences.Unlock()
+
+	s.divergences = append(s.divergences, divergence)
+	if len(s.divergences) > maxShadowDivergences {
+		s.divergences = s.divergences[len(s.divergences)-maxShadowDivergences:]
+	}
+
+	return nil
+}
+
+func (s *Storage) SetPolicyCapture(ctx context.Context, repository, group, name, version string, capture *storage.CaptureConfig) error {
+	key := s.keyConstructor.ConstructKey(repository, group, name, version)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.policies[key]
+	if !ok {
+		return errors.New(errors.NotFound, "policy not found in memory storage")
+	}
+
+	p.Capture = capture
+
+	// send the changed policy to subscribers
+	go func(policy *storage.Policy) {
+		select {
+		case s.changes <- *policy:
+		case <-time.After(10 * time.Second):
+		case <-ctx.Done():
+		}
+	}(p)
+
+	return nil
+}
+
+// SaveCapturedEvaluation keeps the last maxCapturedEvaluations
+// records of every policy version in memory.
+func (s *Storage) SaveCapturedEvaluation(_ context.Context, evaluation *storage.CapturedEvaluation, maxRecords int) (bool, error) {
+	key := s.keyConstructor.ConstructKey(evaluation.Repository, evaluation.Group, evaluation.Name, evaluation.Version)
+
+	s.muCaptures.Lock()
+	defer s.muCaptures.Unlock()
+
+	if maxRecords > 0 && len(s.captures[key]) >= maxRecords {
+		return false, nil
+	}
+
+	captures := append(s.captures[key], evaluation)
+	if len(captures) > maxCapturedEvaluations {
+		captures = captures[len(captures)-maxCapturedEvaluations:]
+	}
+	s.captures[key] = captures
+
+	return true, nil
+}
+
+// CapturedEvaluations returns the newest captured evaluations first.
+func (s *Storage) CapturedEvaluations(_ context.Context, repository, group, name, version string, limit int) ([]*storage.CapturedEvaluation, error) {
+	s.muCaptures.Lock()
+	defer s.muCaptures.Unlock()
+
+	captures := s.captures[s.keyConstructor.ConstructKey(repository, group, name, version)]
+	res := make([]*storage.CapturedEvaluation, 0, len(captures))
+	for i := len(captures) - 1; i >= 0; i-- {
+		if limit > 0 && len(res) == limit {
+			break
+		}
+		res = append(res, captures[i])
+	}
+
+	return res, nil
+}
+
+func (s *Storage) DeleteCapturedEvaluations(_ context.Context, repository, group, name, version string) error {
+	s.muCaptures.Lock()
+	defer s.muCaptures.Unlock()
+
+	delete(s.captures, s.keyConstructor.ConstructKey(repository, group, name, version))
+
+	return nil
+}
+
+// SaveAuditRecord keeps the last maxAuditRecords records in memory.
+func (s *Storage) SaveAuditRecord(_ context.Context, record *storage.AuditRecord) error {
+	s.muAudit.Lock()
+	defer s.muAudit.Unlock()
+
+	r := *record
+	s.audit = append(s.audit, &r)
+	if len(s.audit) > maxAuditRecords {
+		s.audit = s.audit[len(s.audit)-maxAuditRecords:]
+	}
+
+	return nil
+}
+
+// AuditRecords returns the audit records matching the filter, newest first.
+func (s *Storage) AuditRecords(_ context.Context, filter *storage.AuditFilter) ([]*storage.AuditRecord, error) {
+	s.muAudit.Lock()
+	defer s.muAudit.Unlock()
+
+	res := make([]*storage.AuditRecord, 0)
+	skipped := 0
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(res) == filter.Limit {
+			break
+		}
+
+		r := s.audit[i]
+		if !matchAuditFilter(r, filter) {
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func matchAuditFilter(r *storage.AuditRecord, filter *storage.AuditFilter) bool {
+	for _, f := range [][2]string{
+		{r.Operation, filter.Operation},
+		{r.Actor, filter.Actor},
+		{r.Repository, filter.Repository},
+		{r.Group, filter.Group},
+		{r.Name, filter.Name},
+		{r.Version, filter.Version},
+	} {
+		if f[1] != "" && f[0] != f[1] {
+			return false
+		}
+	}
+	if !filter.From.IsZero() && r.Timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && r.Timestamp.After(filter.To) {
+		return false
+	}
+	return true
+}
+
+func (s *Storage) CreateJob(_ context.Context, job *storage.Job) error {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	if _, ok := s.jobs[job.ID]; ok {
+		return errors.New(errors.Exist, "job already exists")
+	}
+
+	j := *job
+	s.jobs[job.ID] = &j
+
+	return nil
+}
+
+func (s *Storage) Job(_ context.Context, id string) (*storage.Job, error) {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, errors.New(errors.NotFound, "job not found in memory storage")
+	}
+
+	job := *j
+	return &job, nil
+}
+
+func (s *Storage) ClaimJob(_ context.Context, id string, staleBefore time.Time) (*storage.Job, error) {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok || !resumable(j, staleBefore) {
+		return nil, errors.New(errors.NotFound, "resumable job not found in memory storage")
+	}
+
+	j.Status = storage.JobRunning
+	j.UpdatedAt = time.Now()
+
+	job := *j
+	return &job, nil
+}
+
+func (s *Storage) UpdateJob(_ context.Context, job *storage.Job) error {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return errors.New(errors.NotFound, "job not found in memory storage")
+	}
+
+	j := *job
+	s.jobs[job.ID] = &j
+
+	return nil
+}
+
+func (s *Storage) ResumableJobs(_ context.Context, staleBefore time.Time) ([]*storage.Job, error) {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	var jobs []*storage.Job
+	for _, j := range s.jobs {
+		if resumable(j, staleBefore) {
+			job := *j
+			jobs = append(jobs, &job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *storage.Job) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+func (s *Storage) DeleteJobs(_ context.Context, before time.Time) error {
+	s.muJobs.Lock()
+	defer s.muJobs.Unlock()
+
+	for id, j := range s.jobs {
+		if j.Finished() && j.UpdatedAt.Before(before) {
+			delete(s.jobs, id)
+		}
+	}
+
+	return nil
+}
+
+func resumable(job *storage.Job, staleBefore time.Time) bool {
+	return job.Status == storage.JobPending ||
+		(job.Status == storage.JobRunning && job.UpdatedAt.Before(staleBefore))
+}
@@ -2,6 +2,8 @@ package memory_test
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -49,15 +51,19 @@ func TestStorage_Policy(t *testing.T) {
 }
 
 func TestStorage_SetPolicyLock(t *testing.T) {
+	lock := &storage.LockEvent{Locked: true, Reason: "maintenance", Actor: "admin", Source: storage.LockSourceManual}
+	unlock := &storage.LockEvent{Locked: false, Actor: "admin", Source: storage.LockSourceManual}
+
 	// policy is not found in memory storage
 	keyConstructor := &memoryfakes.FakeKeyConstructor{ConstructKeyStub: func(s string, s2 string, s3 string, s4 string) string {
 		return "non-existing policy key"
 	}}
 
-	storage := memory.New(keyConstructor, makePolicies(), zap.NewNop())
+	s := memory.New(keyConstructor, makePolicies(), zap.NewNop())
 
-	err := storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", true)
+	changed, err := s.SetPolicyLock(context.Background(), "repo", "group", "name", "version", lock)
 	assert.Error(t, err)
+	assert.False(t, changed)
 
 	e, ok := err.(*errors.Error)
 	assert.True(t, ok)
@@ -70,12 +76,28 @@ func TestStorage_SetPolicyLock(t *testing.T) {
 	}}
 
 	policies := makePolicies()
-	storage = memory.New(keyConstructor, policies, zap.NewNop())
+	s = memory.New(keyConstructor, policies, zap.NewNop())
 
 	// lock the policy
-	err = storage.SetPolicyLock(context.Background(), "repo", "group", "name", "version", true)
+	changed, err = s.SetPolicyLock(context.Background(), "repo", "group", "name", "version", lock)
 	assert.NoError(t, err)
+	assert.True(t, changed)
 	assert.Equal(t, true, policies[validKey].Locked)
+	assert.Equal(t, lock, policies[validKey].Lock)
+
+	// locking a locked policy is not recorded
+	changed, err = s.SetPolicyLock(context.Background(), "repo", "group", "name", "version", lock)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Len(t, policies[validKey].LockHistory, 1)
+
+	// unlock the policy
+	changed, err = s.SetPolicyLock(context.Background(), "repo", "group", "name", "version", unlock)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, false, policies[validKey].Locked)
+	assert.Nil(t, policies[validKey].Lock)
+	assert.Equal(t, []storage.LockEvent{*lock, *unlock}, policies[validKey].LockHistory)
 }
 
 func TestStorage_GetPolicies(t *testing.T) {
@@ -229,3 +251,127 @@ func makePolicies() map[string]*storage.Policy {
 		},
 	}
 }
+
+func TestStorage_Jobs(t *testing.T) {
+	s := memory.New(&memoryfakes.FakeKeyConstructor{}, makePolicies(), zap.NewNop())
+	ctx := context.Background()
+
+	now := time.Now()
+	jobs := []*storage.Job{
+		{ID: "pending", Status: storage.JobPending, CreatedAt: now, UpdatedAt: now},
+		{ID: "running", Status: storage.JobRunning, CreatedAt: now.Add(-time.Minute), UpdatedAt: now},
+		{ID: "stale", Status: storage.JobRunning, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+		{ID: "done", Status: storage.JobDone, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+	}
+	for _, job := range jobs {
+		assert.NoError(t, s.CreateJob(ctx, job))
+	}
+
+	err := s.CreateJob(ctx, &storage.Job{ID: "pending"})
+	assert.Error(t, err)
+	assert.True(t, errors.Is(errors.Exist, err))
+
+	staleBefore := now.Add(-30 * time.Minute)
+	resumable, err := s.ResumableJobs(ctx, staleBefore)
+	assert.NoError(t, err)
+	assert.Len(t, resumable, 2)
+	assert.Equal(t, "stale", resumable[0].ID)
+	assert.Equal(t, "pending", resumable[1].ID)
+
+	// running and finished jobs can't be claimed
+	for _, id := range []string{"running", "done", "missing"} {
+		_, err = s.ClaimJob(ctx, id, staleBefore)
+		assert.True(t, errors.Is(errors.NotFound, err), id)
+	}
+
+	job, err := s.ClaimJob(ctx, "pending", staleBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.JobRunning, job.Status)
+
+	// claimed job can't be claimed again
+	_, err = s.ClaimJob(ctx, "pending", staleBefore)
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	job.Status = storage.JobDone
+	assert.NoError(t, s.UpdateJob(ctx, job))
+
+	job, err = s.Job(ctx, "pending")
+	assert.NoError(t, err)
+	assert.Equal(t, storage.JobDone, job.Status)
+
+	// only finished jobs are removed
+	assert.NoError(t, s.DeleteJobs(ctx, now.Add(-30*time.Minute)))
+	_, err = s.Job(ctx, "done")
+	assert.True(t, errors.Is(errors.NotFound, err))
+	_, err = s.Job(ctx, "stale")
+	assert.NoError(t, err)
+}
+
+func TestStorage_SaveCapturedEvaluation(t *testing.T) {
+	s := memory.New(&memoryfakes.FakeKeyConstructor{}, makePolicies(), zap.NewNop())
+	ctx := context.Background()
+
+	// concurrent evaluations don't exceed the maximum number of records
+	var wg sync.WaitGroup
+	var saved atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := s.SaveCapturedEvaluation(ctx, &storage.CapturedEvaluation{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"}, 10)
+			assert.NoError(t, err)
+			if ok {
+				saved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), saved.Load())
+	evaluations, err := s.CapturedEvaluations(ctx, "policies", "example", "foo", "1.0", 0)
+	assert.NoError(t, err)
+	assert.Len(t, evaluations, 10)
+
+	// deleted evaluations free up the records
+	assert.NoError(t, s.DeleteCapturedEvaluations(ctx, "policies", "example", "foo", "1.0"))
+	ok, err := s.SaveCapturedEvaluation(ctx, &storage.CapturedEvaluation{Repository: "policies", Group: "example", Name: "foo", Version: "1.0"}, 10)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestStorage_AuditRecords(t *testing.T) {
+	s := memory.New(&memoryfakes.FakeKeyConstructor{}, makePolicies(), zap.NewNop())
+	ctx := context.Background()
+
+	now := time.Now()
+	records := []*storage.AuditRecord{
+		{Operation: storage.AuditLock, Actor: "alice", Group: "example", Timestamp: now.Add(-3 * time.Hour)},
+		{Operation: storage.AuditUnlock, Actor: "bob", Group: "example", Timestamp: now.Add(-2 * time.Hour)},
+		{Operation: storage.AuditLock, Actor: "bob", Group: "other", Timestamp: now.Add(-time.Hour)},
+		{Operation: storage.AuditStorageSet, Actor: "alice", Target: "key", Timestamp: now},
+	}
+	for _, r := range records {
+		assert.NoError(t, s.SaveAuditRecord(ctx, r))
+	}
+
+	res, err := s.AuditRecords(ctx, &storage.AuditFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+	assert.Equal(t, storage.AuditStorageSet, res[0].Operation)
+	assert.Equal(t, storage.AuditLock, res[3].Operation)
+
+	res, err = s.AuditRecords(ctx, &storage.AuditFilter{Operation: storage.AuditLock, Actor: "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "other", res[0].Group)
+
+	res, err = s.AuditRecords(ctx, &storage.AuditFilter{Group: "example", From: now.Add(-150 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, storage.AuditUnlock, res[0].Operation)
+
+	res, err = s.AuditRecords(ctx, &storage.AuditFilter{To: now.Add(-30 * time.Minute), Limit: 1, Offset: 1})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, storage.AuditUnlock, res[0].Operation)
+}
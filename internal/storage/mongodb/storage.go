@@ -17,13 +17,20 @@ import (
 )
 
 const (
-	subscriberCollectionName = "subscribers"
-	commonStorage            = "common_storage"
-	autoImportCollection     = "policy_auto_import"
-	lockedField              = "locked"
-	policyNameField          = "name"
-	dataField                = "data"
-	nextDataRefreshTimeField = "nextDataRefreshTime"
+	subscriberCollectionName   = "subscribers"
+	commonStorage              = "common_storage"
+	autoImportCollection       = "policy_auto_import"
+	decisionLogCollection      = "decision_logs"
+	aliasCollection            = "policy_aliases"
+	shadowDivergenceCollection = "shadow_divergences"
+	jobCollection              = "evaluation_jobs"
+	captureCollection          = "captured_evaluations"
+	captureCounterCollection   = "captured_evaluation_counters"
+	auditCollection            = "audit_log"
+	lockedField                = "locked"
+	policyNameField            = "name"
+	dataField                  = "data"
+	nextDataRefreshTimeField   = "nextDataRefreshTime"
 )
 
 type Storage struct {
@@ -32,6 +39,13 @@ type Storage struct {
 	subscriber    *mongo.Collection
 	commonStorage *mongo.Collection
 	autoImport    *mongo.Collection
+	decisionLog   *mongo.Collection
+	alias         *mongo.Collection
+	divergence    *mongo.Collection
+	job           *mongo.Collection
+	capture       *mongo.Collection
+	captureCount  *mongo.Collection
+	audit         *mongo.Collection
 	subscribers   []storage.PolicySubscriber
 	logger        *zap.Logger
 }
@@ -43,13 +57,44 @@ func New(db *mongo.Client, dbname, collection string, logger *zap.Logger) (*Stor
 
 	database := db.Database(dbname)
 
+	// concurrent upserts of the same alias would otherwise create
+	// duplicate aliases, which resolve to arbitrary versions
+	_, err := database.Collection(aliasCollection).Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "repository", Value: 1},
+			{Key: "group", Value: 1},
+			{Key: "name", Value: 1},
+			{Key: "alias", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating policy alias index: %w", err)
+	}
+
 	return &Storage{
 		db:            db,
 		policy:        database.Collection(collection),
 		subscriber:    database.Collection(subscriberCollectionName),
 		commonStorage: database.Collection(commonStorage),
 		autoImport:    database.Collection(autoImportCollection),
-		logger:        logger,
+		// decision inputs and results are decoded as maps, so that
+		// they are serialized as JSON objects in API responses
+		decisionLog: database.Collection(
+			decisionLogCollection,
+			options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true}),
+		),
+		alias:      database.Collection(aliasCollection),
+		divergence: database.Collection(shadowDivergenceCollection),
+		job:        database.Collection(jobCollection),
+		// captured inputs and results are decoded as maps like decisions
+		capture: database.Collection(
+			captureCollection,
+			options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true}),
+		),
+		captureCount: database.Collection(captureCounterCollection),
+		audit:        database.Collection(auditCollection),
+		logger:       logger,
 	}, nil
 }
 
@@ -84,38 +129,103 @@ func (s *Storage) SavePolicy(ctx context.Context, policy *storage.Policy) error
 		"name":       policy.Name,
 		"version":    policy.Version,
 	}
-	update := bson.M{"$set": bson.M{
-		"locked":              policy.Locked,
-		"rego":                policy.Rego,
-		"data":                policy.Data,
-		"dataConfig":          policy.DataConfig,
-		"outputSchema":        policy.OutputSchema,
-		"lastUpdate":          time.Now(),
-		"nextDataRefreshTime": time.Time{},
-	}}
+	update := bson.M{
+		"$set": bson.M{
+			"locked":              policy.Locked,
+			"lock":                policy.Lock,
+			"modules":             policy.Modules,
+			"data":                policy.Data,
+			"dataConfig":          policy.DataConfig,
+			"outputSchema":        policy.OutputSchema,
+			"inputSchema":         policy.InputSchema,
+			"runtimeConfig":       policy.RuntimeConfig,
+			"tests":               policy.Tests,
+			"testCases":           policy.TestCases,
+			"testReport":          policy.TestReport,
+			"lastUpdate":          time.Now(),
+			"nextDataRefreshTime": time.Time{},
+		},
+		// the legacy single module source is replaced by the modules
+		"$unset": bson.M{"rego": ""},
+	}
+	if policy.Locked && policy.Lock != nil {
+		update["$push"] = lockHistoryPush(policy.Lock)
+	}
 
 	_, err := s.policy.UpdateOne(ctx, filter, update, opts)
 
 	return err
 }
 
-func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, lock bool) error {
-	_, err := s.policy.UpdateOne(
+func (s *Storage) SetPolicyLock(ctx context.Context, repository, group, name, version string, event *storage.LockEvent) (bool, error) {
+	var lock *storage.LockEvent
+	if event.Locked {
+		lock = event
+	}
+
+	// the lock state is part of the filter, so that concurrent
+	// (un)locks don't record the same event twice
+	res, err := s.policy.UpdateOne(
 		ctx,
 		bson.M{
 			"repository": repository,
 			"group":      group,
 			"name":       name,
 			"version":    version,
+			"locked":     bson.M{"$ne": event.Locked},
 		},
 		bson.M{
 			"$set": bson.M{
-				"locked":     lock,
+				"locked":     event.Locked,
+				"lock":       lock,
 				"lastUpdate": time.Now(),
 			},
+			"$push": lockHistoryPush(event),
 		},
 	)
-	return err
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+// lockHistoryPush returns the $push operator which appends the event
+// to the lock history and keeps only the newest events.
+func lockHistoryPush(event *storage.LockEvent) bson.M {
+	return bson.M{
+		"lockHistory": bson.M{
+			"$each":  bson.A{event},
+			"$slice": -storage.MaxLockHistory,
+		},
+	}
+}
+
+func (s *Storage) SetPolicyShadow(ctx context.Context, repository, group, name, version, shadowVersion string) error {
+	result, err := s.policy.UpdateOne(
+		ctx,
+		bson.M{
+			"repository": repository,
+			"group":      group,
+			"name":       name,
+			"version":    version,
+		},
+		bson.M{
+			"$set": bson.M{
+				"shadowVersion": shadowVersion,
+				"lastUpdate":    time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New(errors.NotFound, "policy not found")
+	}
+
+	return nil
 }
 
 type PolicyEvent struct {
@@ -141,7 +251,7 @@ func (s *Storage) ListenPolicyDataChanges(ctx context.Context) error {
 		policy := policyEvent.Policy
 
 		for _, subscriber := range s.subscribers {
-			err := subscriber.PolicyDataChange(ctx, policy.Repository, policy.Name, policy.Group, policy.Version)
+			err := subscriber.PolicyDataChange(ctx, policy.Repository, policy.Group, policy.Name, policy.Version)
 			if err != nil {
 				s.logger.Error("error notifying policy change subscribers", zap.Error(err))
 			}
@@ -478,3 +588,424 @@ func (s *Storage) DeleteAutoImportConfig(ctx context.Context, policyURL string)
 
 	return nil
 }
+
+func (s *Storage) SaveDecisions(ctx context.Context, decisions []*storage.Decision) error {
+	docs := make([]interface{}, len(decisions))
+	for i, d := range decisions {
+		d.MongoID = primitive.NewObjectID()
+		docs[i] = d
+	}
+
+	_, err := s.decisionLog.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (s *Storage) Decisions(ctx context.Context, filter *storage.DecisionFilter) ([]*storage.Decision, error) {
+	query := bson.M{}
+	for field, value := range map[string]string{
+		"repository":   filter.Repository,
+		"group":        filter.Group,
+		"name":         filter.Name,
+		"version":      filter.Version,
+		"evaluationid": filter.EvaluationID,
+		"caller":       filter.Caller,
+	} {
+		if value != "" {
+			query[field] = value
+		}
+	}
+
+	timestamp := bson.M{}
+	if !filter.From.IsZero() {
+		timestamp["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timestamp["$lte"] = filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	opts := options.Find().SetSort(bson.M{"timestamp": -1})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		opts.SetSkip(int64(filter.Offset))
+	}
+
+	cursor, err := s.decisionLog.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var decisions []*storage.Decision
+	if err := cursor.All(ctx, &decisions); err != nil {
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
+func (s *Storage) PolicyVersions(ctx context.Context, repository, group, name string) ([]string, error) {
+	values, err := s.policy.Distinct(ctx, "version", bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(values))
+	for _, v := range values {
+		if version, ok := v.(string); ok {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+func (s *Storage) PolicyAlias(ctx context.Context, repository, group, name, alias string) (*storage.PolicyAlias, error) {
+	result := s.alias.FindOne(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"alias":      alias,
+	})
+
+	if result.Err() != nil {
+		if goerrors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, errors.New(errors.NotFound, "policy alias not found")
+		}
+		return nil, result.Err()
+	}
+
+	var a storage.PolicyAlias
+	if err := result.Decode(&a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (s *Storage) PolicyAliases(ctx context.Context, repository, group, name string) ([]*storage.PolicyAlias, error) {
+	cursor, err := s.alias.Find(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var aliases []*storage.PolicyAlias
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+func (s *Storage) SavePolicyAlias(ctx context.Context, alias *storage.PolicyAlias) error {
+	opts := options.Update().SetUpsert(true)
+	filter := bson.M{
+		"repository": alias.Repository,
+		"group":      alias.Group,
+		"name":       alias.Name,
+		"alias":      alias.Alias,
+	}
+	update := bson.M{"$set": bson.M{
+		"version":    alias.Version,
+		"lastUpdate": time.Now(),
+	}}
+
+	_, err := s.alias.UpdateOne(ctx, filter, update, opts)
+
+	return err
+}
+
+func (s *Storage) DeletePolicyAlias(ctx context.Context, repository, group, name, alias string) error {
+	result, err := s.alias.DeleteOne(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"alias":      alias,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New(errors.NotFound, "policy alias not found")
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveShadowDivergence(ctx context.Context, divergence *storage.ShadowDivergence) error {
+	divergence.MongoID = primitive.NewObjectID()
+	_, err := s.divergence.InsertOne(ctx, divergence)
+	return err
+}
+
+func (s *Storage) SetPolicyCapture(ctx context.Context, repository, group, name, version string, capture *storage.CaptureConfig) error {
+	result, err := s.policy.UpdateOne(
+		ctx,
+		bson.M{
+			"repository": repository,
+			"group":      group,
+			"name":       name,
+			"version":    version,
+		},
+		bson.M{
+			"$set": bson.M{
+				"capture":    capture,
+				"lastUpdate": time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New(errors.NotFound, "policy not found")
+	}
+
+	return nil
+}
+
+// SaveCapturedEvaluation reserves a record in the counter document of the policy
+// version before the evaluation is inserted. The counter is only incremented while
+// it's below maxRecords, so concurrent evaluations can't exceed the limit.
+func (s *Storage) SaveCapturedEvaluation(ctx context.Context, evaluation *storage.CapturedEvaluation, maxRecords int) (bool, error) {
+	counterID := captureCounterID(evaluation.Repository, evaluation.Group, evaluation.Name, evaluation.Version)
+
+	filter := bson.M{"_id": counterID}
+	if maxRecords > 0 {
+		filter["count"] = bson.M{"$lt": maxRecords}
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := s.captureCount.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"count": 1}}, opts); err != nil {
+		// the counter exists but doesn't match the filter,
+		// so the upsert conflicts with the existing counter
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	evaluation.MongoID = primitive.NewObjectID()
+	if _, err := s.capture.InsertOne(ctx, evaluation); err != nil {
+		// release the reserved record
+		if _, uerr := s.captureCount.UpdateOne(ctx, bson.M{"_id": counterID}, bson.M{"$inc": bson.M{"count": -1}}); uerr != nil {
+			s.logger.Error("error releasing captured evaluation record", zap.Error(uerr))
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Storage) CapturedEvaluations(ctx context.Context, repository, group, name, version string, limit int) ([]*storage.CapturedEvaluation, error) {
+	opts := options.Find().SetSort(bson.M{"timestamp": -1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := s.capture.Find(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"version":    version,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var evaluations []*storage.CapturedEvaluation
+	if err := cursor.All(ctx, &evaluations); err != nil {
+		return nil, err
+	}
+
+	return evaluations, nil
+}
+
+func (s *Storage) DeleteCapturedEvaluations(ctx context.Context, repository, group, name, version string) error {
+	_, err := s.capture.DeleteMany(ctx, bson.M{
+		"repository": repository,
+		"group":      group,
+		"name":       name,
+		"version":    version,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.captureCount.DeleteOne(ctx, bson.M{"_id": captureCounterID(repository, group, name, version)})
+	return err
+}
+
+// captureCounterID identifies the counter document of a policy version. The fields
+// are ordered, because embedded documents are compared field by field.
+func captureCounterID(repository, group, name, version string) bson.D {
+	return bson.D{
+		{Key: "repository", Value: repository},
+		{Key: "group", Value: group},
+		{Key: "name", Value: name},
+		{Key: "version", Value: version},
+	}
+}
+
+func (s *Storage) SaveAuditRecord(ctx context.Context, record *storage.AuditRecord) error {
+	record.MongoID = primitive.NewObjectID()
+	_, err := s.audit.InsertOne(ctx, record)
+	return err
+}
+
+func (s *Storage) AuditRecords(ctx context.Context, filter *storage.AuditFilter) ([]*storage.AuditRecord, error) {
+	query := bson.M{}
+	for field, value := range map[string]string{
+		"operation":  filter.Operation,
+		"actor":      filter.Actor,
+		"repository": filter.Repository,
+		"group":      filter.Group,
+		"name":       filter.Name,
+		"version":    filter.Version,
+	} {
+		if value != "" {
+			query[field] = value
+		}
+	}
+
+	timestamp := bson.M{}
+	if !filter.From.IsZero() {
+		timestamp["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timestamp["$lte"] = filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	opts := options.Find().SetSort(bson.M{"timestamp": -1})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		opts.SetSkip(int64(filter.Offset))
+	}
+
+	cursor, err := s.audit.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*storage.AuditRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *Storage) CreateJob(ctx context.Context, job *storage.Job) error {
+	_, err := s.job.InsertOne(ctx, job)
+	return err
+}
+
+func (s *Storage) Job(ctx context.Context, id string) (*storage.Job, error) {
+	result := s.job.FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		if goerrors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, errors.New(errors.NotFound, "job not found")
+		}
+		return nil, result.Err()
+	}
+
+	var job storage.Job
+	if err := result.Decode(&job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimJob atomically changes the job status, so that a job is
+// executed by a single instance when the storage is shared.
+func (s *Storage) ClaimJob(ctx context.Context, id string, staleBefore time.Time) (*storage.Job, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"status": storage.JobPending},
+			bson.M{"status": storage.JobRunning, "updatedAt": bson.M{"$lt": staleBefore}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":    storage.JobRunning,
+		"updatedAt": time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	result := s.job.FindOneAndUpdate(ctx, filter, update, opts)
+	if result.Err() != nil {
+		if goerrors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, errors.New(errors.NotFound, "resumable job not found")
+		}
+		return nil, result.Err()
+	}
+
+	var job storage.Job
+	if err := result.Decode(&job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *Storage) UpdateJob(ctx context.Context, job *storage.Job) error {
+	result, err := s.job.ReplaceOne(ctx, bson.M{"_id": job.ID}, job)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New(errors.NotFound, "job not found")
+	}
+
+	return nil
+}
+
+func (s *Storage) ResumableJobs(ctx context.Context, staleBefore time.Time) ([]*storage.Job, error) {
+	cursor, err := s.job.Find(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"status": storage.JobPending},
+			bson.M{"status": storage.JobRunning, "updatedAt": bson.M{"$lt": staleBefore}},
+		},
+	}, options.Find().SetSort(bson.M{"createdAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*storage.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (s *Storage) DeleteJobs(ctx context.Context, before time.Time) error {
+	_, err := s.job.DeleteMany(ctx, bson.M{
+		"status":    bson.M{"$in": bson.A{storage.JobDone, storage.JobFailed}},
+		"updatedAt": bson.M{"$lt": before},
+	})
+	return err
+}
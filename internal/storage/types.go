@@ -4,32 +4,123 @@ import (
 	"context"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const RefreshPostponePeriod = 5 * time.Minute
 
+// PolicyFilename is the filename of the main module of a policy.
+const PolicyFilename = "policy.rego"
+
+// TestCasesFilename is the filename of the golden test cases of a policy.
+const TestCasesFilename = "tests.json"
+
+// Module is a rego source file of a policy.
+type Module struct {
+	// Filename is relative to the policy folder, e.g. 'policy.rego'.
+	Filename string
+	Rego     string
+}
+
 type PolicySubscriber interface {
 	PolicyDataChange(ctx context.Context, repo, group, name, version string) error
 }
 
 type Policy struct {
-	MongoID             primitive.ObjectID `bson:"_id"`
-	Filename            string
-	Repository          string
-	Name                string
-	Group               string
-	Version             string
-	Rego                string
-	Data                string
-	DataConfig          string
-	OutputSchema        string
-	ExportConfig        string
-	Locked              bool
+	MongoID    primitive.ObjectID `bson:"_id"`
+	Filename   string
+	Repository string
+	Name       string
+	Group      string
+	Version    string
+	// Modules contains the rego source files of the policy,
+	// which are compiled together during evaluation.
+	Modules      []Module
+	Data         string
+	DataConfig   string
+	OutputSchema string
+	InputSchema  string
+	ExportConfig string
+	// RuntimeConfig contains the evaluation settings of the policy.
+	RuntimeConfig string
+	// ShadowVersion is a candidate version of the policy, which is
+	// evaluated in shadow with the same input as this version.
+	ShadowVersion string
+	// Capture enables the recording of evaluations of this version,
+	// which can be replayed against another version of the policy.
+	Capture *CaptureConfig
+	// Tests contains the '_test.rego' modules of the policy, which
+	// are only compiled when the policy tests are executed.
+	Tests []Module
+	// TestCases contains the golden test cases of the policy.
+	TestCases string
+	// TestReport is the result of the last test run of the policy.
+	TestReport *TestReport
+	Locked     bool
+	// Lock describes the current lock of a locked policy.
+	Lock *LockEvent
+	// LockHistory contains the previous lock and unlock events, oldest first.
+	LockHistory         []LockEvent
 	LastUpdate          time.Time
 	NextDataRefreshTime time.Time
 }
 
+// UnmarshalBSON decodes a policy document. Policies which were stored
+// before multiple modules were supported have their source code in a
+// single 'rego' field, which is converted to the main policy module.
+func (p *Policy) UnmarshalBSON(data []byte) error {
+	type PolicyDocument Policy
+	var doc struct {
+		PolicyDocument `bson:",inline"`
+		Rego           string `bson:"rego"`
+	}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	*p = Policy(doc.PolicyDocument)
+	if len(p.Modules) == 0 && doc.Rego != "" {
+		p.Modules = []Module{{Filename: PolicyFilename, Rego: doc.Rego}}
+	}
+
+	return nil
+}
+
+// Module returns the source code of the policy module with the given filename.
+func (p *Policy) Module(filename string) (string, bool) {
+	for _, m := range p.Modules {
+		if m.Filename == filename {
+			return m.Rego, true
+		}
+	}
+	return "", false
+}
+
+// TestReport is the result of running the tests of a policy.
+type TestReport struct {
+	Passed bool
+	// Error is set if the tests couldn't be executed,
+	// e.g. because a test module doesn't compile.
+	Error  string
+	Total  int
+	Failed int
+	// Coverage is the percentage of the policy module
+	// lines which are evaluated by the tests.
+	Coverage float64
+	Results  []TestResult
+	RunAt    time.Time
+}
+
+// TestResult is the result of a single test rule or golden test case.
+type TestResult struct {
+	Name     string
+	Location string
+	Passed   bool
+	Error    string
+	Duration time.Duration
+}
+
 type Subscriber struct {
 	MongoID          primitive.ObjectID `bson:"_id"`
 	Name             string
@@ -53,3 +144,241 @@ type PolicyAutoImport struct {
 	Interval   time.Duration
 	NextImport time.Time
 }
+
+// PolicyAlias is a named reference to a version of a policy,
+// which can be used instead of the version in policy URLs.
+type PolicyAlias struct {
+	MongoID    primitive.ObjectID `bson:"_id" json:"-"`
+	Repository string             `json:"repository"`
+	Group      string             `json:"group"`
+	Name       string             `json:"name"`
+	Alias      string             `json:"alias"`
+	Version    string             `json:"version"`
+	LastUpdate time.Time          `json:"lastUpdate"`
+}
+
+// Decision is a decision log record of a single policy evaluation.
+type Decision struct {
+	MongoID          primitive.ObjectID `bson:"_id" json:"-"`
+	EvaluationID     string             `json:"evaluationID"`
+	Repository       string             `json:"repository"`
+	Group            string             `json:"group"`
+	Name             string             `json:"name"`
+	Version          string             `json:"version"`
+	Rule             string             `json:"rule,omitempty"`
+	PolicyLastUpdate time.Time          `json:"policyLastUpdate"`
+	InputHash        string             `json:"inputHash"`
+	Input            any                `json:"input,omitempty"`
+	Result           any                `json:"result,omitempty"`
+	Error            string             `json:"error,omitempty"`
+	Duration         time.Duration      `json:"duration"`
+	Caller           string             `json:"caller,omitempty"`
+	ClientIP         string             `json:"clientIP,omitempty"`
+	RequestID        string             `json:"requestID,omitempty"`
+	Timestamp        time.Time          `json:"timestamp"`
+}
+
+// ShadowDivergence is a record of a shadow evaluation whose candidate
+// version returned a different result than the evaluated policy version.
+type ShadowDivergence struct {
+	MongoID       primitive.ObjectID `bson:"_id" json:"-"`
+	EvaluationID  string             `json:"evaluationID"`
+	Repository    string             `json:"repository"`
+	Group         string             `json:"group"`
+	Name          string             `json:"name"`
+	Version       string             `json:"version"`
+	ShadowVersion string             `json:"shadowVersion"`
+	Rule          string             `json:"rule,omitempty"`
+	InputHash     string             `json:"inputHash"`
+	Result        any                `json:"result"`
+	ShadowResult  any                `json:"shadowResult,omitempty"`
+	ShadowError   string             `json:"shadowError,omitempty"`
+	Timestamp     time.Time          `json:"timestamp"`
+}
+
+// MaxLockHistory limits the number of lock events kept in the lock history of a policy.
+const MaxLockHistory = 100
+
+// Sources of lock events.
+const (
+	// LockSourceManual is a lock or unlock through the API.
+	LockSourceManual = "manual"
+	// LockSourceValidation is a lock after a failed output validation.
+	LockSourceValidation = "validation"
+	// LockSourceImport is a lock of an imported policy bundle.
+	LockSourceImport = "import"
+	// LockSourceExpiry is an automatic unlock after the unlock time of a lock.
+	LockSourceExpiry = "expiry"
+)
+
+// LockEvent records the locking or unlocking of a policy.
+type LockEvent struct {
+	// Locked is true for a lock and false for an unlock.
+	Locked bool
+	Reason string
+	// Actor is the subject of the caller who (un)locked the policy.
+	Actor  string
+	Source string
+	// UnlockAt is the time when a locked policy is unlocked automatically.
+	UnlockAt  *time.Time `bson:",omitempty"`
+	Timestamp time.Time
+}
+
+// CaptureConfig specifies which evaluations of a policy are recorded.
+type CaptureConfig struct {
+	// SampleRate is the fraction (0 to 1) of evaluations which are recorded.
+	SampleRate float64
+	// MaxRecords limits the number of recorded evaluations of the policy version.
+	MaxRecords int
+	// RedactFields are dot separated paths of input fields, e.g. 'user.email',
+	// whose values are replaced in the recorded input.
+	RedactFields []string
+	// RedactHeaders are the names of request headers
+	// whose values are replaced in the recorded headers.
+	RedactHeaders []string
+}
+
+// CapturedEvaluation is a recorded policy evaluation together with
+// the responses of the extension functions called during the evaluation.
+type CapturedEvaluation struct {
+	MongoID      primitive.ObjectID `bson:"_id"`
+	EvaluationID string
+	Repository   string
+	Group        string
+	Name         string
+	Version      string
+	Rule         string
+	Input        any
+	Headers      map[string]string
+	Result       any
+	Error        string
+	Calls        []FunctionCall
+	// Duration of the evaluation including the extension function calls.
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// FunctionCall is a recorded call of an extension function.
+type FunctionCall struct {
+	Name   string
+	Args   []any
+	Result any
+	// Undefined is set if the function call had no result.
+	Undefined bool
+	Error     string
+	Duration  time.Duration
+}
+
+// DecisionFilter specifies the criteria for querying decision log records.
+// Empty values are not used for filtering.
+type DecisionFilter struct {
+	Repository   string
+	Group        string
+	Name         string
+	Version      string
+	EvaluationID string
+	Caller       string
+	From         time.Time
+	To           time.Time
+	Limit        int
+	Offset       int
+}
+
+// Operations recorded in the audit log.
+const (
+	AuditLock                   = "lock"
+	AuditUnlock                 = "unlock"
+	AuditImportBundle           = "importBundle"
+	AuditSetPolicyAutoImport    = "setPolicyAutoImport"
+	AuditDeletePolicyAutoImport = "deletePolicyAutoImport"
+	AuditSubscribe              = "subscribeForPolicyChange"
+	AuditStorageSet             = "storageSet"
+	AuditStorageDelete          = "storageDelete"
+	AuditSync                   = "sync"
+	AuditSetPolicyAlias         = "setPolicyAlias"
+	AuditDeletePolicyAlias      = "deletePolicyAlias"
+)
+
+// AuditRecord is an append-only record of a state-changing operation.
+type AuditRecord struct {
+	MongoID   primitive.ObjectID `bson:"_id" json:"-"`
+	Operation string             `json:"operation"`
+	// Actor is the subject of the caller, or the name of the
+	// process for operations which aren't requested by a caller.
+	Actor      string `json:"actor,omitempty"`
+	ClientIP   string `json:"clientIP,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Group      string `json:"group,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Version    string `json:"version,omitempty"`
+	// Target identifies the changed resource if it isn't a
+	// policy, e.g. the key of a storage.set operation.
+	Target string `json:"target,omitempty"`
+	// BeforeHash and AfterHash are the SHA256 hashes of the changed
+	// resource before and after the operation. They are empty if
+	// the resource didn't exist before or after the operation.
+	BeforeHash string `json:"beforeHash,omitempty"`
+	AfterHash  string `json:"afterHash,omitempty"`
+	// BeforeVersion and AfterVersion are the policy versions referenced
+	// by the changed resource, e.g. an alias, before and after the operation.
+	BeforeVersion string    `json:"beforeVersion,omitempty"`
+	AfterVersion  string    `json:"afterVersion,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// AuditFilter specifies the criteria for querying audit records.
+// Empty values are not used for filtering.
+type AuditFilter struct {
+	Operation  string
+	Actor      string
+	Repository string
+	Group      string
+	Name       string
+	Version    string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+// Statuses of asynchronous evaluation jobs.
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Job is an asynchronous policy evaluation. The evaluation result is stored
+// in the cache under the evaluation ID, the same as for synchronous evaluations.
+type Job struct {
+	ID           string `bson:"_id" json:"id"`
+	EvaluationID string `bson:"evaluationID" json:"evaluationID"`
+	Repository   string `bson:"repository" json:"repository"`
+	Group        string `bson:"group" json:"group"`
+	Name         string `bson:"name" json:"name"`
+	Version      string `bson:"version" json:"version"`
+	Rule         string `bson:"rule,omitempty" json:"rule,omitempty"`
+	// Input is the JSON encoded input of the policy evaluation.
+	Input []byte `bson:"input" json:"-"`
+	// TTL of the evaluation result in the cache, if it's set by the caller.
+	TTL *int `bson:"ttl,omitempty" json:"ttl,omitempty"`
+	// Headers, Caller and ClientIP of the submitting request, which are
+	// restored when the job is resumed. The headers are removed when
+	// the job is finished, as they may contain credentials.
+	Headers  map[string]string `bson:"headers,omitempty" json:"-"`
+	Caller   string            `bson:"caller,omitempty" json:"caller,omitempty"`
+	ClientIP string            `bson:"clientIP,omitempty" json:"clientIP,omitempty"`
+	// CallbackURL receives the evaluation result when the job is finished.
+	CallbackURL   string    `bson:"callbackURL,omitempty" json:"callbackURL,omitempty"`
+	CallbackError string    `bson:"callbackError,omitempty" json:"callbackError,omitempty"`
+	Status        string    `bson:"status" json:"status"`
+	Error         string    `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt     time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Finished reports whether the job is done or failed.
+func (j *Job) Finished() bool {
+	return j.Status == JobDone || j.Status == JobFailed
+}
@@ -0,0 +1,56 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/eclipse-xfsc/custom-policy-agent/internal/storage"
+)
+
+func TestPolicy_UnmarshalBSON(t *testing.T) {
+	t.Run("policy with modules", func(t *testing.T) {
+		data, err := bson.Marshal(bson.M{
+			"name":    "example",
+			"modules": bson.A{bson.M{"filename": "policy.rego", "rego": "package a.b"}, bson.M{"filename": "helpers.rego", "rego": "package a.b"}},
+		})
+		require.NoError(t, err)
+
+		var p storage.Policy
+		require.NoError(t, bson.Unmarshal(data, &p))
+		assert.Equal(t, "example", p.Name)
+		assert.Equal(t, []storage.Module{
+			{Filename: "policy.rego", Rego: "package a.b"},
+			{Filename: "helpers.rego", Rego: "package a.b"},
+		}, p.Modules)
+	})
+
+	t.Run("policy with legacy rego field", func(t *testing.T) {
+		data, err := bson.Marshal(bson.M{
+			"fullDocument": bson.M{"name": "example", "rego": "package a.b", "locked": true},
+		})
+		require.NoError(t, err)
+
+		var event struct {
+			Policy storage.Policy `bson:"fullDocument"`
+		}
+		require.NoError(t, bson.Unmarshal(data, &event))
+		assert.Equal(t, "example", event.Policy.Name)
+		assert.True(t, event.Policy.Locked)
+		assert.Equal(t, []storage.Module{{Filename: "policy.rego", Rego: "package a.b"}}, event.Policy.Modules)
+	})
+}
+
+func TestPolicy_Module(t *testing.T) {
+	p := storage.Policy{Modules: []storage.Module{{Filename: "policy.rego", Rego: "package a.b"}}}
+
+	rego, ok := p.Module("policy.rego")
+	assert.True(t, ok)
+	assert.Equal(t, "package a.b", rego)
+
+	rego, ok = p.Module("missing.rego")
+	assert.False(t, ok)
+	assert.Empty(t, rego)
+}
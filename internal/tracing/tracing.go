@@ -0,0 +1,144 @@
+// Package tracing configures the OpenTelemetry tracing of the service
+// and provides helpers for creating spans in the service components.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterNone disables the export of spans. The trace context
+	// of incoming requests is still propagated to outgoing requests.
+	ExporterNone = "none"
+	// ExporterOTLP exports spans to an OTLP collector over HTTP.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes spans to stdout, e.g. for local development.
+	ExporterStdout = "stdout"
+
+	instrumentationName = "github.com/eclipse-xfsc/custom-policy-agent"
+)
+
+// Config specifies the export of spans.
+type Config struct {
+	// Exporter is one of 'none', 'otlp' or 'stdout'.
+	Exporter string
+	// OTLPEndpoint is the host and port of the OTLP collector. If it's empty,
+	// the standard OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS for the connection to the OTLP collector.
+	OTLPInsecure bool
+	// SampleRatio is the ratio of sampled traces which are started by the
+	// service. Traces of incoming requests follow the parent sampling decision.
+	SampleRatio float64
+	// ServiceName and ServiceVersion identify the service in the spans.
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup configures the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans and must be
+// called before the service is stopped.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create tracing exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create tracing resource: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service components.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start creates a span as a child of the span in the context.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error in the span, if it's not nil, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// PolicyAttributes returns the span attributes of a policy version.
+func PolicyAttributes(repository, group, name, version string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("policy.repository", repository),
+		attribute.String("policy.group", group),
+		attribute.String("policy.name", name),
+		attribute.String("policy.version", version),
+	}
+}
+
+// Transport returns an HTTP transport which creates a span for every
+// outgoing request and propagates the trace context to the server.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// Handler returns an HTTP handler which creates a span for every
+// incoming request, continuing the trace context of the client.
+func Handler(h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, "policy", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return "HTTP " + r.Method
+	}))
+}
@@ -0,0 +1,1073 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: policy/v1/policy.proto
+
+package policyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EvaluateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Group      string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	PolicyName string                 `protobuf:"bytes,3,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Version    string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Input data passed to the policy execution runtime.
+	Input *structpb.Value `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	// Identifier overwriting the randomly generated evaluation ID.
+	EvaluationId string `protobuf:"bytes,6,opt,name=evaluation_id,json=evaluationId,proto3" json:"evaluation_id,omitempty"`
+	// TTL in seconds for storing the policy result in cache.
+	Ttl *int32 `protobuf:"varint,7,opt,name=ttl,proto3,oneof" json:"ttl,omitempty"`
+	// Path of a rule inside the policy package, e.g. 'allow' or 'violations/critical'.
+	Rule string `protobuf:"bytes,8,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Report returns the validation verdict together with the result. Used only for validation.
+	Report bool `protobuf:"varint,9,opt,name=report,proto3" json:"report,omitempty"`
+	// Coerce applies the default values of the output schema. Used only for validation.
+	Coerce        bool `protobuf:"varint,10,opt,name=coerce,proto3" json:"coerce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_policy_v1_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvaluateRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetInput() *structpb.Value {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetEvaluationId() string {
+	if x != nil {
+		return x.EvaluationId
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetTtl() int32 {
+	if x != nil && x.Ttl != nil {
+		return *x.Ttl
+	}
+	return 0
+}
+
+func (x *EvaluateRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetReport() bool {
+	if x != nil {
+		return x.Report
+	}
+	return false
+}
+
+func (x *EvaluateRequest) GetCoerce() bool {
+	if x != nil {
+		return x.Coerce
+	}
+	return false
+}
+
+type EvaluateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Policy result, which is any JSON value.
+	Result *structpb.Value `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Unique identifier of the policy evaluation.
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Policy version which was evaluated, after resolving aliases and semver ranges.
+	Version       string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_policy_v1_policy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvaluateResponse) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *EvaluateResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *EvaluateResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type EvaluateBatchResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Group      string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	PolicyName string                 `protobuf:"bytes,3,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Version    string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Result     *structpb.Value        `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	Etag       string                 `protobuf:"bytes,6,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Error message if the policy evaluation failed.
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateBatchResponse) Reset() {
+	*x = EvaluateBatchResponse{}
+	mi := &file_policy_v1_policy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateBatchResponse) ProtoMessage() {}
+
+func (x *EvaluateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateBatchResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EvaluateBatchResponse) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *EvaluateBatchResponse) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *EvaluateBatchResponse) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *EvaluateBatchResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *EvaluateBatchResponse) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *EvaluateBatchResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *EvaluateBatchResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type LockRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Group      string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	PolicyName string                 `protobuf:"bytes,3,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Version    string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Reason for locking the policy.
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Time when the policy is unlocked automatically as Unix timestamp.
+	UnlockAt      *int64 `protobuf:"varint,6,opt,name=unlock_at,json=unlockAt,proto3,oneof" json:"unlock_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockRequest) Reset() {
+	*x = LockRequest{}
+	mi := &file_policy_v1_policy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockRequest) ProtoMessage() {}
+
+func (x *LockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockRequest.ProtoReflect.Descriptor instead.
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LockRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *LockRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *LockRequest) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *LockRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *LockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LockRequest) GetUnlockAt() int64 {
+	if x != nil && x.UnlockAt != nil {
+		return *x.UnlockAt
+	}
+	return 0
+}
+
+type LockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockResponse) Reset() {
+	*x = LockResponse{}
+	mi := &file_policy_v1_policy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockResponse) ProtoMessage() {}
+
+func (x *LockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockResponse.ProtoReflect.Descriptor instead.
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{4}
+}
+
+type UnlockRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Group      string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	PolicyName string                 `protobuf:"bytes,3,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Version    string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Reason for unlocking the policy.
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	mi := &file_policy_v1_policy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockRequest) ProtoMessage() {}
+
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UnlockRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *UnlockRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *UnlockRequest) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *UnlockRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UnlockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockResponse) Reset() {
+	*x = UnlockResponse{}
+	mi := &file_policy_v1_policy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockResponse) ProtoMessage() {}
+
+func (x *UnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockResponse.ProtoReflect.Descriptor instead.
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{6}
+}
+
+type ListPoliciesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Locked     *bool                  `protobuf:"varint,1,opt,name=locked,proto3,oneof" json:"locked,omitempty"`
+	PolicyName *string                `protobuf:"bytes,2,opt,name=policy_name,json=policyName,proto3,oneof" json:"policy_name,omitempty"`
+	// Rego, data and data_config include the policy source code and data in the response.
+	Rego          bool `protobuf:"varint,3,opt,name=rego,proto3" json:"rego,omitempty"`
+	Data          bool `protobuf:"varint,4,opt,name=data,proto3" json:"data,omitempty"`
+	DataConfig    bool `protobuf:"varint,5,opt,name=data_config,json=dataConfig,proto3" json:"data_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliciesRequest) Reset() {
+	*x = ListPoliciesRequest{}
+	mi := &file_policy_v1_policy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesRequest) ProtoMessage() {}
+
+func (x *ListPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPoliciesRequest) GetLocked() bool {
+	if x != nil && x.Locked != nil {
+		return *x.Locked
+	}
+	return false
+}
+
+func (x *ListPoliciesRequest) GetPolicyName() string {
+	if x != nil && x.PolicyName != nil {
+		return *x.PolicyName
+	}
+	return ""
+}
+
+func (x *ListPoliciesRequest) GetRego() bool {
+	if x != nil {
+		return x.Rego
+	}
+	return false
+}
+
+func (x *ListPoliciesRequest) GetData() bool {
+	if x != nil {
+		return x.Data
+	}
+	return false
+}
+
+func (x *ListPoliciesRequest) GetDataConfig() bool {
+	if x != nil {
+		return x.DataConfig
+	}
+	return false
+}
+
+type ListPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*Policy              `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliciesResponse) Reset() {
+	*x = ListPoliciesResponse{}
+	mi := &file_policy_v1_policy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesResponse) ProtoMessage() {}
+
+func (x *ListPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPoliciesResponse) GetPolicies() []*Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type Policy struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PolicyName string                 `protobuf:"bytes,2,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	Group      string                 `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	Version    string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Rego       string                 `protobuf:"bytes,5,opt,name=rego,proto3" json:"rego,omitempty"`
+	Data       string                 `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	DataConfig string                 `protobuf:"bytes,7,opt,name=data_config,json=dataConfig,proto3" json:"data_config,omitempty"`
+	Locked     bool                   `protobuf:"varint,8,opt,name=locked,proto3" json:"locked,omitempty"`
+	// Last update as Unix timestamp.
+	LastUpdate    int64             `protobuf:"varint,9,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	Modules       map[string]string `protobuf:"bytes,10,rep,name=modules,proto3" json:"modules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ShadowVersion string            `protobuf:"bytes,11,opt,name=shadow_version,json=shadowVersion,proto3" json:"shadow_version,omitempty"`
+	// Current lock of a locked policy.
+	Lock          *PolicyLockEvent `protobuf:"bytes,12,opt,name=lock,proto3" json:"lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_policy_v1_policy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Policy) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *Policy) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *Policy) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Policy) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Policy) GetRego() string {
+	if x != nil {
+		return x.Rego
+	}
+	return ""
+}
+
+func (x *Policy) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Policy) GetDataConfig() string {
+	if x != nil {
+		return x.DataConfig
+	}
+	return ""
+}
+
+func (x *Policy) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *Policy) GetLastUpdate() int64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+func (x *Policy) GetModules() map[string]string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *Policy) GetShadowVersion() string {
+	if x != nil {
+		return x.ShadowVersion
+	}
+	return ""
+}
+
+func (x *Policy) GetLock() *PolicyLockEvent {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+type PolicyLockEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True for a lock and false for an unlock.
+	Locked bool `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
+	// Reason for (un)locking the policy.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Subject of the caller who (un)locked the policy.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Source of the event.
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// Time when the policy is unlocked automatically as Unix timestamp.
+	UnlockAt *int64 `protobuf:"varint,5,opt,name=unlock_at,json=unlockAt,proto3,oneof" json:"unlock_at,omitempty"`
+	// Time of the event as Unix timestamp.
+	Timestamp     int64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyLockEvent) Reset() {
+	*x = PolicyLockEvent{}
+	mi := &file_policy_v1_policy_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyLockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyLockEvent) ProtoMessage() {}
+
+func (x *PolicyLockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_v1_policy_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyLockEvent.ProtoReflect.Descriptor instead.
+func (*PolicyLockEvent) Descriptor() ([]byte, []int) {
+	return file_policy_v1_policy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PolicyLockEvent) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *PolicyLockEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PolicyLockEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *PolicyLockEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PolicyLockEvent) GetUnlockAt() int64 {
+	if x != nil && x.UnlockAt != nil {
+		return *x.UnlockAt
+	}
+	return 0
+}
+
+func (x *PolicyLockEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_policy_v1_policy_proto protoreflect.FileDescriptor
+
+const file_policy_v1_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x16policy/v1/policy.proto\x12\tpolicy.v1\x1a\x1cgoogle/protobuf/struct.proto\"\xb8\x02\n" +
+	"\x0fEvaluateRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1f\n" +
+	"\vpolicy_name\x18\x03 \x01(\tR\n" +
+	"policyName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12,\n" +
+	"\x05input\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\x05input\x12#\n" +
+	"\revaluation_id\x18\x06 \x01(\tR\fevaluationId\x12\x15\n" +
+	"\x03ttl\x18\a \x01(\x05H\x00R\x03ttl\x88\x01\x01\x12\x12\n" +
+	"\x04rule\x18\b \x01(\tR\x04rule\x12\x16\n" +
+	"\x06report\x18\t \x01(\bR\x06report\x12\x16\n" +
+	"\x06coerce\x18\n" +
+	" \x01(\bR\x06coerceB\x06\n" +
+	"\x04_ttl\"p\n" +
+	"\x10EvaluateResponse\x12.\n" +
+	"\x06result\x18\x01 \x01(\v2\x16.google.protobuf.ValueR\x06result\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"\xe2\x01\n" +
+	"\x15EvaluateBatchResponse\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1f\n" +
+	"\vpolicy_name\x18\x03 \x01(\tR\n" +
+	"policyName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12.\n" +
+	"\x06result\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\x06result\x12\x12\n" +
+	"\x04etag\x18\x06 \x01(\tR\x04etag\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\xc6\x01\n" +
+	"\vLockRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1f\n" +
+	"\vpolicy_name\x18\x03 \x01(\tR\n" +
+	"policyName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12 \n" +
+	"\tunlock_at\x18\x06 \x01(\x03H\x00R\bunlockAt\x88\x01\x01B\f\n" +
+	"\n" +
+	"_unlock_at\"\x0e\n" +
+	"\fLockResponse\"\x98\x01\n" +
+	"\rUnlockRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1f\n" +
+	"\vpolicy_name\x18\x03 \x01(\tR\n" +
+	"policyName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\x10\n" +
+	"\x0eUnlockResponse\"\xbc\x01\n" +
+	"\x13ListPoliciesRequest\x12\x1b\n" +
+	"\x06locked\x18\x01 \x01(\bH\x00R\x06locked\x88\x01\x01\x12$\n" +
+	"\vpolicy_name\x18\x02 \x01(\tH\x01R\n" +
+	"policyName\x88\x01\x01\x12\x12\n" +
+	"\x04rego\x18\x03 \x01(\bR\x04rego\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\bR\x04data\x12\x1f\n" +
+	"\vdata_config\x18\x05 \x01(\bR\n" +
+	"dataConfigB\t\n" +
+	"\a_lockedB\x0e\n" +
+	"\f_policy_name\"E\n" +
+	"\x14ListPoliciesResponse\x12-\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x11.policy.v1.PolicyR\bpolicies\"\xc8\x03\n" +
+	"\x06Policy\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x1f\n" +
+	"\vpolicy_name\x18\x02 \x01(\tR\n" +
+	"policyName\x12\x14\n" +
+	"\x05group\x18\x03 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x12\n" +
+	"\x04rego\x18\x05 \x01(\tR\x04rego\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\tR\x04data\x12\x1f\n" +
+	"\vdata_config\x18\a \x01(\tR\n" +
+	"dataConfig\x12\x16\n" +
+	"\x06locked\x18\b \x01(\bR\x06locked\x12\x1f\n" +
+	"\vlast_update\x18\t \x01(\x03R\n" +
+	"lastUpdate\x128\n" +
+	"\amodules\x18\n" +
+	" \x03(\v2\x1e.policy.v1.Policy.ModulesEntryR\amodules\x12%\n" +
+	"\x0eshadow_version\x18\v \x01(\tR\rshadowVersion\x12.\n" +
+	"\x04lock\x18\f \x01(\v2\x1a.policy.v1.PolicyLockEventR\x04lock\x1a:\n" +
+	"\fModulesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x01\n" +
+	"\x0fPolicyLockEvent\x12\x16\n" +
+	"\x06locked\x18\x01 \x01(\bR\x06locked\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12 \n" +
+	"\tunlock_at\x18\x05 \x01(\x03H\x00R\bunlockAt\x88\x01\x01\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestampB\f\n" +
+	"\n" +
+	"_unlock_at2\xb5\x03\n" +
+	"\rPolicyService\x12C\n" +
+	"\bEvaluate\x12\x1a.policy.v1.EvaluateRequest\x1a\x1b.policy.v1.EvaluateResponse\x12C\n" +
+	"\bValidate\x12\x1a.policy.v1.EvaluateRequest\x1a\x1b.policy.v1.EvaluateResponse\x12Q\n" +
+	"\rEvaluateBatch\x12\x1a.policy.v1.EvaluateRequest\x1a .policy.v1.EvaluateBatchResponse(\x010\x01\x127\n" +
+	"\x04Lock\x12\x16.policy.v1.LockRequest\x1a\x17.policy.v1.LockResponse\x12=\n" +
+	"\x06Unlock\x12\x18.policy.v1.UnlockRequest\x1a\x19.policy.v1.UnlockResponse\x12O\n" +
+	"\fListPolicies\x12\x1e.policy.v1.ListPoliciesRequest\x1a\x1f.policy.v1.ListPoliciesResponseBFZDgithub.com/eclipse-xfsc/custom-policy-agent/proto/policy/v1;policyv1b\x06proto3"
+
+var (
+	file_policy_v1_policy_proto_rawDescOnce sync.Once
+	file_policy_v1_policy_proto_rawDescData []byte
+)
+
+func file_policy_v1_policy_proto_rawDescGZIP() []byte {
+	file_policy_v1_policy_proto_rawDescOnce.Do(func() {
+		file_policy_v1_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_policy_v1_policy_proto_rawDesc), len(file_policy_v1_policy_proto_rawDesc)))
+	})
+	return file_policy_v1_policy_proto_rawDescData
+}
+
+var file_policy_v1_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_policy_v1_policy_proto_goTypes = []any{
+	(*EvaluateRequest)(nil),       // 0: policy.v1.EvaluateRequest
+	(*EvaluateResponse)(nil),      // 1: policy.v1.EvaluateResponse
+	(*EvaluateBatchResponse)(nil), // 2: policy.v1.EvaluateBatchResponse
+	(*LockRequest)(nil),           // 3: policy.v1.LockRequest
+	(*LockResponse)(nil),          // 4: policy.v1.LockResponse
+	(*UnlockRequest)(nil),         // 5: policy.v1.UnlockRequest
+	(*UnlockResponse)(nil),        // 6: policy.v1.UnlockResponse
+	(*ListPoliciesRequest)(nil),   // 7: policy.v1.ListPoliciesRequest
+	(*ListPoliciesResponse)(nil),  // 8: policy.v1.ListPoliciesResponse
+	(*Policy)(nil),                // 9: policy.v1.Policy
+	(*PolicyLockEvent)(nil),       // 10: policy.v1.PolicyLockEvent
+	nil,                           // 11: policy.v1.Policy.ModulesEntry
+	(*structpb.Value)(nil),        // 12: google.protobuf.Value
+}
+var file_policy_v1_policy_proto_depIdxs = []int32{
+	12, // 0: policy.v1.EvaluateRequest.input:type_name -> google.protobuf.Value
+	12, // 1: policy.v1.EvaluateResponse.result:type_name -> google.protobuf.Value
+	12, // 2: policy.v1.EvaluateBatchResponse.result:type_name -> google.protobuf.Value
+	9,  // 3: policy.v1.ListPoliciesResponse.policies:type_name -> policy.v1.Policy
+	11, // 4: policy.v1.Policy.modules:type_name -> policy.v1.Policy.ModulesEntry
+	10, // 5: policy.v1.Policy.lock:type_name -> policy.v1.PolicyLockEvent
+	0,  // 6: policy.v1.PolicyService.Evaluate:input_type -> policy.v1.EvaluateRequest
+	0,  // 7: policy.v1.PolicyService.Validate:input_type -> policy.v1.EvaluateRequest
+	0,  // 8: policy.v1.PolicyService.EvaluateBatch:input_type -> policy.v1.EvaluateRequest
+	3,  // 9: policy.v1.PolicyService.Lock:input_type -> policy.v1.LockRequest
+	5,  // 10: policy.v1.PolicyService.Unlock:input_type -> policy.v1.UnlockRequest
+	7,  // 11: policy.v1.PolicyService.ListPolicies:input_type -> policy.v1.ListPoliciesRequest
+	1,  // 12: policy.v1.PolicyService.Evaluate:output_type -> policy.v1.EvaluateResponse
+	1,  // 13: policy.v1.PolicyService.Validate:output_type -> policy.v1.EvaluateResponse
+	2,  // 14: policy.v1.PolicyService.EvaluateBatch:output_type -> policy.v1.EvaluateBatchResponse
+	4,  // 15: policy.v1.PolicyService.Lock:output_type -> policy.v1.LockResponse
+	6,  // 16: policy.v1.PolicyService.Unlock:output_type -> policy.v1.UnlockResponse
+	8,  // 17: policy.v1.PolicyService.ListPolicies:output_type -> policy.v1.ListPoliciesResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_policy_v1_policy_proto_init() }
+func file_policy_v1_policy_proto_init() {
+	if File_policy_v1_policy_proto != nil {
+		return
+	}
+	file_policy_v1_policy_proto_msgTypes[0].OneofWrappers = []any{}
+	file_policy_v1_policy_proto_msgTypes[3].OneofWrappers = []any{}
+	file_policy_v1_policy_proto_msgTypes[7].OneofWrappers = []any{}
+	file_policy_v1_policy_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_policy_v1_policy_proto_rawDesc), len(file_policy_v1_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_policy_v1_policy_proto_goTypes,
+		DependencyIndexes: file_policy_v1_policy_proto_depIdxs,
+		MessageInfos:      file_policy_v1_policy_proto_msgTypes,
+	}.Build()
+	File_policy_v1_policy_proto = out.File
+	file_policy_v1_policy_proto_goTypes = nil
+	file_policy_v1_policy_proto_depIdxs = nil
+}
@@ -0,0 +1,10 @@
+#!/bin/bash
+
+set -e
+
+# generate the protobuf messages and the gRPC service stubs
+# (requires protoc, protoc-gen-go and protoc-gen-go-grpc)
+protoc --proto_path=proto \
+  --go_out=proto --go_opt=paths=source_relative \
+  --go-grpc_out=proto --go-grpc_opt=paths=source_relative \
+  policy/v1/policy.proto